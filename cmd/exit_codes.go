@@ -18,6 +18,10 @@ const (
 	ExitNotFound = 4 // Resource not found
 	ExitConflict = 5 // Conflict / resource already exists
 
+	// Usage alert outcomes that need to be told apart from a plain breach.
+	ExitNotificationFailed       = shared.ExitNotificationFailed       // Notification delivery failed
+	ExitBreachNotificationFailed = shared.ExitBreachNotificationFailed // Breach and notification delivery failed
+
 	// HTTP 4xx range: 10 + (status - 400)
 	// Note: 404 and 409 are mapped to ExitNotFound and ExitConflict above.
 	ExitHTTPBadRequest    = 10 // 400
@@ -43,6 +47,11 @@ func ExitCodeFromError(err error) int {
 		return ExitUsage
 	}
 
+	// Commands that pick their own exit code
+	if coder, ok := errors.AsType[shared.ExitCoder](err); ok {
+		return coder.ExitCode()
+	}
+
 	// Well-known error types
 	if errors.Is(err, shared.ErrMissingAuth) ||
 		errors.Is(err, asc.ErrUnauthorized) ||
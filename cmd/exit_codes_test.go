@@ -58,6 +58,11 @@ func TestExitCodeFromError(t *testing.T) {
 			err:      asc.ErrConflict,
 			expected: ExitConflict,
 		},
+		{
+			name:     "exit coder selects its own code",
+			err:      shared.NewExitCodeError(errors.Join(errors.New("breach"), asc.ErrForbidden), ExitBreachNotificationFailed),
+			expected: ExitBreachNotificationFailed,
+		},
 		{
 			name:     "generic error returns generic error",
 			err:      errors.New("something went wrong"),
@@ -106,6 +111,12 @@ func TestExitCodeConstants(t *testing.T) {
 	if ExitConflict != 5 {
 		t.Errorf("ExitConflict = %d, want 5", ExitConflict)
 	}
+	if ExitNotificationFailed != 6 {
+		t.Errorf("ExitNotificationFailed = %d, want 6", ExitNotificationFailed)
+	}
+	if ExitBreachNotificationFailed != 7 {
+		t.Errorf("ExitBreachNotificationFailed = %d, want 7", ExitBreachNotificationFailed)
+	}
 }
 
 func TestAPIErrorCodeToExitCode(t *testing.T) {
@@ -33,6 +33,7 @@ func RootCommand(version string) *ffcli.Command {
 
 	for _, subcommand := range subcommands {
 		shared.WrapCommandOutputValidation(subcommand)
+		shared.InstallFlagSuggestions(subcommand)
 	}
 
 	root.FlagSet.BoolVar(&versionRequested, "version", false, "Print version and exit")
@@ -65,6 +65,11 @@ var retryLogOverride struct {
 	val *bool
 }
 
+var timeoutOverride struct {
+	mu  sync.RWMutex
+	val *time.Duration
+}
+
 var debugOverride struct {
 	mu          sync.RWMutex
 	enabled     *bool
@@ -91,6 +96,14 @@ func SetRetryLogOverride(value *bool) {
 	retryLogOverride.val = value
 }
 
+// SetTimeoutOverride sets an explicit request timeout override.
+// When set, it takes precedence over env/config and command defaults. When unset (nil), behavior falls back to env/config.
+func SetTimeoutOverride(value *time.Duration) {
+	timeoutOverride.mu.Lock()
+	defer timeoutOverride.mu.Unlock()
+	timeoutOverride.val = value
+}
+
 // SetDebugOverride sets an explicit debug override.
 // When set, it takes precedence over env/config. When unset (nil), behavior falls back to env/config.
 func SetDebugOverride(value *bool) {
@@ -427,7 +440,15 @@ func ResolveUploadTimeout() time.Duration {
 
 // ResolveTimeoutWithDefault returns the request timeout using a custom default.
 // ASC_TIMEOUT and ASC_TIMEOUT_SECONDS override the default when set.
+// An explicit override (the root --timeout flag) takes precedence over both.
 func ResolveTimeoutWithDefault(defaultTimeout time.Duration) time.Duration {
+	timeoutOverride.mu.RLock()
+	override := timeoutOverride.val
+	timeoutOverride.mu.RUnlock()
+	if override != nil {
+		return *override
+	}
+
 	cfg := loadConfig()
 	var timeout config.DurationValue
 	var timeoutSeconds config.DurationValue
@@ -22,3 +22,23 @@ func TestResolveUploadTimeout_UsesUploadTimeoutEnv(t *testing.T) {
 		t.Fatalf("ResolveUploadTimeout() = %s, want 17s", got)
 	}
 }
+
+func TestResolveTimeout_OverrideTakesPrecedenceOverEnv(t *testing.T) {
+	t.Setenv("ASC_TIMEOUT", "17s")
+	t.Setenv("ASC_TIMEOUT_SECONDS", "")
+	t.Cleanup(func() { SetTimeoutOverride(nil) })
+
+	override := 5 * time.Minute
+	SetTimeoutOverride(&override)
+	if got := ResolveTimeout(); got != override {
+		t.Fatalf("ResolveTimeout() = %s, want %s", got, override)
+	}
+	if got := ResolveTimeoutWithDefault(30 * time.Minute); got != override {
+		t.Fatalf("ResolveTimeoutWithDefault() = %s, want %s", got, override)
+	}
+
+	SetTimeoutOverride(nil)
+	if got := ResolveTimeout(); got != 17*time.Second {
+		t.Fatalf("ResolveTimeout() after reset = %s, want 17s", got)
+	}
+}
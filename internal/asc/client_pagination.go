@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 )
 
 // GetLinks returns the links field for pagination.
@@ -22,24 +23,75 @@ type PaginateFunc func(ctx context.Context, nextURL string) (PaginatedResponse,
 // PageConsumer handles one pagination page.
 type PageConsumer func(page PaginatedResponse) error
 
-// PaginateAll fetches all pages and aggregates results.
+var paginationCap struct {
+	mu          sync.RWMutex
+	maxResults  int
+	onTruncated func(maxResults int)
+}
+
+// SetPaginationCap caps the records PaginateAll aggregates. onTruncated is
+// called after a capped PaginateAll dropped records. maxResults <= 0 removes
+// the cap.
+func SetPaginationCap(maxResults int, onTruncated func(maxResults int)) {
+	paginationCap.mu.Lock()
+	defer paginationCap.mu.Unlock()
+	paginationCap.maxResults = maxResults
+	paginationCap.onTruncated = onTruncated
+}
+
+// PaginateAll fetches all pages and aggregates results, stopping early at the
+// cap set by SetPaginationCap.
 // It uses reflection to create an empty result container of the same type as
 // firstPage, eliminating the need for a type switch per response type.
 func PaginateAll(ctx context.Context, firstPage PaginatedResponse, fetchNext PaginateFunc) (PaginatedResponse, error) {
+	paginationCap.mu.RLock()
+	maxResults, onTruncated := paginationCap.maxResults, paginationCap.onTruncated
+	paginationCap.mu.RUnlock()
+
+	result, truncated, err := PaginateAllLimit(ctx, firstPage, fetchNext, maxResults)
+	if err == nil && truncated && onTruncated != nil {
+		onTruncated(maxResults)
+	}
+	return result, err
+}
+
+// PaginateAllUncapped is PaginateAll without the SetPaginationCap cap, for
+// lookups that need every record to be correct.
+func PaginateAllUncapped(ctx context.Context, firstPage PaginatedResponse, fetchNext PaginateFunc) (PaginatedResponse, error) {
+	result, _, err := PaginateAllLimit(ctx, firstPage, fetchNext, 0)
+	return result, err
+}
+
+// PaginateAllLimit is PaginateAll with a cap on aggregated records. Once
+// maxResults records are collected it stops fetching, trims the last page, and
+// reports truncated=true when more records were available. maxResults <= 0
+// means no cap.
+func PaginateAllLimit(ctx context.Context, firstPage PaginatedResponse, fetchNext PaginateFunc, maxResults int) (result PaginatedResponse, truncated bool, err error) {
+	return PaginateAllLimitWithProgress(ctx, firstPage, fetchNext, maxResults, nil)
+}
+
+// PaginateProgressFunc is called after each page is aggregated with the number
+// of pages fetched so far and the running record total.
+type PaginateProgressFunc func(pages, records int)
+
+// PaginateAllLimitWithProgress is PaginateAllLimit that reports progress after
+// every page. A nil onPage is ignored.
+func PaginateAllLimitWithProgress(ctx context.Context, firstPage PaginatedResponse, fetchNext PaginateFunc, maxResults int, onPage PaginateProgressFunc) (result PaginatedResponse, truncated bool, err error) {
 	if firstPage == nil {
-		return nil, nil
+		return nil, false, nil
 	}
 
 	// Check for typed nil (non-nil interface containing nil pointer).
 	// Return an empty result of the same type rather than panicking.
 	if reflect.ValueOf(firstPage).IsNil() {
-		return newEmptyPaginatedResponse(firstPage)
+		result, err = newEmptyPaginatedResponse(firstPage)
+		return result, false, err
 	}
 
 	// Create an empty result of the same concrete type using reflection.
-	result, err := newEmptyPaginatedResponse(firstPage)
+	result, err = newEmptyPaginatedResponse(firstPage)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	page := 1
@@ -47,17 +99,28 @@ func PaginateAll(ctx context.Context, firstPage PaginatedResponse, fetchNext Pag
 	for {
 		// Aggregate data from current page using reflection over the Data field.
 		if err := aggregatePageData(result, firstPage); err != nil {
-			return nil, fmt.Errorf("page %d: %w", page, err)
+			return nil, false, fmt.Errorf("page %d: %w", page, err)
+		}
+		if onPage != nil {
+			onPage(page, pageDataLen(result))
 		}
 
 		// Check for next page
 		links := firstPage.GetLinks()
-		if links == nil || links.Next == "" {
+		hasNext := links != nil && links.Next != ""
+		if maxResults > 0 {
+			if collected := pageDataLen(result); collected >= maxResults {
+				truncated = collected > maxResults || hasNext
+				truncatePageData(result, maxResults)
+				break
+			}
+		}
+		if !hasNext {
 			break
 		}
 
 		if _, ok := seenNext[links.Next]; ok {
-			return result, fmt.Errorf("page %d: %w", page+1, ErrRepeatedPaginationURL)
+			return result, false, fmt.Errorf("page %d: %w", page+1, ErrRepeatedPaginationURL)
 		}
 		seenNext[links.Next] = struct{}{}
 		page++
@@ -65,18 +128,18 @@ func PaginateAll(ctx context.Context, firstPage PaginatedResponse, fetchNext Pag
 		// Fetch next page
 		nextPage, err := fetchNext(ctx, links.Next)
 		if err != nil {
-			return result, fmt.Errorf("page %d: %w", page, err)
+			return result, false, fmt.Errorf("page %d: %w", page, err)
 		}
 
 		// Validate that the response type matches
 		if reflect.TypeOf(nextPage) != reflect.TypeOf(firstPage) {
-			return result, fmt.Errorf("page %d: unexpected response type (expected %T, got %T)", page, firstPage, nextPage)
+			return result, false, fmt.Errorf("page %d: unexpected response type (expected %T, got %T)", page, firstPage, nextPage)
 		}
 
 		firstPage = nextPage
 	}
 
-	return result, nil
+	return result, truncated, nil
 }
 
 // PaginateEach iterates pages and invokes consume for each page without
@@ -188,3 +251,32 @@ func aggregatePageData(result, page PaginatedResponse) error {
 	resultData.Set(reflect.AppendSlice(resultData, pageData))
 	return nil
 }
+
+// pageDataLen returns the length of result's Data slice, or 0 when it has none.
+func pageDataLen(result PaginatedResponse) int {
+	data := pageDataField(result)
+	if !data.IsValid() {
+		return 0
+	}
+	return data.Len()
+}
+
+// truncatePageData shortens result's Data slice to at most n records.
+func truncatePageData(result PaginatedResponse, n int) {
+	data := pageDataField(result)
+	if data.IsValid() && data.Len() > n {
+		data.Set(data.Slice(0, n))
+	}
+}
+
+func pageDataField(result PaginatedResponse) reflect.Value {
+	value := reflect.ValueOf(result)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return reflect.Value{}
+	}
+	data := value.Elem().FieldByName("Data")
+	if !data.IsValid() || data.Kind() != reflect.Slice {
+		return reflect.Value{}
+	}
+	return data
+}
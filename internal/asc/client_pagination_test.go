@@ -105,6 +105,81 @@ func TestPaginateAll_MultiPage(t *testing.T) {
 	}
 }
 
+func TestPaginateAllLimit_StopsOnceCapReached(t *testing.T) {
+	const totalPages = 5
+	const perPage = 2
+
+	fetchCalls := 0
+	firstPage := makeBetaGroupsPage(1, perPage, totalPages)
+	result, truncated, err := PaginateAllLimit(context.Background(), firstPage, func(ctx context.Context, nextURL string) (PaginatedResponse, error) {
+		fetchCalls++
+		page, err := parseMockPageNum(nextURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next URL %q: %w", nextURL, err)
+		}
+		return makeBetaGroupsPage(page, perPage, totalPages), nil
+	}, 3)
+	if err != nil {
+		t.Fatalf("PaginateAllLimit() error: %v", err)
+	}
+	if !truncated {
+		t.Fatal("expected truncated result")
+	}
+	if fetchCalls != 1 {
+		t.Fatalf("expected pagination to stop after page 2, got %d fetches", fetchCalls)
+	}
+	groups := result.(*BetaGroupsResponse)
+	if len(groups.Data) != 3 || groups.Data[2].ID != "group-2-0" {
+		t.Fatalf("expected first 3 items, got %+v", groups.Data)
+	}
+}
+
+func TestPaginateAllLimitWithProgress_ReportsRunningTotals(t *testing.T) {
+	const totalPages = 3
+	const perPage = 2
+
+	type progress struct{ pages, records int }
+	var got []progress
+	firstPage := makeBetaGroupsPage(1, perPage, totalPages)
+	_, _, err := PaginateAllLimitWithProgress(context.Background(), firstPage, func(ctx context.Context, nextURL string) (PaginatedResponse, error) {
+		page, err := parseMockPageNum(nextURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next URL %q: %w", nextURL, err)
+		}
+		return makeBetaGroupsPage(page, perPage, totalPages), nil
+	}, 0, func(pages, records int) {
+		got = append(got, progress{pages, records})
+	})
+	if err != nil {
+		t.Fatalf("PaginateAllLimitWithProgress() error: %v", err)
+	}
+	want := []progress{{1, 2}, {2, 4}, {3, 6}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d progress calls, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("progress[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPaginateAllLimit_NotTruncatedWhenCapMatchesTotal(t *testing.T) {
+	firstPage := makeBetaGroupsPage(1, 2, 2)
+	result, truncated, err := PaginateAllLimit(context.Background(), firstPage, func(ctx context.Context, nextURL string) (PaginatedResponse, error) {
+		return makeBetaGroupsPage(2, 2, 2), nil
+	}, 4)
+	if err != nil {
+		t.Fatalf("PaginateAllLimit() error: %v", err)
+	}
+	if truncated {
+		t.Fatal("expected complete result not to be marked truncated")
+	}
+	if groups := result.(*BetaGroupsResponse); len(groups.Data) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(groups.Data))
+	}
+}
+
 func TestPaginateAll_APIErrorOnPageN(t *testing.T) {
 	const totalPages = 5
 	const perPage = 2
@@ -541,10 +541,7 @@ func runAppInfoSetBatch(
 		return buildAppInfoSetBatchResult(appID, versionID, true, results), nil
 	}
 
-	workers := len(locales)
-	if workers > 4 {
-		workers = 4
-	}
+	workers := max(min(len(locales), shared.Concurrency()), 1)
 	sem := make(chan struct{}, workers)
 	var wg sync.WaitGroup
 	for idx, locale := range locales {
@@ -144,7 +144,7 @@ func resolveBuildBetaGroups(ctx context.Context, client *asc.Client, buildID str
 	}
 	allGroups := firstPage
 	if firstPage != nil && firstPage.Links.Next != "" {
-		paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 			return client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsNextURL(nextURL))
 		})
 		if err != nil {
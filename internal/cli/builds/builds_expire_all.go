@@ -93,7 +93,7 @@ Examples:
 				return fmt.Errorf("builds expire-all: failed to fetch: %w", err)
 			}
 
-			allPages, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+			allPages, err := asc.PaginateAllUncapped(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 				return client.GetBuilds(ctx, resolvedAppID, asc.WithBuildsNextURL(nextURL))
 			})
 			if err != nil {
@@ -0,0 +1,92 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestXcodeCloudProductsBuildRunsSortsAggregatedPages(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	const firstURL = "https://api.appstoreconnect.apple.com/v1/ciProducts/prod-1/buildRuns?cursor=AQ&limit=200"
+	const secondURL = "https://api.appstoreconnect.apple.com/v1/ciProducts/prod-1/buildRuns?cursor=BQ&limit=200"
+	bodies := map[string]string{
+		firstURL:  `{"data":[{"type":"ciBuildRuns","id":"run-2","attributes":{"createdDate":"2026-02-01T00:00:00Z"}},{"type":"ciBuildRuns","id":"run-none","attributes":{}}],"links":{"next":"` + secondURL + `"}}`,
+		secondURL: `{"data":[{"type":"ciBuildRuns","id":"run-3","attributes":{"createdDate":"2026-03-01T00:00:00Z"}},{"type":"ciBuildRuns","id":"run-1","attributes":{"createdDate":"2026-01-01T00:00:00Z"}}],"links":{"next":""}}`,
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, ok := bodies[req.URL.String()]
+		if !ok {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{
+			"xcode-cloud", "products", "build-runs",
+			"--paginate", "--next", firstURL,
+			"--sort", "createdDate", "--reverse",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var payload struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	got := make([]string, 0, len(payload.Data))
+	for _, item := range payload.Data {
+		got = append(got, item.ID)
+	}
+	if strings.Join(got, ",") != "run-3,run-2,run-1,run-none" {
+		t.Fatalf("unexpected sort order: %v", got)
+	}
+}
+
+func TestXcodeCloudProductsWorkflowsRejectsUnknownSortField(t *testing.T) {
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"xcode-cloud", "products", "workflows", "--id", "prod-1", "--sort", "createdDate"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if !errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "--sort must be one of: name, lastModifiedDate, isEnabled") {
+		t.Fatalf("expected sort validation error, got %q", stderr)
+	}
+}
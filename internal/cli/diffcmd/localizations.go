@@ -220,7 +220,7 @@ func fetchVersionLocalizations(ctx context.Context, client *asc.Client, versionI
 
 	resp := firstPage
 	if firstPage != nil && firstPage.Links.Next != "" {
-		paginated, err := asc.PaginateAll(
+		paginated, err := asc.PaginateAllUncapped(
 			ctx,
 			firstPage,
 			func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
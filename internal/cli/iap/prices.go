@@ -10,7 +10,6 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -21,7 +20,6 @@ import (
 
 const (
 	iapPricesDateLayout      = "2006-01-02"
-	defaultIAPPricesWorkers  = 4
 	maxIncludedScheduleLimit = 50
 )
 
@@ -122,7 +120,7 @@ Examples:
 					return fmt.Errorf("iap prices: failed to fetch IAP list: %w", err)
 				}
 
-				paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				paginated, err := asc.PaginateAllUncapped(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetInAppPurchasesV2(ctx, resolvedAppID, asc.WithIAPNextURL(nextURL))
 				})
 				if err != nil {
@@ -167,43 +165,17 @@ func resolveIAPPriceSummaries(
 		return []iapPriceSummary{}, nil
 	}
 
-	workers := max(min(len(iaps), defaultIAPPricesWorkers), 1)
-
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	sem := make(chan struct{}, workers)
 	results := make([]iapPriceSummary, len(iaps))
-	errs := make(chan error, len(iaps))
-	var once sync.Once
-	var wg sync.WaitGroup
-
-	for idx := range iaps {
-		wg.Go(func() {
-			select {
-			case sem <- struct{}{}:
-			case <-ctx.Done():
-				return
-			}
-			defer func() { <-sem }()
-
-			summary, err := resolveIAPPriceSummary(ctx, client, iaps[idx], territoryFilter, now)
-			if err != nil {
-				once.Do(cancel)
-				errs <- fmt.Errorf("resolve %s: %w", iaps[idx].ID, err)
-				return
-			}
-			results[idx] = summary
-		})
-	}
-
-	wg.Wait()
-	close(errs)
-
-	for err := range errs {
+	err := shared.ForEachBounded(ctx, len(iaps), shared.Concurrency(), func(ctx context.Context, idx int) error {
+		summary, err := resolveIAPPriceSummary(ctx, client, iaps[idx], territoryFilter, now)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("resolve %s: %w", iaps[idx].ID, err)
 		}
+		results[idx] = summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err := ctx.Err(); err != nil {
@@ -337,7 +309,7 @@ func fetchSchedulePriceEntries(ctx context.Context, fetch schedulePriceFetcher)
 		return nil, err
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return fetch(ctx, asc.WithIAPPriceSchedulePricesNextURL(nextURL))
 	})
 	if err != nil {
@@ -408,7 +380,7 @@ func fetchIAPPricePointValues(
 		return nil, "", fmt.Errorf("fetch price points: %w", err)
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetInAppPurchasePricePoints(ctx, iapID, asc.WithIAPPricePointsNextURL(nextURL))
 	})
 	if err != nil {
@@ -276,7 +276,7 @@ func fetchAppInfoLocalizations(ctx context.Context, client *asc.Client, appInfoI
 		return firstPage.Data, nil
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetAppInfoLocalizations(ctx, appInfoID, asc.WithAppInfoLocalizationsNextURL(nextURL))
 	})
 	if err != nil {
@@ -301,7 +301,7 @@ func fetchVersionLocalizations(ctx context.Context, client *asc.Client, versionI
 		return firstPage.Data, nil
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetAppStoreVersionLocalizations(ctx, versionID, asc.WithAppStoreVersionLocalizationsNextURL(nextURL))
 	})
 	if err != nil {
@@ -204,7 +204,7 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("pre-orders enable: %w", err)
 			}
-			paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+			paginated, err := asc.PaginateAllUncapped(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 				return client.GetTerritoryAvailabilities(ctx, availabilityID, asc.WithTerritoryAvailabilitiesNextURL(nextURL))
 			})
 			if err != nil {
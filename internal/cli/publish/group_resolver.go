@@ -30,7 +30,7 @@ func listAllPublishBetaGroups(ctx context.Context, client publishBetaGroupsClien
 		return firstPage, nil
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsNextURL(nextURL))
 	})
 	if err != nil {
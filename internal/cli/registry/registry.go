@@ -1,9 +1,6 @@
 package registry
 
 import (
-	"context"
-	"fmt"
-
 	"github.com/peterbourgon/ff/v3/ffcli"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/accessibility"
@@ -66,7 +63,6 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/sandbox"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/screenshots"
-	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/signing"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/snitch"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/status"
@@ -84,20 +80,6 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/xcodecloud"
 )
 
-// VersionCommand returns a version subcommand.
-func VersionCommand(version string) *ffcli.Command {
-	return &ffcli.Command{
-		Name:       "version",
-		ShortUsage: "asc version",
-		ShortHelp:  "Print version information and exit.",
-		UsageFunc:  shared.DefaultUsageFunc,
-		Exec: func(ctx context.Context, args []string) error {
-			fmt.Println(version)
-			return nil
-		},
-	}
-}
-
 // Subcommands returns all root subcommands in display order.
 func Subcommands(version string) []*ffcli.Command {
 	subs := []*ffcli.Command{
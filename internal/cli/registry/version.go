@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	defaultBuildVersion = "dev"
+	defaultBuildValue   = "unknown"
+)
+
+// BuildInfo describes the running asc binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+var (
+	buildInfoMu  sync.RWMutex
+	ldflagsBuild *BuildInfo
+
+	readBuildInfoFn = debug.ReadBuildInfo
+)
+
+// SetBuildInfo records the version, commit, and build date embedded via -ldflags.
+func SetBuildInfo(version, commit, date string) {
+	buildInfoMu.Lock()
+	defer buildInfoMu.Unlock()
+	ldflagsBuild = &BuildInfo{Version: version, Commit: commit, Date: date}
+}
+
+// CurrentBuildInfo returns the recorded build info. Values that were not
+// embedded via -ldflags are filled from the Go module build info when available.
+func CurrentBuildInfo() BuildInfo {
+	buildInfoMu.RLock()
+	var info BuildInfo
+	if ldflagsBuild != nil {
+		info = *ldflagsBuild
+	}
+	buildInfoMu.RUnlock()
+	return resolveBuildInfo(info)
+}
+
+func resolveBuildInfo(info BuildInfo) BuildInfo {
+	info.Version = strings.TrimSpace(info.Version)
+	info.Commit = strings.TrimSpace(info.Commit)
+	info.Date = strings.TrimSpace(info.Date)
+
+	if bi, ok := readBuildInfoFn(); ok && bi != nil {
+		if isUnsetBuildValue(info.Version) {
+			if v := strings.TrimSpace(bi.Main.Version); v != "" && v != "(devel)" {
+				info.Version = v
+			}
+		}
+		settings := map[string]string{}
+		for _, setting := range bi.Settings {
+			settings[setting.Key] = setting.Value
+		}
+		if isUnsetBuildValue(info.Commit) {
+			if revision := strings.TrimSpace(settings["vcs.revision"]); revision != "" {
+				if settings["vcs.modified"] == "true" {
+					revision += "-dirty"
+				}
+				info.Commit = revision
+			}
+		}
+		if isUnsetBuildValue(info.Date) {
+			if vcsTime := strings.TrimSpace(settings["vcs.time"]); vcsTime != "" {
+				info.Date = vcsTime
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = defaultBuildVersion
+	}
+	if info.Commit == "" {
+		info.Commit = defaultBuildValue
+	}
+	if info.Date == "" {
+		info.Date = defaultBuildValue
+	}
+	info.GoVersion = runtime.Version()
+	info.Platform = runtime.GOOS + "/" + runtime.GOARCH
+	return info
+}
+
+func isUnsetBuildValue(value string) bool {
+	return value == "" || value == defaultBuildVersion || value == defaultBuildValue
+}
+
+// VersionCommand returns a version subcommand.
+// The version string is printed as-is when no build info was recorded.
+func VersionCommand(version string) *ffcli.Command {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	output := shared.BindOutputFlagsWith(fs, "output", "table", "Output format: table (default), json, markdown")
+
+	return &ffcli.Command{
+		Name:       "version",
+		ShortUsage: "asc version [flags]",
+		ShortHelp:  "Print version information and exit.",
+		LongHelp: `Print the asc version, git commit, build date, and Go runtime.
+
+Include this output when reporting issues.
+
+Table output is the default so scripts reading the version keep working;
+use --output json for the build information as JSON.
+
+Examples:
+  asc version
+  asc version --output json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			buildInfoMu.RLock()
+			recorded := ldflagsBuild != nil
+			buildInfoMu.RUnlock()
+
+			if !recorded && shared.NormalizeOutputFormat(*output.Output) == "table" {
+				fmt.Println(version)
+				return nil
+			}
+
+			var info BuildInfo
+			if recorded {
+				info = CurrentBuildInfo()
+			} else {
+				info = resolveBuildInfo(BuildInfo{Version: version})
+			}
+
+			return shared.PrintOutputWithRenderers(
+				info,
+				*output.Output,
+				*output.Pretty,
+				func() error {
+					fmt.Printf("asc %s\n", info.Version)
+					fmt.Printf("commit: %s\n", info.Commit)
+					fmt.Printf("built: %s\n", info.Date)
+					fmt.Printf("go: %s %s\n", info.GoVersion, info.Platform)
+					return nil
+				},
+				func() error {
+					asc.RenderMarkdown(buildInfoHeaders(), buildInfoRows(info))
+					return nil
+				},
+			)
+		},
+	}
+}
+
+func buildInfoHeaders() []string {
+	return []string{"Field", "Value"}
+}
+
+func buildInfoRows(info BuildInfo) [][]string {
+	return [][]string{
+		{"Version", info.Version},
+		{"Commit", info.Commit},
+		{"Built", info.Date},
+		{"Go", info.GoVersion + " " + info.Platform},
+	}
+}
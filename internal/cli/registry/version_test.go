@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func captureVersionStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	_ = w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read stdout: %v", err)
+	}
+	return string(out)
+}
+
+func stubBuildInfo(t *testing.T, recorded *BuildInfo, module *debug.BuildInfo) {
+	t.Helper()
+	buildInfoMu.Lock()
+	origRecorded := ldflagsBuild
+	ldflagsBuild = recorded
+	buildInfoMu.Unlock()
+	origRead := readBuildInfoFn
+	readBuildInfoFn = func() (*debug.BuildInfo, bool) { return module, module != nil }
+	t.Cleanup(func() {
+		buildInfoMu.Lock()
+		ldflagsBuild = origRecorded
+		buildInfoMu.Unlock()
+		readBuildInfoFn = origRead
+	})
+}
+
+func TestCurrentBuildInfoPrefersLdflags(t *testing.T) {
+	stubBuildInfo(t, nil, &debug.BuildInfo{
+		Main:     debug.Module{Version: "v0.0.1"},
+		Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "fallback"}},
+	})
+	SetBuildInfo("v1.2.3", "abc123", "2026-02-10T00:00:00Z")
+
+	info := CurrentBuildInfo()
+	if info.Version != "v1.2.3" || info.Commit != "abc123" || info.Date != "2026-02-10T00:00:00Z" {
+		t.Fatalf("unexpected build info: %+v", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Fatalf("GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+}
+
+func TestCurrentBuildInfoFallsBackToModuleBuildInfo(t *testing.T) {
+	stubBuildInfo(t, &BuildInfo{Version: "dev", Commit: "unknown", Date: "unknown"}, &debug.BuildInfo{
+		Main: debug.Module{Version: "v0.9.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "deadbeef"},
+			{Key: "vcs.time", Value: "2026-03-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+
+	info := CurrentBuildInfo()
+	if info.Version != "v0.9.0" || info.Commit != "deadbeef-dirty" || info.Date != "2026-03-01T12:00:00Z" {
+		t.Fatalf("unexpected build info: %+v", info)
+	}
+}
+
+func TestCurrentBuildInfoDefaultsWithoutModuleInfo(t *testing.T) {
+	stubBuildInfo(t, &BuildInfo{}, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+
+	info := CurrentBuildInfo()
+	if info.Version != "dev" || info.Commit != "unknown" || info.Date != "unknown" {
+		t.Fatalf("unexpected build info: %+v", info)
+	}
+}
+
+func TestVersionCommandJSON(t *testing.T) {
+	stubBuildInfo(t, &BuildInfo{Version: "v1.2.3", Commit: "abc123", Date: "2026-02-10T00:00:00Z"}, nil)
+
+	cmd := VersionCommand("v1.2.3")
+	if err := cmd.FlagSet.Parse([]string{"--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout := captureVersionStdout(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var info BuildInfo
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if info.Version != "v1.2.3" || info.Commit != "abc123" || info.GoVersion == "" || info.Platform == "" {
+		t.Fatalf("unexpected JSON build info: %+v", info)
+	}
+	if !strings.Contains(stdout, `"go_version"`) {
+		t.Fatalf("expected snake_case go_version key, got %s", stdout)
+	}
+}
+
+func TestVersionCommandText(t *testing.T) {
+	stubBuildInfo(t, &BuildInfo{Version: "v1.2.3", Commit: "abc123", Date: "2026-02-10T00:00:00Z"}, nil)
+
+	stdout := captureVersionStdout(t, func() {
+		if err := VersionCommand("v1.2.3").Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	for _, want := range []string{"asc v1.2.3", "commit: abc123", "built: 2026-02-10T00:00:00Z", "go: " + runtime.Version()} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in output, got %q", want, stdout)
+		}
+	}
+}
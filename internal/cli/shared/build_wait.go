@@ -12,12 +12,10 @@ import (
 // PublishDefaultPollInterval is the default polling interval for build discovery.
 const PublishDefaultPollInterval = 30 * time.Second
 
-// ContextWithTimeoutDuration creates a context with a specific timeout.
+// ContextWithTimeoutDuration creates a context with a specific timeout, capped
+// at the root --timeout deadline.
 func ContextWithTimeoutDuration(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	return context.WithTimeout(ctx, timeout)
+	return contextWithCommandDeadline(ctx, timeout)
 }
 
 // WaitForBuildByNumber waits for a build matching version/build number.
@@ -98,6 +98,10 @@ type PaginatedListCommandConfig struct {
 
 	ContextTimeout func(context.Context) (context.Context, context.CancelFunc)
 	FetchPage      func(context.Context, *asc.Client, string, int, string) (asc.PaginatedResponse, error)
+
+	// SortFields opts the command into --sort/--reverse. Each entry is an
+	// attribute key as it appears in JSON (e.g. "createdDate").
+	SortFields []string
 }
 
 // BuildPaginatedListCommand builds a list command that supports --next and
@@ -122,6 +126,12 @@ func BuildPaginatedListCommand(config PaginatedListCommandConfig) *ffcli.Command
 	limit := fs.Int("limit", 0, fmt.Sprintf("Maximum results per page (1-%d)", limitMax))
 	next := fs.String("next", "", "Fetch next page using a links.next URL")
 	paginate := fs.Bool("paginate", false, "Automatically fetch all pages (aggregate results)")
+	var sortField *string
+	var reverse *bool
+	if len(config.SortFields) > 0 {
+		sortField = fs.String("sort", "", "Sort fetched results by: "+strings.Join(config.SortFields, ", ")+" (use with --paginate to sort everything)")
+		reverse = fs.Bool("reverse", false, "Reverse the sort order (or the API order when --sort is unset)")
+	}
 	output := BindOutputFlags(fs)
 
 	timeout := config.ContextTimeout
@@ -143,6 +153,13 @@ func BuildPaginatedListCommand(config PaginatedListCommandConfig) *ffcli.Command
 			if err := ValidateNextURL(*next); err != nil {
 				return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
 			}
+			sortKey, sortReverse := "", false
+			if sortField != nil {
+				sortKey, sortReverse = strings.TrimSpace(*sortField), *reverse
+				if err := ValidateSort(sortKey, config.SortFields...); err != nil {
+					return UsageError(err.Error())
+				}
+			}
 
 			resolvedParentID := strings.TrimSpace(*parentID)
 			if resolvedParentID == "" && strings.TrimSpace(*next) == "" {
@@ -169,6 +186,9 @@ func BuildPaginatedListCommand(config PaginatedListCommandConfig) *ffcli.Command
 				if err != nil {
 					return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
 				}
+				if err := SortPaginatedData(resp, sortKey, sortReverse); err != nil {
+					return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
+				}
 
 				return PrintOutput(resp, *output.Output, *output.Pretty)
 			}
@@ -177,6 +197,9 @@ func BuildPaginatedListCommand(config PaginatedListCommandConfig) *ffcli.Command
 			if err != nil {
 				return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
 			}
+			if err := SortPaginatedData(resp, sortKey, sortReverse); err != nil {
+				return fmt.Errorf("%s: %w", config.ErrorPrefix, err)
+			}
 
 			return PrintOutput(resp, *output.Output, *output.Pretty)
 		},
@@ -105,3 +105,29 @@ func TestBuildConfirmDeleteCommand_MissingConfirmReturnsUsageError(t *testing.T)
 		t.Fatalf("expected missing confirm usage error, got %q", stderr)
 	}
 }
+
+func TestSortPaginatedDataReverseWithoutField(t *testing.T) {
+	resp := &asc.AppsResponse{Data: []asc.Resource[asc.AppAttributes]{
+		{ID: "app-1"}, {ID: "app-2"}, {ID: "app-3"},
+	}}
+	if err := SortPaginatedData(resp, "", true); err != nil {
+		t.Fatalf("SortPaginatedData() error: %v", err)
+	}
+	if resp.Data[0].ID != "app-3" || resp.Data[2].ID != "app-1" {
+		t.Fatalf("expected API order reversed, got %+v", resp.Data)
+	}
+}
+
+func TestSortPaginatedDataByNumericAttribute(t *testing.T) {
+	resp := &asc.CiBuildRunsResponse{Data: []asc.CiBuildRunResource{
+		{ID: "run-10", Attributes: asc.CiBuildRunAttributes{Number: 10}},
+		{ID: "run-2", Attributes: asc.CiBuildRunAttributes{Number: 2}},
+		{ID: "run-7", Attributes: asc.CiBuildRunAttributes{Number: 7}},
+	}}
+	if err := SortPaginatedData(resp, "number", false); err != nil {
+		t.Fatalf("SortPaginatedData() error: %v", err)
+	}
+	if resp.Data[0].ID != "run-2" || resp.Data[1].ID != "run-7" || resp.Data[2].ID != "run-10" {
+		t.Fatalf("expected numeric order, got %+v", resp.Data)
+	}
+}
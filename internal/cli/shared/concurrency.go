@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency is the fan-out worker limit when --concurrency is unset.
+const DefaultConcurrency = 4
+
+var concurrency int
+
+// setRootConcurrency validates the root --concurrency value.
+func setRootConcurrency(value string) error {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("must be a whole number")
+	}
+	if parsed < 1 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	concurrency = parsed
+	return nil
+}
+
+// Concurrency returns the root --concurrency limit, or DefaultConcurrency
+// when unset. Commands that fan out requests size their worker pools with it.
+func Concurrency() int {
+	if concurrency < 1 {
+		return DefaultConcurrency
+	}
+	return concurrency
+}
+
+// SetConcurrency sets the --concurrency limit (tests only). Zero restores the default.
+func SetConcurrency(value int) {
+	concurrency = value
+}
+
+// ForEachBounded calls fn for each index in [0, count) with at most workers
+// calls in flight. Indexes are handed out in order. The first error cancels
+// the context passed to fn, stops handing out indexes, and is returned once
+// in-flight calls finish; indexes never handed out are not called.
+func ForEachBounded(ctx context.Context, count, workers int, fn func(ctx context.Context, index int) error) error {
+	if count <= 0 {
+		return nil
+	}
+	workers = max(min(count, workers), 1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan int)
+	for range workers {
+		wg.Go(func() {
+			for index := range jobs {
+				if err := fn(ctx, index); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		})
+	}
+
+dispatch:
+	for index := range count {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
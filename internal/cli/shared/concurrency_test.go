@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRootConcurrencyFlag(t *testing.T) {
+	t.Cleanup(func() { SetConcurrency(0) })
+	if got := Concurrency(); got != DefaultConcurrency {
+		t.Fatalf("Concurrency() = %d, want default %d", got, DefaultConcurrency)
+	}
+
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--concurrency", "8"}); err != nil {
+		t.Fatalf("parse root flags: %v", err)
+	}
+	if got := Concurrency(); got != 8 {
+		t.Fatalf("Concurrency() = %d, want 8", got)
+	}
+
+	for _, value := range []string{"0", "-2", "many"} {
+		fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		BindRootFlags(fs)
+		if err := fs.Parse([]string{"--concurrency", value}); err == nil {
+			t.Fatalf("expected --concurrency %q to be rejected", value)
+		}
+	}
+}
+
+func TestForEachBoundedLimitsWorkers(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	var mu sync.Mutex
+	seen := map[int]bool{}
+
+	err := ForEachBounded(context.Background(), 20, 3, func(ctx context.Context, index int) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		mu.Lock()
+		seen[index] = true
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachBounded() error: %v", err)
+	}
+	if len(seen) != 20 {
+		t.Fatalf("expected every index to run, got %d", len(seen))
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("expected at most 3 concurrent calls, saw %d", peak.Load())
+	}
+}
+
+func TestForEachBoundedStopsOnFirstError(t *testing.T) {
+	failure := errors.New("boom")
+	var calls atomic.Int32
+
+	err := ForEachBounded(context.Background(), 50, 1, func(ctx context.Context, index int) error {
+		calls.Add(1)
+		if index == 2 {
+			return failure
+		}
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected first error, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected dispatch to stop after the failure, got %d calls", got)
+	}
+}
+
+func TestForEachBoundedCancelsInFlightCalls(t *testing.T) {
+	failure := errors.New("boom")
+	started := make(chan struct{})
+
+	err := ForEachBounded(context.Background(), 2, 2, func(ctx context.Context, index int) error {
+		if index == 0 {
+			<-started
+			return failure
+		}
+		close(started)
+		<-ctx.Done()
+		if !strings.Contains(ctx.Err().Error(), "canceled") {
+			t.Errorf("unexpected context error %v", ctx.Err())
+		}
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package shared
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+)
+
+const csvOutputFormat = "csv"
+
+// WriteCSV writes headers and rows to w as RFC 4180 CSV.
+// Fields containing commas, quotes, or newlines are quoted by encoding/csv.
+// A nil or empty headers slice skips the header record.
+func WriteCSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if len(headers) > 0 {
+		if err := writer.Write(headers); err != nil {
+			return fmt.Errorf("write csv header: %w", err)
+		}
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
+
+// PrintCSV writes headers and rows to stdout, or --out-file, as CSV.
+func PrintCSV(headers []string, rows [][]string) error {
+	return WithOutputDestination(func() error {
+		return WriteCSV(os.Stdout, headers, rows)
+	})
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSVQuotesSpecialCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"Name", "Value"}
+	rows := [][]string{
+		{"plain", "value"},
+		{"comma", "a,b"},
+		{"quote", `say "hi"`},
+		{"newline", "line1\nline2"},
+	}
+	if err := WriteCSV(&buf, headers, rows); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+
+	want := "Name,Value\nplain,value\ncomma,\"a,b\"\nquote,\"say \"\"hi\"\"\"\nnewline,\"line1\nline2\"\n"
+	if buf.String() != want {
+		t.Fatalf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read back CSV: %v", err)
+	}
+	if len(records) != len(rows)+1 || records[3][1] != `say "hi"` {
+		t.Fatalf("unexpected round-trip records: %#v", records)
+	}
+}
+
+func TestWriteCSVWithoutHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, nil, [][]string{{"a", "b"}}); err != nil {
+		t.Fatalf("WriteCSV() error: %v", err)
+	}
+	if buf.String() != "a,b\n" {
+		t.Fatalf("WriteCSV() = %q, want %q", buf.String(), "a,b\n")
+	}
+}
+
+func TestWriteCSVReportsWriterErrors(t *testing.T) {
+	err := WriteCSV(failingWriter{}, []string{"Name"}, [][]string{{"value"}})
+	if err == nil || !strings.Contains(err.Error(), "write failed") {
+		t.Fatalf("expected writer error, got %v", err)
+	}
+}
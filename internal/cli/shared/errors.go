@@ -52,3 +52,41 @@ func UsageError(message string) error {
 func UsageErrorf(format string, args ...any) error {
 	return UsageError(fmt.Sprintf(format, args...))
 }
+
+// Exit codes that commands can request with NewExitCodeError when a plain
+// non-zero exit cannot tell the outcomes apart.
+const (
+	ExitNotificationFailed       = 6 // Notification delivery failed; no threshold breach
+	ExitBreachNotificationFailed = 7 // Threshold breach and notification delivery failed
+)
+
+// ExitCoder is an error that selects its own process exit code.
+type ExitCoder interface {
+	error
+	ExitCode() int
+}
+
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e exitCodeError) Unwrap() error {
+	return e.err
+}
+
+func (e exitCodeError) ExitCode() int {
+	return e.code
+}
+
+// NewExitCodeError wraps err so the process exits with code.
+func NewExitCodeError(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return exitCodeError{err: err, code: code}
+}
@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared/suggest"
+)
+
+// undefinedFlagPrefix starts the error the flag package prints for an
+// unknown flag, before it prints usage and exits.
+const undefinedFlagPrefix = "flag provided but not defined: "
+
+// InstallFlagSuggestions makes cmd and its subcommands follow an unknown flag
+// error with the closest defined flag names, so a typo such as --produt-ids
+// points at --product-ids. The flag package exits on the error itself, so the
+// hook is the flag set's output writer, which sees the error line first.
+func InstallFlagSuggestions(cmd *ffcli.Command) {
+	if cmd == nil {
+		return
+	}
+	for _, sub := range cmd.Subcommands {
+		InstallFlagSuggestions(sub)
+	}
+	if cmd.FlagSet == nil {
+		return
+	}
+	if _, ok := cmd.FlagSet.Output().(*flagSuggestionWriter); ok {
+		return
+	}
+	cmd.FlagSet.SetOutput(&flagSuggestionWriter{fs: cmd.FlagSet})
+}
+
+// flagSuggestionWriter writes to the current os.Stderr, the flag package
+// default, and appends suggestions after an unknown flag error.
+type flagSuggestionWriter struct {
+	fs *flag.FlagSet
+}
+
+func (w *flagSuggestionWriter) Write(p []byte) (int, error) {
+	n, err := os.Stderr.Write(p)
+	if err != nil {
+		return n, err
+	}
+	name, ok := strings.CutPrefix(strings.TrimSpace(string(p)), undefinedFlagPrefix)
+	if !ok {
+		return n, nil
+	}
+	if suggestions := suggestFlags(w.fs, name); len(suggestions) > 0 {
+		fmt.Fprintf(os.Stderr, "Did you mean: %s\n\n", strings.Join(suggestions, ", "))
+	}
+	return n, nil
+}
+
+func suggestFlags(fs *flag.FlagSet, input string) []string {
+	names := make([]string, 0)
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	suggestions := suggest.Flags(input, names)
+	for i, suggestion := range suggestions {
+		suggestions[i] = SanitizeTerminal(suggestion)
+	}
+	return suggestions
+}
@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+func TestInstallFlagSuggestionsSuggestsClosestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("usage days", flag.ContinueOnError)
+	fs.String("product-ids", "", "Product IDs")
+	fs.String("start", "", "Start date")
+	sub := &ffcli.Command{Name: "days", FlagSet: fs}
+	root := &ffcli.Command{Name: "usage", FlagSet: flag.NewFlagSet("usage", flag.ContinueOnError), Subcommands: []*ffcli.Command{sub}}
+	InstallFlagSuggestions(root)
+	InstallFlagSuggestions(root)
+
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"days", "--produt-ids", "prod-1"}); err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+	})
+	if !strings.Contains(stderr, "flag provided but not defined: -produt-ids\nDid you mean: --product-ids\n") {
+		t.Fatalf("expected flag suggestion, got %q", stderr)
+	}
+	if strings.Count(stderr, "Did you mean") != 1 {
+		t.Fatalf("expected one suggestion line, got %q", stderr)
+	}
+}
+
+func TestInstallFlagSuggestionsSkipsUnrelatedFlags(t *testing.T) {
+	fs := flag.NewFlagSet("usage days", flag.ContinueOnError)
+	fs.String("product-ids", "", "Product IDs")
+	cmd := &ffcli.Command{Name: "days", FlagSet: fs}
+	InstallFlagSuggestions(cmd)
+
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Parse([]string{"--zzzzzzzzzz"}); err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+	})
+	if !strings.Contains(stderr, "flag provided but not defined: -zzzzzzzzzz") || strings.Contains(stderr, "Did you mean") {
+		t.Fatalf("expected error without suggestion, got %q", stderr)
+	}
+}
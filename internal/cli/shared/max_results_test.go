@@ -0,0 +1,101 @@
+package shared
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestRootMaxResultsFlag(t *testing.T) {
+	t.Cleanup(func() { SetMaxResults(0) })
+
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--max-results", "500"}); err != nil {
+		t.Fatalf("parse root flags: %v", err)
+	}
+	if got := MaxResults(); got != 500 {
+		t.Fatalf("expected max results 500, got %d", got)
+	}
+
+	for _, value := range []string{"0", "-5", "many"} {
+		fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		BindRootFlags(fs)
+		if err := fs.Parse([]string{"--max-results", value}); err == nil {
+			t.Fatalf("expected error for --max-results %q", value)
+		}
+	}
+}
+
+func TestPaginateWithSpinnerHonorsMaxResults(t *testing.T) {
+	SetMaxResults(1)
+	t.Cleanup(func() { SetMaxResults(0) })
+
+	page := func(id, next string) *asc.AppsResponse {
+		return &asc.AppsResponse{
+			Data:  []asc.Resource[asc.AppAttributes]{{Type: asc.ResourceTypeApps, ID: id}},
+			Links: asc.Links{Next: next},
+		}
+	}
+	var result asc.PaginatedResponse
+	_, stderr := captureOutput(t, func() {
+		var err error
+		result, err = PaginateWithSpinner(context.Background(),
+			func(ctx context.Context) (asc.PaginatedResponse, error) { return page("app-1", "next"), nil },
+			func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				t.Fatal("expected no further page fetches")
+				return nil, nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("PaginateWithSpinner() error: %v", err)
+		}
+	})
+	if apps := result.(*asc.AppsResponse); len(apps.Data) != 1 {
+		t.Fatalf("expected 1 app, got %d", len(apps.Data))
+	}
+	if !strings.Contains(stderr, "results truncated to 1 records (--max-results)") {
+		t.Fatalf("expected truncation warning, got %q", stderr)
+	}
+}
+
+func TestMaxResultsCapsPaginateAll(t *testing.T) {
+	SetMaxResults(1)
+	t.Cleanup(func() { SetMaxResults(0) })
+
+	first := &asc.AppsResponse{
+		Data:  []asc.Resource[asc.AppAttributes]{{Type: asc.ResourceTypeApps, ID: "app-1"}},
+		Links: asc.Links{Next: "next"},
+	}
+	next := func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return &asc.AppsResponse{
+			Data: []asc.Resource[asc.AppAttributes]{{Type: asc.ResourceTypeApps, ID: "app-2"}},
+		}, nil
+	}
+
+	var capped, uncapped asc.PaginatedResponse
+	_, stderr := captureOutput(t, func() {
+		var err error
+		if capped, err = asc.PaginateAll(context.Background(), first, next); err != nil {
+			t.Fatalf("PaginateAll() error: %v", err)
+		}
+		if uncapped, err = asc.PaginateAllUncapped(context.Background(), first, next); err != nil {
+			t.Fatalf("PaginateAllUncapped() error: %v", err)
+		}
+	})
+	if apps := capped.(*asc.AppsResponse); len(apps.Data) != 1 {
+		t.Fatalf("expected PaginateAll to stop at 1 app, got %d", len(apps.Data))
+	}
+	if apps := uncapped.(*asc.AppsResponse); len(apps.Data) != 2 {
+		t.Fatalf("expected PaginateAllUncapped to return 2 apps, got %d", len(apps.Data))
+	}
+	if strings.Count(stderr, "results truncated to 1 records (--max-results)") != 1 {
+		t.Fatalf("expected one truncation warning, got %q", stderr)
+	}
+}
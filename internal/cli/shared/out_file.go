@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// outputFilePath holds the --out-file value for commands bound with the shared
+// output flags or BindOutFileFlag. Empty means stdout.
+var outputFilePath string
+
+// BindOutFileFlag registers --out-file. Commands bound with the shared output
+// flags get it automatically; commands that print their own format call it
+// directly and route their output through WithOutputDestination.
+func BindOutFileFlag(fs *flag.FlagSet) {
+	fs.StringVar(&outputFilePath, "out-file", "", "Write the rendered output (any format) to PATH instead of stdout; parent directories are created and the file is replaced atomically")
+}
+
+// WithOutputDestination runs render with stdout redirected to --out-file when
+// it is set. Output is written to a temporary file in the destination
+// directory and renamed into place only when render succeeds, so readers never
+// observe a partial file and a failed render leaves any previous file intact.
+func WithOutputDestination(render func() error) error {
+	path := strings.TrimSpace(outputFilePath)
+	if path == "" {
+		return render()
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("--out-file: refusing to follow symlink %q", path)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("--out-file: %q is a directory", path)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+	tempName := tempFile.Name()
+	committed := false
+	defer func() {
+		_ = tempFile.Close()
+		if !committed {
+			_ = os.Remove(tempName)
+		}
+	}()
+	if err := tempFile.Chmod(0o600); err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = tempFile
+	renderErr := render()
+	os.Stdout = stdout
+	if renderErr != nil {
+		return renderErr
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+	if err := os.Rename(tempName, path); err != nil {
+		return fmt.Errorf("--out-file: %w", err)
+	}
+	committed = true
+	return nil
+}
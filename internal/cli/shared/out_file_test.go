@@ -0,0 +1,112 @@
+package shared
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func bindOutFileTestFlags(t *testing.T, args ...string) OutputFlags {
+	t.Helper()
+	t.Cleanup(func() { outputFilePath = "" })
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := BindOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	return output
+}
+
+func TestPrintOutputWritesOutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "result.json")
+	output := bindOutFileTestFlags(t, "--output", "json", "--out-file", path)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(map[string]int{"used": 3}, *output.Output, *output.Pretty); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected nothing on stdout, got %q", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read out file: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != `{"used":3}` {
+		t.Fatalf("unexpected file contents %q", data)
+	}
+}
+
+func TestPrintOutputWithRenderersWritesTableToOutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.txt")
+	output := bindOutFileTestFlags(t, "--output", "table", "--out-file", path)
+
+	stdout, _ := captureOutput(t, func() {
+		err := PrintOutputWithRenderers(nil, *output.Output, *output.Pretty, func() error {
+			_, err := os.Stdout.WriteString("rendered table\n")
+			return err
+		}, nil)
+		if err != nil {
+			t.Fatalf("PrintOutputWithRenderers() error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected nothing on stdout, got %q", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read out file: %v", err)
+	}
+	if string(data) != "rendered table\n" {
+		t.Fatalf("unexpected file contents %q", data)
+	}
+}
+
+func TestOutFileRenderFailureKeepsPreviousFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "result.txt")
+	if err := os.WriteFile(path, []byte("previous\n"), 0o600); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+	output := bindOutFileTestFlags(t, "--output", "table", "--out-file", path)
+
+	renderErr := errors.New("render failed")
+	err := PrintOutputWithRenderers(nil, *output.Output, *output.Pretty, func() error {
+		_, _ = os.Stdout.WriteString("partial")
+		return renderErr
+	}, nil)
+	if !errors.Is(err, renderErr) {
+		t.Fatalf("expected render error, got %v", err)
+	}
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("read out file: %v", readErr)
+	}
+	if string(data) != "previous\n" {
+		t.Fatalf("expected previous contents to be kept, got %q", data)
+	}
+	entries, readErr := os.ReadDir(dir)
+	if readErr != nil {
+		t.Fatalf("read dir: %v", readErr)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected temporary file to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestOutFileDefaultsToStdout(t *testing.T) {
+	output := bindOutFileTestFlags(t, "--output", "json")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(map[string]int{"used": 1}, *output.Output, *output.Pretty); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if strings.TrimSpace(stdout) != `{"used":1}` {
+		t.Fatalf("expected JSON on stdout, got %q", stdout)
+	}
+}
@@ -0,0 +1,108 @@
+package shared
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+// EnvelopeCounter is implemented by list-shaped results whose record count
+// is not a top-level slice, Data list, or Items list.
+type EnvelopeCounter interface {
+	EnvelopeCount() int
+}
+
+type outputEnvelope struct {
+	Data any                `json:"data"`
+	Meta outputEnvelopeMeta `json:"meta"`
+}
+
+type outputEnvelopeMeta struct {
+	Command     string `json:"command"`
+	GeneratedAt string `json:"generated_at"`
+	TeamID      string `json:"team_id,omitempty"`
+	Count       *int   `json:"count,omitempty"`
+}
+
+var (
+	envelopeOutput  bool
+	envelopeCommand string
+	envelopeTeamID  string
+	envelopeNowFn   = time.Now
+)
+
+// SetEnvelopeTeamID records the team the current command acts on for the
+// --envelope metadata.
+func SetEnvelopeTeamID(teamID string) {
+	envelopeTeamID = strings.TrimSpace(teamID)
+}
+
+// SetEnvelopeOutput toggles --envelope and clears recorded metadata (tests only).
+func SetEnvelopeOutput(enabled bool) {
+	envelopeOutput = enabled
+	envelopeCommand = ""
+	envelopeTeamID = ""
+}
+
+func setEnvelopeCommand(path []*ffcli.Command) {
+	names := make([]string, 0, len(path)+1)
+	names = append(names, "asc")
+	for _, cmd := range path {
+		names = append(names, cmd.Name)
+	}
+	envelopeCommand = strings.Join(names, " ")
+}
+
+// wrapOutputEnvelope wraps JSON output data with command metadata when
+// --envelope is set.
+func wrapOutputEnvelope(data any) any {
+	if !envelopeOutput {
+		return data
+	}
+	return outputEnvelope{
+		Data: data,
+		Meta: outputEnvelopeMeta{
+			Command:     envelopeCommand,
+			GeneratedAt: envelopeNowFn().UTC().Format(time.RFC3339),
+			TeamID:      envelopeTeamID,
+			Count:       envelopeCount(data),
+		},
+	}
+}
+
+// envelopeCount returns the record count for list-shaped data, or nil when
+// the data is a single object.
+func envelopeCount(data any) *int {
+	if counter, ok := data.(EnvelopeCounter); ok && !isNilValue(data) {
+		count := counter.EnvelopeCount()
+		return &count
+	}
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		count := value.Len()
+		return &count
+	case reflect.Struct:
+		for _, name := range []string{"Data", "Items"} {
+			field := value.FieldByName(name)
+			if field.IsValid() && field.Kind() == reflect.Slice {
+				count := field.Len()
+				return &count
+			}
+		}
+	}
+	return nil
+}
+
+func isNilValue(data any) bool {
+	value := reflect.ValueOf(data)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
@@ -0,0 +1,118 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+type envelopeCounterResult struct {
+	Rows []string `json:"rows"`
+}
+
+func (r *envelopeCounterResult) EnvelopeCount() int { return len(r.Rows) }
+
+func TestPrintOutputEnvelopeWrapsJSON(t *testing.T) {
+	SetEnvelopeOutput(false)
+	prevNow := envelopeNowFn
+	t.Cleanup(func() {
+		SetEnvelopeOutput(false)
+		envelopeNowFn = prevNow
+	})
+	envelopeNowFn = func() time.Time { return time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC) }
+
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--envelope"}); err != nil {
+		t.Fatalf("parse root flags: %v", err)
+	}
+
+	leaf := &ffcli.Command{Name: "list", FlagSet: flag.NewFlagSet("list", flag.ContinueOnError)}
+	group := &ffcli.Command{Name: "apps", Subcommands: []*ffcli.Command{leaf}}
+	leaf.Exec = func(ctx context.Context, args []string) error {
+		SetEnvelopeTeamID("TEAM-1")
+		return PrintOutput(&asc.AppsResponse{Data: []asc.Resource[asc.AppAttributes]{{ID: "a"}, {ID: "b"}}}, "json", false)
+	}
+	WrapCommandOutputValidation(group)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := leaf.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var envelope struct {
+		Data struct {
+			Data []map[string]any `json:"data"`
+		} `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if len(envelope.Data.Data) != 2 {
+		t.Fatalf("expected wrapped data, got %s", stdout)
+	}
+	want := map[string]any{
+		"command":      "asc apps list",
+		"generated_at": "2026-03-01T12:00:00Z",
+		"team_id":      "TEAM-1",
+		"count":        float64(2),
+	}
+	for key, value := range want {
+		if envelope.Meta[key] != value {
+			t.Fatalf("meta[%q] = %v, want %v (meta %v)", key, envelope.Meta[key], value, envelope.Meta)
+		}
+	}
+}
+
+func TestPrintOutputWithoutEnvelopeIsUnchanged(t *testing.T) {
+	SetEnvelopeOutput(false)
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(map[string]string{"id": "a"}, "json", false); err != nil {
+			t.Fatalf("print error: %v", err)
+		}
+	})
+	if stdout != "{\"id\":\"a\"}\n" {
+		t.Fatalf("expected raw JSON, got %q", stdout)
+	}
+}
+
+func TestEnvelopeCount(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want int
+		none bool
+	}{
+		{name: "slice", data: []int{1, 2, 3}, want: 3},
+		{name: "data list", data: &asc.AppsResponse{Data: []asc.Resource[asc.AppAttributes]{{ID: "a"}}}, want: 1},
+		{name: "items list", data: struct{ Items []string }{Items: []string{"a", "b"}}, want: 2},
+		{name: "counter", data: &envelopeCounterResult{Rows: []string{"a", "b", "c", "d"}}, want: 4},
+		{name: "single object", data: map[string]string{"id": "a"}, none: true},
+		{name: "struct without list", data: struct{ ID string }{ID: "a"}, none: true},
+		{name: "nil", data: nil, none: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := envelopeCount(test.data)
+			if test.none {
+				if got != nil {
+					t.Fatalf("expected no count, got %d", *got)
+				}
+				return
+			}
+			if got == nil || *got != test.want {
+				t.Fatalf("envelopeCount() = %v, want %d", got, test.want)
+			}
+		})
+	}
+}
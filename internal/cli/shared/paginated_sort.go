@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// SortPaginatedData sorts resp's Data slice in place by the attribute whose
+// JSON key is field, then reverses it when reverse is set. Resources missing
+// the attribute (or with an empty string) sort last in either direction. An empty field with reverse
+// unset is a no-op.
+func SortPaginatedData(resp asc.PaginatedResponse, field string, reverse bool) error {
+	if field == "" && !reverse {
+		return nil
+	}
+	value := reflect.ValueOf(resp)
+	if !value.IsValid() || value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+	data := value.Elem().FieldByName("Data")
+	if !data.IsValid() || data.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot sort %T: no Data list", resp)
+	}
+
+	if field == "" {
+		swap := reflect.Swapper(data.Interface())
+		for i, j := 0, data.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+		return nil
+	}
+
+	keys := make([]reflect.Value, data.Len())
+	for i := range keys {
+		keys[i] = resourceAttribute(data.Index(i), field)
+	}
+	order := make([]int, data.Len())
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		keyA, keyB := keys[order[a]], keys[order[b]]
+		switch {
+		case !keyA.IsValid() || !keyB.IsValid():
+			return keyA.IsValid() && !keyB.IsValid()
+		case reverse:
+			return compareAttributeValues(keyB, keyA) < 0
+		default:
+			return compareAttributeValues(keyA, keyB) < 0
+		}
+	})
+
+	sorted := reflect.MakeSlice(data.Type(), data.Len(), data.Len())
+	for i, idx := range order {
+		sorted.Index(i).Set(data.Index(idx))
+	}
+	data.Set(sorted)
+	return nil
+}
+
+// resourceAttribute returns the Attributes field tagged json:"field", or an
+// invalid Value when the resource has no such attribute or it is unset.
+func resourceAttribute(resource reflect.Value, field string) reflect.Value {
+	resource = reflect.Indirect(resource)
+	if resource.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	attributes := reflect.Indirect(resource.FieldByName("Attributes"))
+	if !attributes.IsValid() || attributes.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	attributesType := attributes.Type()
+	for i := range attributesType.NumField() {
+		name, _, _ := strings.Cut(attributesType.Field(i).Tag.Get("json"), ",")
+		if name != field {
+			continue
+		}
+		attr := reflect.Indirect(attributes.Field(i))
+		if !attr.IsValid() || (attr.Kind() == reflect.String && attr.String() == "") {
+			return reflect.Value{}
+		}
+		return attr
+	}
+	return reflect.Value{}
+}
+
+// compareAttributeValues orders scalar attributes. Dates are ISO 8601 strings,
+// so they order correctly as text.
+func compareAttributeValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+	case reflect.String:
+		return strings.Compare(strings.ToLower(a.String()), strings.ToLower(b.String()))
+	default:
+		return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	}
+}
+
+func boolRank(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
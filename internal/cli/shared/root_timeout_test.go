@@ -0,0 +1,105 @@
+package shared
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestRootTimeoutFlagOverridesContextDeadline(t *testing.T) {
+	t.Setenv("ASC_TIMEOUT", "5s")
+	t.Setenv("ASC_TIMEOUT_SECONDS", "")
+	asc.SetTimeoutOverride(nil)
+	t.Cleanup(func() {
+		asc.SetTimeoutOverride(nil)
+		commandDeadline = time.Time{}
+	})
+
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--timeout", "5m"}); err != nil {
+		t.Fatalf("parse root flags: %v", err)
+	}
+
+	ctx, cancel := ContextWithTimeout(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected context deadline")
+	}
+	if remaining := time.Until(deadline); remaining < 4*time.Minute {
+		t.Fatalf("expected deadline near 5m, got %s", remaining)
+	}
+}
+
+func TestRootTimeoutFlagRejectsInvalidValues(t *testing.T) {
+	asc.SetTimeoutOverride(nil)
+	t.Cleanup(func() { asc.SetTimeoutOverride(nil) })
+
+	tests := []struct {
+		value   string
+		wantErr string
+	}{
+		{value: "soon", wantErr: "must be a duration"},
+		{value: "0s", wantErr: "must be greater than 0"},
+		{value: "-1m", wantErr: "must be greater than 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			BindRootFlags(fs)
+			err := fs.Parse([]string{"--timeout", tt.value})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q error, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRootTimeoutFlagBoundsEveryContextOfTheCommand(t *testing.T) {
+	t.Setenv("ASC_UPLOAD_TIMEOUT", "1h")
+	asc.SetTimeoutOverride(nil)
+	t.Cleanup(func() {
+		asc.SetTimeoutOverride(nil)
+		commandDeadline = time.Time{}
+	})
+
+	fs := flag.NewFlagSet("asc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	BindRootFlags(fs)
+	if err := fs.Parse([]string{"--timeout", "5m"}); err != nil {
+		t.Fatalf("parse root flags: %v", err)
+	}
+
+	first, cancelFirst := ContextWithTimeout(context.Background())
+	defer cancelFirst()
+	time.Sleep(10 * time.Millisecond)
+	contexts := map[string]func(context.Context) (context.Context, context.CancelFunc){
+		"timeout": ContextWithTimeout,
+		"upload":  ContextWithUploadTimeout,
+		"duration": func(ctx context.Context) (context.Context, context.CancelFunc) {
+			return ContextWithTimeoutDuration(ctx, time.Hour)
+		},
+	}
+	want, _ := first.Deadline()
+	for name, newContext := range contexts {
+		ctx, cancel := newContext(context.Background())
+		deadline, ok := ctx.Deadline()
+		cancel()
+		if !ok || !deadline.Equal(want) {
+			t.Fatalf("%s: expected the command deadline %s, got %s", name, want, deadline)
+		}
+	}
+
+	BindRootFlags(flag.NewFlagSet("asc", flag.ContinueOnError))
+	if !commandDeadline.IsZero() {
+		t.Fatalf("expected BindRootFlags to reset the command deadline, got %s", commandDeadline)
+	}
+}
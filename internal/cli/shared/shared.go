@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"text/tabwriter"
@@ -67,8 +68,13 @@ var (
 	selectedProfile     string
 	strictAuth          bool
 	retryLog            OptionalBool
+	jsonCompact         bool
 	debug               OptionalBool
 	apiDebug            OptionalBool
+	maxResults          int
+	// commandDeadline is set by --timeout and caps every context the command
+	// creates, so the flag bounds the whole command.
+	commandDeadline time.Time
 
 	getCredentialsWithSourceFn = auth.GetCredentialsWithSource
 )
@@ -85,15 +91,59 @@ func BindRootFlags(fs *flag.FlagSet) {
 	retryLog.EnableBoolFlag()
 	debug.EnableBoolFlag()
 	apiDebug.EnableBoolFlag()
+	commandDeadline = time.Time{}
 
 	fs.StringVar(&selectedProfile, "profile", "", "Use named authentication profile")
 	fs.BoolVar(&strictAuth, "strict-auth", false, "Fail when credentials are resolved from multiple sources")
 	fs.Var(&retryLog, "retry-log", "Enable retry logging to stderr (overrides ASC_RETRY_LOG/config when set)")
 	fs.Var(&debug, "debug", "Enable debug logging to stderr")
 	fs.Var(&apiDebug, "api-debug", "Enable HTTP debug logging to stderr (redacts sensitive values)")
+	fs.Func("timeout", "Deadline for the entire command, including all fan-out requests (e.g. 90s, 5m; overrides ASC_TIMEOUT/config)", setRootTimeout)
+	fs.BoolVar(&envelopeOutput, "envelope", false, "Wrap JSON output as {data, meta} with command, generated_at, team_id, and count")
+	fs.Func("max-results", "Stop --paginate and other paginated lists once N records are collected and note the truncation on stderr", setRootMaxResults)
+	fs.Func("concurrency", "Maximum concurrent requests for commands that fan out (default 4): iap prices, subscriptions pricing, app-info set, web xcode-cloud env-vars list-all/audit/rotate/set, web xcode-cloud usage top", setRootConcurrency)
 	BindCIFlags(fs)
 }
 
+// setRootTimeout validates the root --timeout value, applies it as the request
+// timeout override, and starts the deadline for the rest of the invocation.
+func setRootTimeout(value string) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("must be a duration such as 90s or 5m")
+	}
+	if parsed <= 0 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	asc.SetTimeoutOverride(&parsed)
+	commandDeadline = time.Now().Add(parsed)
+	return nil
+}
+
+// setRootMaxResults validates the root --max-results value.
+func setRootMaxResults(value string) error {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("must be a whole number")
+	}
+	if parsed < 1 {
+		return fmt.Errorf("must be greater than 0")
+	}
+	SetMaxResults(parsed)
+	return nil
+}
+
+// MaxResults returns the root --max-results cap, or 0 when unset.
+func MaxResults() int {
+	return maxResults
+}
+
+// SetMaxResults sets the --max-results cap and applies it to asc.PaginateAll.
+func SetMaxResults(value int) {
+	maxResults = value
+	asc.SetPaginationCap(value, warnResultsTruncated)
+}
+
 // SelectedProfile returns the current profile override.
 func SelectedProfile() string {
 	return selectedProfile
@@ -640,6 +690,15 @@ func resetInvalidStrictAuthWarnings() {
 }
 
 func printOutput(data any, format string, pretty bool) error {
+	return WithOutputDestination(func() error {
+		return renderOutput(data, format, pretty)
+	})
+}
+
+func renderOutput(data any, format string, pretty bool) error {
+	if NormalizeOutputFormat(format) == templateOutputFormat {
+		return printTemplateOutput(data, pretty)
+	}
 	format, err := validateOutputFormat(format, pretty)
 	if err != nil {
 		return err
@@ -657,6 +716,15 @@ func printOutput(data any, format string, pretty bool) error {
 }
 
 func printOutputWithRenderers(data any, format string, pretty bool, tableRenderer, markdownRenderer func() error) error {
+	return WithOutputDestination(func() error {
+		return renderOutputWithRenderers(data, format, pretty, tableRenderer, markdownRenderer)
+	})
+}
+
+func renderOutputWithRenderers(data any, format string, pretty bool, tableRenderer, markdownRenderer func() error) error {
+	if NormalizeOutputFormat(format) == templateOutputFormat {
+		return printTemplateOutput(data, pretty)
+	}
 	format, err := validateOutputFormat(format, pretty)
 	if err != nil {
 		return err
@@ -679,8 +747,11 @@ func printOutputWithRenderers(data any, format string, pretty bool, tableRendere
 	}
 }
 
+// printJSONOutput prints compact JSON unless --pretty is set; --json-compact
+// always wins so piped output stays single-line.
 func printJSONOutput(data any, pretty bool) error {
-	if pretty {
+	data = wrapOutputEnvelope(data)
+	if pretty && !jsonCompact {
 		return asc.PrintPrettyJSON(data)
 	}
 	return asc.PrintJSON(data)
@@ -844,6 +915,8 @@ func BindPrettyJSONFlag(fs *flag.FlagSet) *bool {
 
 func bindPrettyJSONFlagWithValue(fs *flag.FlagSet, value *bool) *bool {
 	fs.BoolVar(value, "pretty", false, "Pretty-print JSON output")
+	fs.BoolVar(&jsonCompact, "json-compact", false, "Force single-line compact JSON output (wins over --pretty)")
+	BindOutFileFlag(fs)
 	return value
 }
 
@@ -921,6 +994,7 @@ func wrapCommandOutputValidation(cmd *ffcli.Command, parents []*ffcli.Command) {
 		if err := validateCommandOutputPath(path); err != nil {
 			return UsageError(err.Error())
 		}
+		setEnvelopeCommand(path)
 		return originalExec(ctx, args)
 	}
 }
@@ -940,17 +1014,25 @@ func resolveAppID(appID string) string {
 }
 
 func contextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	return context.WithTimeout(ctx, asc.ResolveTimeout())
+	return contextWithCommandDeadline(ctx, asc.ResolveTimeout())
 }
 
 func contextWithUploadTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return contextWithCommandDeadline(ctx, asc.ResolveUploadTimeout())
+}
+
+// contextWithCommandDeadline returns a context that expires after timeout, or
+// at the --timeout deadline when that comes first, so commands that create
+// several contexts stay within one deadline.
+func contextWithCommandDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	return context.WithTimeout(ctx, asc.ResolveUploadTimeout())
+	deadline := time.Now().Add(timeout)
+	if !commandDeadline.IsZero() && commandDeadline.Before(deadline) {
+		deadline = commandDeadline
+	}
+	return context.WithDeadline(ctx, deadline)
 }
 
 func splitCSV(value string) []string {
@@ -433,6 +433,31 @@ func TestPrintOutputWithRenderers_JSONPrettyPath(t *testing.T) {
 	}
 }
 
+func TestPrintOutputWithRenderers_JSONCompactWinsOverPretty(t *testing.T) {
+	t.Cleanup(func() { jsonCompact = false })
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := BindOutputFlags(fs)
+	if err := fs.Parse([]string{"--output", "json", "--pretty", "--json-compact"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutputWithRenderers(
+			map[string]any{"status": "ok", "items": []string{"a", "b"}},
+			*output.Output,
+			*output.Pretty,
+			func() error { t.Fatal("table renderer should not run"); return nil },
+			func() error { t.Fatal("markdown renderer should not run"); return nil },
+		); err != nil {
+			t.Fatalf("PrintOutputWithRenderers() error = %v", err)
+		}
+	})
+	if strings.Count(stdout, "\n") != 1 || !strings.Contains(stdout, `"status":"ok"`) {
+		t.Fatalf("expected single-line compact JSON, got %q", stdout)
+	}
+}
+
 func TestPrintOutputWithRenderers_EmptyFormatDefaultsJSON(t *testing.T) {
 	stdout, _ := captureOutput(t, func() {
 		if err := PrintOutputWithRenderers(
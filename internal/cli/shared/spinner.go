@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -18,6 +19,38 @@ var spinnerFrames = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "
 
 const spinnerTickRate = 120 * time.Millisecond
 
+var activeSpinner struct {
+	mu sync.Mutex
+	s  *spinner
+}
+
+// StopActiveSpinner stops the spinner drawing on stderr, if any, so an
+// interactive prompt gets a clean line. The spinner stays stopped for the
+// rest of its operation; a delayed spinner that has not started yet will not
+// start.
+func StopActiveSpinner() {
+	activeSpinner.mu.Lock()
+	s := activeSpinner.s
+	activeSpinner.mu.Unlock()
+	if s != nil {
+		s.Stop()
+	}
+}
+
+// setActiveSpinner makes s the spinner StopActiveSpinner stops and returns a
+// function that restores the previous one.
+func setActiveSpinner(s *spinner) func() {
+	activeSpinner.mu.Lock()
+	prev := activeSpinner.s
+	activeSpinner.s = s
+	activeSpinner.mu.Unlock()
+	return func() {
+		activeSpinner.mu.Lock()
+		activeSpinner.s = prev
+		activeSpinner.mu.Unlock()
+	}
+}
+
 // SpinnerEnabled reports whether the CLI should render an indeterminate spinner
 // on stderr for the current run.
 //
@@ -57,7 +90,9 @@ func WithSpinner(label string, fn func() error) (err error) {
 
 	s := newSpinner(os.Stderr)
 	s.Start(label)
+	restore := setActiveSpinner(s)
 	defer func() {
+		restore()
 		s.Stop()
 		if r := recover(); r != nil {
 			panic(r)
@@ -83,6 +118,8 @@ func WithSpinnerDelayed(label string, delay time.Duration, fn func() error) (err
 	}
 
 	s := newSpinner(os.Stderr)
+	restore := setActiveSpinner(s)
+	defer restore()
 	done := make(chan struct{})
 	started := make(chan bool, 1)
 
@@ -130,26 +167,78 @@ func spinnerDisabledByEnv() bool {
 	}
 }
 
+// WithSpinnerProgress is WithSpinner for operations that report progress:
+// fn receives an update function that replaces the spinner label. When the
+// spinner is disabled (for example, stderr or stdout is not a TTY), update is
+// a no-op so nothing extra is written.
+func WithSpinnerProgress(label string, fn func(update func(label string)) error) (err error) {
+	if fn == nil {
+		return nil
+	}
+	if !SpinnerEnabled() {
+		return fn(func(string) {})
+	}
+
+	s := newSpinner(os.Stderr)
+	s.Start(label)
+	restore := setActiveSpinner(s)
+	defer func() {
+		restore()
+		s.Stop()
+		if r := recover(); r != nil {
+			panic(r)
+		}
+	}()
+	return fn(s.SetLabel)
+}
+
 // FetchFunc fetches the first page of a paginated resource.
 type FetchFunc func(ctx context.Context) (asc.PaginatedResponse, error)
 
 // PaginateWithSpinner fetches all pages with a spinner on stderr.
 // It wraps both the initial fetch and the pagination loop so the spinner
-// is visible even for single-page results.
+// is visible even for single-page results, and labels it with the pages and
+// records fetched so far. Pagination stops early once the root --max-results
+// cap is reached, with a truncation warning on stderr.
 func PaginateWithSpinner(ctx context.Context, fetch FetchFunc, next asc.PaginateFunc) (asc.PaginatedResponse, error) {
 	var result asc.PaginatedResponse
-	err := WithSpinner("", func() error {
+	truncated := false
+	err := WithSpinnerProgress("", func(update func(string)) error {
 		firstPage, fetchErr := fetch(ctx)
 		if fetchErr != nil {
 			return fetchErr
 		}
 		var paginateErr error
-		result, paginateErr = asc.PaginateAll(ctx, firstPage, next)
+		result, truncated, paginateErr = asc.PaginateAllLimitWithProgress(ctx, firstPage, next, maxResults, func(pages, records int) {
+			update(paginationProgressLabel(pages, records))
+		})
 		return paginateErr
 	})
+	if err == nil && truncated {
+		warnResultsTruncated(maxResults)
+	}
 	return result, err
 }
 
+// warnResultsTruncated notes on stderr that --max-results cut a list short.
+func warnResultsTruncated(limit int) {
+	fmt.Fprintf(os.Stderr, "Warning: results truncated to %d records (--max-results); more are available\n", limit)
+}
+
+// paginationProgressLabel formats the spinner label, e.g.
+// "Fetched 3 pages, 600 records".
+func paginationProgressLabel(pages, records int) string {
+	pageNoun := "pages"
+	if pages == 1 {
+		pageNoun = "page"
+	}
+	recordNoun := "records"
+	if records == 1 {
+		recordNoun = "record"
+	}
+	return fmt.Sprintf("Fetched %d %s, %d %s", pages, pageNoun, records, recordNoun)
+}
+
 func debugOrRetryLogsEnabled() bool {
 	// Root-level flags should take effect immediately, even before shared.GetASCClient() applies
 	// overrides into the asc package, so we need to resolve “effective” values here.
@@ -185,8 +274,11 @@ type spinner struct {
 	stopCh   chan struct{}
 	doneCh   chan struct{}
 
-	mu     sync.Mutex
-	maxLen int // rune count of the longest line written (for clearing)
+	mu      sync.Mutex
+	label   string
+	maxLen  int  // rune count of the longest line written (for clearing)
+	started bool // Start has launched the render loop
+	halted  bool // Stop was called; a later Start is a no-op
 }
 
 func newSpinner(w io.Writer) *spinner {
@@ -201,10 +293,17 @@ func newSpinner(w io.Writer) *spinner {
 }
 
 func (s *spinner) Start(label string) {
-	label = strings.TrimSpace(label)
+	s.mu.Lock()
+	if s.halted || s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = true
+	s.label = strings.TrimSpace(label)
+	s.mu.Unlock()
 
 	// Render immediately (helps with short-running operations).
-	s.renderLine(spinnerLine(spinnerFrames[0], label))
+	s.renderLine(spinnerLine(spinnerFrames[0], s.currentLabel()))
 
 	go func() {
 		ticker := time.NewTicker(spinnerTickRate)
@@ -220,13 +319,33 @@ func (s *spinner) Start(label string) {
 			case <-ticker.C:
 				frame := spinnerFrames[i%len(spinnerFrames)]
 				i++
-				s.renderLine(spinnerLine(frame, label))
+				s.renderLine(spinnerLine(frame, s.currentLabel()))
 			}
 		}
 	}()
 }
 
+// SetLabel replaces the label shown from the next frame on.
+func (s *spinner) SetLabel(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.label = strings.TrimSpace(label)
+}
+
+func (s *spinner) currentLabel() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.label
+}
+
 func (s *spinner) Stop() {
+	s.mu.Lock()
+	s.halted = true
+	started := s.started
+	s.mu.Unlock()
+	if !started {
+		return
+	}
 	s.stopOnce.Do(func() {
 		close(s.stopCh)
 		<-s.doneCh
@@ -349,3 +349,97 @@ func TestWithSpinnerDelayed_StartsWhenSlow(t *testing.T) {
 		t.Fatalf("expected delayed spinner to render initial frame + label, got %q", stderr)
 	}
 }
+
+func TestStopActiveSpinner_ClearsLineBeforePrompt(t *testing.T) {
+	resetSpinnerTestState(t)
+	t.Setenv(spinnerDisabledEnvVar, "0")
+
+	_, stderr := captureOutput(t, func() {
+		withTTYStub(t, true, true)
+
+		if err := WithSpinner("Working", func() error {
+			StopActiveSpinner()
+			_, _ = os.Stderr.WriteString("Password: ")
+			time.Sleep(3 * spinnerTickRate)
+			return nil
+		}); err != nil {
+			t.Fatalf("WithSpinner() error: %v", err)
+		}
+	})
+
+	if !strings.HasSuffix(stderr, "\rPassword: ") {
+		t.Fatalf("expected the spinner to be cleared and stay stopped after the prompt, got %q", stderr)
+	}
+}
+
+func TestStopActiveSpinner_KeepsDelayedSpinnerFromStarting(t *testing.T) {
+	resetSpinnerTestState(t)
+	t.Setenv(spinnerDisabledEnvVar, "0")
+
+	_, stderr := captureOutput(t, func() {
+		withTTYStub(t, true, true)
+
+		if err := WithSpinnerDelayed("Working", 10*time.Millisecond, func() error {
+			StopActiveSpinner()
+			time.Sleep(30 * time.Millisecond)
+			return nil
+		}); err != nil {
+			t.Fatalf("WithSpinnerDelayed() error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected a stopped delayed spinner never to render, got %q", stderr)
+	}
+}
+
+func TestWithSpinnerProgress_UpdatesLabel(t *testing.T) {
+	resetSpinnerTestState(t)
+	t.Setenv(spinnerDisabledEnvVar, "0")
+
+	_, stderr := captureOutput(t, func() {
+		withTTYStub(t, true, true)
+
+		err := WithSpinnerProgress("", func(update func(string)) error {
+			update(paginationProgressLabel(3, 600))
+			time.Sleep(3 * spinnerTickRate)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithSpinnerProgress() error: %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, " Fetched 3 pages, 600 records") {
+		t.Fatalf("expected progress label in spinner output, got %q", stderr)
+	}
+}
+
+func TestWithSpinnerProgress_SilentWhenDisabled(t *testing.T) {
+	resetSpinnerTestState(t)
+
+	_, stderr := captureOutput(t, func() {
+		withTTYStub(t, false, true)
+
+		err := WithSpinnerProgress("", func(update func(string)) error {
+			update(paginationProgressLabel(2, 400))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithSpinnerProgress() error: %v", err)
+		}
+	})
+
+	if stderr != "" {
+		t.Fatalf("expected no progress output when not a TTY, got %q", stderr)
+	}
+}
+
+func TestPaginationProgressLabel(t *testing.T) {
+	if got := paginationProgressLabel(1, 1); got != "Fetched 1 page, 1 record" {
+		t.Fatalf("unexpected singular label %q", got)
+	}
+	if got := paginationProgressLabel(3, 600); got != "Fetched 3 pages, 600 records" {
+		t.Fatalf("unexpected plural label %q", got)
+	}
+}
@@ -69,6 +69,16 @@ func Commands(input string, candidates []string) []string {
 	return out
 }
 
+// Flags returns up to a few likely flag-name suggestions, formatted as
+// --name, for an unknown flag. Leading dashes on the input are ignored.
+func Flags(input string, candidates []string) []string {
+	suggestions := Commands(strings.TrimLeft(strings.TrimSpace(input), "-"), candidates)
+	for i, suggestion := range suggestions {
+		suggestions[i] = "--" + suggestion
+	}
+	return suggestions
+}
+
 func withinThreshold(input string, dist int) bool {
 	n := len(input)
 	// Conservative default thresholds that work well for short command names.
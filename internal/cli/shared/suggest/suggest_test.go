@@ -25,6 +25,16 @@ func TestCommandsConservativeBehavior(t *testing.T) {
 	}
 }
 
+func TestFlagsSuggestsDashedNames(t *testing.T) {
+	got := Flags("-produt-ids", []string{"product-ids", "product-id", "output"})
+	if len(got) == 0 || got[0] != "--product-ids" {
+		t.Fatalf("expected --product-ids suggestion, got %v", got)
+	}
+	if got := Flags("--zzzzzzzzzz", []string{"product-ids", "output"}); len(got) != 0 {
+		t.Fatalf("expected no suggestions, got %v", got)
+	}
+}
+
 func TestLevenshteinAndThresholdHelpers(t *testing.T) {
 	if d := levenshtein("apps", "apps"); d != 0 {
 		t.Fatalf("expected equal strings distance 0, got %d", d)
@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+)
+
+// AllowTSVOutput adds tsv to the formats accepted by the --output flag bound
+// on fs. Commands that call it must print with PrintOutputWithTabular.
+func AllowTSVOutput(fs *flag.FlagSet) {
+	allowOutputFormat(fs, tsvOutputFormat)
+}
+
+// AllowCSVOutput adds csv to the formats accepted by the --output flag bound
+// on fs. Commands that call it must print with PrintOutputWithTabular.
+func AllowCSVOutput(fs *flag.FlagSet) {
+	allowOutputFormat(fs, csvOutputFormat)
+}
+
+func allowOutputFormat(fs *flag.FlagSet, format string) {
+	f := fs.Lookup("output")
+	if f == nil {
+		return
+	}
+	value, ok := f.Value.(*validatedOutputValue)
+	if !ok || slices.Contains(value.allowed, format) {
+		return
+	}
+	value.allowed = append(value.allowed, format)
+	f.Usage += ", " + format
+}
+
+// PrintOutputWithTabular is PrintOutputWithRenderers plus --output csv and
+// tsv, which print the headers and rows returned by tabular.
+func PrintOutputWithTabular(
+	data any,
+	format string,
+	pretty bool,
+	tableRenderer, markdownRenderer func() error,
+	tabular func() ([]string, [][]string),
+) error {
+	normalized := NormalizeOutputFormat(format)
+	if normalized != csvOutputFormat && normalized != tsvOutputFormat {
+		return printOutputWithRenderers(data, format, pretty, tableRenderer, markdownRenderer)
+	}
+	if pretty {
+		return fmt.Errorf("--pretty is only valid with JSON output")
+	}
+	if tabular == nil {
+		return fmt.Errorf("%s rows are required", normalized)
+	}
+	headers, rows := tabular()
+	if normalized == csvOutputFormat {
+		return PrintCSV(headers, rows)
+	}
+	return PrintTSV(headers, rows)
+}
@@ -0,0 +1,73 @@
+package shared
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAllowTSVOutputAcceptsTSV(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	output := BindOutputFlags(fs)
+	if err := fs.Parse([]string{"--output", "tsv"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := ValidateBoundOutputFlags(fs); err == nil {
+		t.Fatal("expected tsv to be rejected before AllowTSVOutput")
+	}
+
+	AllowTSVOutput(fs)
+	AllowTSVOutput(fs)
+	if err := ValidateBoundOutputFlags(fs); err != nil {
+		t.Fatalf("expected tsv to be accepted, got %v", err)
+	}
+	if usage := fs.Lookup("output").Usage; !strings.HasSuffix(usage, "markdown, tsv") {
+		t.Fatalf("unexpected usage %q", usage)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		err := PrintOutputWithTabular(nil, *output.Output, *output.Pretty, nil, nil, func() ([]string, [][]string) {
+			return []string{"ID", "Name"}, [][]string{{"1", "Alpha"}}
+		})
+		if err != nil {
+			t.Fatalf("PrintOutputWithTabular() error: %v", err)
+		}
+	})
+	if stdout != "ID\tName\n1\tAlpha\n" {
+		t.Fatalf("unexpected tsv output %q", stdout)
+	}
+}
+
+func TestPrintOutputWithTabularRejectsPretty(t *testing.T) {
+	err := PrintOutputWithTabular(nil, "tsv", true, nil, nil, func() ([]string, [][]string) { return nil, nil })
+	if err == nil || !strings.Contains(err.Error(), "--pretty is only valid with JSON output") {
+		t.Fatalf("expected --pretty error, got %v", err)
+	}
+}
+
+func TestAllowCSVOutputPrintsQuotedCSV(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	output := BindOutputFlags(fs)
+	AllowCSVOutput(fs)
+	if err := fs.Parse([]string{"--output", "csv"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := ValidateBoundOutputFlags(fs); err != nil {
+		t.Fatalf("expected csv to be accepted, got %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		err := PrintOutputWithTabular(nil, *output.Output, *output.Pretty, nil, nil, func() ([]string, [][]string) {
+			return []string{"ID", "Name"}, [][]string{{"1", `Build, "Test"`}}
+		})
+		if err != nil {
+			t.Fatalf("PrintOutputWithTabular() error: %v", err)
+		}
+	})
+	if stdout != "ID,Name\n1,\"Build, \"\"Test\"\"\"\n" {
+		t.Fatalf("unexpected csv output %q", stdout)
+	}
+}
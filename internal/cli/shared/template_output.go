@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const templateOutputFormat = "template"
+
+// outputTemplateText holds the --template value for commands bound with
+// BindOutputFlagsWithTemplate.
+var outputTemplateText string
+
+// BindOutputFlagsWithTemplate registers --output and --pretty like
+// BindOutputFlags, additionally allowing --output template with --template.
+// The template is parsed during output validation, before Exec runs.
+func BindOutputFlagsWithTemplate(fs *flag.FlagSet) OutputFlags {
+	output := BindOutputFlagsWithAllowed(
+		fs,
+		"output",
+		DefaultOutputFormat(),
+		"Output format: json, table, markdown, template",
+		"json", "table", "markdown", templateOutputFormat,
+	)
+	outputTemplateText = ""
+	fs.Var(&templateFlagValue{text: &outputTemplateText, format: output.Output}, "template", "Go text/template rendered against the result (requires --output template)")
+	return output
+}
+
+type templateFlagValue struct {
+	text   *string
+	format *string
+}
+
+func (v *templateFlagValue) String() string {
+	if v == nil || v.text == nil {
+		return ""
+	}
+	return *v.text
+}
+
+func (v *templateFlagValue) Set(value string) error {
+	if v == nil || v.text == nil {
+		return fmt.Errorf("template flag is not initialized")
+	}
+	*v.text = value
+	return nil
+}
+
+func (v *templateFlagValue) Validate() error {
+	if v == nil || v.text == nil || v.format == nil {
+		return nil
+	}
+	text := strings.TrimSpace(*v.text)
+	if NormalizeOutputFormat(*v.format) != templateOutputFormat {
+		if text != "" {
+			return fmt.Errorf("--template requires --output template")
+		}
+		return nil
+	}
+	_, err := parseOutputTemplate(text)
+	return err
+}
+
+func parseOutputTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("--template is required with --output template")
+	}
+	tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printTemplateOutput renders data through the --template value and prints it
+// with a trailing newline. Missing fields and map keys are reported as errors.
+func printTemplateOutput(data any, pretty bool) error {
+	if pretty {
+		return fmt.Errorf("--pretty is only valid with JSON output")
+	}
+	tmpl, err := parseOutputTemplate(outputTemplateText)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("template output failed: %w", err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = os.Stdout.Write(buf.Bytes())
+	return err
+}
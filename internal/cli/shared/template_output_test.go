@@ -0,0 +1,134 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+)
+
+type templateTestPlan struct {
+	Used  int
+	Total int
+}
+
+type templateTestResult struct {
+	Plan templateTestPlan
+}
+
+func bindTemplateTestFlags(t *testing.T, args ...string) OutputFlags {
+	t.Helper()
+	t.Cleanup(func() { outputTemplateText = "" })
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := BindOutputFlagsWithTemplate(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := ValidateBoundOutputFlags(fs); err != nil {
+		t.Fatalf("ValidateBoundOutputFlags() error: %v", err)
+	}
+	return output
+}
+
+func TestPrintOutputWithRenderers_Template(t *testing.T) {
+	output := bindTemplateTestFlags(t, "--output", "template", "--template", "{{.Plan.Used}}/{{.Plan.Total}}")
+
+	stdout, _ := captureOutput(t, func() {
+		err := PrintOutputWithRenderers(templateTestResult{Plan: templateTestPlan{Used: 30, Total: 1500}}, *output.Output, *output.Pretty, nil, nil)
+		if err != nil {
+			t.Fatalf("PrintOutputWithRenderers() error: %v", err)
+		}
+	})
+	if stdout != "30/1500\n" {
+		t.Fatalf("expected rendered template, got %q", stdout)
+	}
+}
+
+func TestPrintOutput_TemplateMissingFieldErrors(t *testing.T) {
+	output := bindTemplateTestFlags(t, "--output", "template", "--template", "{{.Plan.Missing}}")
+
+	var err error
+	stdout, _ := captureOutput(t, func() {
+		err = PrintOutput(templateTestResult{}, *output.Output, *output.Pretty)
+	})
+	if err == nil || !strings.Contains(err.Error(), "template output failed") || !strings.Contains(err.Error(), "Missing") {
+		t.Fatalf("expected missing field error, got %v", err)
+	}
+	if stdout != "" {
+		t.Fatalf("expected no partial output, got %q", stdout)
+	}
+}
+
+func TestBindOutputFlagsWithTemplate_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "missing template", args: []string{"--output", "template"}, wantErr: "--template is required with --output template"},
+		{name: "parse error", args: []string{"--output", "template", "--template", "{{.Plan"}, wantErr: "invalid --template"},
+		{name: "template without output", args: []string{"--output", "json", "--template", "{{.}}"}, wantErr: "--template requires --output template"},
+		{name: "pretty", args: []string{"--output", "template", "--template", "{{.}}", "--pretty"}, wantErr: "--pretty is only valid with JSON output"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Cleanup(func() { outputTemplateText = "" })
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			BindOutputFlagsWithTemplate(fs)
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			err := ValidateBoundOutputFlags(fs)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWrapCommandOutputValidation_TemplateParseErrorSkipsExec(t *testing.T) {
+	t.Cleanup(func() { outputTemplateText = "" })
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindOutputFlagsWithTemplate(fs)
+	executed := false
+	cmd := &ffcli.Command{
+		Name:    "test",
+		FlagSet: fs,
+		Exec: func(ctx context.Context, args []string) error {
+			executed = true
+			return nil
+		},
+	}
+	WrapCommandOutputValidation(cmd)
+	if err := fs.Parse([]string{"--output", "template", "--template", "{{if}}"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var err error
+	_, stderr := captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", err)
+	}
+	if !strings.Contains(stderr, "invalid --template") {
+		t.Fatalf("expected template parse error on stderr, got %q", stderr)
+	}
+	if executed {
+		t.Fatal("expected Exec to be skipped when the template is invalid")
+	}
+}
+
+func TestBindOutputFlags_RejectsTemplateWithoutOptIn(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindOutputFlags(fs)
+	if err := fs.Parse([]string{"--output", "template"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if err := ValidateBoundOutputFlags(fs); err == nil || !strings.Contains(err.Error(), "unsupported format: template") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const tsvOutputFormat = "tsv"
+
+// tsvFieldReplacer flattens characters that would break a TSV record.
+var tsvFieldReplacer = strings.NewReplacer("\r\n", " ", "\t", " ", "\n", " ", "\r", " ")
+
+// WriteTSV writes headers and rows to w as tab-separated values. Fields are
+// never quoted; embedded tabs and newlines are replaced with spaces so every
+// record stays on one line for cut and awk.
+// A nil or empty headers slice skips the header record.
+func WriteTSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := bufio.NewWriter(w)
+	writeRecord := func(fields []string) {
+		for i, field := range fields {
+			if i > 0 {
+				writer.WriteByte('\t')
+			}
+			writer.WriteString(tsvFieldReplacer.Replace(field))
+		}
+		writer.WriteByte('\n')
+	}
+	if len(headers) > 0 {
+		writeRecord(headers)
+	}
+	for _, row := range rows {
+		writeRecord(row)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("write tsv: %w", err)
+	}
+	return nil
+}
+
+// PrintTSV writes headers and rows to stdout, or --out-file, as TSV.
+func PrintTSV(headers []string, rows [][]string) error {
+	return WithOutputDestination(func() error {
+		return WriteTSV(os.Stdout, headers, rows)
+	})
+}
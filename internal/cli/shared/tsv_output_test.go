@@ -0,0 +1,32 @@
+package shared
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTSVReplacesTabsAndNewlines(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTSV(&buf, []string{"Name", "Value"}, [][]string{
+		{"KEY", "a\tb"},
+		{"MULTI", "line1\nline2\r\nline3"},
+		{"QUOTED", `"kept", as-is`},
+	})
+	if err != nil {
+		t.Fatalf("WriteTSV() error: %v", err)
+	}
+	want := "Name\tValue\nKEY\ta b\nMULTI\tline1 line2 line3\nQUOTED\t\"kept\", as-is\n"
+	if buf.String() != want {
+		t.Fatalf("WriteTSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteTSVSkipsEmptyHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteTSV(&buf, nil, [][]string{{"a", "b"}}); err != nil {
+		t.Fatalf("WriteTSV() error: %v", err)
+	}
+	if buf.String() != "a\tb\n" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
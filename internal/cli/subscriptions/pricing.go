@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -17,8 +16,7 @@ import (
 )
 
 const (
-	defaultSubscriptionPricingWorkers = 4
-	subscriptionPricingDateLayout     = "2006-01-02"
+	subscriptionPricingDateLayout = "2006-01-02"
 )
 
 type subWithGroup struct {
@@ -114,7 +112,7 @@ Examples:
 					return fmt.Errorf("subscriptions pricing: failed to fetch groups: %w", err)
 				}
 
-				paginatedGroups, err := asc.PaginateAll(ctx, groupsResp, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				paginatedGroups, err := asc.PaginateAllUncapped(ctx, groupsResp, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
 					defer pageCancel()
 					return client.GetSubscriptionGroups(pageCtx, resolvedAppID, asc.WithSubscriptionGroupsNextURL(nextURL))
@@ -185,43 +183,17 @@ func resolveSubscriptionPriceSummaries(
 		return []subscriptionPriceSummary{}, nil
 	}
 
-	workers := max(min(len(subs), defaultSubscriptionPricingWorkers), 1)
-
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	sem := make(chan struct{}, workers)
 	results := make([]subscriptionPriceSummary, len(subs))
-	errs := make(chan error, len(subs))
-	var once sync.Once
-	var wg sync.WaitGroup
-
-	for idx := range subs {
-		wg.Go(func() {
-			select {
-			case sem <- struct{}{}:
-			case <-ctx.Done():
-				return
-			}
-			defer func() { <-sem }()
-
-			summary, err := resolveSubscriptionPriceSummary(ctx, client, subs[idx], territory)
-			if err != nil {
-				once.Do(cancel)
-				errs <- fmt.Errorf("resolve %s: %w", subs[idx].Sub.ID, err)
-				return
-			}
-			results[idx] = summary
-		})
-	}
-
-	wg.Wait()
-	close(errs)
-
-	for err := range errs {
+	err := shared.ForEachBounded(ctx, len(subs), shared.Concurrency(), func(ctx context.Context, idx int) error {
+		summary, err := resolveSubscriptionPriceSummary(ctx, client, subs[idx], territory)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("resolve %s: %w", subs[idx].Sub.ID, err)
 		}
+		results[idx] = summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if err := ctx.Err(); err != nil {
@@ -350,7 +350,7 @@ func paginateBetaGroups(ctx context.Context, client testFlightSyncClient, appID
 	if firstPage == nil {
 		return &asc.BetaGroupsResponse{}, nil
 	}
-	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	allPages, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetBetaGroups(ctx, appID, asc.WithBetaGroupsNextURL(nextURL))
 	})
 	if err != nil {
@@ -370,7 +370,7 @@ func paginateBetaGroupBuilds(ctx context.Context, client testFlightSyncClient, g
 	if firstPage == nil {
 		return &asc.BuildsResponse{}, nil
 	}
-	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	allPages, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetBetaGroupBuilds(ctx, groupID, asc.WithBetaGroupBuildsNextURL(nextURL))
 	})
 	if err != nil {
@@ -390,7 +390,7 @@ func paginateBetaGroupTesters(ctx context.Context, client testFlightSyncClient,
 	if firstPage == nil {
 		return &asc.BetaTestersResponse{}, nil
 	}
-	allPages, err := asc.PaginateAll(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	allPages, err := asc.PaginateAllUncapped(ctx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		return client.GetBetaGroupTesters(ctx, groupID, asc.WithBetaGroupTestersNextURL(nextURL))
 	})
 	if err != nil {
@@ -74,7 +74,7 @@ func runValidateIAP(ctx context.Context, opts validateIAPOptions) error {
 		return fmt.Errorf("validate iap: failed to fetch in-app purchases: %w", err)
 	}
 
-	paginated, err := asc.PaginateAll(ctx, firstPage, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginated, err := asc.PaginateAllUncapped(ctx, firstPage, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
 		defer pageCancel()
 		return client.GetInAppPurchasesV2(pageCtx, opts.AppID, asc.WithIAPNextURL(nextURL))
@@ -28,7 +28,7 @@ func fetchSubscriptions(ctx context.Context, client *asc.Client, appID string) (
 		return nil, fmt.Errorf("failed to fetch subscription groups: %w", err)
 	}
 
-	paginatedGroups, err := asc.PaginateAll(ctx, groupsResp, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
+	paginatedGroups, err := asc.PaginateAllUncapped(ctx, groupsResp, func(_ context.Context, nextURL string) (asc.PaginatedResponse, error) {
 		pageCtx, pageCancel := shared.ContextWithTimeout(ctx)
 		defer pageCancel()
 		return client.GetSubscriptionGroups(pageCtx, appID, asc.WithSubscriptionGroupsNextURL(nextURL))
@@ -212,10 +212,11 @@ Required:
   --name, --bundle-id, --sku
 
 Authentication:
-  --apple-id with one of:
+  --apple-id (or ASC_APPLE_ID) with one of:
     - secure interactive prompt (default and recommended for local use)
-    - ASC_WEB_PASSWORD environment variable
+    - ASC_WEB_PASSWORD or ASC_APPLE_PASSWORD environment variable
   If you already have a cached web session, --apple-id can be omitted.
+  See "asc web auth login --help" for the security tradeoffs of environment credentials.
 
 ` + webWarningText + `
 
@@ -17,7 +17,14 @@ import (
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
-const webPasswordEnv = "ASC_WEB_PASSWORD"
+const (
+	webPasswordEnv = "ASC_WEB_PASSWORD"
+
+	// Fallbacks for non-interactive runs; an explicit flag always wins.
+	webAppleIDEnv       = "ASC_APPLE_ID"
+	webApplePasswordEnv = "ASC_APPLE_PASSWORD"
+	webTwoFactorCodeEnv = "ASC_2FA_CODE"
+)
 
 var (
 	promptTwoFactorCodeFn           = promptTwoFactorCodeInteractive
@@ -29,6 +36,7 @@ var (
 	tryResumeSessionFn              = webcore.TryResumeSession
 	tryResumeLastFn                 = webcore.TryResumeLastSession
 	resolveSessionFn                = resolveSession
+	loginSessionFn                  = loginWebSession
 	sessionExpiredWriter  io.Writer = os.Stderr
 )
 
@@ -40,9 +48,10 @@ type webAuthStatus struct {
 	ProviderID    int64  `json:"providerId,omitempty"`
 }
 
+// readPasswordFromInput reads ASC_WEB_PASSWORD, then ASC_APPLE_PASSWORD, and
+// falls back to an interactive prompt.
 func readPasswordFromInput() (string, error) {
-	password := strings.TrimSpace(os.Getenv(webPasswordEnv))
-	if password != "" {
+	if password := passwordFromEnv(); password != "" {
 		return password, nil
 	}
 	password, err := promptPasswordFn()
@@ -52,6 +61,17 @@ func readPasswordFromInput() (string, error) {
 	return strings.TrimSpace(password), nil
 }
 
+// passwordFromEnv returns ASC_WEB_PASSWORD, then ASC_APPLE_PASSWORD, or ""
+// when neither is set.
+func passwordFromEnv() string {
+	for _, envName := range []string{webPasswordEnv, webApplePasswordEnv} {
+		if password := strings.TrimSpace(os.Getenv(envName)); password != "" {
+			return password
+		}
+	}
+	return ""
+}
+
 func readPasswordFromTerminalFD(fd int, writer io.Writer) (string, error) {
 	if writer == nil {
 		return "", fmt.Errorf("password prompt unavailable")
@@ -190,11 +210,20 @@ func tryResumeLastWebSession(ctx context.Context) (*webcore.AuthSession, bool, e
 	return session, ok, err
 }
 
+// flagOrEnv returns the trimmed flag value, or the named environment variable
+// when the flag is empty.
+func flagOrEnv(value, envName string) string {
+	if value = strings.TrimSpace(value); value != "" {
+		return value
+	}
+	return strings.TrimSpace(os.Getenv(envName))
+}
+
 func resolveSession(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
 	shared.ApplyRootLoggingOverrides()
 
-	appleID = strings.TrimSpace(appleID)
-	twoFactorCode = strings.TrimSpace(twoFactorCode)
+	appleID = flagOrEnv(appleID, webAppleIDEnv)
+	twoFactorCode = flagOrEnv(twoFactorCode, webTwoFactorCodeEnv)
 	cacheExpired := false
 
 	if appleID != "" {
@@ -214,8 +243,18 @@ func resolveSession(ctx context.Context, appleID, password, twoFactorCode string
 		printExpiredSessionNotice(sessionExpiredWriter)
 	}
 
+	session, err := loginSessionFn(ctx, appleID, password, twoFactorCode)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, "fresh", nil
+}
+
+// loginWebSession signs in without consulting the session cache and caches
+// the new session.
+func loginWebSession(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, error) {
 	if appleID == "" {
-		return nil, "", shared.UsageError("--apple-id is required when no cached web session is available")
+		return nil, shared.UsageError("--apple-id (or ASC_APPLE_ID) is required when no cached web session is available")
 	}
 
 	password = strings.TrimSpace(password)
@@ -223,21 +262,21 @@ func resolveSession(ctx context.Context, appleID, password, twoFactorCode string
 		var err error
 		password, err = readPasswordFromInput()
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 	}
 	if password == "" {
-		return nil, "", shared.UsageError("password is required: run in a terminal for an interactive prompt or set ASC_WEB_PASSWORD")
+		return nil, shared.UsageError("password is required: run in a terminal for an interactive prompt or set ASC_WEB_PASSWORD (or ASC_APPLE_PASSWORD)")
 	}
 
 	session, err := loginWithOptionalTwoFactor(ctx, appleID, password, twoFactorCode)
 	if err != nil {
-		return nil, "", fmt.Errorf("web auth login failed: %w", err)
+		return nil, fmt.Errorf("web auth login failed: %w", err)
 	}
 	if err := webcore.PersistSession(session); err != nil {
-		return nil, "", fmt.Errorf("web auth login succeeded but failed to cache session: %w", err)
+		return nil, fmt.Errorf("web auth login succeeded but failed to cache session: %w", err)
 	}
-	return session, "fresh", nil
+	return session, nil
 }
 
 // WebAuthCommand returns the detached web auth command group.
@@ -285,13 +324,24 @@ Authenticate using Apple web-session behavior for detached "asc web" workflows.
 
 Password input options:
   - secure interactive prompt (default and recommended for local use)
-  - ASC_WEB_PASSWORD environment variable
+  - ASC_WEB_PASSWORD environment variable (ASC_APPLE_PASSWORD is also accepted)
+
+Non-interactive runs can set ASC_APPLE_ID and ASC_2FA_CODE in place of
+--apple-id and --two-factor-code; flags take precedence over the environment.
+ASC_2FA_CODE is rarely useful because codes expire within minutes; prefer
+logging in once interactively and reusing the cached session.
+
+Security: environment variables are readable by other processes running as the
+same user and can leak into CI logs, crash reports, or shell history. Only set
+the password from a CI secret store, scope it to the step that needs it, and
+prefer a dedicated Apple Account with the least access required.
 
 ` + webWarningText + `
 
 Examples:
   asc web auth login --apple-id "user@example.com"
   ASC_WEB_PASSWORD="..." asc web auth login --apple-id "user@example.com"
+  ASC_APPLE_ID="user@example.com" ASC_APPLE_PASSWORD="..." asc web auth login
   asc web auth login --apple-id "user@example.com" --two-factor-code 123456`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -33,8 +33,39 @@ func TestReadPasswordFromInput(t *testing.T) {
 		}
 	})
 
+	t.Run("uses ASC_APPLE_PASSWORD when ASC_WEB_PASSWORD is unset", func(t *testing.T) {
+		t.Setenv(webPasswordEnv, "")
+		t.Setenv(webApplePasswordEnv, " apple-password ")
+		promptPasswordFn = func() (string, error) {
+			t.Fatal("did not expect prompt fallback when env password is set")
+			return "", nil
+		}
+
+		password, err := readPasswordFromInput()
+		if err != nil {
+			t.Fatalf("readPasswordFromInput returned error: %v", err)
+		}
+		if password != "apple-password" {
+			t.Fatalf("expected env password %q, got %q", "apple-password", password)
+		}
+	})
+
+	t.Run("prefers ASC_WEB_PASSWORD over ASC_APPLE_PASSWORD", func(t *testing.T) {
+		t.Setenv(webPasswordEnv, "web-password")
+		t.Setenv(webApplePasswordEnv, "apple-password")
+
+		password, err := readPasswordFromInput()
+		if err != nil {
+			t.Fatalf("readPasswordFromInput returned error: %v", err)
+		}
+		if password != "web-password" {
+			t.Fatalf("expected %q, got %q", "web-password", password)
+		}
+	})
+
 	t.Run("falls back to interactive prompt when env is not provided", func(t *testing.T) {
 		t.Setenv(webPasswordEnv, "")
+		t.Setenv(webApplePasswordEnv, "")
 		called := false
 		promptPasswordFn = func() (string, error) {
 			called = true
@@ -287,6 +318,7 @@ func TestResolveSessionRequiresAppleIDWhenNoCachedSessionExists(t *testing.T) {
 	t.Cleanup(func() {
 		tryResumeLastFn = origTryResumeLast
 	})
+	t.Setenv(webAppleIDEnv, "")
 
 	tryResumeLastFn = func(ctx context.Context) (*webcore.AuthSession, bool, error) {
 		return nil, false, nil
@@ -359,3 +391,76 @@ func TestResolveSessionPrintsExpiredNoticeBeforePrompt(t *testing.T) {
 		t.Fatalf("expected expired notice output, got %q", got)
 	}
 }
+
+func TestResolveSessionUsesEnvironmentCredentials(t *testing.T) {
+	origTryResume := tryResumeSessionFn
+	origTryResumeLast := tryResumeLastFn
+	origPromptPassword := promptPasswordFn
+	origWebLogin := webLoginFn
+	origSubmitTwoFactor := submitTwoFactorCodeFn
+	t.Cleanup(func() {
+		tryResumeSessionFn = origTryResume
+		tryResumeLastFn = origTryResumeLast
+		promptPasswordFn = origPromptPassword
+		webLoginFn = origWebLogin
+		submitTwoFactorCodeFn = origSubmitTwoFactor
+	})
+
+	t.Setenv("ASC_WEB_SESSION_CACHE", "0")
+	t.Setenv(webAppleIDEnv, "env@example.com")
+	t.Setenv(webPasswordEnv, "")
+	t.Setenv(webApplePasswordEnv, "env-secret")
+	t.Setenv(webTwoFactorCodeEnv, "654321")
+
+	tests := []struct {
+		name        string
+		appleIDFlag string
+		wantAppleID string
+	}{
+		{name: "env fallback", wantAppleID: "env@example.com"},
+		{name: "flag wins over env", appleIDFlag: "flag@example.com", wantAppleID: "flag@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := &webcore.AuthSession{UserEmail: tt.wantAppleID}
+			tryResumeSessionFn = func(ctx context.Context, username string) (*webcore.AuthSession, bool, error) {
+				if username != tt.wantAppleID {
+					t.Fatalf("expected cache lookup for %q, got %q", tt.wantAppleID, username)
+				}
+				return nil, false, nil
+			}
+			tryResumeLastFn = func(ctx context.Context) (*webcore.AuthSession, bool, error) {
+				t.Fatal("did not expect last-session lookup when an Apple ID is known")
+				return nil, false, nil
+			}
+			promptPasswordFn = func() (string, error) {
+				t.Fatal("did not expect a password prompt when ASC_APPLE_PASSWORD is set")
+				return "", nil
+			}
+			webLoginFn = func(ctx context.Context, creds webcore.LoginCredentials) (*webcore.AuthSession, error) {
+				if creds.Username != tt.wantAppleID || creds.Password != "env-secret" {
+					t.Fatalf("unexpected credentials %q/%q", creds.Username, creds.Password)
+				}
+				return expected, nil
+			}
+
+			session, source, err := resolveSession(context.Background(), tt.appleIDFlag, "", "")
+			if err != nil {
+				t.Fatalf("resolveSession returned error: %v", err)
+			}
+			if source != "fresh" || session != expected {
+				t.Fatalf("expected fresh login session, got %q %+v", source, session)
+			}
+		})
+	}
+}
+
+func TestFlagOrEnv(t *testing.T) {
+	t.Setenv(webTwoFactorCodeEnv, " 123456 ")
+	if got := flagOrEnv("", webTwoFactorCodeEnv); got != "123456" {
+		t.Fatalf("expected env fallback, got %q", got)
+	}
+	if got := flagOrEnv(" 999999 ", webTwoFactorCodeEnv); got != "999999" {
+		t.Fatalf("expected flag to win, got %q", got)
+	}
+}
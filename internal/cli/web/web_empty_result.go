@@ -0,0 +1,55 @@
+package web
+
+import webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+
+// emptyResultStatus is the JSON "status" of list results that came back
+// empty, so automation can tell zero usage from a malformed response.
+const emptyResultStatus = "empty"
+
+// noDataResultStatus is the JSON "status" of usage results whose response had
+// neither range metadata nor usage, so there is no period to report on at all.
+const noDataResultStatus = "no_data"
+
+// noDataForRangeLabel replaces the range in table and markdown output when
+// the response carried no range metadata and no usage to derive one from.
+const noDataForRangeLabel = "no data for requested range"
+
+// Empty-state messages shared by the table renderers and JSON output.
+const (
+	emptyWorkflowUsageMessage = "No workflow usage found."
+	emptyDailyUsageMessage    = "No daily usage data."
+	emptyMonthlyUsageMessage  = "No monthly usage data."
+	emptyProductUsageMessage  = "No product usage found."
+	emptyEnvVarsMessage       = "No environment variables found."
+	emptySharedEnvVarsMessage = "No shared environment variables found."
+	noDataForRangeMessage     = "No data for the requested range: the response had no range metadata and no usage."
+)
+
+// emptyResultNote is embedded in list results. Both fields are omitted
+// unless the list is empty.
+type emptyResultNote struct {
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func newEmptyResultNote(count int, message string) emptyResultNote {
+	if count > 0 {
+		return emptyResultNote{}
+	}
+	return emptyResultNote{Status: emptyResultStatus, Message: message}
+}
+
+// newUsageRangeResultNote is newEmptyResultNote for range-based usage
+// results. A response with no range metadata and no records reports
+// "no_data" instead of "empty", which is kept for a real zero-usage period.
+func newUsageRangeResultNote(info webcore.CIUsageInfo, count int, message string) emptyResultNote {
+	if count == 0 && !usageInfoHasRange(info) {
+		return emptyResultNote{Status: noDataResultStatus, Message: noDataForRangeMessage}
+	}
+	return newEmptyResultNote(count, message)
+}
+
+// usageInfoHasRange reports whether a usage response included its range.
+func usageInfoHasRange(info webcore.CIUsageInfo) bool {
+	return info.StartMonth > 0 && info.StartYear > 0 && info.EndMonth > 0 && info.EndYear > 0
+}
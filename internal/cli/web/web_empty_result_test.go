@@ -0,0 +1,194 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestNewEmptyResultNote(t *testing.T) {
+	if note := newEmptyResultNote(2, emptyEnvVarsMessage); note != (emptyResultNote{}) {
+		t.Fatalf("expected no note for non-empty results, got %+v", note)
+	}
+	note := newEmptyResultNote(0, emptyEnvVarsMessage)
+	if note.Status != "empty" || note.Message != "No environment variables found." {
+		t.Fatalf("unexpected note %+v", note)
+	}
+}
+
+func TestEmptyResultJSONIncludesStatusAndMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		args    []string
+		run     func(args []string) error
+		message string
+	}{
+		{
+			name: "env-vars list",
+			body: `{"id":"wf-1","content":{"name":"WF","environment_variables":[]}}`,
+			args: []string{"--product-id", "prod-1", "--workflow-id", "wf-1"},
+			run: func(args []string) error {
+				cmd := webXcodeCloudEnvVarsListCommand()
+				if err := cmd.FlagSet.Parse(args); err != nil {
+					return err
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			message: emptyEnvVarsMessage,
+		},
+		{
+			name: "env-vars shared list",
+			body: `[]`,
+			args: []string{"--product-id", "prod-1"},
+			run: func(args []string) error {
+				cmd := webXcodeCloudEnvVarsSharedListCommand()
+				if err := cmd.FlagSet.Parse(args); err != nil {
+					return err
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			message: emptySharedEnvVarsMessage,
+		},
+		{
+			name: "usage workflows",
+			body: `{"usage":[],"workflow_usage":[]}`,
+			args: []string{"--product-id", "prod-1", "--no-names"},
+			run: func(args []string) error {
+				cmd := webXcodeCloudUsageWorkflowsCommand()
+				if err := cmd.FlagSet.Parse(args); err != nil {
+					return err
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			message: emptyWorkflowUsageMessage,
+		},
+		{
+			name: "usage months",
+			body: `{"usage":[],"product_usage":[],"info":{"start_month":1,"start_year":2026,"end_month":3,"end_year":2026}}`,
+			args: nil,
+			run: func(args []string) error {
+				cmd := webXcodeCloudUsageMonthsCommand()
+				if err := cmd.FlagSet.Parse(args); err != nil {
+					return err
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			message: emptyMonthlyUsageMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+				return http.StatusOK, test.body
+			})
+			args := append([]string{"--apple-id", "user@example.com", "--output", "json"}, test.args...)
+			stdout, _ := captureOutput(t, func() {
+				if err := test.run(args); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+			var got map[string]any
+			if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+				t.Fatalf("decode output %q: %v", stdout, err)
+			}
+			if got["status"] != "empty" || got["message"] != test.message {
+				t.Fatalf("expected empty status and %q, got %v", test.message, got)
+			}
+		})
+	}
+}
+
+func TestNonEmptyResultJSONOmitsStatus(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"id":"wf-1","content":{"name":"WF","environment_variables":[{"name":"A","value":{"plaintext":"1"}}]}}`
+	})
+	cmd := webXcodeCloudEnvVarsListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1", "--workflow-id", "wf-1", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, `"status"`) || strings.Contains(stdout, `"message"`) {
+		t.Fatalf("expected no empty-state fields for a non-empty list, got %s", stdout)
+	}
+	if !strings.Contains(stdout, `"variables":[`) {
+		t.Fatalf("expected variables in output, got %s", stdout)
+	}
+}
+
+func TestUsageRangeResultNoteDistinguishesMissingRange(t *testing.T) {
+	note := newUsageRangeResultNote(webcore.CIUsageInfo{}, 0, emptyDailyUsageMessage)
+	if note.Status != "no_data" || note.Message != noDataForRangeMessage {
+		t.Fatalf("expected no_data note without info and usage, got %+v", note)
+	}
+	info := webcore.CIUsageInfo{StartMonth: 1, StartYear: 2026, EndMonth: 1, EndYear: 2026}
+	if note := newUsageRangeResultNote(info, 0, emptyDailyUsageMessage); note.Status != "empty" {
+		t.Fatalf("expected empty note for a zero-usage range, got %+v", note)
+	}
+	if note := newUsageRangeResultNote(webcore.CIUsageInfo{}, 3, emptyDailyUsageMessage); note != (emptyResultNote{}) {
+		t.Fatalf("expected no note when usage is present, got %+v", note)
+	}
+}
+
+func TestUsageRangeFormattersReportMissingRange(t *testing.T) {
+	if got := formatCIMonthRange(nil, webcore.CIUsageInfo{}); got != noDataForRangeLabel {
+		t.Fatalf("formatCIMonthRange() = %q", got)
+	}
+	if got := formatCIDayRange(nil, webcore.CIUsageInfo{}); got != noDataForRangeLabel {
+		t.Fatalf("formatCIDayRange() = %q", got)
+	}
+}
+
+func TestUsageMonthsAndDaysReportNoDataWithoutInfoAndUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func() *ffcli.Command
+		args []string
+	}{
+		{name: "months", cmd: webXcodeCloudUsageMonthsCommand},
+		{name: "days", cmd: webXcodeCloudUsageDaysCommand, args: []string{"--product-ids", "prod-1", "--no-overall"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+				return http.StatusOK, `{"usage":[],"info":{}}`
+			})
+			for _, format := range []string{"json", "table"} {
+				cmd := test.cmd()
+				args := append([]string{"--apple-id", "user@example.com", "--output", format}, test.args...)
+				if err := cmd.FlagSet.Parse(args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				stdout, _ := captureOutput(t, func() {
+					if err := cmd.Exec(context.Background(), nil); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				})
+				if format == "table" {
+					if !strings.Contains(stdout, "Range: no data for requested range") {
+						t.Fatalf("expected no-data range in table, got:\n%s", stdout)
+					}
+					continue
+				}
+				var got map[string]any
+				if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+					t.Fatalf("decode output %q: %v", stdout, err)
+				}
+				if got["status"] != "no_data" || got["message"] != noDataForRangeMessage {
+					t.Fatalf("expected no_data status, got %v", got)
+				}
+			}
+		})
+	}
+}
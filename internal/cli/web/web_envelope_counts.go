@@ -0,0 +1,36 @@
+package web
+
+// EnvelopeCount methods give --envelope a record count for list-shaped
+// results whose rows are not a top-level Data or Items list.
+
+func (r *CIWorkflowsResult) EnvelopeCount() int         { return len(r.Workflows) }
+func (r *CIEnvVarsListResult) EnvelopeCount() int       { return len(r.Variables) }
+func (r *CIEnvVarsAuditResult) EnvelopeCount() int      { return len(r.Variables) }
+func (r *CISharedEnvVarsListResult) EnvelopeCount() int { return len(r.Variables) }
+func (r *CIUsageCyclesResult) EnvelopeCount() int       { return len(r.Cycles) }
+func (r *CIProductUsageResult) EnvelopeCount() int      { return len(r.Products) }
+func (r *CIUsageSummaryResult) EnvelopeCount() int      { return len(r.Products) }
+func (r *CIWorkflowStatusResult) EnvelopeCount() int    { return len(r.Workflows) }
+
+// EnvelopeCount counts the variables across every workflow.
+func (r *CIEnvVarsListAllResult) EnvelopeCount() int {
+	count := 0
+	for _, workflow := range r.Workflows {
+		count += len(workflow.Variables)
+	}
+	return count
+}
+
+func (r *CIUsageMonthsResult) EnvelopeCount() int {
+	if r.CIUsageMonths == nil {
+		return 0
+	}
+	return len(r.Usage)
+}
+
+func (r *CIUsageDaysResult) EnvelopeCount() int {
+	if r.CIUsageDays == nil {
+		return 0
+	}
+	return len(r.Usage)
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestWebXcodeCloudUsageProductsEnvelope(t *testing.T) {
+	stubUsageProductsSession(t, testCIUsageProductsMonths(), nil)
+	shared.SetEnvelopeOutput(true)
+	t.Cleanup(func() { shared.SetEnvelopeOutput(false) })
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--months", "3", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var envelope struct {
+		Data CIProductUsageResult `json:"data"`
+		Meta struct {
+			TeamID string `json:"team_id"`
+			Count  *int   `json:"count"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if len(envelope.Data.Products) != 3 {
+		t.Fatalf("expected wrapped products, got %s", stdout)
+	}
+	if envelope.Meta.TeamID != "TEAM-123" {
+		t.Fatalf("expected team_id TEAM-123, got %q", envelope.Meta.TeamID)
+	}
+	if envelope.Meta.Count == nil || *envelope.Meta.Count != 3 {
+		t.Fatalf("expected count 3, got %v", envelope.Meta.Count)
+	}
+}
+
+func TestTeamRedactorRedactsEnvelopeTeamID(t *testing.T) {
+	shared.SetEnvelopeOutput(true)
+	t.Cleanup(func() { shared.SetEnvelopeOutput(false) })
+	shared.SetEnvelopeTeamID("TEAM-123")
+	redactor := newTeamRedactor(true, "TEAM-123")
+	if got := envelopeTeamIDForTest(t); got != "TEAM-123" {
+		t.Fatalf("expected building a redactor to leave team_id alone, got %q", got)
+	}
+	redactor.redactEnvelopeTeam()
+	if got := envelopeTeamIDForTest(t); got != "team-dda77381" {
+		t.Fatalf("expected pseudonymous team_id, got %q", got)
+	}
+}
+
+func TestCIUsageResultsEnvelopeCount(t *testing.T) {
+	months := &CIUsageMonthsResult{CIUsageMonths: &webcore.CIUsageMonths{Usage: make([]webcore.CIMonthUsage, 2)}}
+	if got := months.EnvelopeCount(); got != 2 {
+		t.Fatalf("expected months count 2, got %d", got)
+	}
+	days := &CIUsageDaysResult{CIUsageDays: &webcore.CIUsageDays{Usage: make([]webcore.CIDayUsage, 3)}}
+	if got := days.EnvelopeCount(); got != 3 {
+		t.Fatalf("expected days count 3, got %d", got)
+	}
+	if got := (&CIUsageDaysResult{}).EnvelopeCount(); got != 0 {
+		t.Fatalf("expected 0 without a response, got %d", got)
+	}
+}
+
+func TestWebXcodeCloudEnvVarsListAllEnvelopeCountsVariables(t *testing.T) {
+	var workflowFetches atomic.Int32
+	stubEnvVarsListAllSession(t, &workflowFetches)
+	shared.SetEnvelopeOutput(true)
+	t.Cleanup(func() { shared.SetEnvelopeOutput(false) })
+
+	cmd := webXcodeCloudEnvVarsListAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var envelope struct {
+		Meta struct {
+			Count *int `json:"count"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if envelope.Meta.Count == nil || *envelope.Meta.Count != 3 {
+		t.Fatalf("expected count 3 across both workflows, got %v", envelope.Meta.Count)
+	}
+	if got := (&CIEnvVarsListAllResult{}).EnvelopeCount(); got != 0 {
+		t.Fatalf("expected 0 without workflows, got %d", got)
+	}
+}
+
+func envelopeTeamIDForTest(t *testing.T) string {
+	t.Helper()
+
+	stdout, _ := captureOutput(t, func() {
+		if err := shared.PrintOutput(map[string]string{}, "json", false); err != nil {
+			t.Fatalf("print error: %v", err)
+		}
+	})
+	var envelope struct {
+		Meta struct {
+			TeamID string `json:"team_id"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &envelope); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	return envelope.Meta.TeamID
+}
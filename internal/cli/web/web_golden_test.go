@@ -0,0 +1,242 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// Golden tests run commands against recorded API fixtures and compare stdout
+// with checked-in files under testdata/golden. Regenerate them with:
+//
+//	UPDATE_GOLDEN=1 go test ./internal/cli/web -run TestGolden
+
+// goldenFixture is a recorded set of API responses, loaded from
+// testdata/fixtures. Each route answers requests whose method matches and
+// whose path ends with Path; the longest matching path wins.
+type goldenFixture struct {
+	Routes []goldenRoute `json:"routes"`
+}
+
+type goldenRoute struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Status int             `json:"status,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// goldenCase runs one command against a fixture. The golden file is
+// testdata/golden/<name>.golden.
+type goldenCase struct {
+	name    string
+	fixture string
+	command func() *ffcli.Command
+	args    []string
+	wantErr bool
+}
+
+// goldenNow pins the clock so default date ranges and timestamps are stable.
+var goldenNow = time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC)
+
+func loadGoldenFixture(t *testing.T, name string) goldenFixture {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", name+".json"))
+	if err != nil {
+		t.Fatalf("failed to read fixture %q: %v", name, err)
+	}
+	var fixture goldenFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("failed to decode fixture %q: %v", name, err)
+	}
+	return fixture
+}
+
+// transport serves fixture routes. Unmatched requests fail the test and get a
+// 404 so the command under test reports them instead of hanging.
+func (f goldenFixture) transport(t *testing.T) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var match *goldenRoute
+		for i := range f.Routes {
+			route := &f.Routes[i]
+			if !strings.EqualFold(route.Method, req.Method) || !strings.HasSuffix(req.URL.Path, route.Path) {
+				continue
+			}
+			if match == nil || len(route.Path) > len(match.Path) {
+				match = route
+			}
+		}
+		status := http.StatusNotFound
+		body := `{"errors":[{"status":"404","detail":"no golden fixture route"}]}`
+		if match == nil {
+			t.Errorf("no fixture route for %s %s", req.Method, req.URL.Path)
+		} else {
+			status = http.StatusOK
+			if match.Status != 0 {
+				status = match.Status
+			}
+			body = string(match.Body)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func runGoldenCase(t *testing.T, tc goldenCase) {
+	t.Helper()
+	fixture := loadGoldenFixture(t, tc.fixture)
+
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+		resetWorkflowNameCache()
+	})
+	resetWorkflowNameCache()
+	webNowFn = func() time.Time { return goldenNow }
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client:           &http.Client{Transport: fixture.transport(t)},
+		}, "cache", nil
+	}
+
+	// Commands read webNowFn for flag defaults, so build them after pinning it.
+	cmd := tc.command()
+	if err := cmd.FlagSet.Parse(append([]string{"--apple-id", "user@example.com"}, tc.args...)); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if tc.wantErr != (runErr != nil) {
+		t.Fatalf("Exec() error = %v, wantErr %v (stderr=%q)", runErr, tc.wantErr, stderr)
+	}
+	compareGolden(t, tc.name, stdout)
+}
+
+func compareGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if os.Getenv("UPDATE_GOLDEN") == "1" {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with UPDATE_GOLDEN=1 to create it): %v", path, err)
+	}
+	if !bytes.Equal(want, []byte(got)) {
+		t.Fatalf("output does not match %s (run with UPDATE_GOLDEN=1 to update)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func TestGoldenOutputs(t *testing.T) {
+	cases := []goldenCase{
+		{
+			name:    "usage_summary",
+			fixture: "usage",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--output", "json"},
+		},
+		{
+			name:    "usage_summary_table",
+			fixture: "usage",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--output", "table"},
+		},
+		{
+			name:    "usage_summary_pace",
+			fixture: "usage",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--pace", "--output", "json"},
+		},
+		{
+			name:    "usage_summary_pace_table",
+			fixture: "usage",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--pace", "--output", "table"},
+		},
+		{
+			name:    "usage_summary_history_table",
+			fixture: "usage",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--history", "3", "--output", "table"},
+		},
+		{
+			name:    "usage_months",
+			fixture: "usage",
+			command: webXcodeCloudUsageMonthsCommand,
+			args:    []string{"--start-month", "12", "--start-year", "2025", "--end-month", "2", "--end-year", "2026", "--output", "json"},
+		},
+		{
+			name:    "usage_days",
+			fixture: "usage",
+			command: webXcodeCloudUsageDaysCommand,
+			args:    []string{"--product-ids", "prod-1", "--start", "2026-02-26", "--end", "2026-02-27", "--output", "json"},
+		},
+		{
+			name:    "usage_workflows",
+			fixture: "usage",
+			command: webXcodeCloudUsageWorkflowsCommand,
+			args:    []string{"--product-id", "prod-1", "--start", "2026-02-26", "--end", "2026-02-27", "--output", "json"},
+		},
+		{
+			name:    "usage_alert",
+			fixture: "usage",
+			command: webXcodeCloudUsageAlertCommand,
+			args:    []string{"--trend-months", "0", "--output", "json"},
+			wantErr: true,
+		},
+		{
+			name:    "envvars_list",
+			fixture: "envvars",
+			command: webXcodeCloudEnvVarsListCommand,
+			args:    []string{"--product-id", "prod-1", "--workflow-id", "wf-1", "--output", "json"},
+		},
+		{
+			name:    "envvars_shared_list",
+			fixture: "envvars",
+			command: webXcodeCloudEnvVarsSharedListCommand,
+			args:    []string{"--product-id", "prod-1", "--output", "json"},
+		},
+		{
+			name:    "envvars_list_names",
+			fixture: "envvars",
+			command: webXcodeCloudEnvVarsListCommand,
+			args:    []string{"--product-id", "prod-1", "--workflow-id", "wf-1", "--names-only"},
+		},
+		{
+			name:    "envvars_shared_list_names",
+			fixture: "envvars",
+			command: webXcodeCloudEnvVarsSharedListCommand,
+			args:    []string{"--product-id", "prod-1", "--sort", "name", "--names-only"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			runGoldenCase(t, tc)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// rawOutput implements --raw: every API response body received during the
+// command is recorded and printed unparsed, bypassing decoding and
+// normalization so schema drift is visible as-is.
+type rawOutput struct {
+	recorder *webcore.RawResponseRecorder
+}
+
+// rawResponseOutput is the JSON shape of one recorded response. Body holds the
+// server's JSON as-is, or a string when the body is not JSON.
+type rawResponseOutput struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+func bindRawFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("raw", false, "Print the unparsed API response bodies as a pretty-printed JSON array instead of normal output (for bug reports)")
+}
+
+func newRawOutput(enabled bool) rawOutput {
+	if !enabled {
+		return rawOutput{}
+	}
+	return rawOutput{recorder: &webcore.RawResponseRecorder{}}
+}
+
+func (r rawOutput) enabled() bool {
+	return r.recorder != nil
+}
+
+// context attaches the recorder. Call it after the session is resolved so
+// only the command's own API requests are captured.
+func (r rawOutput) context(ctx context.Context) context.Context {
+	return webcore.WithRawResponseRecorder(ctx, r.recorder)
+}
+
+// print writes the recorded responses to stdout, or --out-file, and then
+// returns err, so a request that failed to decode still shows exactly what the
+// server sent.
+func (r rawOutput) print(err error) error {
+	responses := r.recorder.Responses()
+	out := make([]rawResponseOutput, 0, len(responses))
+	for _, response := range responses {
+		body := json.RawMessage(response.Body)
+		if !json.Valid(body) {
+			encoded, marshalErr := json.Marshal(string(response.Body))
+			if marshalErr != nil {
+				return marshalErr
+			}
+			body = encoded
+		}
+		out = append(out, rawResponseOutput{
+			Method: response.Method,
+			Path:   response.Path,
+			Status: response.Status,
+			Body:   body,
+		})
+	}
+	data, marshalErr := json.MarshalIndent(out, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if writeErr := shared.WithOutputDestination(func() error {
+		_, printErr := fmt.Println(string(data))
+		return printErr
+	}); writeErr != nil {
+		return errors.Join(writeErr, err)
+	}
+	return err
+}
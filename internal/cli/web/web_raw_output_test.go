@@ -0,0 +1,142 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUsageSummaryRawPrintsUnparsedBody(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"plan":{"used":10,"available":90,"total":100},"brand_new_field":{"nested":true}}`
+	})
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--raw"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var got []struct {
+		Method string         `json:"method"`
+		Path   string         `json:"path"`
+		Status int            `json:"status"`
+		Body   map[string]any `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("expected JSON array output, got %q: %v", stdout, err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 recorded response, got %d", len(got))
+	}
+	if got[0].Method != http.MethodGet || got[0].Status != http.StatusOK {
+		t.Fatalf("unexpected response metadata: %+v", got[0])
+	}
+	if !strings.Contains(got[0].Path, "/usage/summary") {
+		t.Fatalf("expected summary path, got %q", got[0].Path)
+	}
+	if _, ok := got[0].Body["brand_new_field"]; !ok {
+		t.Fatalf("expected unknown fields to be preserved, got %v", got[0].Body)
+	}
+	if !strings.Contains(stdout, "\n  ") {
+		t.Fatalf("expected pretty-printed output, got %q", stdout)
+	}
+}
+
+func TestUsageSummaryRawPrintsErrorBodyAndFails(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusInternalServerError, "upstream exploded"
+	})
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--raw"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err == nil {
+			t.Fatal("expected the request error to be returned")
+		}
+	})
+	if !strings.Contains(stdout, `"body": "upstream exploded"`) {
+		t.Fatalf("expected non-JSON body as a string, got %q", stdout)
+	}
+	if !strings.Contains(stdout, `"status": 500`) {
+		t.Fatalf("expected status in output, got %q", stdout)
+	}
+}
+
+func TestRawFlagConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func() error
+		want string
+	}{
+		{
+			name: "summary watch",
+			cmd: func() error {
+				cmd := webXcodeCloudUsageSummaryCommand()
+				if err := cmd.FlagSet.Parse([]string{"--raw", "--watch"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			want: "--raw cannot be used with --watch",
+		},
+		{
+			name: "summary percent-only",
+			cmd: func() error {
+				cmd := webXcodeCloudUsageSummaryCommand()
+				if err := cmd.FlagSet.Parse([]string{"--raw", "--percent-only"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			want: "--raw cannot be combined with --percent-only",
+		},
+		{
+			name: "env-vars list mask",
+			cmd: func() error {
+				cmd := webXcodeCloudEnvVarsListCommand()
+				if err := cmd.FlagSet.Parse([]string{"--product-id", "p", "--workflow-id", "w", "--raw", "--mask"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			want: "--raw cannot be combined with --mask",
+		},
+		{
+			name: "shared list sort",
+			cmd: func() error {
+				cmd := webXcodeCloudEnvVarsSharedListCommand()
+				if err := cmd.FlagSet.Parse([]string{"--product-id", "p", "--raw", "--sort", "name"}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				return cmd.Exec(context.Background(), nil)
+			},
+			want: "--raw cannot be combined with --sort",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, stderr := captureOutput(t, func() {
+				if err := test.cmd(); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.want) {
+				t.Fatalf("expected %q in stderr, got %q", test.want, stderr)
+			}
+		})
+	}
+}
@@ -5,25 +5,66 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
 type webSessionFlags struct {
 	appleID       *string
 	twoFactorCode *string
+	recordDir     *string
+	replayDir     *string
+	userAgent     *string
 }
 
 func bindWebSessionFlags(fs *flag.FlagSet) webSessionFlags {
 	return webSessionFlags{
-		appleID:       fs.String("apple-id", "", "Apple Account email used to scope a user-owned session cache (optional when a cached session exists)"),
-		twoFactorCode: fs.String("two-factor-code", "", "2FA code if your account requires verification"),
+		appleID:       fs.String("apple-id", "", "Apple Account email used to scope a user-owned session cache (optional when a cached session exists; or set ASC_APPLE_ID)"),
+		twoFactorCode: fs.String("two-factor-code", "", "2FA code if your account requires verification (or set ASC_2FA_CODE)"),
+		recordDir:     fs.String("record", "", "Save each web API request/response to DIR as JSON fixtures (secrets redacted)"),
+		replayDir:     fs.String("replay", "", "Serve web API responses from fixtures in DIR instead of contacting Apple"),
+		userAgent:     fs.String("user-agent", "", "User-Agent header for web API requests (or set ASC_USER_AGENT; default App-Store-Connect-CLI/<version>)"),
 	}
 }
 
+// resolveWebSessionForCommand returns the session for a web command. --replay
+// skips authentication entirely and serves recorded fixtures; --record wraps
+// the resolved session's client so every exchange is saved. --user-agent is
+// applied to a copy so cached sessions are left untouched. A session resumed
+// from the cache can sign in again if the API rejects it.
 func resolveWebSessionForCommand(ctx context.Context, flags webSessionFlags) (*webcore.AuthSession, error) {
-	session, _, err := resolveSessionFn(
+	session, err := resolveWebSessionWithoutUserAgent(ctx, flags)
+	if err != nil || session == nil {
+		return session, err
+	}
+	if userAgent := strings.TrimSpace(*flags.userAgent); userAgent != "" {
+		withUserAgent := *session
+		withUserAgent.UserAgent = userAgent
+		return &withUserAgent, nil
+	}
+	return session, nil
+}
+
+func resolveWebSessionWithoutUserAgent(ctx context.Context, flags webSessionFlags) (*webcore.AuthSession, error) {
+	recordDir := strings.TrimSpace(*flags.recordDir)
+	replayDir := strings.TrimSpace(*flags.replayDir)
+	if recordDir != "" && replayDir != "" {
+		return nil, shared.UsageError("--record and --replay are mutually exclusive")
+	}
+	if replayDir != "" {
+		session, err := webcore.NewReplaySession(replayDir)
+		if err != nil {
+			return nil, err
+		}
+		shared.SetEnvelopeTeamID(session.PublicProviderID)
+		return session, nil
+	}
+
+	session, source, err := resolveSessionFn(
 		ctx,
 		*flags.appleID,
 		"",
@@ -32,9 +73,60 @@ func resolveWebSessionForCommand(ctx context.Context, flags webSessionFlags) (*w
 	if err != nil {
 		return nil, err
 	}
+	if session == nil {
+		return nil, nil
+	}
+	shared.SetEnvelopeTeamID(session.PublicProviderID)
+	if source == "cache" {
+		withReauth := *session
+		withReauth.Reauthenticate = newWebSessionReauthenticator(flags, session)
+		session = &withReauth
+	}
+	if recordDir != "" {
+		return webcore.WithRecording(session, recordDir)
+	}
 	return session, nil
 }
 
+// newWebSessionReauthenticator returns the Reauthenticate hook for a cached
+// session. A session can pass the cache check and expire before the first API
+// call; the hook then signs in again, so the failed call is retried instead
+// of the command failing. It signs in at most once per command and every
+// client built from the session shares the result.
+//
+// Without a password in the environment, signing in prompts, so the hook
+// stops any spinner first and gives up when stdin is not a terminal; the
+// failed call then returns its own error with the login hint.
+func newWebSessionReauthenticator(flags webSessionFlags, cached *webcore.AuthSession) func(context.Context) (*webcore.AuthSession, error) {
+	var (
+		once    sync.Once
+		session *webcore.AuthSession
+		err     error
+	)
+	return func(ctx context.Context) (*webcore.AuthSession, error) {
+		once.Do(func() {
+			if passwordFromEnv() == "" && !termIsTerminalFn(int(os.Stdin.Fd())) {
+				err = errors.New("cannot prompt for a password without a terminal")
+				return
+			}
+			appleID := flagOrEnv(*flags.appleID, webAppleIDEnv)
+			if appleID == "" {
+				appleID = strings.TrimSpace(cached.UserEmail)
+			}
+			shared.StopActiveSpinner()
+			printExpiredSessionNotice(sessionExpiredWriter)
+			session, err = loginSessionFn(ctx, appleID, "", flagOrEnv(*flags.twoFactorCode, webTwoFactorCodeEnv))
+			if err != nil {
+				return
+			}
+			if recordDir := strings.TrimSpace(*flags.recordDir); recordDir != "" {
+				session, err = webcore.WithRecording(session, recordDir)
+			}
+		})
+		return session, err
+	}
+}
+
 func withWebAuthHint(err error, operation string) error {
 	if err == nil {
 		return nil
@@ -42,9 +134,17 @@ func withWebAuthHint(err error, operation string) error {
 	if strings.HasPrefix(err.Error(), operation+" failed:") {
 		return err
 	}
+	if errors.Is(err, webcore.ErrNoCIAccess) {
+		// The usage summary probe answers 403 for teams without Xcode Cloud, even with a valid session.
+		return fmt.Errorf("%s failed: this team does not appear to have Xcode Cloud enabled or your account lacks access (ask an Admin to enable Xcode Cloud or grant your role access): %w", operation, err)
+	}
 	var apiErr *webcore.APIError
 	if errors.As(err, &apiErr) && (apiErr.Status == 401 || apiErr.Status == 403) {
 		return fmt.Errorf("%s failed: web session is unauthorized or expired (run 'asc web auth login'): %w", operation, err)
 	}
+	var nonJSONErr *webcore.NonJSONResponseError
+	if errors.As(err, &nonJSONErr) {
+		return fmt.Errorf("%s failed: received a non-JSON response, likely a sign-in page because the web session expired (run 'asc web auth login'): %w", operation, err)
+	}
 	return fmt.Errorf("%s failed: %w", operation, err)
 }
@@ -0,0 +1,85 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestWebSessionRecordThenReplay(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	dir := t.TempDir()
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `[{"id":"var-1","name":"API_URL","value":{"plaintext":"https://example.com"},"is_locked":false,"related_workflow_summaries":[]}]`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := webXcodeCloudEnvVarsSharedListCommand()
+		if err := cmd.FlagSet.Parse(append([]string{"--product-id", "prod-1"}, args...)); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("exec error: %v", err)
+			}
+		})
+		return stdout
+	}
+
+	recorded := run("--apple-id", "user@example.com", "--record", dir)
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		t.Fatal("expected --replay to skip session resolution")
+		return nil, "", nil
+	}
+	replayed := run("--replay", dir)
+	if replayed != recorded {
+		t.Fatalf("expected replay output to match recording\nrecorded: %s\nreplayed: %s", recorded, replayed)
+	}
+	if !strings.Contains(replayed, "API_URL") {
+		t.Fatalf("expected replayed variables, got %s", replayed)
+	}
+}
+
+func TestWebSessionRecordAndReplayAreExclusive(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsSharedListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--record", "a", "--replay", "b"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--record and --replay are mutually exclusive") {
+		t.Fatalf("expected exclusivity error, got %q", stderr)
+	}
+}
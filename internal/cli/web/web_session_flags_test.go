@@ -0,0 +1,323 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestWithWebAuthHintHTMLResponseSuggestsLogin(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"text/html"}},
+						Body:       io.NopCloser(strings.NewReader("<!DOCTYPE html>\n<html>Sign in</html>")),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "", nil
+	}
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil {
+		t.Fatal("expected error for HTML response")
+	}
+	for _, want := range []string{"xcode-cloud usage summary failed", "asc web auth login", "<!DOCTYPE html>"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got %q", want, err.Error())
+		}
+	}
+	if strings.Contains(err.Error(), "failed to decode") {
+		t.Fatalf("expected no decode error, got %q", err.Error())
+	}
+}
+
+func TestWithWebAuthHintDistinguishesCIAccessFromExpiredSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		operation string
+		want      string
+		notWant   string
+	}{
+		{
+			name:      "ci forbidden",
+			err:       fmt.Errorf("%w: %w", webcore.ErrNoCIAccess, &webcore.APIError{Status: http.StatusForbidden}),
+			operation: "xcode-cloud usage summary",
+			want:      "does not appear to have Xcode Cloud enabled or your account lacks access",
+			notWant:   "unauthorized or expired",
+		},
+		{
+			name:      "ci unauthorized",
+			err:       &webcore.APIError{Status: http.StatusUnauthorized},
+			operation: "xcode-cloud usage summary",
+			want:      "web session is unauthorized or expired",
+			notWant:   "Xcode Cloud enabled",
+		},
+		{
+			name:      "non-ci forbidden",
+			err:       &webcore.APIError{Status: http.StatusForbidden},
+			operation: "review list",
+			want:      "web session is unauthorized or expired",
+			notWant:   "Xcode Cloud enabled",
+		},
+		{
+			name:      "non-ci forbidden during xcode-cloud command",
+			err:       &webcore.APIError{Status: http.StatusForbidden},
+			operation: "xcode-cloud env-vars set",
+			want:      "web session is unauthorized or expired",
+			notWant:   "Xcode Cloud enabled",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := withWebAuthHint(test.err, test.operation)
+			if !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected %q in error, got %q", test.want, err.Error())
+			}
+			if strings.Contains(err.Error(), test.notWant) {
+				t.Fatalf("did not expect %q in error, got %q", test.notWant, err.Error())
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryForbiddenReportsMissingCIAccess(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusForbidden,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"403"}]}`)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "", nil
+	}
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not appear to have Xcode Cloud enabled") {
+		t.Fatalf("expected CI access hint, got %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryUserAgentFlag(t *testing.T) {
+	t.Setenv("ASC_USER_AGENT", "from-env/1.0")
+	var userAgents []string
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		userAgents = append(userAgents, req.Header.Get("User-Agent"))
+		return http.StatusOK, `{"plan":{"used":1,"available":9,"total":10}}`
+	})
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--user-agent", "my-dashboard/3.1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, _ = captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if len(userAgents) != 1 || userAgents[0] != "my-dashboard/3.1" {
+		t.Fatalf("expected --user-agent to override ASC_USER_AGENT, got %v", userAgents)
+	}
+}
+
+func stubCachedSessionRejectedOnce(t *testing.T, source string) (loginCalls *int, requests *[]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origLoginSession := loginSessionFn
+	origExpiredWriter := sessionExpiredWriter
+	origIsTerminal := termIsTerminalFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		loginSessionFn = origLoginSession
+		sessionExpiredWriter = origExpiredWriter
+		termIsTerminalFn = origIsTerminal
+	})
+	sessionExpiredWriter = io.Discard
+	t.Setenv(webPasswordEnv, "")
+	t.Setenv(webApplePasswordEnv, "")
+	termIsTerminalFn = func(int) bool { return true }
+
+	var calls int
+	var seen []string
+	sessionClient := func(name string, status int) *http.Client {
+		return &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				seen = append(seen, name)
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"plan":{"name":"Plan","used":30,"available":1470,"total":1500}}`)),
+					Request:    req,
+				}, nil
+			}),
+		}
+	}
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			UserEmail:        "cached@example.com",
+			Client:           sessionClient("cached", http.StatusUnauthorized),
+		}, source, nil
+	}
+	loginSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, error) {
+		calls++
+		if appleID != "cached@example.com" {
+			t.Fatalf("expected re-authentication for the cached account, got %q", appleID)
+		}
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client:           sessionClient("fresh", http.StatusOK),
+		}, nil
+	}
+	return &calls, &seen
+}
+
+func TestResolveWebSessionReauthenticatesRejectedCachedSession(t *testing.T) {
+	t.Setenv(webAppleIDEnv, "")
+	loginCalls, requests := stubCachedSessionRejectedOnce(t, "cache")
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if *loginCalls != 1 {
+		t.Fatalf("expected one re-authentication, got %d", *loginCalls)
+	}
+	if got := strings.Join(*requests, ","); !strings.HasPrefix(got, "cached,fresh") || strings.Count(got, "cached") != 1 {
+		t.Fatalf("expected the rejected call to be retried with the fresh session, got %s", got)
+	}
+	if !strings.Contains(stdout, `"used":30`) {
+		t.Fatalf("expected usage output, got %q", stdout)
+	}
+}
+
+func TestResolveWebSessionReauthenticationFailsFastWithoutTerminal(t *testing.T) {
+	t.Setenv(webAppleIDEnv, "")
+	loginCalls, _ := stubCachedSessionRejectedOnce(t, "cache")
+	termIsTerminalFn = func(int) bool { return false }
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "asc web auth login") {
+		t.Fatalf("expected expired session hint, got %v", err)
+	}
+	if *loginCalls != 0 {
+		t.Fatalf("expected no sign-in attempt without a terminal, got %d", *loginCalls)
+	}
+}
+
+func TestResolveWebSessionDoesNotReauthenticateFreshSession(t *testing.T) {
+	loginCalls, _ := stubCachedSessionRejectedOnce(t, "fresh")
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "asc web auth login") {
+		t.Fatalf("expected expired session hint, got %v", err)
+	}
+	if *loginCalls != 0 {
+		t.Fatalf("expected no re-authentication for a fresh session, got %d", *loginCalls)
+	}
+}
+
+func TestWebXcodeCloudForbiddenOutsideProbeSuggestsLogin(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusForbidden,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"403"}]}`)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "", nil
+	}
+
+	cmd := webXcodeCloudProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "web session is unauthorized or expired") {
+		t.Fatalf("expected re-auth hint for a 403 outside the access probe, got %v", err)
+	}
+	if errors.Is(err, webcore.ErrNoCIAccess) {
+		t.Fatalf("expected a plain API error, got %v", err)
+	}
+}
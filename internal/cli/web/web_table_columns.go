@@ -0,0 +1,97 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// tableColumns is a parsed --columns selection: header indexes in render
+// order. A nil selection keeps every column in its default order.
+type tableColumns []int
+
+func bindColumnsFlag(fs *flag.FlagSet, headers []string) *string {
+	return fs.String("columns", "", "Table/markdown columns to show, in order (comma-separated: "+strings.Join(tableColumnNames(headers), ", ")+")")
+}
+
+// parseTableColumns matches --columns entries against headers without regard
+// to case. Repeated columns are kept once, at their first position.
+func parseTableColumns(value string, headers []string) (tableColumns, error) {
+	names := shared.SplitCSV(value)
+	if len(names) == 0 {
+		if strings.TrimSpace(value) != "" {
+			return nil, fmt.Errorf("--columns must be one of: %s", strings.Join(tableColumnNames(headers), ", "))
+		}
+		return nil, nil
+	}
+
+	columns := make(tableColumns, 0, len(names))
+	seen := map[int]bool{}
+	for _, name := range names {
+		index := -1
+		for i, header := range headers {
+			if strings.EqualFold(name, header) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("--columns must be one of: %s", strings.Join(tableColumnNames(headers), ", "))
+		}
+		if seen[index] {
+			continue
+		}
+		seen[index] = true
+		columns = append(columns, index)
+	}
+	return columns, nil
+}
+
+// apply reorders and subsets headers and rows to the selected columns.
+func (c tableColumns) apply(headers []string, rows [][]string) ([]string, [][]string) {
+	if len(c) == 0 {
+		return headers, rows
+	}
+	selectedHeaders := make([]string, 0, len(c))
+	for _, index := range c {
+		selectedHeaders = append(selectedHeaders, headers[index])
+	}
+	selectedRows := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		selected := make([]string, 0, len(c))
+		for _, index := range c {
+			value := ""
+			if index < len(row) {
+				value = row[index]
+			}
+			selected = append(selected, value)
+		}
+		selectedRows = append(selectedRows, selected)
+	}
+	return selectedHeaders, selectedRows
+}
+
+func tableColumnNames(headers []string) []string {
+	names := make([]string, 0, len(headers))
+	for _, header := range headers {
+		names = append(names, strings.ToLower(header))
+	}
+	return names
+}
+
+// tabularColumns returns headers and rows for --output csv and tsv: the table columns
+// without usage bars, which only make sense in rendered tables.
+func tabularColumns(headers []string, rows [][]string) ([]string, [][]string) {
+	keep := make(tableColumns, 0, len(headers))
+	for i, header := range headers {
+		if !strings.HasPrefix(header, "Usage Bar") {
+			keep = append(keep, i)
+		}
+	}
+	if len(keep) == len(headers) {
+		return headers, rows
+	}
+	return keep.apply(headers, rows)
+}
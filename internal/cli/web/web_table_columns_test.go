@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseTableColumnsReordersAndSubsets(t *testing.T) {
+	headers := sharedEnvVarHeaders()
+	columns, err := parseTableColumns("Workflows, locked,name,LOCKED", headers)
+	if err != nil {
+		t.Fatalf("parseTableColumns() error: %v", err)
+	}
+	gotHeaders, gotRows := columns.apply(headers, [][]string{{"KEY", "plaintext", "1", "yes", "CI"}})
+	if strings.Join(gotHeaders, ",") != "Workflows,Locked,Name" {
+		t.Fatalf("unexpected headers %v", gotHeaders)
+	}
+	if strings.Join(gotRows[0], ",") != "CI,yes,KEY" {
+		t.Fatalf("unexpected row %v", gotRows[0])
+	}
+}
+
+func TestParseTableColumnsEmptyKeepsDefaults(t *testing.T) {
+	columns, err := parseTableColumns("", envVarHeaders())
+	if err != nil || columns != nil {
+		t.Fatalf("expected no selection, got %v, %v", columns, err)
+	}
+	headers, _ := columns.apply(envVarHeaders(), nil)
+	if strings.Join(headers, ",") != "Name,Type,Value" {
+		t.Fatalf("unexpected headers %v", headers)
+	}
+}
+
+func TestParseTableColumnsRejectsUnknownColumn(t *testing.T) {
+	for _, value := range []string{"name,owner", ","} {
+		_, err := parseTableColumns(value, envVarHeaders())
+		if err == nil || err.Error() != "--columns must be one of: name, type, value" {
+			t.Fatalf("parseTableColumns(%q) error = %v", value, err)
+		}
+	}
+}
+
+func TestWebXcodeCloudEnvVarsSharedListColumnsTable(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `[{"id":"v1","name":"API_HOST","value":{"plaintext":"example.com"},"is_locked":true,"related_workflow_summaries":[{"name":"Release"}]}]`
+	})
+	cmd := webXcodeCloudEnvVarsSharedListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1", "--output", "markdown", "--columns", "workflows,locked,name"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	lines := strings.Split(stdout, "\n")
+	if len(lines) < 3 || strings.Join(strings.Fields(lines[0]), " ") != "| Workflows | Locked | Name |" || strings.Join(strings.Fields(lines[2]), " ") != "| Release | yes | API_HOST |" {
+		t.Fatalf("expected reordered columns, got:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "example.com") {
+		t.Fatalf("expected the value column to be omitted, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudEnvVarsListRejectsUnknownColumns(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--workflow-id", "wf-1", "--columns", "locked"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--columns must be one of: name, type, value") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
+
+func TestTSVColumnsDropsUsageBars(t *testing.T) {
+	headers, rows := tabularColumns([]string{"Date", "Minutes", "Builds", "Usage Bar"}, [][]string{{"2026-01-01", "10", "2", "###"}})
+	if strings.Join(headers, ",") != "Date,Minutes,Builds" || strings.Join(rows[0], ",") != "2026-01-01,10,2" {
+		t.Fatalf("unexpected tsv columns %v %v", headers, rows)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsTSVOutput(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"usage":[{"year":2026,"month":1,"duration":30,"number_of_builds":3}],"info":{"start_month":1,"start_year":2026,"end_month":1,"end_year":2026}}`
+	})
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--output", "tsv"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "Year\tMonth\tMinutes\tBuilds\n2026\t1\t30\t3\n" {
+		t.Fatalf("unexpected tsv output %q", stdout)
+	}
+}
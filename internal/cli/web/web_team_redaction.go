@@ -0,0 +1,104 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"maps"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func bindRedactTeamFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("redact-team", false, "Replace the team ID in output with a stable hashed pseudonym")
+}
+
+// teamPseudonym derives a stable stand-in for a team ID from the first 8 hex
+// characters of its SHA-256, so redacted exports stay correlatable.
+func teamPseudonym(teamID string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(teamID)))
+	return "team-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// teamRedactor replaces a team ID inside output values. The zero value leaves
+// everything unchanged.
+type teamRedactor struct {
+	teamID    string
+	pseudonym string
+}
+
+func newTeamRedactor(enabled bool, teamID string) teamRedactor {
+	teamID = strings.TrimSpace(teamID)
+	if !enabled || teamID == "" {
+		return teamRedactor{}
+	}
+	return teamRedactor{teamID: teamID, pseudonym: teamPseudonym(teamID)}
+}
+
+// redactEnvelopeTeam replaces the --envelope team_id with the pseudonym so it
+// matches the redacted payload. Commands call it once for the team they act on.
+func (r teamRedactor) redactEnvelopeTeam() {
+	if r.teamID != "" {
+		shared.SetEnvelopeTeamID(r.pseudonym)
+	}
+}
+
+// String replaces the team ID in value, matching its exact and lowercase forms.
+func (r teamRedactor) String(value string) string {
+	if r.teamID == "" || value == "" {
+		return value
+	}
+	value = strings.ReplaceAll(value, r.teamID, r.pseudonym)
+	if lower := strings.ToLower(r.teamID); lower != r.teamID {
+		value = strings.ReplaceAll(value, lower, r.pseudonym)
+	}
+	return value
+}
+
+// Links returns a copy of links with the team ID redacted from every URL.
+func (r teamRedactor) Links(links map[string]string) map[string]string {
+	if r.teamID == "" || len(links) == 0 {
+		return links
+	}
+	redacted := maps.Clone(links)
+	for key, value := range redacted {
+		redacted[key] = r.String(value)
+	}
+	return redacted
+}
+
+// EnvVars returns a copy of vars with the team ID redacted from plaintext values.
+func (r teamRedactor) EnvVars(vars []webcore.CIEnvironmentVariable) []webcore.CIEnvironmentVariable {
+	if r.teamID == "" || len(vars) == 0 {
+		return vars
+	}
+	redacted := make([]webcore.CIEnvironmentVariable, len(vars))
+	for i, variable := range vars {
+		variable.Value = r.envVarValue(variable.Value)
+		redacted[i] = variable
+	}
+	return redacted
+}
+
+// SharedEnvVars is EnvVars for shared (product-level) variables.
+func (r teamRedactor) SharedEnvVars(vars []webcore.CIProductEnvironmentVariable) []webcore.CIProductEnvironmentVariable {
+	if r.teamID == "" || len(vars) == 0 {
+		return vars
+	}
+	redacted := make([]webcore.CIProductEnvironmentVariable, len(vars))
+	for i, variable := range vars {
+		variable.Value = r.envVarValue(variable.Value)
+		redacted[i] = variable
+	}
+	return redacted
+}
+
+func (r teamRedactor) envVarValue(value webcore.CIEnvironmentVariableValue) webcore.CIEnvironmentVariableValue {
+	if value.Plaintext != nil {
+		plaintext := r.String(*value.Plaintext)
+		value.Plaintext = &plaintext
+	}
+	return value
+}
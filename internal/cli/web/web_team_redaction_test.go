@@ -0,0 +1,89 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestTeamPseudonymIsStableAndOpaque(t *testing.T) {
+	first := teamPseudonym("TEAM-123")
+	if first != "team-dda77381" {
+		t.Fatalf("teamPseudonym() = %q, want team-dda77381", first)
+	}
+	if again := teamPseudonym(" TEAM-123 "); again != first {
+		t.Fatalf("expected stable pseudonym, got %q and %q", first, again)
+	}
+	if other := teamPseudonym("TEAM-456"); other == first {
+		t.Fatalf("expected distinct pseudonyms for distinct teams, got %q", other)
+	}
+}
+
+func TestTeamRedactorReplacesTeamIDWithoutMutatingInput(t *testing.T) {
+	redactor := newTeamRedactor(true, "ABC-Team")
+	links := map[string]string{"manage": "https://example.com/teams/ABC-Team/usage?team=abc-team"}
+	redactedLinks := redactor.Links(links)
+	if strings.Contains(strings.ToLower(redactedLinks["manage"]), "abc-team") {
+		t.Fatalf("expected team ID to be redacted, got %q", redactedLinks["manage"])
+	}
+	if links["manage"] != "https://example.com/teams/ABC-Team/usage?team=abc-team" {
+		t.Fatalf("expected input links to be unchanged, got %q", links["manage"])
+	}
+
+	plaintext := "team=ABC-Team"
+	vars := []webcore.CIEnvironmentVariable{{Name: "TEAM", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plaintext}}}
+	redactedVars := redactor.EnvVars(vars)
+	if got := *redactedVars[0].Value.Plaintext; got != "team="+teamPseudonym("ABC-Team") {
+		t.Fatalf("unexpected redacted value %q", got)
+	}
+	if plaintext != "team=ABC-Team" {
+		t.Fatalf("expected input value to be unchanged, got %q", plaintext)
+	}
+
+	if got := newTeamRedactor(false, "ABC-Team").String("ABC-Team"); got != "ABC-Team" {
+		t.Fatalf("expected disabled redactor to be a no-op, got %q", got)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertRedactTeam(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	summary := &webcore.CIUsageSummary{
+		Plan:  webcore.CIUsagePlan{Name: "Starter", Used: 100, Available: 900, Total: 1000},
+		Links: map[string]string{"manage": "https://appstoreconnect.apple.com/teams/TEAM-123/xcode-cloud"},
+	}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--redact-team",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "TEAM-123") {
+		t.Fatalf("expected team ID to be redacted, got %s", stdout)
+	}
+
+	var result CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &result); err != nil {
+		t.Fatalf("expected valid json output, got error %v", err)
+	}
+	if result.TeamID != "team-dda77381" {
+		t.Fatalf("expected pseudonymous team ID, got %q", result.TeamID)
+	}
+	if !strings.Contains(result.Plan.ManageURL, "/teams/team-dda77381/") {
+		t.Fatalf("expected redacted manage URL, got %q", result.Plan.ManageURL)
+	}
+}
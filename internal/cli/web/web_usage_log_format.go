@@ -0,0 +1,91 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const usageLogFormatLogfmt = "logfmt"
+
+// logfmtField is one key=value pair of a logfmt line.
+type logfmtField struct {
+	key   string
+	value string
+}
+
+func bindUsageLogFormatFlag(fs *flag.FlagSet) *string {
+	return fs.String("log-format", "", "Print a single logfmt line (key=value pairs) to stdout instead of --output: logfmt")
+}
+
+// parseUsageLogFormat reports whether logfmt output was requested.
+func parseUsageLogFormat(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return false, nil
+	case usageLogFormatLogfmt:
+		return true, nil
+	default:
+		return false, fmt.Errorf("--log-format must be: logfmt")
+	}
+}
+
+// printUsageLine prints a single line that bypasses --output, such as a logfmt
+// line or a bare percent, to stdout or --out-file.
+func printUsageLine(line string) error {
+	return shared.WithOutputDestination(func() error {
+		_, err := fmt.Println(line)
+		return err
+	})
+}
+
+// formatLogfmt joins fields into a logfmt line. Values containing spaces,
+// equals signs, or quotes are quoted; empty values are written as "".
+func formatLogfmt(fields []logfmtField) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value := field.value
+		if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+			value = strconv.Quote(value)
+		}
+		parts = append(parts, field.key+"="+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// appendUsagePercentField adds percent= when the plan total is known.
+func appendUsagePercentField(fields []logfmtField, used, total int) []logfmtField {
+	if total <= 0 {
+		return fields
+	}
+	return append(fields, logfmtField{"percent", strconv.Itoa(webcore.CIUsagePercent(used, total))})
+}
+
+func formatUsageAlertLogfmt(result *CIUsageAlertResult) string {
+	fields := []logfmtField{
+		{"severity", string(result.Severity)},
+		{"used", strconv.Itoa(result.Plan.Used)},
+		{"total", strconv.Itoa(result.Plan.Total)},
+	}
+	fields = appendUsagePercentField(fields, result.Plan.Used, result.Plan.Total)
+	fields = append(fields, logfmtField{"team", result.TeamID})
+	return formatLogfmt(fields)
+}
+
+func formatUsageSummaryLogfmt(summary *webcore.CIUsageSummary, team string) string {
+	fields := []logfmtField{
+		{"used", strconv.Itoa(summary.Plan.Used)},
+		{"available", strconv.Itoa(summary.Plan.Available)},
+		{"total", strconv.Itoa(summary.Plan.Total)},
+	}
+	fields = appendUsagePercentField(fields, summary.Plan.Used, summary.Plan.Total)
+	fields = append(fields,
+		logfmtField{"reset_date", summary.Plan.ResetDate},
+		logfmtField{"team", team},
+	)
+	return formatLogfmt(fields)
+}
@@ -0,0 +1,35 @@
+package web
+
+import "testing"
+
+func TestFormatLogfmtQuotesValues(t *testing.T) {
+	got := formatLogfmt([]logfmtField{
+		{"severity", "ok"},
+		{"team", "Acme Corp"},
+		{"note", `a="b"`},
+		{"reset_date", ""},
+	})
+	want := `severity=ok team="Acme Corp" note="a=\"b\"" reset_date=""`
+	if got != want {
+		t.Fatalf("formatLogfmt() = %q, want %q", got, want)
+	}
+}
+
+func TestParseUsageLogFormat(t *testing.T) {
+	if enabled, err := parseUsageLogFormat(""); err != nil || enabled {
+		t.Fatalf("expected empty value to disable logfmt, got %v, %v", enabled, err)
+	}
+	if enabled, err := parseUsageLogFormat(" LOGFMT "); err != nil || !enabled {
+		t.Fatalf("expected logfmt to be enabled, got %v, %v", enabled, err)
+	}
+	if _, err := parseUsageLogFormat("json"); err == nil {
+		t.Fatal("expected an error for an unsupported log format")
+	}
+}
+
+func TestFormatUsageAlertLogfmtOmitsPercentWithoutTotal(t *testing.T) {
+	got := formatUsageAlertLogfmt(&CIUsageAlertResult{Severity: usageAlertSeverityUnknown, TeamID: "TEAM-123"})
+	if got != "severity=unknown used=0 total=0 team=TEAM-123" {
+		t.Fatalf("unexpected logfmt line: %q", got)
+	}
+}
@@ -0,0 +1,329 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	usageDurationFormatMinutes = "minutes"
+	usageDurationFormatHMS     = "hms"
+
+	usageUnitMinutes = "minutes"
+	usageUnitSeconds = "seconds"
+
+	defaultUsageBarWidth = 16
+	minUsageBarWidth     = 4
+	maxUsageBarWidth     = 60
+
+	usagePercentRoundingNearest = "round"
+	usagePercentRoundingFloor   = "floor"
+
+	maxUsagePercentPrecision = 2
+)
+
+// usageNumberFormatOptions controls how minute and build counts render in
+// table/markdown rows. JSON output always carries raw integers.
+type usageNumberFormatOptions struct {
+	humanize    bool
+	durationHMS bool
+	// seconds reports durations in seconds: exact where the API returns
+	// usage_in_seconds, minutes*60 otherwise.
+	seconds bool
+	// barWidth is the usage bar width in characters; zero means the default.
+	barWidth int
+	// percentPrecision is the number of decimal places in displayed usage
+	// percents. Severity classification always uses exact ratios.
+	percentPrecision int
+	// percentFloor truncates displayed percents instead of rounding, so usage
+	// just under the plan total never shows as 100%.
+	percentFloor bool
+}
+
+// usageNumberFormat is set for the duration of a command's Exec from its
+// --humanize/--duration-format/--unit/--bar-width/--percent-* flags; the zero
+// value prints plain minute integers, default-width bars, and rounded whole
+// percents.
+var usageNumberFormat usageNumberFormatOptions
+
+// usageNumberFormatFlags holds the bound format flags; commands that bind only
+// the percent flags leave the others nil.
+type usageNumberFormatFlags struct {
+	humanize         *bool
+	durationFormat   *string
+	unit             *string
+	barWidth         *int
+	percentPrecision *int
+	percentRounding  *string
+}
+
+func bindUsageNumberFormatFlags(fs *flag.FlagSet) usageNumberFormatFlags {
+	return usageNumberFormatFlags{
+		humanize:         fs.Bool("humanize", false, "Format minute and build counts with thousands separators (table/markdown)"),
+		durationFormat:   fs.String("duration-format", usageDurationFormatMinutes, "Render minutes as: minutes, hms (e.g. 2h 25m) (table/markdown)"),
+		unit:             fs.String("unit", usageUnitMinutes, "Report durations in: minutes, seconds (exact where the API returns seconds, else minutes*60)"),
+		barWidth:         fs.Int("bar-width", defaultUsageBarWidth, fmt.Sprintf("Usage bar width in characters, clamped to %d-%d (table/markdown)", minUsageBarWidth, maxUsageBarWidth)),
+		percentPrecision: bindUsagePercentPrecisionFlag(fs),
+		percentRounding:  bindUsagePercentRoundingFlag(fs),
+	}
+}
+
+// bindUsagePercentFormatFlags binds only the percent display flags, for
+// commands that show a used percent but no minute tables.
+func bindUsagePercentFormatFlags(fs *flag.FlagSet) usageNumberFormatFlags {
+	return usageNumberFormatFlags{
+		percentPrecision: bindUsagePercentPrecisionFlag(fs),
+		percentRounding:  bindUsagePercentRoundingFlag(fs),
+	}
+}
+
+func bindUsagePercentPrecisionFlag(fs *flag.FlagSet) *int {
+	return fs.Int("percent-precision", 0, fmt.Sprintf("Decimal places in displayed usage percents (0-%d)", maxUsagePercentPrecision))
+}
+
+func bindUsagePercentRoundingFlag(fs *flag.FlagSet) *string {
+	return fs.String("percent-rounding", usagePercentRoundingNearest, "How displayed usage percents are rounded: round, floor (floor never shows 100% below the cap)")
+}
+
+// apply validates the flags and installs them as the active format. The
+// returned func restores the previous format.
+func (f usageNumberFormatFlags) apply() (func(), error) {
+	var options usageNumberFormatOptions
+	if f.humanize != nil {
+		options.humanize = *f.humanize
+	}
+	if f.durationFormat != nil {
+		switch strings.ToLower(strings.TrimSpace(*f.durationFormat)) {
+		case usageDurationFormatMinutes, "":
+		case usageDurationFormatHMS:
+			options.durationHMS = true
+		default:
+			return func() {}, fmt.Errorf("--duration-format must be one of: minutes, hms")
+		}
+	}
+	if f.unit != nil {
+		switch strings.ToLower(strings.TrimSpace(*f.unit)) {
+		case usageUnitMinutes, "":
+		case usageUnitSeconds:
+			options.seconds = true
+		default:
+			return func() {}, fmt.Errorf("--unit must be one of: minutes, seconds")
+		}
+	}
+	if f.barWidth != nil {
+		options.barWidth = clampUsageBarWidth(*f.barWidth)
+	}
+	if f.percentPrecision != nil {
+		if *f.percentPrecision < 0 || *f.percentPrecision > maxUsagePercentPrecision {
+			return func() {}, fmt.Errorf("--percent-precision must be between 0 and %d", maxUsagePercentPrecision)
+		}
+		options.percentPrecision = *f.percentPrecision
+	}
+	if f.percentRounding != nil {
+		switch strings.ToLower(strings.TrimSpace(*f.percentRounding)) {
+		case usagePercentRoundingNearest, "":
+		case usagePercentRoundingFloor:
+			options.percentFloor = true
+		default:
+			return func() {}, fmt.Errorf("--percent-rounding must be one of: round, floor")
+		}
+	}
+	previous := usageNumberFormat
+	usageNumberFormat = options
+	return func() { usageNumberFormat = previous }, nil
+}
+
+// clampUsageBarWidth keeps a requested bar width within the supported range.
+func clampUsageBarWidth(width int) int {
+	return min(max(width, minUsageBarWidth), maxUsageBarWidth)
+}
+
+// usageBarWidth returns the active usage bar width.
+func usageBarWidth() int {
+	if usageNumberFormat.barWidth <= 0 {
+		return defaultUsageBarWidth
+	}
+	return usageNumberFormat.barWidth
+}
+
+// formatUsagePercent renders used as a percent of total for display, honoring
+// --percent-precision and --percent-rounding. used is clamped to [0, total];
+// a non-positive total renders "n/a". Integer arithmetic keeps exact ratios
+// such as 29/100 from drifting under floor.
+func formatUsagePercent(used, total int) string {
+	if total <= 0 {
+		return "n/a"
+	}
+	used = min(max(used, 0), total)
+	precision := usageNumberFormat.percentPrecision
+	scale := int64(1)
+	for range precision {
+		scale *= 10
+	}
+	scaled := int64(used) * 100 * scale
+	var value int64
+	if usageNumberFormat.percentFloor {
+		value = scaled / int64(total)
+	} else {
+		value = (scaled + int64(total)/2) / int64(total)
+	}
+	if precision == 0 {
+		return fmt.Sprintf("%d%%", value)
+	}
+	return fmt.Sprintf("%d.%0*d%%", value/scale, precision, value%scale)
+}
+
+// formatUsageMinutes renders a minute count for a table cell. With --unit
+// seconds the count is converted to seconds first.
+func formatUsageMinutes(minutes int) string {
+	if usageNumberFormat.seconds {
+		return formatUsageSeconds(minutes * 60)
+	}
+	if !usageNumberFormat.durationHMS {
+		return formatUsageCount(minutes)
+	}
+	sign := ""
+	if minutes < 0 {
+		sign = "-"
+		minutes = -minutes
+	}
+	hours, rest := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%s%dm", sign, rest)
+	case rest == 0:
+		return fmt.Sprintf("%s%sh", sign, formatUsageCount(hours))
+	default:
+		return fmt.Sprintf("%s%sh %dm", sign, formatUsageCount(hours), rest)
+	}
+}
+
+// formatUsageSeconds renders a second count for a table cell, as h/m/s parts
+// under --duration-format hms.
+func formatUsageSeconds(seconds int) string {
+	if !usageNumberFormat.durationHMS {
+		return formatUsageCount(seconds)
+	}
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	hours, minutes, rest := seconds/3600, seconds/60%60, seconds%60
+	parts := make([]string, 0, 3)
+	if hours > 0 {
+		parts = append(parts, formatUsageCount(hours)+"h")
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	if rest > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%ds", rest))
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// formatUsageDuration renders a duration cell from minutes, preferring an
+// exact second count when --unit seconds is active and one is available.
+func formatUsageDuration(minutes, seconds int) string {
+	if usageNumberFormat.seconds && seconds > 0 {
+		return formatUsageSeconds(seconds)
+	}
+	return formatUsageMinutes(minutes)
+}
+
+// formatUsageTotal renders a duration with its unit for summary lines, e.g.
+// "1234 minutes", "74040 seconds", or "20h 34m" with --duration-format hms.
+func formatUsageTotal(minutes, seconds int) string {
+	value := formatUsageDuration(minutes, seconds)
+	switch {
+	case usageNumberFormat.durationHMS:
+		return value
+	case usageNumberFormat.seconds:
+		return value + " " + usageUnitSeconds
+	default:
+		return value + " " + usageUnitMinutes
+	}
+}
+
+// usageUnitHeaders relabels minute columns as seconds under --unit seconds.
+func usageUnitHeaders(headers []string) []string {
+	if !usageNumberFormat.seconds {
+		return headers
+	}
+	relabeled := make([]string, len(headers))
+	for i, header := range headers {
+		relabeled[i] = strings.Replace(header, "Minutes", "Seconds", 1)
+	}
+	return relabeled
+}
+
+// productUsageSeconds returns a product's usage in seconds. Without
+// usage_in_seconds it falls back to minutes*60 and reports it as approximate.
+func productUsageSeconds(product webcore.CIProductUsage) (seconds int, approximate bool) {
+	if product.UsageInSeconds > 0 {
+		return product.UsageInSeconds, false
+	}
+	minutes, _ := normalizeProductUsage(product)
+	return minutes * 60, minutes > 0
+}
+
+// CIProductUsageOutput is a product usage row in usage JSON output. Under
+// --unit seconds, usage_in_seconds is filled from minutes when the API left it
+// out, and UsageInSecondsApproximate flags those rows.
+type CIProductUsageOutput struct {
+	webcore.CIProductUsage
+	UsageInSecondsApproximate bool `json:"usage_in_seconds_approximate,omitempty"`
+}
+
+// productUsageOutput wraps products for JSON output, applying the --unit
+// seconds fallback to the copies. A nil slice stays nil.
+func productUsageOutput(products []webcore.CIProductUsage) []CIProductUsageOutput {
+	if products == nil {
+		return nil
+	}
+	rows := make([]CIProductUsageOutput, len(products))
+	for i, product := range products {
+		rows[i] = CIProductUsageOutput{CIProductUsage: product}
+		if usageNumberFormat.seconds {
+			rows[i].UsageInSeconds, rows[i].UsageInSecondsApproximate = productUsageSeconds(product)
+		}
+	}
+	return rows
+}
+
+// formatUsageCount renders a count for a table cell, adding thousands
+// separators when --humanize is set.
+func formatUsageCount(value int) string {
+	if !usageNumberFormat.humanize {
+		return strconv.Itoa(value)
+	}
+	return formatThousands(value)
+}
+
+func formatThousands(value int) string {
+	digits := strconv.Itoa(value)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= 3 {
+		return sign + digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return sign + b.String()
+}
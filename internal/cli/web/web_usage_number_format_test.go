@@ -0,0 +1,381 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestFormatUsageNumbers(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     usageNumberFormatOptions
+		minutes     int
+		wantMinutes string
+		count       int
+		wantCount   string
+	}{
+		{name: "plain", minutes: 145230, wantMinutes: "145230", count: 1234, wantCount: "1234"},
+		{name: "humanize", options: usageNumberFormatOptions{humanize: true}, minutes: 145230, wantMinutes: "145,230", count: -1234567, wantCount: "-1,234,567"},
+		{name: "hms", options: usageNumberFormatOptions{durationHMS: true}, minutes: 145, wantMinutes: "2h 25m", count: 999, wantCount: "999"},
+		{name: "hms whole hours", options: usageNumberFormatOptions{durationHMS: true}, minutes: 120, wantMinutes: "2h", count: 0, wantCount: "0"},
+		{name: "hms under an hour", options: usageNumberFormatOptions{durationHMS: true}, minutes: 45, wantMinutes: "45m", count: 0, wantCount: "0"},
+		{name: "hms humanized hours", options: usageNumberFormatOptions{humanize: true, durationHMS: true}, minutes: 145230, wantMinutes: "2,420h 30m", count: 1000, wantCount: "1,000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := usageNumberFormat
+			t.Cleanup(func() { usageNumberFormat = previous })
+			usageNumberFormat = test.options
+
+			if got := formatUsageMinutes(test.minutes); got != test.wantMinutes {
+				t.Fatalf("formatUsageMinutes(%d) = %q, want %q", test.minutes, got, test.wantMinutes)
+			}
+			if got := formatUsageCount(test.count); got != test.wantCount {
+				t.Fatalf("formatUsageCount(%d) = %q, want %q", test.count, got, test.wantCount)
+			}
+		})
+	}
+}
+
+func TestUsageNumberFormatFlagsApplyAndRestore(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	if err := fs.Parse([]string{"--humanize", "--duration-format", "HMS"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	restore, err := numberFormat.apply()
+	if err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+	if !usageNumberFormat.humanize || !usageNumberFormat.durationHMS {
+		t.Fatalf("expected format to be applied, got %+v", usageNumberFormat)
+	}
+	restore()
+	if usageNumberFormat != (usageNumberFormatOptions{}) {
+		t.Fatalf("expected format to be restored, got %+v", usageNumberFormat)
+	}
+}
+
+func TestWebXcodeCloudUsageProductsRejectsInvalidDurationFormat(t *testing.T) {
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--duration-format", "seconds"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--duration-format must be one of: minutes, hms") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageProductsHumanizedTable(t *testing.T) {
+	months := testCIUsageProductsMonths()
+	months.ProductUsage[1].UsageInMinutes = 145230
+	months.ProductUsage[1].NumberOfBuilds = 1200
+	stubUsageProductsSession(t, months, nil)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--output", "table",
+		"--humanize",
+		"--duration-format", "hms",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"2,420h 30m", "1,200", "4h 10m"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+	if usageNumberFormat != (usageNumberFormatOptions{}) {
+		t.Fatalf("expected number format to be restored after exec, got %+v", usageNumberFormat)
+	}
+}
+
+func TestFormatUsageSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		options usageNumberFormatOptions
+		seconds int
+		want    string
+	}{
+		{name: "plain", options: usageNumberFormatOptions{seconds: true}, seconds: 8705, want: "8705"},
+		{name: "humanize", options: usageNumberFormatOptions{seconds: true, humanize: true}, seconds: 8705, want: "8,705"},
+		{name: "hms", options: usageNumberFormatOptions{seconds: true, durationHMS: true}, seconds: 8705, want: "2h 25m 5s"},
+		{name: "hms seconds only", options: usageNumberFormatOptions{seconds: true, durationHMS: true}, seconds: 0, want: "0s"},
+		{name: "negative", options: usageNumberFormatOptions{seconds: true, durationHMS: true}, seconds: -1, want: "-1s"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := usageNumberFormat
+			t.Cleanup(func() { usageNumberFormat = previous })
+			usageNumberFormat = test.options
+
+			if got := formatUsageSeconds(test.seconds); got != test.want {
+				t.Fatalf("formatUsageSeconds(%d) = %q, want %q", test.seconds, got, test.want)
+			}
+		})
+	}
+
+	previous := usageNumberFormat
+	t.Cleanup(func() { usageNumberFormat = previous })
+	usageNumberFormat = usageNumberFormatOptions{seconds: true}
+	if got := formatUsageMinutes(3); got != "180" {
+		t.Fatalf("formatUsageMinutes(3) = %q, want %q", got, "180")
+	}
+	if got := formatUsageDuration(3, 175); got != "175" {
+		t.Fatalf("formatUsageDuration(3, 175) = %q, want %q", got, "175")
+	}
+	headers := usageUnitHeaders([]string{"Product", "Minutes", "Plan Minutes"})
+	if strings.Join(headers, ",") != "Product,Seconds,Plan Seconds" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+}
+
+func TestProductUsageSecondsFallback(t *testing.T) {
+	exact, approximate := productUsageSeconds(webcore.CIProductUsage{UsageInMinutes: 3, UsageInSeconds: 175})
+	if exact != 175 || approximate {
+		t.Fatalf("exact seconds = (%d, %v), want (175, false)", exact, approximate)
+	}
+	fallback, approximate := productUsageSeconds(webcore.CIProductUsage{UsageInMinutes: 3})
+	if fallback != 180 || !approximate {
+		t.Fatalf("fallback seconds = (%d, %v), want (180, true)", fallback, approximate)
+	}
+}
+
+func TestUsageNumberFormatFlagsRejectsInvalidUnit(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	if err := fs.Parse([]string{"--unit", "hours"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if _, err := numberFormat.apply(); err == nil || err.Error() != "--unit must be one of: minutes, seconds" {
+		t.Fatalf("apply() error = %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageProductsSecondsTable(t *testing.T) {
+	months := testCIUsageProductsMonths()
+	months.ProductUsage[1].UsageInSeconds = 23995
+	stubUsageProductsSession(t, months, nil)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--output", "table",
+		"--unit", "seconds",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"Seconds", "23995", "15000", "6000"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Minutes") {
+		t.Fatalf("expected minute headers to be relabeled, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsSecondsJSONMarksApproximate(t *testing.T) {
+	months := testCIUsageProductsMonths()
+	months.ProductUsage[1].UsageInSeconds = 23995
+	stubUsageProductsSession(t, months, nil)
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--output", "json",
+		"--unit", "seconds",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var payload struct {
+		ProductUsage []struct {
+			ProductID          string `json:"product_id"`
+			UsageInSeconds     int    `json:"usage_in_seconds"`
+			SecondsApproximate bool   `json:"usage_in_seconds_approximate"`
+		} `json:"product_usage"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	got := map[string][2]any{}
+	for _, product := range payload.ProductUsage {
+		got[product.ProductID] = [2]any{product.UsageInSeconds, product.SecondsApproximate}
+	}
+	if got["prod-a"] != [2]any{6000, true} {
+		t.Fatalf("prod-a = %v, want [6000 true]", got["prod-a"])
+	}
+	if got["prod-b"] != [2]any{23995, false} {
+		t.Fatalf("prod-b = %v, want [23995 false]", got["prod-b"])
+	}
+}
+
+func TestFormatUsageBarWidth(t *testing.T) {
+	previous := usageNumberFormat
+	t.Cleanup(func() { usageNumberFormat = previous })
+
+	usageNumberFormat = usageNumberFormatOptions{}
+	if got := formatUsageBar(50, 100); got != "[########........]  50%" {
+		t.Fatalf("default bar = %q", got)
+	}
+	usageNumberFormat = usageNumberFormatOptions{barWidth: 4}
+	if got := formatUsageBar(50, 100); got != "[##..]  50%" {
+		t.Fatalf("narrow bar = %q", got)
+	}
+	if got := formatUsageBar(10, 0); got != "[....] n/a" {
+		t.Fatalf("narrow n/a bar = %q", got)
+	}
+}
+
+func TestUsageNumberFormatFlagsClampBarWidth(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{value: "1", want: 4},
+		{value: "32", want: 32},
+		{value: "500", want: 60},
+	}
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		numberFormat := bindUsageNumberFormatFlags(fs)
+		if err := fs.Parse([]string{"--bar-width", test.value}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		restore, err := numberFormat.apply()
+		if err != nil {
+			t.Fatalf("apply() error = %v", err)
+		}
+		if got := usageBarWidth(); got != test.want {
+			t.Fatalf("--bar-width %s: width = %d, want %d", test.value, got, test.want)
+		}
+		restore()
+	}
+}
+
+func TestFormatUsagePercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		options usageNumberFormatOptions
+		used    int
+		total   int
+		want    string
+	}{
+		{name: "default rounds to whole percent", used: 9995, total: 10000, want: "100%"},
+		{name: "floor avoids a false 100%", options: usageNumberFormatOptions{percentFloor: true}, used: 9995, total: 10000, want: "99%"},
+		{name: "one decimal", options: usageNumberFormatOptions{percentPrecision: 1}, used: 9995, total: 10000, want: "100.0%"},
+		{name: "one decimal floor", options: usageNumberFormatOptions{percentPrecision: 1, percentFloor: true}, used: 9995, total: 10000, want: "99.9%"},
+		{name: "two decimals", options: usageNumberFormatOptions{percentPrecision: 2}, used: 1, total: 3, want: "33.33%"},
+		{name: "floor keeps exact ratios", options: usageNumberFormatOptions{percentPrecision: 2, percentFloor: true}, used: 29, total: 100, want: "29.00%"},
+		{name: "at cap", options: usageNumberFormatOptions{percentFloor: true}, used: 1000, total: 1000, want: "100%"},
+		{name: "clamped above total", used: 1500, total: 1000, want: "100%"},
+		{name: "no total", used: 10, total: 0, want: "n/a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := usageNumberFormat
+			t.Cleanup(func() { usageNumberFormat = previous })
+			usageNumberFormat = test.options
+
+			if got := formatUsagePercent(test.used, test.total); got != test.want {
+				t.Fatalf("formatUsagePercent(%d, %d) = %q, want %q", test.used, test.total, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatUsageBarPercentPrecision(t *testing.T) {
+	previous := usageNumberFormat
+	t.Cleanup(func() { usageNumberFormat = previous })
+
+	usageNumberFormat = usageNumberFormatOptions{barWidth: 4}
+	if got := formatUsageBar(5, 100); got != "[....]   5%" {
+		t.Fatalf("default bar = %q", got)
+	}
+	usageNumberFormat = usageNumberFormatOptions{barWidth: 4, percentPrecision: 1, percentFloor: true}
+	if got := formatUsageBar(999, 1000); got != "[####]  99.9%" {
+		t.Fatalf("precision bar = %q", got)
+	}
+}
+
+func TestUsagePercentFormatFlagsValidate(t *testing.T) {
+	for _, args := range [][]string{
+		{"--percent-precision", "3"},
+		{"--percent-precision", "-1"},
+		{"--percent-rounding", "ceil"},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		percentFormat := bindUsagePercentFormatFlags(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if _, err := percentFormat.apply(); err == nil {
+			t.Fatalf("expected %v to be rejected", args)
+		}
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	percentFormat := bindUsagePercentFormatFlags(fs)
+	if err := fs.Parse([]string{"--percent-precision", "2", "--percent-rounding", "FLOOR"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	restore, err := percentFormat.apply()
+	if err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+	if usageNumberFormat != (usageNumberFormatOptions{percentPrecision: 2, percentFloor: true}) {
+		t.Fatalf("expected only percent options to be applied, got %+v", usageNumberFormat)
+	}
+	restore()
+	if usageNumberFormat != (usageNumberFormatOptions{}) {
+		t.Fatalf("expected restore to reset format, got %+v", usageNumberFormat)
+	}
+}
+
+func TestProductUsageOutputLeavesResponseUntouched(t *testing.T) {
+	previous := usageNumberFormat
+	t.Cleanup(func() { usageNumberFormat = previous })
+	usageNumberFormat = usageNumberFormatOptions{seconds: true}
+
+	products := []webcore.CIProductUsage{{ProductID: "prod-1", UsageInMinutes: 2}, {ProductID: "prod-2", UsageInSeconds: 61}}
+	rows := productUsageOutput(products)
+	if rows[0].UsageInSeconds != 120 || !rows[0].UsageInSecondsApproximate {
+		t.Fatalf("expected approximate seconds from minutes, got %+v", rows[0])
+	}
+	if rows[1].UsageInSeconds != 61 || rows[1].UsageInSecondsApproximate {
+		t.Fatalf("expected exact API seconds, got %+v", rows[1])
+	}
+	if products[0].UsageInSeconds != 0 {
+		t.Fatalf("expected the API response to be left unchanged, got %+v", products[0])
+	}
+	if productUsageOutput(nil) != nil {
+		t.Fatal("expected nil output for nil products")
+	}
+}
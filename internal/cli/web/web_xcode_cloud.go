@@ -2,10 +2,15 @@ package web
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"maps"
+	"math"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -18,8 +23,14 @@ import (
 var (
 	newCIClientFn = webcore.NewCIClient
 	webNowFn      = time.Now
+
+	// usageWatchIntervalUnit scales --interval; tests shorten it.
+	usageWatchIntervalUnit = time.Second
 )
 
+// clearScreenSequence moves the cursor home and clears the terminal.
+const clearScreenSequence = "\033[H\033[2J"
+
 // WebXcodeCloudCommand returns the xcode-cloud command group.
 func WebXcodeCloudCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud", flag.ExitOnError)
@@ -33,9 +44,16 @@ func WebXcodeCloudCommand() *ffcli.Command {
 Query Xcode Cloud compute usage (plan quota, monthly/daily breakdowns, products)
 using Apple's private CI API. Requires a web session.
 
+Set ASC_CI_BASE_URL to send CI API requests to another http(s) base URL, such
+as a local proxy or replay server (default: https://appstoreconnect.apple.com/ci/api).
+
+Run "asc web xcode-cloud doctor" first when something is off; it checks the
+session, team, and Xcode Cloud access step by step.
+
 ` + webWarningText + `
 
 Examples:
+  asc web xcode-cloud doctor --apple-id "user@example.com"
   asc web xcode-cloud usage summary --apple-id "user@example.com"
   asc web xcode-cloud usage alert --apple-id "user@example.com" --output table
   asc web xcode-cloud products --apple-id "user@example.com" --output table
@@ -53,6 +71,7 @@ Examples:
 			webXcodeCloudProductsCommand(),
 			webXcodeCloudWorkflowsCommand(),
 			webXcodeCloudEnvVarsCommand(),
+			webXcodeCloudDoctorCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -69,7 +88,24 @@ func webXcodeCloudUsageCommand() *ffcli.Command {
 		ShortHelp:  "EXPERIMENTAL: Xcode Cloud usage queries.",
 		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
 
-Query Xcode Cloud compute usage: plan summary, monthly history, daily breakdown, per-workflow usage.
+Query Xcode Cloud compute usage: plan summary, monthly history, daily breakdown, per-workflow usage,
+and per-product rankings. Use 'usage top' for a combined ranking of the top products and workflows,
+'usage metrics' to print OpenMetrics gauges for a Prometheus Pushgateway, and 'usage snapshot'
+to save a baseline and report changes against it later.
+
+Table and markdown output of summary, months, days, workflows, and products accept --humanize
+to add thousands separators and --duration-format hms to show minutes as "2h 25m".
+Use --bar-width N to resize usage bars (default 16, clamped to 4-60).
+months, days, and products also accept --output tsv: tab-separated rows with the table's
+columns minus the usage bars, for cut and awk pipelines.
+JSON output always keeps raw integers. When months, days, workflows, products, or top
+find no usage, JSON output adds "status": "empty" and the same "message" the table shows.
+When a months or days response has neither range metadata nor usage, the table shows
+"Range: no data for requested range" and JSON reports "status": "no_data" instead.
+
+Use --unit seconds to report seconds instead of minutes. Seconds are exact where
+the API returns usage_in_seconds; otherwise they are minutes*60 and JSON marks
+them approximate.
 
 ` + webWarningText,
 		FlagSet:   fs,
@@ -77,9 +113,15 @@ Query Xcode Cloud compute usage: plan summary, monthly history, daily breakdown,
 		Subcommands: []*ffcli.Command{
 			webXcodeCloudUsageSummaryCommand(),
 			webXcodeCloudUsageAlertCommand(),
+			webXcodeCloudUsageAlertAllCommand(),
 			webXcodeCloudUsageMonthsCommand(),
 			webXcodeCloudUsageDaysCommand(),
+			webXcodeCloudUsageDayCommand(),
 			webXcodeCloudUsageWorkflowsCommand(),
+			webXcodeCloudUsageProductsCommand(),
+			webXcodeCloudUsageTopCommand(),
+			webXcodeCloudUsageMetricsCommand(),
+			webXcodeCloudUsageSnapshotCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -90,7 +132,18 @@ Query Xcode Cloud compute usage: plan summary, monthly history, daily breakdown,
 func webXcodeCloudUsageSummaryCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud usage summary", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+	watch := fs.Bool("watch", false, "Re-fetch and re-render the summary every --interval until interrupted")
+	interval := fs.Int("interval", 60, "Refresh interval in seconds for --watch")
+	percentOnly := fs.Bool("percent-only", false, "Print only the integer percent of plan minutes used (ignores --output)")
+	redactTeam := bindRedactTeamFlag(fs)
+	allProducts := fs.Bool("all-products", false, "Also show each product's share of the current billing period's usage")
+	pace := fs.Bool("pace", false, "Compare usage with a linear burn of the plan since the last reset")
+	history := fs.Int("history", 0, "Append a sparkline of the last N months of usage, including the current month (0 to disable, max 24)")
+	logFormat := bindUsageLogFormatFlag(fs)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	maxDataAge := bindMaxDataAgeFlag(fs)
+	raw := bindRawFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "summary",
@@ -99,15 +152,115 @@ func webXcodeCloudUsageSummaryCommand() *ffcli.Command {
 		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
 
 Show current Xcode Cloud plan usage: used/available/total compute minutes and reset date.
+Table and markdown output add a Resets column with the time left until the reset,
+e.g. "in 9 days" or "in 14 hours"; "imminent" means under an hour and "overdue"
+means the reset time has passed but the usage data has not caught up yet.
+
+Use --watch to refresh the summary every --interval seconds until interrupted.
+Table and markdown output clear the screen between refreshes; JSON output
+emits one object per refresh. Each refresh timestamp is printed to stderr.
+
+Use --percent-only to print just the integer used percent for shell scripts
+("n/a" when the plan total is unavailable).
+
+Use --log-format logfmt to print a single key=value line for log pipelines
+instead of --output, e.g.
+"used=960 available=40 total=1000 percent=96 reset_date=2026-03-01 team=TEAM-123".
+With --watch, one line is printed per refresh.
+
+Use --all-products to also list each product's minutes and share of usage since the
+start of the current billing period, sorted by minutes. JSON output nests them
+under "products".
+
+Use --pace to see whether usage is ahead of or behind a linear burn of the plan.
+Expected usage is the plan total scaled by the days elapsed since the last reset,
+derived from the plan reset date. Table and markdown output add a pace bar for the
+expected usage; JSON output nests the comparison under "pace".
+
+Use --history N to append a sparkline of the last N months of usage (e.g. ▁▂▄█▅▃),
+ending with the current month. JSON output nests the monthly minutes and the
+sparkline under "history".
+
+Use --redact-team to replace the team ID in links with a stable hashed pseudonym
+(team- plus the first 8 hex characters of its SHA-256) before sharing output.
+
+Use --max-data-age to fail instead of printing when the usage data looks stale:
+the plan reset time passed more than the given duration ago without the usage
+rolling over to a new cycle. Summaries without a reset date also fail the check.
+
+Use --raw to print the unparsed API response instead of the normalized summary,
+e.g. to show exactly what Apple returned when filing a bug about schema drift.
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud usage summary --apple-id "user@example.com"
-  asc web xcode-cloud usage summary --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --max-data-age 6h
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --raw
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --output table --watch --interval 60
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --percent-only
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --log-format logfmt --watch
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --all-products --output table
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --pace --output table
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --history 6 --output table
+  asc web xcode-cloud usage summary --apple-id "user@example.com" --output template --template '{{.Plan.Used}}/{{.Plan.Total}}'`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			restoreNumberFormat, err := numberFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restoreNumberFormat()
+
+			if *interval <= 0 {
+				fmt.Fprintln(os.Stderr, "Error: --interval must be greater than 0")
+				return flag.ErrHelp
+			}
+			if *watch && *percentOnly {
+				fmt.Fprintln(os.Stderr, "Error: --percent-only cannot be used with --watch")
+				return flag.ErrHelp
+			}
+			if *allProducts && (*watch || *percentOnly) {
+				fmt.Fprintln(os.Stderr, "Error: --all-products cannot be used with --watch or --percent-only")
+				return flag.ErrHelp
+			}
+			logfmt, err := parseUsageLogFormat(*logFormat)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if logfmt && (*percentOnly || *allProducts) {
+				fmt.Fprintln(os.Stderr, "Error: --log-format cannot be used with --percent-only or --all-products")
+				return flag.ErrHelp
+			}
+			if *history < 0 || *history > 24 {
+				fmt.Fprintln(os.Stderr, "Error: --history must be between 0 and 24")
+				return flag.ErrHelp
+			}
+			if (*pace || *history > 0) && (*watch || *percentOnly || *allProducts || logfmt) {
+				fmt.Fprintln(os.Stderr, "Error: --pace and --history cannot be used with --watch, --percent-only, --all-products, or --log-format")
+				return flag.ErrHelp
+			}
+			if err := validateMaxDataAge(*maxDataAge); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if *watch && *maxDataAge > 0 {
+				fmt.Fprintln(os.Stderr, "Error: --max-data-age cannot be used with --watch")
+				return flag.ErrHelp
+			}
+			if *raw && *watch {
+				fmt.Fprintln(os.Stderr, "Error: --raw cannot be used with --watch")
+				return flag.ErrHelp
+			}
+			if *raw && (*percentOnly || *redactTeam || logfmt) {
+				fmt.Fprintln(os.Stderr, "Error: --raw cannot be combined with --percent-only, --redact-team, or --log-format")
+				return flag.ErrHelp
+			}
+
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
 
@@ -121,12 +274,83 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			if *watch {
+				watchCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+				defer stop()
+				return watchCIUsageSummary(
+					watchCtx,
+					client,
+					teamID,
+					time.Duration(*interval)*usageWatchIntervalUnit,
+					*output.Output,
+					*output.Pretty,
+					logfmt,
+					redactor,
+				)
+			}
+
 			result, err := withWebSpinnerValue("Loading Xcode Cloud usage summary", func() (*webcore.CIUsageSummary, error) {
 				return client.GetCIUsageSummary(requestCtx, teamID)
 			})
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud usage summary"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud usage summary")
 			}
+			if err := checkUsageDataAge(result.Plan, webNowFn(), *maxDataAge); err != nil {
+				return fmt.Errorf("xcode-cloud usage summary failed: %w", err)
+			}
+			if *percentOnly {
+				return printUsagePercentOnly(result.Plan.Used, result.Plan.Total)
+			}
+			if logfmt {
+				return printUsageLine(formatUsageSummaryLogfmt(result, redactor.String(teamID)))
+			}
+			result.Links = redactor.Links(result.Links)
+			if *pace || *history > 0 {
+				detail := &CIUsageSummaryDetailResult{CIUsageSummary: result}
+				if *pace {
+					detail.Pace, err = buildCIUsagePace(result.Plan, webNowFn())
+					if err != nil {
+						return fmt.Errorf("xcode-cloud usage summary failed: --pace needs the plan total and reset date: %w", err)
+					}
+				}
+				if *history > 0 {
+					detail.History, err = withWebSpinnerValue("Loading Xcode Cloud usage history", func() (*CIUsageHistory, error) {
+						return loadCIUsageHistory(requestCtx, client, teamID, *history)
+					})
+					if err != nil {
+						return withWebAuthHint(err, "xcode-cloud usage summary")
+					}
+				}
+				return shared.PrintOutputWithRenderers(
+					detail,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderCIUsageSummaryDetailTable(detail) },
+					func() error { return renderCIUsageSummaryDetailMarkdown(detail) },
+				)
+			}
+			if *allProducts {
+				withProducts, err := withWebSpinnerValue("Loading Xcode Cloud product usage", func() (*CIUsageSummaryResult, error) {
+					return loadCIUsageSummaryProducts(requestCtx, client, teamID, result)
+				})
+				if err != nil {
+					return withWebAuthHint(err, "xcode-cloud usage summary")
+				}
+				return shared.PrintOutputWithRenderers(
+					withProducts,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderCIUsageSummaryProductsTable(withProducts) },
+					func() error { return renderCIUsageSummaryProductsMarkdown(withProducts) },
+				)
+			}
 			return shared.PrintOutputWithRenderers(
 				result,
 				*output.Output,
@@ -138,10 +362,83 @@ Examples:
 	}
 }
 
+// watchCIUsageSummary re-fetches and prints the usage summary every interval
+// until ctx is done. The authenticated client is reused across refreshes, and
+// a failed refresh is reported without ending the watch. With logfmt, each
+// refresh prints one logfmt line and the screen is never cleared.
+func watchCIUsageSummary(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID string,
+	interval time.Duration,
+	outputFormat string,
+	pretty bool,
+	logfmt bool,
+	redactor teamRedactor,
+) error {
+	format := shared.NormalizeOutputFormat(outputFormat)
+	clearScreen := !logfmt && format != "json" && termIsTerminalFn(int(os.Stdout.Fd()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tickCtx, cancel := shared.ContextWithTimeout(ctx)
+		result, err := client.GetCIUsageSummary(tickCtx, teamID)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		refreshedAt := webNowFn().Format(time.RFC3339)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: refresh at %s failed: %v\n", refreshedAt, withWebAuthHint(err, "xcode-cloud usage summary"))
+		} else {
+			if clearScreen {
+				fmt.Print(clearScreenSequence)
+			}
+			result.Links = redactor.Links(result.Links)
+			fmt.Fprintf(os.Stderr, "Refreshed at %s (every %s, Ctrl-C to stop)\n", refreshedAt, interval)
+			if logfmt {
+				if err := printUsageLine(formatUsageSummaryLogfmt(result, redactor.String(teamID))); err != nil {
+					return err
+				}
+			} else if err := shared.PrintOutputWithRenderers(
+				result,
+				outputFormat,
+				pretty,
+				func() error { return renderCIUsageSummaryTable(result) },
+				func() error { return renderCIUsageSummaryMarkdown(result) },
+			); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// CIUsageMonthsResult is the usage months JSON output. The embedded usage
+// keeps the API shape.
+type CIUsageMonthsResult struct {
+	*webcore.CIUsageMonths
+	// ProductUsageOutput replaces the embedded product_usage in JSON output.
+	ProductUsageOutput []CIProductUsageOutput `json:"product_usage"`
+	// GroupProductsBy and ProductGroups are set with --group-products-by.
+	GroupProductsBy string                `json:"group_products_by,omitempty"`
+	ProductGroups   []CIProductUsageGroup `json:"product_groups,omitempty"`
+	emptyResultNote
+}
+
 func webXcodeCloudUsageMonthsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud usage months", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
 	output := shared.BindOutputFlags(fs)
+	shared.AllowTSVOutput(fs)
 
 	now := webNowFn()
 	defaultEndMonth := int(now.Month())
@@ -155,6 +452,18 @@ func webXcodeCloudUsageMonthsCommand() *ffcli.Command {
 	endMonth := fs.Int("end-month", defaultEndMonth, "End month (1-12)")
 	endYear := fs.Int("end-year", defaultEndYear, "End year")
 	productIDs := fs.String("product-ids", "", "Comma-separated Xcode Cloud product IDs to filter (optional)")
+	resolveBundle := bindResolveBundleFlag(fs)
+	onlyProduct := fs.String("only-product", "", "Show one product's month-by-month usage instead of the team aggregate")
+	grouping := bindProductGroupingFlags(fs)
+	detailed := fs.Bool("detailed", false, "Add Seconds and % Change vs Prev columns to the product table (table/markdown)")
+	resetAnchored := fs.Bool("reset-anchored", false, "Bucket daily usage into billing cycles starting on the plan reset day instead of calendar months")
+	showDelta := fs.Bool("show-delta", false, "Add a month-to-month change column to the monthly table (table/markdown)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+	clockCheck := bindClockCheckFlags(fs)
+	redactTeam := bindRedactTeamFlag(fs)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	raw := bindRawFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "months",
@@ -164,16 +473,56 @@ func webXcodeCloudUsageMonthsCommand() *ffcli.Command {
 
 Show monthly Xcode Cloud compute usage with per-product breakdown.
 Defaults to the last 12 months. Use --product-ids to filter the product breakdown.
+Use --detailed to add seconds and the percent change versus the previous period per product.
+Use --show-delta to add each month's change in minutes versus the month before it (e.g. +120, -40).
+The range may span at most 24 months because the API caps usage history.
+
+Product IDs that do not look like UUIDs (e.g. a pasted URL or bundle ID) print a
+warning, or fail under --strict. Use --resolve-bundle to pass bundle IDs in
+--product-ids; they are looked up in the product list and replaced with the
+matching product IDs.
+
+Use --group-products-by bundle to roll products that share a bundle ID (e.g. an app and
+its extensions) into one line of the product breakdown; --bundle-prefix-depth 3 groups
+com.example.app.widget with com.example.app. JSON output adds "product_groups" with each
+group_key and its product IDs, next to the unchanged per-product "product_usage".
+
+Use --reset-anchored to report billing cycles instead of calendar months. Cycles start on
+the day of month from the plan reset date, so each row matches what counts against the plan.
+Each cycle is listed under the month it starts in; the in-progress cycle is marked current.
+
+Use --only-product to show a single product's monthly trend. This relies on the per-product
+monthly series the API includes for some teams; when it is missing the command fails with a
+note to use --product-ids for the product's range total instead. A product with no usage in
+the range reports zero minutes and an empty trend.
+
+Use --raw to print the unparsed API responses instead of the normalized months, bypassing
+the field alias handling, e.g. to show exactly what Apple returned in a schema drift bug report.
+` + assertClockHelpText + `
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud usage months --apple-id "user@example.com"
   asc web xcode-cloud usage months --apple-id "user@example.com" --start-month 1 --start-year 2025 --output table
-  asc web xcode-cloud usage months --product-ids "UUID,OTHER_UUID" --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud usage months --product-ids "UUID,OTHER_UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --product-ids "com.example.app" --resolve-bundle --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --detailed --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --group-products-by bundle --bundle-prefix-depth 3 --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --show-delta --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --reset-anchored --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --only-product "UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage months --apple-id "user@example.com" --raw`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			restoreNumberFormat, err := numberFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restoreNumberFormat()
+
 			if *startMonth < 1 || *startMonth > 12 {
 				fmt.Fprintln(os.Stderr, "Error: --start-month must be between 1 and 12")
 				return flag.ErrHelp
@@ -182,11 +531,43 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --end-month must be between 1 and 12")
 				return flag.ErrHelp
 			}
+			if err := validateMonthRange(*startMonth, *startYear, *endMonth, *endYear); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 			requestedProductIDs, err := parseProductIDs(*productIDs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			if !*resolveBundle {
+				if err := validateProductIDFormats(requestedProductIDs, *strict); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					return flag.ErrHelp
+				}
+			}
+			onlyProductID := strings.TrimSpace(*onlyProduct)
+			if onlyProductID != "" && (len(requestedProductIDs) > 0 || *resetAnchored) {
+				fmt.Fprintln(os.Stderr, "Error: --only-product cannot be combined with --product-ids or --reset-anchored")
+				return flag.ErrHelp
+			}
+			if *raw && *resetAnchored {
+				fmt.Fprintln(os.Stderr, "Error: --raw cannot be used with --reset-anchored")
+				return flag.ErrHelp
+			}
+			if err := validateProductGroupingFlags(grouping); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if grouping.enabled() && (onlyProductID != "" || *resetAnchored || *raw) {
+				fmt.Fprintln(os.Stderr, "Error: --group-products-by cannot be combined with --only-product, --reset-anchored, or --raw")
+				return flag.ErrHelp
+			}
+
+			if err := validateClockCheckFlags(clockCheck); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -201,7 +582,34 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			if *resolveBundle {
+				requestedProductIDs, err = withWebSpinnerValue("Resolving Xcode Cloud bundle IDs", func() ([]string, error) {
+					return resolveBundleProductIDs(requestCtx, client, teamID, requestedProductIDs)
+				})
+				if err != nil {
+					return withWebAuthHint(err, "xcode-cloud usage months")
+				}
+			}
+			if *resetAnchored {
+				return runCIUsageCycles(requestCtx, client, teamID, ciUsageCyclesOptions{
+					startMonth:  *startMonth,
+					startYear:   *startYear,
+					endMonth:    *endMonth,
+					endYear:     *endYear,
+					productIDs:  requestedProductIDs,
+					detailed:    *detailed,
+					failIfEmpty: *failIfEmpty,
+					output:      *output.Output,
+					pretty:      *output.Pretty,
+					clockCheck:  clockCheck,
+					strict:      *strict,
+				})
+			}
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			var result *webcore.CIUsageMonths
+			productNames := map[string]string{}
+			productBundles := map[string]string{}
 			planTotal := 0
 			err = withWebSpinner("Loading Xcode Cloud monthly usage", func() error {
 				var err error
@@ -212,25 +620,86 @@ Examples:
 				if len(requestedProductIDs) > 0 {
 					result.ProductUsage = filterProductUsageByIDs(result.ProductUsage, requestedProductIDs)
 				}
+				if onlyProductID != "" {
+					return nil
+				}
+				if grouping.enabled() {
+					products, err := client.ListCIProducts(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "product bundle IDs", err); strictErr != nil {
+						return strictErr
+					}
+					if err == nil {
+						productNames = buildProductNameByID(products)
+						productBundles = buildProductBundleByID(products)
+					}
+				}
 				switch shared.NormalizeOutputFormat(*output.Output) {
 				case "table", "markdown":
 					summary, err := client.GetCIUsageSummary(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "plan summary", err); strictErr != nil {
+						return strictErr
+					}
 					if err == nil && summary != nil {
 						planTotal = summary.Plan.Total
 					}
 				}
 				return nil
 			})
+			if err == nil {
+				err = assertClock(client, clockCheck, *strict)
+			}
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud usage months"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud usage months")
 			}
-			return shared.PrintOutputWithRenderers(
-				result,
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			if onlyProductID != "" {
+				productResult, err := buildCIProductMonthsResult(result, onlyProductID)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud usage months failed: %w", err)
+				}
+				if err := shared.PrintOutputWithTabular(
+					productResult,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderCIProductMonthsTable(productResult, *showDelta) },
+					func() error { return renderCIProductMonthsMarkdown(productResult, *showDelta) },
+					func() ([]string, [][]string) { return ciMonthUsageTSV(productResult.Usage, *showDelta) },
+				); err != nil {
+					return err
+				}
+				printProductVisibilityWarning(result.Info)
+				return checkFailIfEmpty(*failIfEmpty, len(productResult.Usage), "xcode-cloud usage months")
+			}
+			result.Info.Links = redactor.Links(result.Info.Links)
+			recordCount := len(result.Usage)
+			if len(requestedProductIDs) > 0 {
+				recordCount = len(result.ProductUsage)
+			}
+			data := &CIUsageMonthsResult{
+				CIUsageMonths:      result,
+				ProductUsageOutput: productUsageOutput(result.ProductUsage),
+				emptyResultNote:    newUsageRangeResultNote(result.Info, recordCount, emptyMonthlyUsageMessage),
+			}
+			if grouping.enabled() {
+				data.GroupProductsBy = productGroupByBundle
+				data.ProductGroups = buildCIProductUsageGroups(result.ProductUsage, productNames, productBundles, grouping, "minutes")
+			}
+			if err := shared.PrintOutputWithTabular(
+				data,
 				*output.Output,
 				*output.Pretty,
-				func() error { return renderCIUsageMonthsTable(result, planTotal) },
-				func() error { return renderCIUsageMonthsMarkdown(result, planTotal) },
-			)
+				func() error { return renderCIUsageMonthsTable(data, planTotal, *detailed, *showDelta) },
+				func() error { return renderCIUsageMonthsMarkdown(data, planTotal, *detailed, *showDelta) },
+				func() ([]string, [][]string) { return ciMonthUsageTSV(result.Usage, *showDelta) },
+			); err != nil {
+				return err
+			}
+			printProductVisibilityWarning(result.Info)
+			return checkFailIfEmpty(*failIfEmpty, recordCount, "xcode-cloud usage months")
 		},
 	}
 }
@@ -239,14 +708,26 @@ func webXcodeCloudUsageDaysCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud usage days", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
 	output := shared.BindOutputFlags(fs)
+	shared.AllowTSVOutput(fs)
 
 	now := webNowFn()
 	defaultEnd := now.Format("2006-01-02")
 	defaultStart := now.AddDate(0, 0, -30).Format("2006-01-02")
 
-	productIDs := fs.String("product-ids", "", "Comma-separated Xcode Cloud product IDs (required)")
+	productIDs := fs.String("product-ids", "", "Comma-separated Xcode Cloud product IDs, or 'all' for every product (required)")
+	maxProducts := fs.Int("max-products", defaultUsageDaysMaxProducts, "Maximum products to include with --product-ids all")
+	resolveBundle := bindResolveBundleFlag(fs)
 	start := fs.String("start", defaultStart, "Start date (YYYY-MM-DD)")
 	end := fs.String("end", defaultEnd, "End date (YYYY-MM-DD)")
+	reconcile := fs.Bool("reconcile", false, "Compare overall team minutes to the sum of product minutes and warn on mismatch")
+	reconcileTolerance := fs.Float64("reconcile-tolerance", defaultReconcileTolerancePercent, "Allowed --reconcile difference as a percent of team minutes")
+	noOverall := fs.Bool("no-overall", false, "Skip team-wide usage, plan, and product name lookups; show only the primary product's tables")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+	clockCheck := bindClockCheckFlags(fs)
+	redactTeam := bindRedactTeamFlag(fs)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	raw := bindRawFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "days",
@@ -257,26 +738,66 @@ func webXcodeCloudUsageDaysCommand() *ffcli.Command {
 Show daily Xcode Cloud compute usage for one or more products with per-workflow breakdown.
 The first product ID drives the daily/workflow tables; all product IDs are shown in the scope comparison table.
 Defaults to the last 30 days.
+Use --reconcile to check that overall team minutes match the sum of product minutes;
+a mismatch beyond --reconcile-tolerance prints a warning and JSON output gains a reconciliation object.
+Use --no-overall to skip the team-wide lookups and render only the primary product's daily and workflow tables.
+Use --strict to fail instead of degrading when the overall usage, plan summary, or product name lookups error.
+Product IDs that do not look like UUIDs (e.g. a pasted URL or bundle ID) print a warning, or fail under --strict.
+Use --resolve-bundle to pass bundle IDs in --product-ids; they are replaced with the matching product IDs.
+Use --product-ids all to include every product in the team, in product list order. The first product drives
+the daily/workflow tables. At most --max-products products are included; a warning is printed when truncated.
+Use --raw to print the unparsed API responses instead of the normalized usage, bypassing the field alias handling.
+` + assertClockHelpText + `
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud usage days --product-ids "UUID" --apple-id "user@example.com"
   asc web xcode-cloud usage days --product-ids "UUID" --start 2025-01-01 --end 2025-01-31 --apple-id "user@example.com" --output table
-  asc web xcode-cloud usage days --product-ids "UUID,OTHER_ID,ANOTHER_ID" --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud usage days --product-ids "UUID,OTHER_ID,ANOTHER_ID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage days --product-ids "UUID" --reconcile --apple-id "user@example.com"
+  asc web xcode-cloud usage days --product-ids "com.example.app" --resolve-bundle --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage days --product-ids all --max-products 50 --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage days --product-ids "UUID" --no-overall --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage days --product-ids "UUID" --strict --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage days --product-ids "UUID" --no-overall --raw --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
-			requestedProductIDs, err := parseProductIDs(*productIDs)
+			restoreNumberFormat, err := numberFormat.apply()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
-			if len(requestedProductIDs) == 0 {
-				fmt.Fprintln(os.Stderr, "Error: --product-ids is required")
+			defer restoreNumberFormat()
+
+			allProducts := isAllProductIDs(*productIDs)
+			var requestedProductIDs []string
+			if !allProducts {
+				requestedProductIDs, err = parseProductIDs(*productIDs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					return flag.ErrHelp
+				}
+				if len(requestedProductIDs) == 0 {
+					fmt.Fprintln(os.Stderr, "Error: --product-ids is required")
+					return flag.ErrHelp
+				}
+			}
+			if *maxProducts < 1 {
+				fmt.Fprintln(os.Stderr, "Error: --max-products must be at least 1")
 				return flag.ErrHelp
 			}
-			primaryProductID := requestedProductIDs[0]
+			if allProducts && *resolveBundle {
+				fmt.Fprintln(os.Stderr, "Error: --resolve-bundle cannot be used with --product-ids all")
+				return flag.ErrHelp
+			}
+			if !*resolveBundle {
+				if err := validateProductIDFormats(requestedProductIDs, *strict); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					return flag.ErrHelp
+				}
+			}
 			if err := validateDateFlag("--start", *start); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
@@ -285,6 +806,19 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			if *reconcileTolerance < 0 {
+				fmt.Fprintln(os.Stderr, "Error: --reconcile-tolerance must be zero or greater")
+				return flag.ErrHelp
+			}
+			if *noOverall && *reconcile {
+				fmt.Fprintln(os.Stderr, "Error: --no-overall cannot be combined with --reconcile")
+				return flag.ErrHelp
+			}
+
+			if err := validateClockCheckFlags(clockCheck); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -299,6 +833,33 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			if allProducts {
+				products, err := withWebSpinnerValue("Loading Xcode Cloud products", func() (*webcore.CIProductListResponse, error) {
+					return client.ListCIProducts(requestCtx, teamID)
+				})
+				if err != nil {
+					return withWebAuthHint(err, "xcode-cloud usage days")
+				}
+				var total int
+				requestedProductIDs, total = allProductIDs(products, *maxProducts)
+				if len(requestedProductIDs) == 0 {
+					return fmt.Errorf("xcode-cloud usage days failed: no Xcode Cloud products found for --product-ids all")
+				}
+				if total > len(requestedProductIDs) {
+					fmt.Fprintf(os.Stderr, "Warning: --product-ids all matched %d products; showing the first %d (raise --max-products to include more)\n", total, len(requestedProductIDs))
+				}
+			}
+			if *resolveBundle {
+				requestedProductIDs, err = withWebSpinnerValue("Resolving Xcode Cloud bundle IDs", func() ([]string, error) {
+					return resolveBundleProductIDs(requestCtx, client, teamID, requestedProductIDs)
+				})
+				if err != nil {
+					return withWebAuthHint(err, "xcode-cloud usage days")
+				}
+			}
+			primaryProductID := requestedProductIDs[0]
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			var result *webcore.CIUsageDays
 			var overall *webcore.CIUsageDays
 			productNames := map[string]string{}
@@ -309,25 +870,70 @@ Examples:
 				if err != nil {
 					return err
 				}
-				switch shared.NormalizeOutputFormat(*output.Output) {
+				if *noOverall {
+					return nil
+				}
+				format := shared.NormalizeOutputFormat(*output.Output)
+				if *reconcile || format == "table" || format == "markdown" {
+					var overallErr error
+					overall, overallErr = client.GetCIUsageDaysOverall(requestCtx, teamID, *start, *end)
+					if overallErr != nil {
+						overall = nil
+						if *reconcile {
+							return fmt.Errorf("--reconcile requires overall team usage: %w", overallErr)
+						}
+						if strictErr := strictSupplementaryError(*strict, "overall team usage", overallErr); strictErr != nil {
+							return strictErr
+						}
+					}
+				}
+				switch format {
 				case "table", "markdown":
-					overall, _ = client.GetCIUsageDaysOverall(requestCtx, teamID, *start, *end)
 					summary, err := client.GetCIUsageSummary(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "plan summary", err); strictErr != nil {
+						return strictErr
+					}
 					if err == nil && summary != nil {
 						planTotal = summary.Plan.Total
 					}
 					products, err := client.ListCIProducts(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "product names", err); strictErr != nil {
+						return strictErr
+					}
 					if err == nil {
 						productNames = buildProductNameByID(products)
 					}
 				}
 				return nil
 			})
+			if err == nil {
+				err = assertClock(client, clockCheck, *strict)
+			}
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud usage days"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud usage days")
 			}
-			return shared.PrintOutputWithRenderers(
-				result,
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			result.Info.Links = redactor.Links(result.Info.Links)
+			if result.Info.CanViewAllProducts == nil && overall != nil {
+				// Product scope rows come from the overall response.
+				result.Info.CanViewAllProducts = overall.Info.CanViewAllProducts
+			}
+			var reconciliation *CIUsageReconciliation
+			if *reconcile {
+				reconciliation = reconcileCIUsage(overall, *reconcileTolerance)
+			}
+			data := &CIUsageDaysResult{
+				CIUsageDays:        result,
+				ProductUsageOutput: productUsageOutput(result.ProductUsage),
+				Reconciliation:     reconciliation,
+				emptyResultNote:    newUsageRangeResultNote(result.Info, len(result.Usage), emptyDailyUsageMessage),
+			}
+			if err := shared.PrintOutputWithTabular(
+				data,
 				*output.Output,
 				*output.Pretty,
 				func() error {
@@ -337,6 +943,7 @@ Examples:
 						requestedProductIDs,
 						productNames,
 						planTotal,
+						!*noOverall,
 					)
 				},
 				func() error {
@@ -346,13 +953,115 @@ Examples:
 						requestedProductIDs,
 						productNames,
 						planTotal,
+						!*noOverall,
 					)
 				},
-			)
+				func() ([]string, [][]string) {
+					return tabularColumns(ciDayUsageHeaders(), buildCIDayUsageRows(result.Usage, maxDayUsageMinutes(result.Usage)))
+				},
+			); err != nil {
+				return err
+			}
+			printUsageReconciliationWarning(reconciliation)
+			printProductVisibilityWarning(result.Info)
+			return checkFailIfEmpty(*failIfEmpty, len(result.Usage), "xcode-cloud usage days")
 		},
 	}
 }
 
+const defaultReconcileTolerancePercent = 1.0
+
+// CIUsageDaysResult is the usage days JSON output. The embedded usage keeps
+// the API shape; reconciliation is only set with --reconcile.
+type CIUsageDaysResult struct {
+	*webcore.CIUsageDays
+	// ProductUsageOutput replaces the embedded product_usage in JSON output.
+	ProductUsageOutput []CIProductUsageOutput `json:"product_usage,omitempty"`
+	Reconciliation     *CIUsageReconciliation `json:"reconciliation,omitempty"`
+	emptyResultNote
+}
+
+// CIUsageReconciliation compares overall team minutes to the sum of the
+// per-product minutes reported for the same range.
+type CIUsageReconciliation struct {
+	TeamMinutes       int     `json:"team_minutes"`
+	ProductMinutes    int     `json:"product_minutes"`
+	DifferenceMinutes int     `json:"difference_minutes"`
+	DifferencePercent float64 `json:"difference_percent"`
+	TolerancePercent  float64 `json:"tolerance_percent"`
+	WithinTolerance   bool    `json:"within_tolerance"`
+}
+
+func reconcileCIUsage(overall *webcore.CIUsageDays, tolerancePercent float64) *CIUsageReconciliation {
+	if overall == nil {
+		overall = &webcore.CIUsageDays{}
+	}
+	productMinutes := 0
+	for _, product := range overall.ProductUsage {
+		minutes, _ := normalizeProductUsage(product)
+		productMinutes += minutes
+	}
+	teamMinutes := overall.Info.Current.Used
+	difference := teamMinutes - productMinutes
+
+	percent := 0.0
+	switch {
+	case teamMinutes > 0:
+		percent = math.Abs(float64(difference)) / float64(teamMinutes) * 100
+	case difference != 0:
+		percent = 100
+	}
+	return &CIUsageReconciliation{
+		TeamMinutes:       teamMinutes,
+		ProductMinutes:    productMinutes,
+		DifferenceMinutes: difference,
+		DifferencePercent: math.Round(percent*100) / 100,
+		TolerancePercent:  tolerancePercent,
+		WithinTolerance:   percent <= tolerancePercent,
+	}
+}
+
+func printUsageReconciliationWarning(reconciliation *CIUsageReconciliation) {
+	if reconciliation == nil || reconciliation.WithinTolerance {
+		return
+	}
+	fmt.Fprintf(
+		os.Stderr,
+		"Warning: overall team usage (%d minutes) differs from the sum of product usage (%d minutes) by %d minutes (%.2f%%, tolerance %.2f%%)\n",
+		reconciliation.TeamMinutes,
+		reconciliation.ProductMinutes,
+		reconciliation.DifferenceMinutes,
+		reconciliation.DifferencePercent,
+		reconciliation.TolerancePercent,
+	)
+}
+
+// productVisibilityRestricted reports whether the API said this account cannot
+// view every product, making per-product usage partial.
+func productVisibilityRestricted(info webcore.CIUsageInfo) bool {
+	return info.CanViewAllProducts != nil && !*info.CanViewAllProducts
+}
+
+// formatProductVisibility describes info.CanViewAllProducts for table and
+// markdown headers, or returns "" when the API did not report it.
+func formatProductVisibility(info webcore.CIUsageInfo) string {
+	switch {
+	case info.CanViewAllProducts == nil:
+		return ""
+	case *info.CanViewAllProducts:
+		return "all products"
+	default:
+		return "restricted (product-level numbers may be partial)"
+	}
+}
+
+func printProductVisibilityWarning(info webcore.CIUsageInfo) {
+	if !productVisibilityRestricted(info) {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Warning: this account cannot view all Xcode Cloud products; product-level numbers may be partial")
+}
+
 // CIWorkflowsResult is the output type for the workflows command.
 // It wraps the workflow usage data with product context for clean JSON output.
 type CIWorkflowsResult struct {
@@ -360,12 +1069,14 @@ type CIWorkflowsResult struct {
 	Start     string                    `json:"start"`
 	End       string                    `json:"end"`
 	Workflows []webcore.CIWorkflowUsage `json:"workflows"`
+	emptyResultNote
 }
 
 func webXcodeCloudUsageWorkflowsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud usage workflows", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
 	output := shared.BindOutputFlags(fs)
+	shared.AllowCSVOutput(fs)
 
 	now := webNowFn()
 	defaultEnd := now.Format("2006-01-02")
@@ -375,6 +1086,13 @@ func webXcodeCloudUsageWorkflowsCommand() *ffcli.Command {
 	workflowID := fs.String("workflow-id", "", "Specific workflow ID to drill into (optional)")
 	start := fs.String("start", defaultStart, "Start date (YYYY-MM-DD)")
 	end := fs.String("end", defaultEnd, "End date (YYYY-MM-DD)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+	clockCheck := bindClockCheckFlags(fs)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	includeDeleted := fs.Bool("include-deleted", false, "Also resolve names of deleted workflows, suffixed \"(deleted)\"")
+	noNames := fs.Bool("no-names", false, "Skip the workflow name lookup and show workflow IDs only")
+	raw := bindRawFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "workflows",
@@ -386,15 +1104,34 @@ Show Xcode Cloud compute usage broken down by workflow for a product.
 Without --workflow-id, lists all workflows and their usage.
 With --workflow-id, shows daily breakdown for that specific workflow.
 Defaults to the last 30 days.
+Use --include-deleted to name usage from workflows that have since been deleted;
+their names are suffixed "(deleted)".
+Use --no-names to skip the extra workflow listing request when names are not
+needed, such as in scripts; workflows are then identified by ID only.
+Use --raw to print the unparsed API responses (including the workflow name
+lookup unless --no-names is set) instead of the normalized usage.
+Use --output csv for spreadsheets: one row per workflow, or one row per day with --workflow-id.
+` + assertClockHelpText + `
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud usage workflows --product-id "UUID" --apple-id "user@example.com" --output table
-  asc web xcode-cloud usage workflows --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud usage workflows --product-id "UUID" --no-names --apple-id "user@example.com" --output json
+  asc web xcode-cloud usage workflows --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage workflows --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output csv > workflow-days.csv
+  asc web xcode-cloud usage workflows --product-id "UUID" --start 2024-01-01 --end 2024-03-31 --include-deleted --apple-id "user@example.com"
+  asc web xcode-cloud usage workflows --product-id "UUID" --no-names --raw --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
+			restoreNumberFormat, err := numberFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restoreNumberFormat()
+
 			pid := strings.TrimSpace(*productID)
 			if pid == "" {
 				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
@@ -408,6 +1145,15 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			if *noNames && *includeDeleted {
+				fmt.Fprintln(os.Stderr, "Error: --no-names cannot be combined with --include-deleted")
+				return flag.ErrHelp
+			}
+
+			if err := validateClockCheckFlags(clockCheck); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -422,6 +1168,8 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			var result *webcore.CIUsageDays
 			err = withWebSpinner("Loading Xcode Cloud workflow usage", func() error {
 				var err error
@@ -429,12 +1177,24 @@ Examples:
 				if err != nil {
 					return err
 				}
+				if *noNames {
+					return nil
+				}
 
 				// Resolve workflow names from the workflows endpoint.
-				wfNames := buildWorkflowNameByID(requestCtx, client, teamID, pid)
+				wfNames, err := resolveWorkflowNameByID(requestCtx, client, teamID, pid, *includeDeleted)
+				if strictErr := strictSupplementaryError(*strict, "workflow names", err); strictErr != nil {
+					return strictErr
+				}
 				populateWorkflowNames(result.WorkflowUsage, wfNames)
 				return nil
 			})
+			if err == nil {
+				err = assertClock(client, clockCheck, *strict)
+			}
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud usage workflows"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud usage workflows")
 			}
@@ -446,12 +1206,15 @@ Examples:
 				if wf == nil {
 					return fmt.Errorf("workflow %q not found in product %q", wfID, pid)
 				}
-				return shared.PrintOutputWithRenderers(
+				return shared.PrintOutputWithTabular(
 					wf,
 					*output.Output,
 					*output.Pretty,
 					func() error { return renderCIWorkflowDetailTable(wf) },
 					func() error { return renderCIWorkflowDetailMarkdown(wf) },
+					func() ([]string, [][]string) {
+						return tabularColumns(ciDayUsageHeaders(), buildCIDayUsageRows(wf.Usage, maxDayUsageMinutes(wf.Usage)))
+					},
 				)
 			}
 
@@ -462,41 +1225,140 @@ Examples:
 				End:       *end,
 				Workflows: result.WorkflowUsage,
 			}
+			out.emptyResultNote = newEmptyResultNote(len(out.Workflows), emptyWorkflowUsageMessage)
 			planTotal := 0
 			switch shared.NormalizeOutputFormat(*output.Output) {
 			case "table", "markdown":
-				summary, _ := withWebSpinnerValue("Loading Xcode Cloud plan summary", func() (*webcore.CIUsageSummary, error) {
+				summary, err := withWebSpinnerValue("Loading Xcode Cloud plan summary", func() (*webcore.CIUsageSummary, error) {
 					return client.GetCIUsageSummary(requestCtx, teamID)
 				})
+				if strictErr := strictSupplementaryError(*strict, "plan summary", err); strictErr != nil {
+					return withWebAuthHint(strictErr, "xcode-cloud usage workflows")
+				}
 				if summary != nil {
 					planTotal = summary.Plan.Total
 				}
 			}
-			return shared.PrintOutputWithRenderers(
+			if err := shared.PrintOutputWithTabular(
 				out,
 				*output.Output,
 				*output.Pretty,
 				func() error { return renderCIWorkflowsListTable(out, planTotal) },
 				func() error { return renderCIWorkflowsListMarkdown(out, planTotal) },
-			)
+				func() ([]string, [][]string) {
+					return tabularColumns(ciWorkflowUsageHeaders(), buildCIWorkflowUsageRows(out.Workflows, maxWorkflowUsageMinutes(out.Workflows)))
+				},
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(out.Workflows), "xcode-cloud usage workflows")
 		},
 	}
 }
 
-func buildWorkflowNameByID(ctx context.Context, client *webcore.Client, teamID, productID string) map[string]string {
-	names := map[string]string{}
+// workflowNameCache memoizes workflow ID to name maps per team and product for
+// the lifetime of the process. Failed lookups are not cached.
+var workflowNameCache = struct {
+	mu      sync.Mutex
+	entries map[string]map[string]string
+}{entries: map[string]map[string]string{}}
+
+func workflowNameCacheKey(teamID, productID string) string {
+	return strings.ToLower(strings.TrimSpace(teamID)) + "/" + strings.ToLower(strings.TrimSpace(productID))
+}
+
+// deletedWorkflowNameCacheKey keys name maps that also cover deleted workflows.
+func deletedWorkflowNameCacheKey(teamID, productID string) string {
+	return workflowNameCacheKey(teamID, productID) + "+deleted"
+}
+
+// buildWorkflowNameByID returns workflow names keyed by lowercased workflow ID.
+// Repeated calls for the same team and product reuse the first successful lookup.
+// With includeDeleted, deleted workflows are named too, suffixed "(deleted)".
+// Lookup failures yield an empty map.
+func buildWorkflowNameByID(ctx context.Context, client *webcore.Client, teamID, productID string, includeDeleted bool) map[string]string {
+	names, _ := resolveWorkflowNameByID(ctx, client, teamID, productID, includeDeleted)
+	return names
+}
+
+// resolveWorkflowNameByID is buildWorkflowNameByID that also reports the
+// lookup error. The returned map is never nil.
+func resolveWorkflowNameByID(ctx context.Context, client *webcore.Client, teamID, productID string, includeDeleted bool) (map[string]string, error) {
+	key := workflowNameCacheKey(teamID, productID)
+	if includeDeleted {
+		key = deletedWorkflowNameCacheKey(teamID, productID)
+	}
+	workflowNameCache.mu.Lock()
+	cached, ok := workflowNameCache.entries[key]
+	workflowNameCache.mu.Unlock()
+	if ok {
+		return maps.Clone(cached), nil
+	}
+
+	if includeDeleted {
+		return resolveWorkflowNameByIDIncludingDeleted(ctx, client, teamID, productID)
+	}
 	workflows, err := client.ListCIWorkflows(ctx, teamID, productID)
-	if err != nil || workflows == nil {
-		return names
+	if err != nil {
+		return map[string]string{}, err
 	}
-	for _, wf := range workflows.Items {
+	if workflows == nil {
+		return map[string]string{}, nil
+	}
+	return primeWorkflowNameCache(teamID, productID, workflows.Items), nil
+}
+
+// resolveWorkflowNameByIDIncludingDeleted names active workflows as usual and
+// adds workflows that only appear in the include-deleted listing with a
+// "(deleted)" suffix.
+func resolveWorkflowNameByIDIncludingDeleted(ctx context.Context, client *webcore.Client, teamID, productID string) (map[string]string, error) {
+	names, err := resolveWorkflowNameByID(ctx, client, teamID, productID, false)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	workflows, err := client.ListCIWorkflowsIncludingDeleted(ctx, teamID, productID)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	if workflows != nil {
+		for _, wf := range workflows.Items {
+			canonical := strings.ToLower(strings.TrimSpace(wf.ID))
+			name := strings.TrimSpace(wf.Content.Name)
+			if canonical == "" || name == "" {
+				continue
+			}
+			if _, active := names[canonical]; !active {
+				names[canonical] = name + " (deleted)"
+			}
+		}
+	}
+	workflowNameCache.mu.Lock()
+	workflowNameCache.entries[deletedWorkflowNameCacheKey(teamID, productID)] = names
+	workflowNameCache.mu.Unlock()
+	return maps.Clone(names), nil
+}
+
+// primeWorkflowNameCache caches names from an existing workflow listing and
+// returns a copy of the resulting map.
+func primeWorkflowNameCache(teamID, productID string, workflows []webcore.CIWorkflow) map[string]string {
+	names := map[string]string{}
+	for _, wf := range workflows {
 		canonical := strings.ToLower(strings.TrimSpace(wf.ID))
 		name := strings.TrimSpace(wf.Content.Name)
 		if canonical != "" && name != "" {
 			names[canonical] = name
 		}
 	}
-	return names
+	workflowNameCache.mu.Lock()
+	workflowNameCache.entries[workflowNameCacheKey(teamID, productID)] = names
+	workflowNameCache.mu.Unlock()
+	return maps.Clone(names)
+}
+
+func resetWorkflowNameCache() {
+	workflowNameCache.mu.Lock()
+	workflowNameCache.entries = map[string]map[string]string{}
+	workflowNameCache.mu.Unlock()
 }
 
 func populateWorkflowNames(workflows []webcore.CIWorkflowUsage, names map[string]string) {
@@ -526,7 +1388,7 @@ func findWorkflowByID(workflows []webcore.CIWorkflowUsage, id string) *webcore.C
 
 func renderCIWorkflowsListTable(result *CIWorkflowsResult, planTotal int) error {
 	if result == nil || len(result.Workflows) == 0 {
-		fmt.Println("No workflow usage found.")
+		fmt.Println(emptyWorkflowUsageMessage)
 		return nil
 	}
 	maxMinutes := maxWorkflowUsageMinutes(result.Workflows)
@@ -534,7 +1396,7 @@ func renderCIWorkflowsListTable(result *CIWorkflowsResult, planTotal int) error
 	fmt.Printf("Range: %s to %s\n", result.Start, result.End)
 	fmt.Printf("Workflows: %d\n\n", len(result.Workflows))
 	asc.RenderTable(
-		[]string{"Workflow ID", "Workflow Name", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar"},
+		ciWorkflowUsageHeaders(),
 		buildCIWorkflowUsageRows(result.Workflows, maxMinutes),
 	)
 	if planTotal > 0 {
@@ -550,7 +1412,7 @@ func renderCIWorkflowsListTable(result *CIWorkflowsResult, planTotal int) error
 
 func renderCIWorkflowsListMarkdown(result *CIWorkflowsResult, planTotal int) error {
 	if result == nil || len(result.Workflows) == 0 {
-		fmt.Println("No workflow usage found.")
+		fmt.Println(emptyWorkflowUsageMessage)
 		return nil
 	}
 	maxMinutes := maxWorkflowUsageMinutes(result.Workflows)
@@ -558,7 +1420,7 @@ func renderCIWorkflowsListMarkdown(result *CIWorkflowsResult, planTotal int) err
 	fmt.Printf("**Range:** %s to %s\n\n", result.Start, result.End)
 	fmt.Printf("**Workflows:** %d\n\n", len(result.Workflows))
 	asc.RenderMarkdown(
-		[]string{"Workflow ID", "Workflow Name", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar"},
+		ciWorkflowUsageHeaders(),
 		buildCIWorkflowUsageRows(result.Workflows, maxMinutes),
 	)
 	if planTotal > 0 {
@@ -584,11 +1446,11 @@ func renderCIWorkflowDetailTable(wf *webcore.CIWorkflowUsage) error {
 	fmt.Printf("Previous: %d minutes, %d builds\n\n", wf.PreviousUsageInMinutes, wf.PreviousNumberOfBuilds)
 
 	if len(wf.Usage) == 0 {
-		fmt.Println("No daily usage data.")
+		fmt.Println(emptyDailyUsageMessage)
 		return nil
 	}
 	asc.RenderTable(
-		[]string{"Date", "Minutes", "Builds", "Usage Bar"},
+		ciDayUsageHeaders(),
 		buildCIDayUsageRows(wf.Usage, maxDayMinutes),
 	)
 	return nil
@@ -606,11 +1468,11 @@ func renderCIWorkflowDetailMarkdown(wf *webcore.CIWorkflowUsage) error {
 	fmt.Printf("**Previous:** %d minutes, %d builds\n\n", wf.PreviousUsageInMinutes, wf.PreviousNumberOfBuilds)
 
 	if len(wf.Usage) == 0 {
-		fmt.Println("No daily usage data.")
+		fmt.Println(emptyDailyUsageMessage)
 		return nil
 	}
 	asc.RenderMarkdown(
-		[]string{"Date", "Minutes", "Builds", "Usage Bar"},
+		ciDayUsageHeaders(),
 		buildCIDayUsageRows(wf.Usage, maxDayMinutes),
 	)
 	return nil
@@ -619,7 +1481,11 @@ func renderCIWorkflowDetailMarkdown(wf *webcore.CIWorkflowUsage) error {
 func webXcodeCloudProductsCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud products", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+	shared.AllowTSVOutput(fs)
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	wide := fs.Bool("wide", false, "Include the Icon URL column in table/markdown output")
+	raw := bindRawFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "products",
@@ -629,14 +1495,25 @@ func webXcodeCloudProductsCommand() *ffcli.Command {
 
 List Xcode Cloud products (apps) for the authenticated team.
 Use the product IDs with 'usage days' for per-product daily breakdowns.
+Use 'products get' to show one product including its icon URL.
+Use --wide to add the Icon URL column to table/markdown/tsv output.
+Use --output tsv for tab-separated rows with the table's columns.
+Use --raw to print the unparsed API response instead of the decoded product list.
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud products --apple-id "user@example.com"
-  asc web xcode-cloud products --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud products --apple-id "user@example.com" --output table
+  asc web xcode-cloud products --apple-id "user@example.com" --output table --wide
+  asc web xcode-cloud products --apple-id "user@example.com" --output tsv | cut -f1,3
+  asc web xcode-cloud products --apple-id "user@example.com" --raw
+  asc web xcode-cloud products get --product-id "UUID" --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			webXcodeCloudProductsGetCommand(),
+		},
 		Exec: func(ctx context.Context, args []string) error {
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -651,26 +1528,35 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			result, err := withWebSpinnerValue("Loading Xcode Cloud products", func() (*webcore.CIProductListResponse, error) {
 				return client.ListCIProducts(requestCtx, teamID)
 			})
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud products"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud products")
 			}
-			return shared.PrintOutputWithRenderers(
+			if err := shared.PrintOutputWithTabular(
 				result,
 				*output.Output,
 				*output.Pretty,
-				func() error { return renderCIProductsTable(result) },
-				func() error { return renderCIProductsMarkdown(result) },
-			)
+				func() error { return renderCIProductsTable(result, *wide) },
+				func() error { return renderCIProductsMarkdown(result, *wide) },
+				func() ([]string, [][]string) { return ciProductHeaders(*wide), buildCIProductRows(result, *wide) },
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(result.Items), "xcode-cloud products")
 		},
 	}
 }
 
 func renderCIUsageSummaryTable(result *webcore.CIUsageSummary) error {
 	asc.RenderTable(
-		[]string{"Plan", "Usage Bar", "Used", "Available", "Total", "Reset Date", "Reset Date Time", "Manage URL"},
+		ciUsageSummaryHeaders(),
 		buildCIUsageSummaryRows(result),
 	)
 	return nil
@@ -678,12 +1564,16 @@ func renderCIUsageSummaryTable(result *webcore.CIUsageSummary) error {
 
 func renderCIUsageSummaryMarkdown(result *webcore.CIUsageSummary) error {
 	asc.RenderMarkdown(
-		[]string{"Plan", "Usage Bar", "Used", "Available", "Total", "Reset Date", "Reset Date Time", "Manage URL"},
+		ciUsageSummaryHeaders(),
 		buildCIUsageSummaryRows(result),
 	)
 	return nil
 }
 
+func ciUsageSummaryHeaders() []string {
+	return []string{"Plan", "Usage Bar", "Used", "Available", "Total", "Reset Date", "Reset Date Time", "Resets", "Manage URL"}
+}
+
 func buildCIUsageSummaryRows(result *webcore.CIUsageSummary) [][]string {
 	if result == nil {
 		result = &webcore.CIUsageSummary{}
@@ -692,92 +1582,188 @@ func buildCIUsageSummaryRows(result *webcore.CIUsageSummary) [][]string {
 		{
 			valueOrNA(result.Plan.Name),
 			formatUsageBarWithValues(result.Plan.Used, result.Plan.Total),
-			fmt.Sprintf("%d", result.Plan.Used),
-			fmt.Sprintf("%d", result.Plan.Available),
-			fmt.Sprintf("%d", result.Plan.Total),
+			formatUsageMinutes(result.Plan.Used),
+			formatUsageMinutes(result.Plan.Available),
+			formatUsageMinutes(result.Plan.Total),
 			valueOrNA(result.Plan.ResetDate),
 			valueOrNA(result.Plan.ResetDateTime),
+			formatUsageResetCountdown(result.Plan, webNowFn()),
 			valueOrNA(result.Links["manage"]),
 		},
 	}
 }
 
-func renderCIUsageMonthsTable(result *webcore.CIUsageMonths, planTotal int) error {
-	if result == nil {
-		result = &webcore.CIUsageMonths{}
+func renderCIUsageMonthsTable(data *CIUsageMonthsResult, planTotal int, detailed, showDelta bool) error {
+	result := &webcore.CIUsageMonths{}
+	if data != nil && data.CIUsageMonths != nil {
+		result = data.CIUsageMonths
 	}
 	maxMonthMinutes := maxMonthUsageMinutes(result.Usage)
 
 	fmt.Printf("Range: %s\n", formatCIMonthRange(result.Usage, result.Info))
+	if visibility := formatProductVisibility(result.Info); visibility != "" {
+		fmt.Printf("Product visibility: %s\n", visibility)
+	}
 	fmt.Printf("Current: %d minutes (%d builds), avg30=%d\n", result.Info.Current.Used, result.Info.Current.Builds, result.Info.Current.Average30Days)
 	fmt.Printf("Previous: %d minutes (%d builds), avg30=%d\n\n", result.Info.Previous.Used, result.Info.Previous.Builds, result.Info.Previous.Average30Days)
-	asc.RenderTable([]string{"Year", "Month", "Minutes", "Builds", "Usage Bar"}, buildCIMonthUsageRows(result.Usage, maxMonthMinutes))
+	asc.RenderTable(ciMonthUsageHeaders(showDelta), buildCIMonthUsageRows(result.Usage, maxMonthMinutes, showDelta))
 
+	if data != nil && data.GroupProductsBy != "" {
+		if len(data.ProductGroups) > 0 {
+			fmt.Println()
+			renderCIProductUsageGroupsTable(data.ProductGroups, planTotal)
+		}
+		return nil
+	}
 	if len(result.ProductUsage) > 0 {
 		fmt.Println()
 		asc.RenderTable(
-			[]string{"Product ID", "Product Name", "Bundle ID", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"},
-			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal),
+			ciProductUsageSummaryHeaders(detailed),
+			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal, detailed),
 		)
 	}
 
 	return nil
 }
 
-func renderCIUsageMonthsMarkdown(result *webcore.CIUsageMonths, planTotal int) error {
-	if result == nil {
-		result = &webcore.CIUsageMonths{}
+func renderCIUsageMonthsMarkdown(data *CIUsageMonthsResult, planTotal int, detailed, showDelta bool) error {
+	result := &webcore.CIUsageMonths{}
+	if data != nil && data.CIUsageMonths != nil {
+		result = data.CIUsageMonths
 	}
 	maxMonthMinutes := maxMonthUsageMinutes(result.Usage)
 
 	fmt.Printf("**Range:** %s\n\n", formatCIMonthRange(result.Usage, result.Info))
+	if visibility := formatProductVisibility(result.Info); visibility != "" {
+		fmt.Printf("**Product visibility:** %s\n\n", visibility)
+	}
 	fmt.Printf("**Current:** %d minutes (%d builds), avg30=%d\n\n", result.Info.Current.Used, result.Info.Current.Builds, result.Info.Current.Average30Days)
 	fmt.Printf("**Previous:** %d minutes (%d builds), avg30=%d\n\n", result.Info.Previous.Used, result.Info.Previous.Builds, result.Info.Previous.Average30Days)
-	asc.RenderMarkdown([]string{"Year", "Month", "Minutes", "Builds", "Usage Bar"}, buildCIMonthUsageRows(result.Usage, maxMonthMinutes))
+	asc.RenderMarkdown(ciMonthUsageHeaders(showDelta), buildCIMonthUsageRows(result.Usage, maxMonthMinutes, showDelta))
 
+	if data != nil && data.GroupProductsBy != "" {
+		if len(data.ProductGroups) > 0 {
+			fmt.Println()
+			renderCIProductUsageGroupsMarkdown(data.ProductGroups, planTotal)
+		}
+		return nil
+	}
 	if len(result.ProductUsage) > 0 {
 		fmt.Println()
 		asc.RenderMarkdown(
-			[]string{"Product ID", "Product Name", "Bundle ID", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"},
-			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal),
+			ciProductUsageSummaryHeaders(detailed),
+			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal, detailed),
 		)
 	}
 
 	return nil
 }
 
-func buildCIMonthUsageRows(usage []webcore.CIMonthUsage, maxMinutes int) [][]string {
+// ciMonthUsageTSV returns the monthly table for --output tsv.
+func ciMonthUsageTSV(usage []webcore.CIMonthUsage, showDelta bool) ([]string, [][]string) {
+	return tabularColumns(ciMonthUsageHeaders(showDelta), buildCIMonthUsageRows(usage, maxMonthUsageMinutes(usage), showDelta))
+}
+
+func ciDayUsageHeaders() []string {
+	return usageUnitHeaders([]string{"Date", "Minutes", "Builds", "Usage Bar"})
+}
+
+// ciMonthUsageHeaders returns the monthly table headers, with a change column
+// after Minutes when showDelta is set.
+func ciMonthUsageHeaders(showDelta bool) []string {
+	headers := []string{"Year", "Month", "Minutes"}
+	if showDelta {
+		headers = append(headers, "Minutes Change")
+	}
+	return usageUnitHeaders(append(headers, "Builds", "Usage Bar"))
+}
+
+func buildCIMonthUsageRows(usage []webcore.CIMonthUsage, maxMinutes int, showDelta bool) [][]string {
 	rows := make([][]string, 0, len(usage))
-	for _, monthUsage := range usage {
-		rows = append(rows, []string{
+	for i, monthUsage := range usage {
+		row := []string{
 			fmt.Sprintf("%d", monthUsage.Year),
 			fmt.Sprintf("%d", monthUsage.Month),
-			fmt.Sprintf("%d", monthUsage.Duration),
-			fmt.Sprintf("%d", monthUsage.NumberOfBuilds),
+			formatUsageMinutes(monthUsage.Duration),
+		}
+		if showDelta {
+			delta := "n/a"
+			if i > 0 {
+				delta = formatUsageMinutesDelta(monthUsage.Duration - usage[i-1].Duration)
+			}
+			row = append(row, delta)
+		}
+		rows = append(rows, append(row,
+			formatUsageCount(monthUsage.NumberOfBuilds),
 			formatUsageBar(monthUsage.Duration, maxMinutes),
-		})
+		))
 	}
 	return rows
 }
 
-func buildCIProductUsageSummaryRows(productUsage []webcore.CIProductUsage, planTotal int) [][]string {
+// formatUsageMinutesDelta renders a change in minutes with an explicit sign
+// for increases, e.g. "+120" or "-40".
+func formatUsageMinutesDelta(minutes int) string {
+	if minutes > 0 {
+		return "+" + formatUsageMinutes(minutes)
+	}
+	return formatUsageMinutes(minutes)
+}
+
+func ciProductUsageSummaryHeaders(detailed bool) []string {
+	headers := []string{"Product ID", "Product Name", "Bundle ID", "Minutes", "Builds", "Prev Minutes", "Prev Builds"}
+	if detailed {
+		headers = append(headers, "Seconds", "% Change vs Prev")
+	}
+	return usageUnitHeaders(append(headers, "Usage Bar (Plan)"))
+}
+
+func buildCIProductUsageSummaryRows(productUsage []webcore.CIProductUsage, planTotal int, detailed bool) [][]string {
 	rows := make([][]string, 0)
 	for _, product := range productUsage {
 		minutes, builds := normalizeProductUsage(product)
-		rows = append(rows, []string{
+		seconds, _ := productUsageSeconds(product)
+		row := []string{
 			valueOrNA(product.ProductID),
 			valueOrNA(product.ProductName),
 			valueOrNA(product.BundleID),
-			fmt.Sprintf("%d", minutes),
-			fmt.Sprintf("%d", builds),
-			fmt.Sprintf("%d", product.PreviousUsageInMinutes),
-			fmt.Sprintf("%d", product.PreviousNumberOfBuilds),
-			formatUsageBarWithValues(minutes, planTotal),
-		})
+			formatUsageDuration(minutes, seconds),
+			formatUsageCount(builds),
+			formatUsageMinutes(product.PreviousUsageInMinutes),
+			formatUsageCount(product.PreviousNumberOfBuilds),
+		}
+		if detailed {
+			detailedSeconds := product.UsageInSeconds
+			if usageNumberFormat.seconds {
+				detailedSeconds = seconds
+			}
+			row = append(row,
+				formatUsageCount(detailedSeconds),
+				formatUsageChangePercent(minutes, product.PreviousUsageInMinutes),
+			)
+		}
+		bar := formatUsageBarWithValues(minutes, planTotal)
+		if usageNumberFormat.seconds {
+			bar = formatUsageBarWithSeconds(seconds, planTotal)
+		}
+		rows = append(rows, append(row, bar))
 	}
 	return rows
 }
 
+// formatUsageChangePercent formats (current-previous)/previous as a signed
+// percentage. A product with no previous usage reports "new" instead.
+func formatUsageChangePercent(current, previous int) string {
+	if previous <= 0 {
+		if current <= 0 {
+			return "n/a"
+		}
+		return "new"
+	}
+	change := float64(current-previous) / float64(previous) * 100
+	return fmt.Sprintf("%+.1f%%", change)
+}
+
 func filterProductUsageByIDs(productUsage []webcore.CIProductUsage, productIDs []string) []webcore.CIProductUsage {
 	if len(productIDs) == 0 {
 		return productUsage
@@ -800,6 +1786,7 @@ func renderCIUsageDaysTable(
 	productIDs []string,
 	productNames map[string]string,
 	planTotal int,
+	showScope bool,
 ) error {
 	hasOverall := overall != nil
 	if result == nil {
@@ -815,29 +1802,34 @@ func renderCIUsageDaysTable(
 	}
 
 	fmt.Printf("Range: %s\n", formatCIDayRange(result.Usage, result.Info))
-	if hasOverall {
-		fmt.Printf("Overall current: %d minutes (%d builds), avg30=%d\n", overallCurrent.Used, overallCurrent.Builds, overallCurrent.Average30Days)
-		fmt.Printf("Overall previous: %d minutes (%d builds), avg30=%d\n\n", overallPrevious.Used, overallPrevious.Builds, overallPrevious.Average30Days)
-	} else {
-		fmt.Printf("Overall usage unavailable; showing selected product scope only.\n\n")
+	if visibility := formatProductVisibility(result.Info); visibility != "" {
+		fmt.Printf("Product visibility: %s\n", visibility)
+	}
+	if showScope {
+		if hasOverall {
+			fmt.Printf("Overall current: %d minutes (%d builds), avg30=%d\n", overallCurrent.Used, overallCurrent.Builds, overallCurrent.Average30Days)
+			fmt.Printf("Overall previous: %d minutes (%d builds), avg30=%d\n\n", overallPrevious.Used, overallPrevious.Builds, overallPrevious.Average30Days)
+		} else {
+			fmt.Printf("Overall usage unavailable; showing selected product scope only.\n\n")
+		}
+		asc.RenderTable(
+			usageUnitHeaders([]string{"Scope", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"}),
+			buildCIUsageScopeRows(
+				result,
+				overall,
+				productIDs,
+				productNames,
+				planTotal,
+			),
+		)
 	}
-	asc.RenderTable(
-		[]string{"Scope", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"},
-		buildCIUsageScopeRows(
-			result,
-			overall,
-			productIDs,
-			productNames,
-			planTotal,
-		),
-	)
 	fmt.Println()
-	asc.RenderTable([]string{"Date", "Minutes", "Builds", "Usage Bar"}, buildCIDayUsageRows(result.Usage, maxDayMinutes))
+	asc.RenderTable(ciDayUsageHeaders(), buildCIDayUsageRows(result.Usage, maxDayMinutes))
 
 	if len(result.WorkflowUsage) > 0 {
 		fmt.Println()
 		asc.RenderTable(
-			[]string{"Workflow ID", "Workflow Name", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar"},
+			ciWorkflowUsageHeaders(),
 			buildCIWorkflowUsageRows(result.WorkflowUsage, maxWorkflowMinutes),
 		)
 	}
@@ -850,6 +1842,7 @@ func renderCIUsageDaysMarkdown(
 	productIDs []string,
 	productNames map[string]string,
 	planTotal int,
+	showScope bool,
 ) error {
 	hasOverall := overall != nil
 	if result == nil {
@@ -865,29 +1858,34 @@ func renderCIUsageDaysMarkdown(
 	}
 
 	fmt.Printf("**Range:** %s\n\n", formatCIDayRange(result.Usage, result.Info))
-	if hasOverall {
-		fmt.Printf("**Overall current:** %d minutes (%d builds), avg30=%d\n\n", overallCurrent.Used, overallCurrent.Builds, overallCurrent.Average30Days)
-		fmt.Printf("**Overall previous:** %d minutes (%d builds), avg30=%d\n\n", overallPrevious.Used, overallPrevious.Builds, overallPrevious.Average30Days)
-	} else {
-		fmt.Printf("**Overall usage unavailable; showing selected product scope only.**\n\n")
+	if visibility := formatProductVisibility(result.Info); visibility != "" {
+		fmt.Printf("**Product visibility:** %s\n\n", visibility)
+	}
+	if showScope {
+		if hasOverall {
+			fmt.Printf("**Overall current:** %d minutes (%d builds), avg30=%d\n\n", overallCurrent.Used, overallCurrent.Builds, overallCurrent.Average30Days)
+			fmt.Printf("**Overall previous:** %d minutes (%d builds), avg30=%d\n\n", overallPrevious.Used, overallPrevious.Builds, overallPrevious.Average30Days)
+		} else {
+			fmt.Printf("**Overall usage unavailable; showing selected product scope only.**\n\n")
+		}
+		asc.RenderMarkdown(
+			usageUnitHeaders([]string{"Scope", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"}),
+			buildCIUsageScopeRows(
+				result,
+				overall,
+				productIDs,
+				productNames,
+				planTotal,
+			),
+		)
+		fmt.Println()
 	}
-	asc.RenderMarkdown(
-		[]string{"Scope", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"},
-		buildCIUsageScopeRows(
-			result,
-			overall,
-			productIDs,
-			productNames,
-			planTotal,
-		),
-	)
-	fmt.Println()
-	asc.RenderMarkdown([]string{"Date", "Minutes", "Builds", "Usage Bar"}, buildCIDayUsageRows(result.Usage, maxDayMinutes))
+	asc.RenderMarkdown(ciDayUsageHeaders(), buildCIDayUsageRows(result.Usage, maxDayMinutes))
 
 	if len(result.WorkflowUsage) > 0 {
 		fmt.Println()
 		asc.RenderMarkdown(
-			[]string{"Workflow ID", "Workflow Name", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar"},
+			ciWorkflowUsageHeaders(),
 			buildCIWorkflowUsageRows(result.WorkflowUsage, maxWorkflowMinutes),
 		)
 	}
@@ -900,14 +1898,18 @@ func buildCIDayUsageRows(usage []webcore.CIDayUsage, maxMinutes int) [][]string
 	for _, dayUsage := range usage {
 		rows = append(rows, []string{
 			valueOrNA(dayUsage.Date),
-			fmt.Sprintf("%d", dayUsage.Duration),
-			fmt.Sprintf("%d", dayUsage.NumberOfBuilds),
+			formatUsageMinutes(dayUsage.Duration),
+			formatUsageCount(dayUsage.NumberOfBuilds),
 			formatUsageBar(dayUsage.Duration, maxMinutes),
 		})
 	}
 	return rows
 }
 
+func ciWorkflowUsageHeaders() []string {
+	return usageUnitHeaders([]string{"Workflow ID", "Workflow Name", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar"})
+}
+
 func buildCIWorkflowUsageRows(workflowUsage []webcore.CIWorkflowUsage, maxMinutes int) [][]string {
 	rows := make([][]string, 0)
 	for _, workflow := range workflowUsage {
@@ -915,60 +1917,72 @@ func buildCIWorkflowUsageRows(workflowUsage []webcore.CIWorkflowUsage, maxMinute
 		rows = append(rows, []string{
 			valueOrNA(workflow.WorkflowID),
 			valueOrNA(workflow.WorkflowName),
-			fmt.Sprintf("%d", minutes),
-			fmt.Sprintf("%d", builds),
-			fmt.Sprintf("%d", workflow.PreviousUsageInMinutes),
-			fmt.Sprintf("%d", workflow.PreviousNumberOfBuilds),
+			formatUsageMinutes(minutes),
+			formatUsageCount(builds),
+			formatUsageMinutes(workflow.PreviousUsageInMinutes),
+			formatUsageCount(workflow.PreviousNumberOfBuilds),
 			formatUsageBar(minutes, maxMinutes),
 		})
 	}
 	return rows
 }
 
-func renderCIProductsTable(result *webcore.CIProductListResponse) error {
-	asc.RenderTable([]string{"Product ID", "Name", "Bundle ID", "Type"}, buildCIProductRows(result))
+func renderCIProductsTable(result *webcore.CIProductListResponse, wide bool) error {
+	asc.RenderTable(ciProductHeaders(wide), buildCIProductRows(result, wide))
 	return nil
 }
 
-func renderCIProductsMarkdown(result *webcore.CIProductListResponse) error {
-	asc.RenderMarkdown([]string{"Product ID", "Name", "Bundle ID", "Type"}, buildCIProductRows(result))
+func renderCIProductsMarkdown(result *webcore.CIProductListResponse, wide bool) error {
+	asc.RenderMarkdown(ciProductHeaders(wide), buildCIProductRows(result, wide))
 	return nil
 }
 
-func buildCIProductRows(result *webcore.CIProductListResponse) [][]string {
+func ciProductHeaders(wide bool) []string {
+	headers := []string{"Product ID", "Name", "Bundle ID", "Type"}
+	if wide {
+		headers = append(headers, "Icon URL")
+	}
+	return headers
+}
+
+func buildCIProductRows(result *webcore.CIProductListResponse, wide bool) [][]string {
 	if result == nil {
 		result = &webcore.CIProductListResponse{}
 	}
 	rows := make([][]string, 0, len(result.Items))
 	for _, item := range result.Items {
-		rows = append(rows, []string{
+		row := []string{
 			valueOrNA(item.ID),
 			valueOrNA(item.Name),
 			valueOrNA(item.BundleID),
 			valueOrNA(item.Type),
-		})
+		}
+		if wide {
+			row = append(row, valueOrNA(item.IconURL))
+		}
+		rows = append(rows, row)
 	}
 	return rows
 }
 
 func formatCIMonthRange(usage []webcore.CIMonthUsage, info webcore.CIUsageInfo) string {
-	if info.StartMonth < 1 || info.StartYear < 1 || info.EndMonth < 1 || info.EndYear < 1 {
+	if !usageInfoHasRange(info) {
 		if len(usage) > 0 {
 			first := usage[0]
 			last := usage[len(usage)-1]
 			return fmt.Sprintf("%04d-%02d to %04d-%02d", first.Year, first.Month, last.Year, last.Month)
 		}
-		return "n/a"
+		return noDataForRangeLabel
 	}
 	return fmt.Sprintf("%04d-%02d to %04d-%02d", info.StartYear, info.StartMonth, info.EndYear, info.EndMonth)
 }
 
 func formatCIDayRange(usage []webcore.CIDayUsage, info webcore.CIUsageInfo) string {
-	if info.StartMonth > 0 && info.StartYear > 0 && info.EndMonth > 0 && info.EndYear > 0 {
+	if usageInfoHasRange(info) {
 		return fmt.Sprintf("%04d-%02d to %04d-%02d", info.StartYear, info.StartMonth, info.EndYear, info.EndMonth)
 	}
 	if len(usage) == 0 {
-		return "n/a"
+		return noDataForRangeLabel
 	}
 	return fmt.Sprintf("%s to %s", valueOrNA(usage[0].Date), valueOrNA(usage[len(usage)-1].Date))
 }
@@ -1118,20 +2132,20 @@ func buildCIUsageScopeRows(
 	for _, scope := range scopes {
 		rows = append(rows, []string{
 			scope.Label,
-			fmt.Sprintf("%d", scope.Current.Used),
-			fmt.Sprintf("%d", scope.Current.Builds),
-			fmt.Sprintf("%d", scope.Previous.Used),
-			fmt.Sprintf("%d", scope.Previous.Builds),
+			formatUsageMinutes(scope.Current.Used),
+			formatUsageCount(scope.Current.Builds),
+			formatUsageMinutes(scope.Previous.Used),
+			formatUsageCount(scope.Previous.Builds),
 			formatUsageBarWithValues(scope.Current.Used, absoluteTotal),
 		})
 	}
 	if hasOverall {
 		rows = append(rows, []string{
 			"Overall Team",
-			fmt.Sprintf("%d", overallCurrent.Used),
-			fmt.Sprintf("%d", overallCurrent.Builds),
-			fmt.Sprintf("%d", overallPrevious.Used),
-			fmt.Sprintf("%d", overallPrevious.Builds),
+			formatUsageMinutes(overallCurrent.Used),
+			formatUsageCount(overallCurrent.Builds),
+			formatUsageMinutes(overallPrevious.Used),
+			formatUsageCount(overallPrevious.Builds),
 			formatUsageBarWithValues(overallCurrent.Used, absoluteTotal),
 		})
 	}
@@ -1207,17 +2221,34 @@ func maxWorkflowUsageMinutes(workflows []webcore.CIWorkflowUsage) int {
 	return max
 }
 
+// formatUsageBarWithValues renders a usage bar followed by "(value/totalm)",
+// or "(value/totals)" in seconds under --unit seconds.
+// A non-positive total means the plan total is unavailable, so only the n/a bar
+// is returned rather than a misleading "(value/0m)" suffix.
 func formatUsageBarWithValues(value, total int) string {
 	if total <= 0 {
 		return formatUsageBar(value, total)
 	}
+	if usageNumberFormat.seconds {
+		return formatUsageBarWithSeconds(value*60, total)
+	}
 	return fmt.Sprintf("%s (%d/%dm)", formatUsageBar(value, total), value, total)
 }
 
+// formatUsageBarWithSeconds renders a usage bar for a second count against a
+// plan total in minutes, labelling both sides in seconds.
+func formatUsageBarWithSeconds(seconds, totalMinutes int) string {
+	if totalMinutes <= 0 {
+		return formatUsageBar(seconds, totalMinutes)
+	}
+	total := totalMinutes * 60
+	return fmt.Sprintf("%s (%d/%ds)", formatUsageBar(seconds, total), seconds, total)
+}
+
 func formatUsageBar(value, total int) string {
-	const barWidth = 16
+	barWidth := usageBarWidth()
 	if total <= 0 {
-		return "[................] n/a"
+		return "[" + strings.Repeat(".", barWidth) + "] n/a"
 	}
 	if value < 0 {
 		value = 0
@@ -1226,7 +2257,6 @@ func formatUsageBar(value, total int) string {
 		value = total
 	}
 
-	percent := (value*100 + total/2) / total
 	filled := (value*barWidth + total/2) / total
 	if filled < 0 {
 		filled = 0
@@ -1234,14 +2264,76 @@ func formatUsageBar(value, total int) string {
 	if filled > barWidth {
 		filled = barWidth
 	}
+	percentWidth := 4
+	if precision := usageNumberFormat.percentPrecision; precision > 0 {
+		percentWidth += precision + 1
+	}
 	return fmt.Sprintf(
-		"[%s%s] %3d%%",
+		"[%s%s] %*s",
 		strings.Repeat("#", filled),
 		strings.Repeat(".", barWidth-filled),
-		percent,
+		percentWidth,
+		formatUsagePercent(value, total),
 	)
 }
 
+func bindStrictFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("strict", false, "Fail when a supplementary lookup (overall usage, plan summary, names, trend) errors instead of degrading")
+}
+
+// strictSupplementaryError turns a failed supplementary lookup into a command
+// error under --strict. Without --strict the caller degrades and it returns nil.
+func strictSupplementaryError(strict bool, lookup string, err error) error {
+	if isContextDoneError(err) {
+		// A cancelled or timed-out command stops here instead of degrading
+		// through its remaining lookups.
+		return err
+	}
+	if !strict || err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s unavailable (--strict): %w", lookup, err)
+}
+
+// isContextDoneError reports whether err comes from a cancelled or expired context.
+func isContextDoneError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func bindFailIfEmptyFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("fail-if-empty", false, "Exit non-zero when the result contains zero records")
+}
+
+// checkFailIfEmpty returns an error naming the command when --fail-if-empty is set
+// and the result contains no records.
+func checkFailIfEmpty(enabled bool, count int, operation string) error {
+	if !enabled || count > 0 {
+		return nil
+	}
+	return fmt.Errorf("%s returned no results (--fail-if-empty)", operation)
+}
+
+// maxUsageMonthsRange caps month ranges; the CI API does not return older history.
+const maxUsageMonthsRange = 24
+
+func validateMonthRange(startMonth, startYear, endMonth, endYear int) error {
+	start := startYear*12 + (startMonth - 1)
+	end := endYear*12 + (endMonth - 1)
+	if start > end {
+		return fmt.Errorf(
+			"--start-year/--start-month (%04d-%02d) must not be after --end-year/--end-month (%04d-%02d)",
+			startYear, startMonth, endYear, endMonth,
+		)
+	}
+	if months := end - start + 1; months > maxUsageMonthsRange {
+		return fmt.Errorf(
+			"month range spans %d months; at most %d months are supported because the API caps usage history",
+			months, maxUsageMonthsRange,
+		)
+	}
+	return nil
+}
+
 func validateDateFlag(name, value string) error {
 	value = strings.TrimSpace(value)
 	if value == "" {
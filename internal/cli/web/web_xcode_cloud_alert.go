@@ -8,10 +8,12 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,13 +26,13 @@ import (
 
 const usageAlertSlackWebhookEnv = "ASC_SLACK_WEBHOOK"
 
-type usageAlertSeverity string
+type usageAlertSeverity = webcore.CIUsageSeverity
 
 const (
-	usageAlertSeverityUnknown  usageAlertSeverity = "unknown"
-	usageAlertSeverityOK       usageAlertSeverity = "ok"
-	usageAlertSeverityWarning  usageAlertSeverity = "warning"
-	usageAlertSeverityCritical usageAlertSeverity = "critical"
+	usageAlertSeverityUnknown  = webcore.CIUsageSeverityUnknown
+	usageAlertSeverityOK       = webcore.CIUsageSeverityOK
+	usageAlertSeverityWarning  = webcore.CIUsageSeverityWarning
+	usageAlertSeverityCritical = webcore.CIUsageSeverityCritical
 )
 
 type usageAlertFailOn string
@@ -62,21 +64,27 @@ var (
 // CIUsageAlertResult is the output payload for usage alert evaluation.
 type CIUsageAlertResult struct {
 	TeamID        string                     `json:"team_id"`
+	TeamName      string                     `json:"team_name,omitempty"`
 	EvaluatedAt   string                     `json:"evaluated_at"`
 	Severity      usageAlertSeverity         `json:"severity"`
+	SeverityLevel int                        `json:"severity_level"`
 	Message       string                     `json:"message"`
 	FailOn        usageAlertFailOn           `json:"fail_on"`
 	NotifyOn      usageAlertNotifyOn         `json:"notify_on"`
 	Thresholds    CIUsageAlertThresholds     `json:"thresholds"`
 	Plan          CIUsageAlertPlan           `json:"plan"`
 	Trend         *CIUsageAlertTrend         `json:"trend,omitempty"`
+	Growth        *CIUsageAlertGrowth        `json:"growth,omitempty"`
+	Annotations   map[string]string          `json:"annotations,omitempty"`
 	Notifications []CIUsageAlertNotification `json:"notifications,omitempty"`
 }
 
 // CIUsageAlertThresholds captures warning and critical threshold percentages.
 type CIUsageAlertThresholds struct {
-	WarnAt     int `json:"warn_at"`
-	CriticalAt int `json:"critical_at"`
+	WarnAt           int `json:"warn_at"`
+	CriticalAt       int `json:"critical_at"`
+	GrowthWarnAt     int `json:"growth_warn_at,omitempty"`
+	GrowthCriticalAt int `json:"growth_critical_at,omitempty"`
 }
 
 // CIUsageAlertPlan captures plan quota and calculated usage percentage.
@@ -109,25 +117,38 @@ type CIUsageAlertMonth struct {
 	Builds  int `json:"builds"`
 }
 
+// CIUsageAlertGrowth compares current-period usage with the previous period.
+// Percent is nil when the previous period has no usage to compare against.
+type CIUsageAlertGrowth struct {
+	Available         bool               `json:"available"`
+	UnavailableReason string             `json:"unavailable_reason,omitempty"`
+	Previous          int                `json:"previous"`
+	Current           int                `json:"current"`
+	Percent           *int               `json:"percent,omitempty"`
+	Severity          usageAlertSeverity `json:"severity"`
+}
+
 // CIUsageAlertNotification captures delivery status for outbound notifications.
 type CIUsageAlertNotification struct {
 	Channel    string `json:"channel"`
 	Triggered  bool   `json:"triggered"`
+	Suppressed bool   `json:"suppressed,omitempty"`
 	Delivered  bool   `json:"delivered"`
 	StatusCode int    `json:"status_code,omitempty"`
 	Error      string `json:"error,omitempty"`
 }
 
-type usageAlertHeaderFlags []string
+// usageAlertRepeatedFlag collects every value of a repeatable flag.
+type usageAlertRepeatedFlag []string
 
-func (f *usageAlertHeaderFlags) String() string {
+func (f *usageAlertRepeatedFlag) String() string {
 	if f == nil {
 		return ""
 	}
 	return strings.Join(*f, ",")
 }
 
-func (f *usageAlertHeaderFlags) Set(value string) error {
+func (f *usageAlertRepeatedFlag) Set(value string) error {
 	*f = append(*f, value)
 	return nil
 }
@@ -135,18 +156,34 @@ func (f *usageAlertHeaderFlags) Set(value string) error {
 func webXcodeCloudUsageAlertCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud usage alert", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
 
 	warnAt := fs.Int("warn-at", 80, "Warning threshold percent (1-99)")
 	criticalAt := fs.Int("critical-at", 95, "Critical threshold percent (1-100)")
 	failOn := fs.String("fail-on", string(usageAlertFailOnCritical), "Exit non-zero when severity reaches: none, warning, critical")
 	notifyOn := fs.String("notify-on", string(usageAlertNotifyOnWarning), "Send notifications when severity reaches: none, warning, critical, always")
 	slackWebhook := fs.String("slack-webhook", "", "Slack incoming webhook URL (optional, or set ASC_SLACK_WEBHOOK)")
+	slackPlain := fs.Bool("slack-plain", false, "Send a plain text Slack message instead of the formatted attachment")
+	slackWebhookFile := fs.String("slack-webhook-file", "", "Path to a file containing the Slack webhook URL (optional)")
 	webhook := fs.String("webhook", "", "Generic webhook URL for JSON alert payloads (optional)")
+	webhookFile := fs.String("webhook-file", "", "Path to a file containing the generic webhook URL (optional)")
+	dedupFile := fs.String("dedup-file", "", "Path to a state file used to skip repeat notifications within a reset period (optional)")
 	trendMonths := fs.Int("trend-months", 6, "Monthly trend window in months (0 to disable, max 24)")
-
-	var webhookHeaders usageAlertHeaderFlags
+	showDelta := fs.Bool("show-delta", false, "Add a month-to-month change column to the trend table (table/markdown)")
+	growthWarn := fs.Int("growth-warn", 0, "Warn when usage grew by at least this percent over the previous period (0 to disable)")
+	growthCritical := fs.Int("growth-critical", 0, "Go critical when usage grew by at least this percent over the previous period (0 to disable)")
+	percentOnly := fs.Bool("percent-only", false, "Print only the integer percent of plan minutes used (ignores --output)")
+	quiet := fs.Bool("quiet", false, "Print only a one-line status to stderr and suppress normal output")
+	logFormat := bindUsageLogFormatFlag(fs)
+	strict := bindStrictFlag(fs)
+	redactTeam := bindRedactTeamFlag(fs)
+	maxDataAge := bindMaxDataAgeFlag(fs)
+	percentFormat := bindUsagePercentFormatFlags(fs)
+
+	var webhookHeaders usageAlertRepeatedFlag
 	fs.Var(&webhookHeaders, "webhook-header", "Header for --webhook in 'Key: Value' format (repeatable)")
+	var annotations usageAlertRepeatedFlag
+	fs.Var(&annotations, "annotation", "Annotation to attach to the result and notifications in 'key=value' format (repeatable)")
 
 	return &ffcli.Command{
 		Name:       "alert",
@@ -157,18 +194,85 @@ func webXcodeCloudUsageAlertCommand() *ffcli.Command {
 Evaluate Xcode Cloud usage thresholds from plan quota, optionally include monthly trend context,
 and optionally notify Slack/webhook endpoints.
 
+JSON output includes severity (unknown, ok, warning, critical) and a matching
+numeric severity_level (0-3) for monitoring rules that compare numbers.
+
 Exit behavior:
   - Exit 0 when thresholds are not breached, or when --fail-on none
   - Exit 1 when severity meets --fail-on level (warning/critical)
   - Exit 2 for invalid flag usage
+  - Exit 6 when a notification fails and thresholds are not breached
+  - Exit 7 when severity meets --fail-on and a notification fails
+
+When a notification fails, a final stderr line summarizes every channel, e.g.
+"notifications: slack delivered, webhook failed (503)".
+
+Use --percent-only to print just the integer used percent for shell scripts
+("n/a" when the plan total is unavailable); exit codes are unchanged.
+
+Use --percent-precision and --percent-rounding floor to show the used percent with
+decimals or truncated, e.g. 99.9% instead of a rounded 100%. This changes only the
+displayed text (tables, --quiet, notification messages); thresholds are always
+compared using exact ratios, and JSON used_percent and --percent-only stay integers.
+
+Use --quiet in CI to print only a one-line status such as
+"xcode-cloud usage critical 96% (960/1000m)" to stderr. Exit codes and
+notifications are unchanged.
+
+Use --log-format logfmt to print a single key=value line for log pipelines
+instead of JSON/table output, e.g.
+"severity=critical used=960 total=1000 percent=96 team=TEAM-123". Exit codes and
+notifications are unchanged.
+
+Use --growth-warn and --growth-critical to also catch sudden spikes: usage so
+far in the current period is compared with the previous period, and the alert
+severity escalates when growth reaches either percentage, even while usage is
+still under quota. The JSON result then includes a growth object.
+
+Use --show-delta to add a month-to-month change column (e.g. +120, -40) to the
+trend table.
+
+Slack messages use a severity-colored attachment with plan fields; use
+--slack-plain for a single line of text.
+
+Use --redact-team to replace the team ID with a stable hashed pseudonym in output
+and notification payloads.
+
+Use --dedup-file when running on a schedule: the last notified severity and plan
+reset date are recorded there for each channel, and later runs skip a channel's
+notification at the same or a lower severity until usage escalates or the reset
+date changes. A channel that failed is retried on the next run. Without a plan
+reset date, nothing is deduplicated.
+
+Webhook URLs resolve in order: explicit flag, then --slack-webhook-file or
+--webhook-file (contents are trimmed), then ASC_SLACK_WEBHOOK for Slack.
+
+Use --max-data-age to fail before evaluating or notifying when the usage data
+looks stale: the plan reset time passed more than the given duration ago
+without the usage rolling over to a new cycle.
+
+Use --annotation to tag the alert with the CI run or build that produced it,
+e.g. --annotation build=1234 --annotation run_url=https://ci.example.com/runs/42.
+Annotations appear under "annotations" in the JSON result and webhook payload,
+and in the Slack message.
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud usage alert --apple-id "user@example.com"
   asc web xcode-cloud usage alert --warn-at 75 --critical-at 90 --fail-on warning --output table
+  asc web xcode-cloud usage alert --percent-only --fail-on none
+  asc web xcode-cloud usage alert --percent-precision 1 --percent-rounding floor --output table
+  asc web xcode-cloud usage alert --quiet --fail-on warning
+  asc web xcode-cloud usage alert --log-format logfmt --fail-on none
+  asc web xcode-cloud usage alert --growth-warn 50 --growth-critical 100
+  asc web xcode-cloud usage alert --show-delta --output table
+  asc web xcode-cloud usage alert --max-data-age 6h --fail-on warning
   asc web xcode-cloud usage alert --slack-webhook "https://hooks.slack.com/services/..." --notify-on critical
-  asc web xcode-cloud usage alert --webhook "https://example.com/alerts" --webhook-header "Authorization: Bearer TOKEN"`,
+  asc web xcode-cloud usage alert --webhook "https://example.com/alerts" --webhook-header "Authorization: Bearer TOKEN"
+  asc web xcode-cloud usage alert --slack-webhook-file /run/secrets/slack-webhook
+  asc web xcode-cloud usage alert --webhook "https://example.com/alerts" --annotation build=1234 --annotation pipeline=nightly
+  asc web xcode-cloud usage alert --slack-webhook-file /run/secrets/slack-webhook --dedup-file ~/.cache/asc/usage-alert.json`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -176,6 +280,12 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			restorePercentFormat, err := percentFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restorePercentFormat()
 			failOnLevel, err := parseUsageAlertFailOn(*failOn)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
@@ -186,18 +296,47 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			if *quiet && *percentOnly {
+				fmt.Fprintln(os.Stderr, "Error: --quiet and --percent-only are mutually exclusive")
+				return flag.ErrHelp
+			}
+			logfmt, err := parseUsageLogFormat(*logFormat)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if logfmt && (*quiet || *percentOnly) {
+				fmt.Fprintln(os.Stderr, "Error: --log-format cannot be used with --quiet or --percent-only")
+				return flag.ErrHelp
+			}
+			if err := validateUsageAlertGrowthThresholds(*growthWarn, *growthCritical); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 			if *trendMonths < 0 || *trendMonths > 24 {
 				fmt.Fprintln(os.Stderr, "Error: --trend-months must be between 0 and 24")
 				return flag.ErrHelp
 			}
-			normalizedSlackWebhook, err := resolveUsageAlertWebhookURL(
-				resolveUsageAlertSlackWebhook(*slackWebhook),
-			)
+			if err := validateMaxDataAge(*maxDataAge); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			slackWebhookValue, err := resolveUsageAlertSlackWebhook(*slackWebhook, *slackWebhookFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			normalizedSlackWebhook, err := resolveUsageAlertWebhookURL(slackWebhookValue)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: --slack-webhook %s\n", err)
 				return flag.ErrHelp
 			}
-			normalizedWebhookURL, err := resolveUsageAlertWebhookURL(*webhook)
+			webhookValue, err := resolveUsageAlertWebhookSource(*webhook, *webhookFile, "--webhook-file", "")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			normalizedWebhookURL, err := resolveUsageAlertWebhookURL(webhookValue)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: --webhook %s\n", err)
 				return flag.ErrHelp
@@ -207,6 +346,11 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				return flag.ErrHelp
 			}
+			parsedAnnotations, err := parseUsageAlertAnnotations(annotations)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -227,6 +371,9 @@ Examples:
 				if err != nil {
 					return err
 				}
+				if err := checkUsageDataAge(summary.Plan, webNowFn(), *maxDataAge); err != nil {
+					return err
+				}
 
 				alertResult = buildCIUsageAlertResult(
 					teamID,
@@ -237,16 +384,41 @@ Examples:
 					notifyOnLevel,
 				)
 				if *trendMonths > 0 {
-					alertResult.Trend = loadUsageAlertTrend(requestCtx, client, teamID, *trendMonths)
+					trend, trendErr := loadUsageAlertTrend(requestCtx, client, teamID, *trendMonths)
+					if strictErr := strictSupplementaryError(*strict, "monthly trend", trendErr); strictErr != nil {
+						return strictErr
+					}
+					alertResult.Trend = trend
+				}
+				if *growthWarn > 0 || *growthCritical > 0 {
+					growth, growthErr := loadUsageAlertGrowth(requestCtx, client, teamID, *growthWarn, *growthCritical)
+					if strictErr := strictSupplementaryError(*strict, "usage growth", growthErr); strictErr != nil {
+						return strictErr
+					}
+					applyUsageAlertGrowth(alertResult, growth, *growthWarn, *growthCritical)
 				}
 				return nil
 			})
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud usage alert")
 			}
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			redactUsageAlertTeam(alertResult, redactor)
+			alertResult.Annotations = parsedAnnotations
 
 			notifyErr := error(nil)
 			if strings.TrimSpace(normalizedSlackWebhook) != "" || strings.TrimSpace(normalizedWebhookURL) != "" {
+				dedupPath := strings.TrimSpace(*dedupFile)
+				var dedupState *usageAlertDedupState
+				if dedupPath != "" {
+					state, err := readUsageAlertDedupState(dedupPath)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: %v; notifying without deduplication\n", err)
+					}
+					dedupState = state
+				}
+				suppressed := usageAlertDedupSuppressed(dedupState, alertResult)
 				notifyErr = withWebSpinner("Sending usage alert notifications", func() error {
 					return deliverUsageAlertNotifications(
 						requestCtx,
@@ -255,35 +427,73 @@ Examples:
 						normalizedWebhookURL,
 						parsedHeaders,
 						notifyOnLevel,
+						*slackPlain,
+						suppressed,
 					)
 				})
+				if dedupPath != "" {
+					if state, changed := recordUsageAlertDeliveries(dedupState, alertResult); changed {
+						if err := writeUsageAlertDedupState(dedupPath, state); err != nil {
+							notifyErr = errors.Join(notifyErr, err)
+						}
+					}
+				}
 			}
 
-			if err := shared.PrintOutputWithRenderers(
+			if *quiet {
+				fmt.Fprintln(os.Stderr, formatUsageAlertQuietLine(alertResult))
+			} else if logfmt {
+				if err := printUsageLine(formatUsageAlertLogfmt(alertResult)); err != nil {
+					return err
+				}
+			} else if *percentOnly {
+				if err := printUsagePercentOnly(alertResult.Plan.Used, alertResult.Plan.Total); err != nil {
+					return err
+				}
+			} else if err := shared.PrintOutputWithRenderers(
 				alertResult,
 				*output.Output,
 				*output.Pretty,
-				func() error { return renderCIUsageAlertTable(alertResult) },
-				func() error { return renderCIUsageAlertMarkdown(alertResult) },
+				func() error { return renderCIUsageAlertTable(alertResult, *showDelta) },
+				func() error { return renderCIUsageAlertMarkdown(alertResult, *showDelta) },
 			); err != nil {
 				return err
 			}
 
 			var resultErr error
 			if notifyErr != nil {
+				fmt.Fprintln(os.Stderr, formatUsageAlertNotificationSummary(alertResult.Notifications))
 				resultErr = fmt.Errorf("xcode-cloud usage alert notification failed: %w", notifyErr)
 			}
-			if shouldFailUsageAlert(alertResult.Severity, failOnLevel) {
+			breached := shouldFailUsageAlert(alertResult.Severity, failOnLevel)
+			if breached {
 				resultErr = errors.Join(
 					resultErr,
 					fmt.Errorf("xcode-cloud usage alert threshold breach: %s", alertResult.Message),
 				)
+				if *quiet && notifyErr == nil {
+					// The status line already reported the breach.
+					return shared.NewReportedError(resultErr)
+				}
 			}
-			return resultErr
+			return usageAlertExitError(resultErr, breached, notifyErr != nil)
 		},
 	}
 }
 
+// redactUsageAlertTeam replaces the team ID and drops the team name in the
+// result before it is printed or sent to notification endpoints.
+func redactUsageAlertTeam(result *CIUsageAlertResult, redactor teamRedactor) {
+	if result == nil {
+		return
+	}
+	result.TeamID = redactor.String(result.TeamID)
+	result.Plan.ManageURL = redactor.String(result.Plan.ManageURL)
+	if redactor.teamID != "" {
+		result.TeamName = ""
+	}
+}
+
 func validateUsageAlertThresholds(warnAt, criticalAt int) error {
 	if warnAt < 1 || warnAt > 99 {
 		return fmt.Errorf("--warn-at must be between 1 and 99")
@@ -297,6 +507,21 @@ func validateUsageAlertThresholds(warnAt, criticalAt int) error {
 	return nil
 }
 
+// validateUsageAlertGrowthThresholds checks the optional growth thresholds;
+// zero disables a level.
+func validateUsageAlertGrowthThresholds(warnAt, criticalAt int) error {
+	if warnAt < 0 {
+		return fmt.Errorf("--growth-warn must be 0 or greater")
+	}
+	if criticalAt < 0 {
+		return fmt.Errorf("--growth-critical must be 0 or greater")
+	}
+	if warnAt > 0 && criticalAt > 0 && warnAt >= criticalAt {
+		return fmt.Errorf("--growth-warn must be less than --growth-critical")
+	}
+	return nil
+}
+
 func parseUsageAlertFailOn(value string) (usageAlertFailOn, error) {
 	switch usageAlertFailOn(strings.ToLower(strings.TrimSpace(value))) {
 	case usageAlertFailOnNone, usageAlertFailOnWarning, usageAlertFailOnCritical:
@@ -315,12 +540,35 @@ func parseUsageAlertNotifyOn(value string) (usageAlertNotifyOn, error) {
 	}
 }
 
-func resolveUsageAlertSlackWebhook(flagValue string) string {
+// resolveUsageAlertSlackWebhook resolves the Slack webhook URL from the flag,
+// then the flag file, then ASC_SLACK_WEBHOOK.
+func resolveUsageAlertSlackWebhook(flagValue, filePath string) (string, error) {
+	return resolveUsageAlertWebhookSource(flagValue, filePath, "--slack-webhook-file", usageAlertSlackWebhookEnv)
+}
+
+// resolveUsageAlertWebhookSource applies explicit flag > flag file > env precedence.
+// An empty envName disables the env fallback.
+func resolveUsageAlertWebhookSource(flagValue, filePath, fileFlagName, envName string) (string, error) {
 	flagValue = strings.TrimSpace(flagValue)
 	if flagValue != "" {
-		return flagValue
+		return flagValue, nil
+	}
+	filePath = strings.TrimSpace(filePath)
+	if filePath != "" {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("%s: failed to read %q: %w", fileFlagName, filePath, err)
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "" {
+			return "", fmt.Errorf("%s: %q is empty", fileFlagName, filePath)
+		}
+		return value, nil
+	}
+	if envName == "" {
+		return "", nil
 	}
-	return strings.TrimSpace(os.Getenv(usageAlertSlackWebhookEnv))
+	return strings.TrimSpace(os.Getenv(envName)), nil
 }
 
 func resolveUsageAlertWebhookURL(raw string) (string, error) {
@@ -365,6 +613,46 @@ func parseUsageAlertHeaders(values []string) (http.Header, error) {
 	return headers, nil
 }
 
+// parseUsageAlertAnnotations parses repeated 'key=value' annotations. A later
+// value for the same key replaces the earlier one.
+func parseUsageAlertAnnotations(values []string) (map[string]string, error) {
+	var annotations map[string]string
+	for _, entry := range values {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("--annotation must be in 'key=value' format")
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("--annotation key cannot be empty")
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[key] = strings.TrimSpace(value)
+	}
+	return annotations, nil
+}
+
+// formatUsageAlertAnnotations renders annotations as 'key=value' pairs sorted
+// by key, so notification text is stable across runs.
+func formatUsageAlertAnnotations(annotations map[string]string, sep string) string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+annotations[key])
+	}
+	return strings.Join(pairs, sep)
+}
+
 func buildCIUsageAlertResult(
 	teamID string,
 	summary *webcore.CIUsageSummary,
@@ -377,15 +665,16 @@ func buildCIUsageAlertResult(
 	}
 	used := summary.Plan.Used
 	total := summary.Plan.Total
-	percentUsed := calculateUsagePercent(used, total)
-	severity := classifyUsageAlertSeverity(used, total, warnAt, criticalAt)
+	percentUsed := webcore.CIUsagePercent(used, total)
+	severity := webcore.ClassifyCIUsageSeverity(used, total, warnAt, criticalAt)
 
 	result := &CIUsageAlertResult{
-		TeamID:      teamID,
-		EvaluatedAt: webNowFn().UTC().Format(time.RFC3339),
-		Severity:    severity,
-		FailOn:      failOn,
-		NotifyOn:    notifyOn,
+		TeamID:        teamID,
+		EvaluatedAt:   webNowFn().UTC().Format(time.RFC3339),
+		Severity:      severity,
+		SeverityLevel: usageAlertSeverityRank(severity),
+		FailOn:        failOn,
+		NotifyOn:      notifyOn,
 		Thresholds: CIUsageAlertThresholds{
 			WarnAt:     warnAt,
 			CriticalAt: criticalAt,
@@ -405,42 +694,41 @@ func buildCIUsageAlertResult(
 	return result
 }
 
-func calculateUsagePercent(used, total int) int {
-	if total <= 0 {
-		return 0
-	}
-	if used < 0 {
-		used = 0
-	}
-	if used > total {
-		used = total
+// formatUsageAlertQuietLine is the single status line printed by --quiet.
+func formatUsageAlertQuietLine(result *CIUsageAlertResult) string {
+	if result.Plan.Total <= 0 {
+		return fmt.Sprintf("xcode-cloud usage %s (plan total unavailable)", result.Severity)
 	}
-	return (used*100 + total/2) / total
+	return fmt.Sprintf(
+		"xcode-cloud usage %s %s (%d/%dm)",
+		result.Severity,
+		formatUsagePercent(result.Plan.Used, result.Plan.Total),
+		result.Plan.Used,
+		result.Plan.Total,
+	)
 }
 
-func classifyUsageAlertSeverity(used, total, warnAt, criticalAt int) usageAlertSeverity {
+// printUsagePercentOnly prints the integer used percent and nothing else, to
+// stdout or --out-file. It prints "n/a" when the plan total is unavailable.
+func printUsagePercentOnly(used, total int) error {
 	if total <= 0 {
-		return usageAlertSeverityUnknown
-	}
-	if used < 0 {
-		used = 0
-	}
-	usedScaled := int64(used) * 100
-	totalScaled := int64(total)
-	if usedScaled >= int64(criticalAt)*totalScaled {
-		return usageAlertSeverityCritical
-	}
-	if usedScaled >= int64(warnAt)*totalScaled {
-		return usageAlertSeverityWarning
+		return printUsageLine("n/a")
 	}
-	return usageAlertSeverityOK
+	return printUsageLine(strconv.Itoa(webcore.CIUsagePercent(used, total)))
 }
 
 func buildUsageAlertMessage(result *CIUsageAlertResult) string {
 	if result == nil {
 		return "xcode-cloud usage alert unavailable"
 	}
+	growth := ""
+	if result.Growth != nil && result.Growth.Percent != nil {
+		growth = fmt.Sprintf("; growth: %+d%% vs previous period (%dm -> %dm)", *result.Growth.Percent, result.Growth.Previous, result.Growth.Current)
+	}
 	if result.Plan.Total <= 0 {
+		if growth != "" {
+			return fmt.Sprintf("xcode-cloud usage is %s%s", result.Severity, growth)
+		}
 		return "xcode-cloud usage alert cannot evaluate thresholds because plan total is unavailable"
 	}
 	reset := strings.TrimSpace(result.Plan.ResetDate)
@@ -448,15 +736,104 @@ func buildUsageAlertMessage(result *CIUsageAlertResult) string {
 		reset = "n/a"
 	}
 	return fmt.Sprintf(
-		"xcode-cloud usage is %s at %d%% (%d/%dm); reset date: %s",
+		"xcode-cloud usage is %s at %s (%d/%dm); reset date: %s%s",
 		result.Severity,
-		result.Plan.UsedPercent,
+		formatUsagePercent(result.Plan.Used, result.Plan.Total),
 		result.Plan.Used,
 		result.Plan.Total,
 		reset,
+		growth,
 	)
 }
 
+// loadUsageAlertGrowth reads current and previous period usage from the
+// monthly endpoint's info block. Like the trend, it always returns a growth
+// value and passes the fetch error through for --strict.
+func loadUsageAlertGrowth(ctx context.Context, client *webcore.Client, teamID string, warnAt, criticalAt int) (*CIUsageAlertGrowth, error) {
+	startMonth, startYear, endMonth, endYear := usageAlertMonthWindow(webNowFn().UTC(), 1)
+	response, err := client.GetCIUsageMonths(ctx, teamID, startMonth, startYear, endMonth, endYear)
+	if err != nil || response == nil {
+		return &CIUsageAlertGrowth{
+			UnavailableReason: "usage growth unavailable",
+			Severity:          usageAlertSeverityUnknown,
+		}, err
+	}
+	return buildUsageAlertGrowth(response.Info.Previous.Used, response.Info.Current.Used, warnAt, criticalAt), nil
+}
+
+func buildUsageAlertGrowth(previous, current, warnAt, criticalAt int) *CIUsageAlertGrowth {
+	growth := &CIUsageAlertGrowth{
+		Previous: previous,
+		Current:  current,
+		Severity: usageAlertSeverityUnknown,
+	}
+	if previous <= 0 {
+		growth.UnavailableReason = "no previous period usage to compare"
+		return growth
+	}
+	percent := int(math.Round(float64(current-previous) * 100 / float64(previous)))
+	growth.Available = true
+	growth.Percent = &percent
+	switch {
+	case criticalAt > 0 && percent >= criticalAt:
+		growth.Severity = usageAlertSeverityCritical
+	case warnAt > 0 && percent >= warnAt:
+		growth.Severity = usageAlertSeverityWarning
+	default:
+		growth.Severity = usageAlertSeverityOK
+	}
+	return growth
+}
+
+// applyUsageAlertGrowth attaches growth to the result and raises the overall
+// severity when growth is more severe than plan usage.
+func applyUsageAlertGrowth(result *CIUsageAlertResult, growth *CIUsageAlertGrowth, warnAt, criticalAt int) {
+	result.Thresholds.GrowthWarnAt = warnAt
+	result.Thresholds.GrowthCriticalAt = criticalAt
+	result.Growth = growth
+	if growth != nil && usageAlertSeverityRank(growth.Severity) > usageAlertSeverityRank(result.Severity) {
+		result.Severity = growth.Severity
+		result.SeverityLevel = usageAlertSeverityRank(result.Severity)
+	}
+	result.Message = buildUsageAlertMessage(result)
+}
+
+// usageAlertSeverityRank orders severities for comparison and is reported as
+// the JSON severity_level: 0=unknown, 1=ok, 2=warning, 3=critical.
+func usageAlertSeverityRank(severity usageAlertSeverity) int {
+	switch severity {
+	case usageAlertSeverityOK:
+		return 1
+	case usageAlertSeverityWarning:
+		return 2
+	case usageAlertSeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func formatUsageAlertGrowth(growth *CIUsageAlertGrowth) string {
+	if growth == nil || growth.Percent == nil {
+		if growth != nil && growth.UnavailableReason != "" {
+			return growth.UnavailableReason
+		}
+		return "n/a"
+	}
+	return fmt.Sprintf("%+d%% (%dm -> %dm, %s)", *growth.Percent, growth.Previous, growth.Current, growth.Severity)
+}
+
+func formatUsageAlertThresholds(thresholds CIUsageAlertThresholds) string {
+	text := fmt.Sprintf("warn=%d%% critical=%d%%", thresholds.WarnAt, thresholds.CriticalAt)
+	if thresholds.GrowthWarnAt > 0 {
+		text += fmt.Sprintf(" growth-warn=%d%%", thresholds.GrowthWarnAt)
+	}
+	if thresholds.GrowthCriticalAt > 0 {
+		text += fmt.Sprintf(" growth-critical=%d%%", thresholds.GrowthCriticalAt)
+	}
+	return text
+}
+
 func shouldFailUsageAlert(severity usageAlertSeverity, failOn usageAlertFailOn) bool {
 	switch failOn {
 	case usageAlertFailOnNone:
@@ -485,12 +862,14 @@ func shouldNotifyUsageAlert(severity usageAlertSeverity, notifyOn usageAlertNoti
 	}
 }
 
-func loadUsageAlertTrend(ctx context.Context, client *webcore.Client, teamID string, months int) *CIUsageAlertTrend {
+// loadUsageAlertTrend always returns a trend, marked unavailable when the
+// monthly fetch fails; the fetch error is returned alongside for --strict.
+func loadUsageAlertTrend(ctx context.Context, client *webcore.Client, teamID string, months int) (*CIUsageAlertTrend, error) {
 	trend := &CIUsageAlertTrend{RequestedMonths: months}
 	if months <= 0 || client == nil {
 		trend.Available = false
 		trend.UnavailableReason = "monthly trend disabled"
-		return trend
+		return trend, nil
 	}
 
 	now := webNowFn().UTC()
@@ -506,7 +885,7 @@ func loadUsageAlertTrend(ctx context.Context, client *webcore.Client, teamID str
 	if err != nil || response == nil {
 		trend.Available = false
 		trend.UnavailableReason = "monthly trend unavailable"
-		return trend
+		return trend, err
 	}
 
 	usage := append([]webcore.CIMonthUsage(nil), response.Usage...)
@@ -522,7 +901,7 @@ func loadUsageAlertTrend(ctx context.Context, client *webcore.Client, teamID str
 	if len(usage) == 0 {
 		trend.Available = false
 		trend.UnavailableReason = "monthly trend unavailable"
-		return trend
+		return trend, nil
 	}
 
 	totalMinutes := 0
@@ -543,7 +922,7 @@ func loadUsageAlertTrend(ctx context.Context, client *webcore.Client, teamID str
 	trend.Available = true
 	trend.AverageMinutes = totalMinutes / len(usage)
 	trend.PeakMinutes = peakMinutes
-	return trend
+	return trend, nil
 }
 
 func usageAlertMonthWindow(now time.Time, months int) (startMonth, startYear, endMonth, endYear int) {
@@ -562,17 +941,20 @@ func deliverUsageAlertNotifications(
 	slackWebhook, webhookURL string,
 	webhookHeaders http.Header,
 	notifyOn usageAlertNotifyOn,
+	slackPlain bool,
+	suppressed map[string]bool,
 ) error {
 	shouldNotify := shouldNotifyUsageAlert(result.Severity, notifyOn)
 	var notifyErr error
 
 	if strings.TrimSpace(slackWebhook) != "" {
 		delivery := CIUsageAlertNotification{
-			Channel:   "slack",
-			Triggered: shouldNotify,
+			Channel:    "slack",
+			Triggered:  shouldNotify && !suppressed["slack"],
+			Suppressed: shouldNotify && suppressed["slack"],
 		}
-		if shouldNotify {
-			statusCode, err := sendUsageAlertSlackFn(ctx, slackWebhook, result)
+		if delivery.Triggered {
+			statusCode, err := sendUsageAlertSlackFn(ctx, slackWebhook, result, slackPlain)
 			delivery.StatusCode = statusCode
 			delivery.Delivered = err == nil
 			if err != nil {
@@ -585,10 +967,11 @@ func deliverUsageAlertNotifications(
 
 	if strings.TrimSpace(webhookURL) != "" {
 		delivery := CIUsageAlertNotification{
-			Channel:   "webhook",
-			Triggered: shouldNotify,
+			Channel:    "webhook",
+			Triggered:  shouldNotify && !suppressed["webhook"],
+			Suppressed: shouldNotify && suppressed["webhook"],
 		}
-		if shouldNotify {
+		if delivery.Triggered {
 			statusCode, err := sendUsageAlertWebhookFn(ctx, webhookURL, webhookHeaders, result)
 			delivery.StatusCode = statusCode
 			delivery.Delivered = err == nil
@@ -603,19 +986,148 @@ func deliverUsageAlertNotifications(
 	return notifyErr
 }
 
-func sendUsageAlertToSlack(ctx context.Context, webhookURL string, result *CIUsageAlertResult) (int, error) {
-	payload := map[string]any{
-		"text": fmt.Sprintf(
-			"Xcode Cloud usage alert: %s (team=%s, used=%d/%dm, threshold warn=%d%% critical=%d%%)",
-			result.Severity,
-			result.TeamID,
-			result.Plan.Used,
-			result.Plan.Total,
-			result.Thresholds.WarnAt,
-			result.Thresholds.CriticalAt,
-		),
+// formatUsageAlertNotificationSummary recaps the delivery state of each
+// channel on one line, e.g. "notifications: slack delivered, webhook failed (503)".
+func formatUsageAlertNotificationSummary(notifications []CIUsageAlertNotification) string {
+	parts := make([]string, 0, len(notifications))
+	seen := map[string]struct{}{}
+	for _, notification := range notifications {
+		if _, ok := seen[notification.Channel]; ok {
+			continue
+		}
+		seen[notification.Channel] = struct{}{}
+		status := "failed"
+		switch {
+		case notification.Suppressed:
+			status = "suppressed"
+		case !notification.Triggered:
+			status = "skipped"
+		case notification.Delivered:
+			status = "delivered"
+		case notification.StatusCode > 0:
+			status = fmt.Sprintf("failed (%d)", notification.StatusCode)
+		}
+		parts = append(parts, notification.Channel+" "+status)
+	}
+	if len(parts) == 0 {
+		return "notifications: none sent"
+	}
+	return "notifications: " + strings.Join(parts, ", ")
+}
+
+// usageAlertExitError gives notification failures their own exit codes so a
+// failed delivery can be told apart from a threshold breach.
+func usageAlertExitError(err error, breached, notifyFailed bool) error {
+	if !notifyFailed {
+		return err
+	}
+	if breached {
+		return shared.NewExitCodeError(err, shared.ExitBreachNotificationFailed)
+	}
+	return shared.NewExitCodeError(err, shared.ExitNotificationFailed)
+}
+
+func sendUsageAlertToSlack(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+	if plain {
+		return postUsageAlertJSON(ctx, webhookURL, nil, map[string]any{"text": usageAlertSlackText(result)})
+	}
+	return postUsageAlertJSON(ctx, webhookURL, nil, buildUsageAlertSlackPayload(result))
+}
+
+// usageAlertSlackText is the plain message; it doubles as the preview fallback
+// for the attachment format.
+func usageAlertSlackText(result *CIUsageAlertResult) string {
+	annotations := ""
+	if len(result.Annotations) > 0 {
+		annotations = ", " + formatUsageAlertAnnotations(result.Annotations, ", ")
+	}
+	return fmt.Sprintf(
+		"Xcode Cloud usage alert: %s (team=%s, used=%d/%dm, threshold warn=%d%% critical=%d%%%s)",
+		result.Severity,
+		result.TeamID,
+		result.Plan.Used,
+		result.Plan.Total,
+		result.Thresholds.WarnAt,
+		result.Thresholds.CriticalAt,
+		annotations,
+	)
+}
+
+// buildUsageAlertSlackPayload builds an attachment with a severity-colored
+// sidebar, a header block, and plan fields.
+func buildUsageAlertSlackPayload(result *CIUsageAlertResult) map[string]any {
+	text := usageAlertSlackText(result)
+
+	percent := formatUsagePercent(result.Plan.Used, result.Plan.Total)
+	reset := valueOrNA(result.Plan.ResetDateTime)
+	if strings.TrimSpace(result.Plan.ResetDateTime) == "" {
+		reset = valueOrNA(result.Plan.ResetDate)
+	}
+	manage := "n/a"
+	if strings.TrimSpace(result.Plan.ManageURL) != "" {
+		manage = fmt.Sprintf("<%s|Manage plan>", result.Plan.ManageURL)
+	}
+
+	fields := []map[string]any{
+		usageAlertSlackField("Used", fmt.Sprintf("%dm", result.Plan.Used)),
+		usageAlertSlackField("Total", fmt.Sprintf("%dm", result.Plan.Total)),
+		usageAlertSlackField("Percent", percent),
+		usageAlertSlackField("Reset", reset),
+		usageAlertSlackField("Manage", manage),
+	}
+	if result.Growth != nil {
+		fields = append(fields, usageAlertSlackField("Growth", formatUsageAlertGrowth(result.Growth)))
+	}
+	if len(result.Annotations) > 0 {
+		fields = append(fields, usageAlertSlackField("Annotations", formatUsageAlertAnnotations(result.Annotations, "\n")))
+	}
+
+	return map[string]any{
+		"text": text,
+		"attachments": []map[string]any{
+			{
+				"color":    usageAlertSlackColor(result.Severity),
+				"fallback": text,
+				"blocks": []map[string]any{
+					{
+						"type": "header",
+						"text": map[string]any{
+							"type": "plain_text",
+							"text": fmt.Sprintf("Xcode Cloud usage: %s", strings.ToUpper(string(result.Severity))),
+						},
+					},
+					{
+						"type": "section",
+						"text": map[string]any{
+							"type": "mrkdwn",
+							"text": valueOrNA(result.Message),
+						},
+						"fields": fields,
+					},
+				},
+			},
+		},
+	}
+}
+
+func usageAlertSlackField(label, value string) map[string]any {
+	return map[string]any{
+		"type": "mrkdwn",
+		"text": fmt.Sprintf("*%s*\n%s", label, value),
+	}
+}
+
+func usageAlertSlackColor(severity usageAlertSeverity) string {
+	switch severity {
+	case usageAlertSeverityOK:
+		return "good"
+	case usageAlertSeverityWarning:
+		return "warning"
+	case usageAlertSeverityCritical:
+		return "danger"
+	default:
+		return "#808080"
 	}
-	return postUsageAlertJSON(ctx, webhookURL, nil, payload)
 }
 
 func sendUsageAlertToWebhook(
@@ -629,6 +1141,9 @@ func sendUsageAlertToWebhook(
 		"message": result.Message,
 		"result":  result,
 	}
+	if len(result.Annotations) > 0 {
+		payload["annotations"] = result.Annotations
+	}
 	return postUsageAlertJSON(ctx, webhookURL, headers, payload)
 }
 
@@ -669,7 +1184,7 @@ func postUsageAlertJSON(
 	return resp.StatusCode, nil
 }
 
-func renderCIUsageAlertTable(result *CIUsageAlertResult) error {
+func renderCIUsageAlertTable(result *CIUsageAlertResult, showDelta bool) error {
 	if result == nil {
 		result = &CIUsageAlertResult{}
 	}
@@ -684,8 +1199,8 @@ func renderCIUsageAlertTable(result *CIUsageAlertResult) error {
 		if result.Trend.Available {
 			fmt.Printf("Trend window: %d months (avg=%dm, peak=%dm)\n\n", result.Trend.RequestedMonths, result.Trend.AverageMinutes, result.Trend.PeakMinutes)
 			asc.RenderTable(
-				[]string{"Year", "Month", "Minutes", "Builds", "Usage Bar (Plan)"},
-				buildCIUsageAlertTrendRows(result.Trend, result.Plan.Total),
+				ciUsageAlertTrendHeaders(showDelta),
+				buildCIUsageAlertTrendRows(result.Trend, result.Plan.Total, showDelta),
 			)
 		} else {
 			fmt.Printf("Trend unavailable: %s\n", valueOrNA(result.Trend.UnavailableReason))
@@ -703,7 +1218,7 @@ func renderCIUsageAlertTable(result *CIUsageAlertResult) error {
 	return nil
 }
 
-func renderCIUsageAlertMarkdown(result *CIUsageAlertResult) error {
+func renderCIUsageAlertMarkdown(result *CIUsageAlertResult, showDelta bool) error {
 	if result == nil {
 		result = &CIUsageAlertResult{}
 	}
@@ -718,8 +1233,8 @@ func renderCIUsageAlertMarkdown(result *CIUsageAlertResult) error {
 		if result.Trend.Available {
 			fmt.Printf("**Trend window:** %d months (avg=%dm, peak=%dm)\n\n", result.Trend.RequestedMonths, result.Trend.AverageMinutes, result.Trend.PeakMinutes)
 			asc.RenderMarkdown(
-				[]string{"Year", "Month", "Minutes", "Builds", "Usage Bar (Plan)"},
-				buildCIUsageAlertTrendRows(result.Trend, result.Plan.Total),
+				ciUsageAlertTrendHeaders(showDelta),
+				buildCIUsageAlertTrendRows(result.Trend, result.Plan.Total, showDelta),
 			)
 		} else {
 			fmt.Printf("**Trend unavailable:** %s\n", valueOrNA(result.Trend.UnavailableReason))
@@ -742,41 +1257,64 @@ func buildCIUsageAlertOverviewRows(result *CIUsageAlertResult, markdown bool) []
 		result = &CIUsageAlertResult{}
 	}
 	usageBar := formatUsageBarWithValues(result.Plan.Used, result.Plan.Total)
+	usedPercent := formatUsagePercent(result.Plan.Used, result.Plan.Total)
 	severity := string(result.Severity)
 	if markdown {
 		severity = strings.ToUpper(severity)
 	}
-	return [][]string{
+	rows := [][]string{
 		{"Severity", valueOrNA(severity)},
 		{"Message", valueOrNA(result.Message)},
 		{"Team ID", valueOrNA(result.TeamID)},
 		{"Plan", valueOrNA(result.Plan.Name)},
 		{"Usage", usageBar},
-		{"Used %", fmt.Sprintf("%d%%", result.Plan.UsedPercent)},
+		{"Used %", usedPercent},
 		{"Used", fmt.Sprintf("%d", result.Plan.Used)},
 		{"Available", fmt.Sprintf("%d", result.Plan.Available)},
 		{"Total", fmt.Sprintf("%d", result.Plan.Total)},
-		{"Thresholds", fmt.Sprintf("warn=%d%% critical=%d%%", result.Thresholds.WarnAt, result.Thresholds.CriticalAt)},
-		{"Reset Date", valueOrNA(result.Plan.ResetDate)},
-		{"Reset Date Time", valueOrNA(result.Plan.ResetDateTime)},
-		{"Manage URL", valueOrNA(result.Plan.ManageURL)},
-		{"Evaluated At", valueOrNA(result.EvaluatedAt)},
+		{"Thresholds", formatUsageAlertThresholds(result.Thresholds)},
+	}
+	if result.Growth != nil {
+		rows = append(rows, []string{"Growth", formatUsageAlertGrowth(result.Growth)})
+	}
+	return append(rows,
+		[]string{"Reset Date", valueOrNA(result.Plan.ResetDate)},
+		[]string{"Reset Date Time", valueOrNA(result.Plan.ResetDateTime)},
+		[]string{"Manage URL", valueOrNA(result.Plan.ManageURL)},
+		[]string{"Evaluated At", valueOrNA(result.EvaluatedAt)},
+	)
+}
+
+func ciUsageAlertTrendHeaders(showDelta bool) []string {
+	headers := []string{"Year", "Month", "Minutes"}
+	if showDelta {
+		headers = append(headers, "Minutes Change")
 	}
+	return append(headers, "Builds", "Usage Bar (Plan)")
 }
 
-func buildCIUsageAlertTrendRows(trend *CIUsageAlertTrend, planTotal int) [][]string {
+func buildCIUsageAlertTrendRows(trend *CIUsageAlertTrend, planTotal int, showDelta bool) [][]string {
 	if trend == nil {
 		return nil
 	}
 	rows := make([][]string, 0, len(trend.Months))
-	for _, month := range trend.Months {
-		rows = append(rows, []string{
+	for i, month := range trend.Months {
+		row := []string{
 			fmt.Sprintf("%d", month.Year),
 			fmt.Sprintf("%02d", month.Month),
 			fmt.Sprintf("%d", month.Minutes),
+		}
+		if showDelta {
+			delta := "n/a"
+			if i > 0 {
+				delta = formatUsageMinutesDelta(month.Minutes - trend.Months[i-1].Minutes)
+			}
+			row = append(row, delta)
+		}
+		rows = append(rows, append(row,
 			fmt.Sprintf("%d", month.Builds),
 			formatUsageBarWithValues(month.Minutes, planTotal),
-		})
+		))
 	}
 	return rows
 }
@@ -787,6 +1325,8 @@ func buildCIUsageAlertNotificationRows(notifications []CIUsageAlertNotification)
 		statusCode := "n/a"
 		if notification.StatusCode > 0 {
 			statusCode = fmt.Sprintf("%d", notification.StatusCode)
+		} else if notification.Suppressed {
+			statusCode = "suppressed"
 		}
 		rows = append(rows, []string{
 			valueOrNA(notification.Channel),
@@ -0,0 +1,448 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+var (
+	listWebProvidersFn  = webcore.ListProviders
+	switchWebProviderFn = webcore.SwitchProvider
+)
+
+func webXcodeCloudUsageAlertAllCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage alert-all", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	teamIDs := fs.String("team-ids", "", "Comma-separated team (public provider) IDs to evaluate (default: every team available to the account)")
+	warnAt := fs.Int("warn-at", 80, "Warning threshold percent (1-99)")
+	criticalAt := fs.Int("critical-at", 95, "Critical threshold percent (1-100)")
+	failOn := fs.String("fail-on", string(usageAlertFailOnCritical), "Exit non-zero when the worst severity reaches: none, warning, critical")
+	notifyOn := fs.String("notify-on", string(usageAlertNotifyOnWarning), "Include teams in the notification when severity reaches: none, warning, critical, always")
+	slackWebhook := fs.String("slack-webhook", "", "Slack incoming webhook URL (optional, or set ASC_SLACK_WEBHOOK)")
+	slackWebhookFile := fs.String("slack-webhook-file", "", "Path to a file containing the Slack webhook URL (optional)")
+	webhook := fs.String("webhook", "", "Generic webhook URL for JSON alert payloads (optional)")
+	webhookFile := fs.String("webhook-file", "", "Path to a file containing the generic webhook URL (optional)")
+	onlyBreaching := fs.Bool("only-breaching", false, "Only output and notify teams whose severity meets --fail-on (the exit code still covers every team)")
+	redactTeam := bindRedactTeamFlag(fs)
+	percentFormat := bindUsagePercentFormatFlags(fs)
+
+	var webhookHeaders usageAlertRepeatedFlag
+	fs.Var(&webhookHeaders, "webhook-header", "Header for --webhook in 'Key: Value' format (repeatable)")
+
+	return &ffcli.Command{
+		Name:       "alert-all",
+		ShortUsage: "asc web xcode-cloud usage alert-all [--team-ids ID,ID] [flags]",
+		ShortHelp:  "EXPERIMENTAL: Evaluate usage thresholds across several teams.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Evaluate Xcode Cloud usage thresholds for every team available to the signed-in
+account, or for the teams passed with --team-ids, in one pass. JSON output is an
+array with one usage alert result per team. Usage is only reported for the web
+session's current team, so the session is switched to each team in turn and
+back to its original team afterwards.
+
+Exit behavior:
+  - Exit 0 when no team breaches, or when --fail-on none
+  - Exit 1 when the worst severity across teams meets --fail-on
+  - Exit 2 for invalid flag usage
+  - Exit 6 when a notification fails and no team breaches
+  - Exit 7 when a team breaches and a notification fails
+
+Notifications are sent once per run and summarize every team whose severity
+meets --notify-on, instead of one message per team. When a notification
+fails, a final stderr line summarizes every channel, e.g.
+"notifications: slack delivered, webhook failed (503)".
+
+With --only-breaching, the output and notifications are limited to teams whose
+severity meets --fail-on; healthy teams are still evaluated and still count
+toward the exit code.
+
+Use --percent-precision and --percent-rounding floor to show used percents with
+decimals or truncated, e.g. 99.9% instead of a rounded 100%. Thresholds are
+always compared using exact ratios, and JSON used_percent stays an integer.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage alert-all --apple-id "user@example.com"
+  asc web xcode-cloud usage alert-all --team-ids "TEAM-A,TEAM-B" --fail-on warning --output table
+  asc web xcode-cloud usage alert-all --slack-webhook-file /run/secrets/slack-webhook --notify-on critical
+  asc web xcode-cloud usage alert-all --only-breaching --fail-on warning --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if err := validateUsageAlertThresholds(*warnAt, *criticalAt); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			restorePercentFormat, err := percentFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restorePercentFormat()
+			failOnLevel, err := parseUsageAlertFailOn(*failOn)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			notifyOnLevel, err := parseUsageAlertNotifyOn(*notifyOn)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if *onlyBreaching && failOnLevel == usageAlertFailOnNone {
+				fmt.Fprintln(os.Stderr, "Error: --only-breaching requires --fail-on warning or critical")
+				return flag.ErrHelp
+			}
+			slackWebhookValue, err := resolveUsageAlertSlackWebhook(*slackWebhook, *slackWebhookFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			normalizedSlackWebhook, err := resolveUsageAlertWebhookURL(slackWebhookValue)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --slack-webhook %s\n", err)
+				return flag.ErrHelp
+			}
+			webhookValue, err := resolveUsageAlertWebhookSource(*webhook, *webhookFile, "--webhook-file", "")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			normalizedWebhookURL, err := resolveUsageAlertWebhookURL(webhookValue)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --webhook %s\n", err)
+				return flag.ErrHelp
+			}
+			parsedHeaders, err := parseUsageAlertHeaders(webhookHeaders)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			if session != nil && session.Reauthenticate != nil {
+				// Signing in again lands on the account's default team while the
+				// loop below switches teams on this session, so a session
+				// rejected mid-loop fails the remaining teams instead of
+				// reporting the default team's usage under their IDs.
+				withoutReauth := *session
+				withoutReauth.Reauthenticate = nil
+				session = &withoutReauth
+			}
+
+			client := newCIClientFn(session)
+			var (
+				results  []*CIUsageAlertResult
+				failures []error
+			)
+			err = withWebSpinner("Loading Xcode Cloud usage alert data", func() error {
+				providers, unavailable, err := resolveUsageAlertProviders(requestCtx, session, shared.SplitUniqueCSV(*teamIDs))
+				if err != nil {
+					return err
+				}
+				failures = append(failures, unavailable...)
+				original := webcore.Provider{ProviderID: session.ProviderID, PublicProviderID: strings.TrimSpace(session.PublicProviderID)}
+				newTeamRedactor(*redactTeam, original.PublicProviderID).redactEnvelopeTeam()
+				defer func() {
+					if err := switchUsageAlertProvider(requestCtx, session, original); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: could not switch the web session back to team %s: %v\n", original.PublicProviderID, err)
+					}
+				}()
+				for _, provider := range providers {
+					if err := switchUsageAlertProvider(requestCtx, session, provider); err != nil {
+						failures = append(failures, fmt.Errorf("team %s: %w", provider.PublicProviderID, err))
+						continue
+					}
+					summary, err := client.GetCIUsageSummary(requestCtx, provider.PublicProviderID)
+					if err != nil {
+						failures = append(failures, fmt.Errorf("team %s: %w", provider.PublicProviderID, err))
+						continue
+					}
+					result := buildCIUsageAlertResult(provider.PublicProviderID, summary, *warnAt, *criticalAt, failOnLevel, notifyOnLevel)
+					result.TeamName = provider.Name
+					redactUsageAlertTeam(result, newTeamRedactor(*redactTeam, provider.PublicProviderID))
+					results = append(results, result)
+				}
+				if len(results) == 0 && len(failures) > 0 {
+					return errors.Join(failures...)
+				}
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud usage alert-all")
+			}
+
+			reported := results
+			if *onlyBreaching {
+				reported = filterBreachingUsageAlerts(results, failOnLevel)
+			}
+
+			notifyErr := error(nil)
+			if strings.TrimSpace(normalizedSlackWebhook) != "" || strings.TrimSpace(normalizedWebhookURL) != "" {
+				notifyErr = withWebSpinner("Sending usage alert notifications", func() error {
+					return deliverUsageAlertSummaryNotifications(
+						requestCtx,
+						reported,
+						normalizedSlackWebhook,
+						normalizedWebhookURL,
+						parsedHeaders,
+						notifyOnLevel,
+					)
+				})
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				reported,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIUsageAlertAllTable(reported) },
+				func() error { return renderCIUsageAlertAllMarkdown(reported) },
+			); err != nil {
+				return err
+			}
+
+			var resultErr error
+			if len(failures) > 0 {
+				resultErr = fmt.Errorf("xcode-cloud usage alert-all failed for %d team(s): %w", len(failures), errors.Join(failures...))
+			}
+			if notifyErr != nil {
+				fmt.Fprintln(os.Stderr, formatUsageAlertNotificationSummary(usageAlertAllNotifications(reported)))
+				resultErr = errors.Join(resultErr, fmt.Errorf("xcode-cloud usage alert-all notification failed: %w", notifyErr))
+			}
+			worst := worstUsageAlertSeverity(results)
+			breached := shouldFailUsageAlert(worst, failOnLevel)
+			if breached {
+				resultErr = errors.Join(
+					resultErr,
+					fmt.Errorf("xcode-cloud usage alert-all threshold breach: worst severity %s (%s)", worst, formatUsageAlertBreaches(results, failOnLevel)),
+				)
+			}
+			return usageAlertExitError(resultErr, breached, notifyErr != nil)
+		},
+	}
+}
+
+// resolveUsageAlertProviders returns the teams to evaluate: every provider on
+// the account, falling back to the session's own team, narrowed to teamIDs when
+// given. Requested IDs the account cannot access are returned as failures.
+func resolveUsageAlertProviders(ctx context.Context, session *webcore.AuthSession, teamIDs []string) ([]webcore.Provider, []error, error) {
+	providers, err := listWebProvidersFn(ctx, session)
+	if err != nil {
+		return nil, nil, fmt.Errorf("xcode-cloud usage alert-all failed: could not list teams: %w", err)
+	}
+	if len(providers) == 0 {
+		teamID := strings.TrimSpace(session.PublicProviderID)
+		if teamID == "" {
+			return nil, nil, fmt.Errorf("xcode-cloud usage alert-all failed: session has no public provider ID")
+		}
+		providers = []webcore.Provider{{ProviderID: session.ProviderID, PublicProviderID: teamID}}
+	}
+	if len(teamIDs) == 0 {
+		return providers, nil, nil
+	}
+
+	selected := make([]webcore.Provider, 0, len(teamIDs))
+	var unavailable []error
+	for _, id := range teamIDs {
+		found := false
+		for _, provider := range providers {
+			if strings.EqualFold(provider.PublicProviderID, id) {
+				selected = append(selected, provider)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unavailable = append(unavailable, fmt.Errorf("team %s: not available to the signed-in account", id))
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil, fmt.Errorf("xcode-cloud usage alert-all failed: %w", errors.Join(unavailable...))
+	}
+	return selected, unavailable, nil
+}
+
+// switchUsageAlertProvider makes provider the session's current team unless it
+// already is.
+func switchUsageAlertProvider(ctx context.Context, session *webcore.AuthSession, provider webcore.Provider) error {
+	if strings.EqualFold(strings.TrimSpace(session.PublicProviderID), provider.PublicProviderID) {
+		return nil
+	}
+	if provider.ProviderID == 0 {
+		return fmt.Errorf("cannot switch the web session to this team: provider ID unknown")
+	}
+	return switchWebProviderFn(ctx, session, provider.ProviderID)
+}
+
+func worstUsageAlertSeverity(results []*CIUsageAlertResult) usageAlertSeverity {
+	worst := usageAlertSeverityUnknown
+	for _, result := range results {
+		if usageAlertSeverityRank(result.Severity) > usageAlertSeverityRank(worst) {
+			worst = result.Severity
+		}
+	}
+	return worst
+}
+
+// filterBreachingUsageAlerts keeps the results whose severity meets failOn.
+// The result is never nil so JSON output stays an array.
+func filterBreachingUsageAlerts(results []*CIUsageAlertResult, failOn usageAlertFailOn) []*CIUsageAlertResult {
+	breaching := make([]*CIUsageAlertResult, 0, len(results))
+	for _, result := range results {
+		if shouldFailUsageAlert(result.Severity, failOn) {
+			breaching = append(breaching, result)
+		}
+	}
+	return breaching
+}
+
+// formatUsageAlertBreaches lists the teams whose severity meets failOn.
+func formatUsageAlertBreaches(results []*CIUsageAlertResult, failOn usageAlertFailOn) string {
+	breaches := make([]string, 0, len(results))
+	for _, result := range results {
+		if shouldFailUsageAlert(result.Severity, failOn) {
+			breaches = append(breaches, fmt.Sprintf("%s %s %s", usageAlertTeamLabel(result), result.Severity, formatUsagePercent(result.Plan.Used, result.Plan.Total)))
+		}
+	}
+	return strings.Join(breaches, ", ")
+}
+
+func usageAlertTeamLabel(result *CIUsageAlertResult) string {
+	if name := strings.TrimSpace(result.TeamName); name != "" {
+		return fmt.Sprintf("%s (%s)", name, result.TeamID)
+	}
+	return result.TeamID
+}
+
+// usageAlertSummaryText is the combined notification message for alert-all.
+func usageAlertSummaryText(notified []*CIUsageAlertResult) string {
+	lines := []string{fmt.Sprintf("Xcode Cloud usage alert: %d team(s) need attention", len(notified))}
+	for _, result := range notified {
+		lines = append(lines, fmt.Sprintf(
+			"- %s: %s at %s (%d/%dm), reset %s",
+			usageAlertTeamLabel(result),
+			result.Severity,
+			formatUsagePercent(result.Plan.Used, result.Plan.Total),
+			result.Plan.Used,
+			result.Plan.Total,
+			valueOrNA(result.Plan.ResetDate),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deliverUsageAlertSummaryNotifications sends one message covering every team
+// whose severity meets notifyOn, and records the delivery on those results.
+func deliverUsageAlertSummaryNotifications(
+	ctx context.Context,
+	results []*CIUsageAlertResult,
+	slackWebhook, webhookURL string,
+	webhookHeaders http.Header,
+	notifyOn usageAlertNotifyOn,
+) error {
+	var notified []*CIUsageAlertResult
+	for _, result := range results {
+		if shouldNotifyUsageAlert(result.Severity, notifyOn) {
+			notified = append(notified, result)
+		}
+	}
+	if len(notified) == 0 {
+		return nil
+	}
+
+	text := usageAlertSummaryText(notified)
+	var notifyErr error
+	send := func(channel string, post func() (int, error)) {
+		statusCode, err := post()
+		delivery := CIUsageAlertNotification{
+			Channel:    channel,
+			Triggered:  true,
+			StatusCode: statusCode,
+			Delivered:  err == nil,
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+			notifyErr = errors.Join(notifyErr, err)
+		}
+		for _, result := range notified {
+			result.Notifications = append(result.Notifications, delivery)
+		}
+	}
+
+	if strings.TrimSpace(slackWebhook) != "" {
+		send("slack", func() (int, error) {
+			return postUsageAlertJSON(ctx, slackWebhook, nil, map[string]any{"text": text})
+		})
+	}
+	if strings.TrimSpace(webhookURL) != "" {
+		send("webhook", func() (int, error) {
+			return postUsageAlertJSON(ctx, webhookURL, webhookHeaders, map[string]any{
+				"event":   "xcode_cloud_usage_alert_all",
+				"message": text,
+				"results": notified,
+			})
+		})
+	}
+	return notifyErr
+}
+
+// usageAlertAllNotifications gathers the deliveries recorded on the results.
+// Every notified team shares the same deliveries, so duplicates are expected.
+func usageAlertAllNotifications(results []*CIUsageAlertResult) []CIUsageAlertNotification {
+	var notifications []CIUsageAlertNotification
+	for _, result := range results {
+		notifications = append(notifications, result.Notifications...)
+	}
+	return notifications
+}
+
+func renderCIUsageAlertAllTable(results []*CIUsageAlertResult) error {
+	asc.RenderTable(ciUsageAlertAllHeaders(), buildCIUsageAlertAllRows(results))
+	return nil
+}
+
+func renderCIUsageAlertAllMarkdown(results []*CIUsageAlertResult) error {
+	asc.RenderMarkdown(ciUsageAlertAllHeaders(), buildCIUsageAlertAllRows(results))
+	return nil
+}
+
+func ciUsageAlertAllHeaders() []string {
+	return []string{"Team", "Team ID", "Severity", "Used %", "Used", "Total", "Reset Date"}
+}
+
+func buildCIUsageAlertAllRows(results []*CIUsageAlertResult) [][]string {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		rows = append(rows, []string{
+			valueOrNA(result.TeamName),
+			valueOrNA(result.TeamID),
+			string(result.Severity),
+			formatUsagePercent(result.Plan.Used, result.Plan.Total),
+			fmt.Sprintf("%d", result.Plan.Used),
+			fmt.Sprintf("%d", result.Plan.Total),
+			valueOrNA(result.Plan.ResetDate),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,429 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// stubUsageAlertAllSession serves summaries only for the session's current
+// team, like the real endpoint. Teams TEAM-A, TEAM-B, ... get provider IDs 1,
+// 2, ... and the session starts on TEAM-A. The returned slice records every
+// provider switch.
+func stubUsageAlertAllSession(t *testing.T, summaries map[string]*webcore.CIUsageSummary) *[]string {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origListProviders := listWebProvidersFn
+	origSwitchProvider := switchWebProviderFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		listWebProvidersFn = origListProviders
+		switchWebProviderFn = origSwitchProvider
+	})
+
+	providers := []webcore.Provider{}
+	for _, suffix := range "ABCDEFGH" {
+		teamID := "TEAM-" + string(suffix)
+		if _, ok := summaries[teamID]; ok {
+			providers = append(providers, webcore.Provider{ProviderID: int64(suffix-'A') + 1, PublicProviderID: teamID})
+		}
+	}
+	listWebProvidersFn = func(ctx context.Context, session *webcore.AuthSession) ([]webcore.Provider, error) {
+		return providers, nil
+	}
+	var switches []string
+	switchWebProviderFn = func(ctx context.Context, session *webcore.AuthSession, providerID int64) error {
+		teamID := fmt.Sprintf("TEAM-%c", 'A'+rune(providerID-1))
+		switches = append(switches, teamID)
+		session.ProviderID = providerID
+		session.PublicProviderID = teamID
+		return nil
+	}
+	resolveSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
+		session := &webcore.AuthSession{ProviderID: 1, PublicProviderID: "TEAM-A"}
+		session.Client = &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				teamID := session.PublicProviderID
+				if summary, ok := summaries[teamID]; ok && strings.Contains(req.URL.Path, "/teams/"+teamID+"/usage/summary") {
+					return usageAlertJSONResponse(t, http.StatusOK, summary), nil
+				}
+				return usageAlertJSONResponse(t, http.StatusNotFound, map[string]any{"error": "not found"}), nil
+			}),
+		}
+		return session, "", nil
+	}
+	return &switches
+}
+
+func TestWebXcodeCloudUsageAlertAllEvaluatesEveryProvider(t *testing.T) {
+	switches := stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 200, Total: 1000}},
+		"TEAM-B": {Plan: webcore.CIUsagePlan{Used: 960, Total: 1000, ResetDate: "2026-03-01"}},
+	})
+	listWebProvidersFn = func(ctx context.Context, session *webcore.AuthSession) ([]webcore.Provider, error) {
+		return []webcore.Provider{
+			{ProviderID: 1, PublicProviderID: "TEAM-A", Name: "Agency"},
+			{ProviderID: 2, PublicProviderID: "TEAM-B", Name: "Client"},
+		}, nil
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "worst severity critical (Client (TEAM-B) critical 96%)") {
+		t.Fatalf("expected critical breach error, got %v", runErr)
+	}
+
+	var results []CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &results); err != nil {
+		t.Fatalf("expected JSON array output, got error %v: %s", err, stdout)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected two results, got %d", len(results))
+	}
+	if results[0].TeamID != "TEAM-A" || results[0].TeamName != "Agency" || results[0].Severity != usageAlertSeverityOK {
+		t.Fatalf("unexpected first result: %+v", results[0])
+	}
+	if results[1].TeamID != "TEAM-B" || results[1].Severity != usageAlertSeverityCritical {
+		t.Fatalf("unexpected second result: %+v", results[1])
+	}
+	if strings.Join(*switches, ",") != "TEAM-B,TEAM-A" {
+		t.Fatalf("expected a switch to TEAM-B and back to TEAM-A, got %v", *switches)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllDoesNotReauthenticateMidLoop(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 200, Total: 1000}},
+		"TEAM-B": {Plan: webcore.CIUsagePlan{Used: 960, Total: 1000}},
+	})
+	origLoginSession := loginSessionFn
+	origIsTerminal := termIsTerminalFn
+	t.Cleanup(func() {
+		loginSessionFn = origLoginSession
+		termIsTerminalFn = origIsTerminal
+	})
+	t.Setenv(webPasswordEnv, "secret")
+	termIsTerminalFn = func(int) bool { return true }
+
+	// The cached session is rejected once it has switched to TEAM-B; a fresh
+	// login would land on the default team and answer for any team ID.
+	stubbedResolve := resolveSessionFn
+	resolveSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
+		session, _, err := stubbedResolve(ctx, appleID, password, twoFactorCode)
+		if err != nil {
+			return nil, "", err
+		}
+		served := session.Client.Transport
+		session.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, "/teams/TEAM-B/") {
+				return usageAlertJSONResponse(t, http.StatusUnauthorized, map[string]any{"error": "expired"}), nil
+			}
+			return served.RoundTrip(req)
+		})}
+		return session, "cache", nil
+	}
+	loginCalls := 0
+	loginSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, error) {
+		loginCalls++
+		return &webcore.AuthSession{
+			ProviderID:       1,
+			PublicProviderID: "TEAM-A",
+			Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 200, Total: 1000}}), nil
+			})},
+		}, nil
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if loginCalls != 0 {
+		t.Fatalf("expected no re-authentication mid-loop, got %d logins", loginCalls)
+	}
+	if runErr == nil || !strings.Contains(runErr.Error(), "team TEAM-B") {
+		t.Fatalf("expected TEAM-B to fail, got %v", runErr)
+	}
+	var results []CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &results); err != nil {
+		t.Fatalf("expected JSON array output, got error %v: %s", err, stdout)
+	}
+	if len(results) != 1 || results[0].TeamID != "TEAM-A" {
+		t.Fatalf("expected only TEAM-A to be reported, got %+v", results)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllRedactsTeamName(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 200, Total: 1000}},
+	})
+	listWebProvidersFn = func(ctx context.Context, session *webcore.AuthSession) ([]webcore.Provider, error) {
+		return []webcore.Provider{{ProviderID: 1, PublicProviderID: "TEAM-A", Name: "Agency"}}, nil
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--redact-team", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "Agency") || strings.Contains(stdout, "TEAM-A") {
+		t.Fatalf("expected team name and ID to be redacted, got:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, teamPseudonym("TEAM-A")) {
+		t.Fatalf("expected team pseudonym in output, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllReportsUnavailableTeam(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 200, Total: 1000}},
+	})
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--team-ids", "TEAM-A,TEAM-Z", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "team TEAM-Z: not available to the signed-in account") {
+		t.Fatalf("expected unavailable team error, got %v", runErr)
+	}
+	var results []CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &results); err != nil || len(results) != 1 {
+		t.Fatalf("expected the available team to still be reported, got %v: %s", err, stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllSendsOneSummaryNotification(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 850, Total: 1000}},
+		"TEAM-B": {Plan: webcore.CIUsagePlan{Used: 960, Total: 1000}},
+		"TEAM-C": {Plan: webcore.CIUsagePlan{Used: 100, Total: 1000}},
+	})
+	origHTTPClient := usageAlertHTTPClientFn
+	t.Cleanup(func() { usageAlertHTTPClientFn = origHTTPClient })
+	var bodies []string
+	usageAlertHTTPClientFn = func() *http.Client {
+		return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}, nil
+		})}
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--team-ids", "TEAM-A,TEAM-B,TEAM-C",
+		"--fail-on", "none",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected one summary notification, got %d", len(bodies))
+	}
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal([]byte(bodies[0]), &payload); err != nil {
+		t.Fatalf("invalid slack payload: %v", err)
+	}
+	for _, want := range []string{"2 team(s) need attention", "TEAM-A: warning at 85%", "TEAM-B: critical at 96%"} {
+		if !strings.Contains(payload.Text, want) {
+			t.Fatalf("expected %q in summary, got %q", want, payload.Text)
+		}
+	}
+	if strings.Contains(payload.Text, "TEAM-C") {
+		t.Fatalf("expected healthy team to be left out of the summary, got %q", payload.Text)
+	}
+
+	var results []CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &results); err != nil {
+		t.Fatalf("expected JSON array output, got error %v", err)
+	}
+	if len(results[0].Notifications) != 1 || !results[0].Notifications[0].Delivered || len(results[2].Notifications) != 0 {
+		t.Fatalf("unexpected notification records: %+v", results)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllOnlyBreaching(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 850, Total: 1000}},
+		"TEAM-B": {Plan: webcore.CIUsagePlan{Used: 960, Total: 1000}},
+		"TEAM-C": {Plan: webcore.CIUsagePlan{Used: 100, Total: 1000}},
+	})
+	origHTTPClient := usageAlertHTTPClientFn
+	t.Cleanup(func() { usageAlertHTTPClientFn = origHTTPClient })
+	var bodies []string
+	usageAlertHTTPClientFn = func() *http.Client {
+		return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}, nil
+		})}
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--team-ids", "TEAM-A,TEAM-B,TEAM-C",
+		"--fail-on", "critical",
+		"--notify-on", "always",
+		"--only-breaching",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "worst severity critical") {
+		t.Fatalf("expected critical breach error, got %v", runErr)
+	}
+
+	var results []CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &results); err != nil {
+		t.Fatalf("expected JSON array output, got error %v: %s", err, stdout)
+	}
+	if len(results) != 1 || results[0].TeamID != "TEAM-B" {
+		t.Fatalf("expected only the critical team, got %+v", results)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected one summary notification, got %d", len(bodies))
+	}
+	if !strings.Contains(bodies[0], "1 team(s) need attention") || strings.Contains(bodies[0], "TEAM-A") || strings.Contains(bodies[0], "TEAM-C") {
+		t.Fatalf("expected notification to cover only TEAM-B, got %s", bodies[0])
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllOnlyBreachingEmptyOutput(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 850, Total: 1000}},
+	})
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--team-ids", "TEAM-A",
+		"--only-breaching",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	if got := strings.TrimSpace(stdout); got != "[]" {
+		t.Fatalf("expected empty JSON array, got %q", got)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllOnlyBreachingRejectsFailOnNone(t *testing.T) {
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--only-breaching", "--fail-on", "none"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if !errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "--only-breaching requires --fail-on") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAllNotificationFailureExitCode(t *testing.T) {
+	stubUsageAlertAllSession(t, map[string]*webcore.CIUsageSummary{
+		"TEAM-A": {Plan: webcore.CIUsagePlan{Used: 850, Total: 1000}},
+	})
+	origHTTPClient := usageAlertHTTPClientFn
+	t.Cleanup(func() { usageAlertHTTPClientFn = origHTTPClient })
+	usageAlertHTTPClientFn = func() *http.Client {
+		return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			status := http.StatusOK
+			if req.URL.Host == "example.com" {
+				status = http.StatusServiceUnavailable
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader("busy")), Header: http.Header{}}, nil
+		})}
+	}
+
+	cmd := webXcodeCloudUsageAlertAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--team-ids", "TEAM-A",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+		"--webhook", "https://example.com/hook",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	coder, ok := errors.AsType[shared.ExitCoder](runErr)
+	if !ok || coder.ExitCode() != shared.ExitNotificationFailed {
+		t.Fatalf("expected notification failure exit code, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "notifications: slack delivered, webhook failed (503)") {
+		t.Fatalf("expected notification summary on stderr, got %q", stderr)
+	}
+}
+
+func TestWorstUsageAlertSeverity(t *testing.T) {
+	results := []*CIUsageAlertResult{
+		{Severity: usageAlertSeverityOK},
+		{Severity: usageAlertSeverityUnknown},
+		{Severity: usageAlertSeverityWarning},
+	}
+	if got := worstUsageAlertSeverity(results); got != usageAlertSeverityWarning {
+		t.Fatalf("worstUsageAlertSeverity() = %q, want warning", got)
+	}
+	if got := worstUsageAlertSeverity(nil); got != usageAlertSeverityUnknown {
+		t.Fatalf("worstUsageAlertSeverity(nil) = %q, want unknown", got)
+	}
+}
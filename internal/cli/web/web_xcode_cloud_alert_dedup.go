@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// usageAlertDedupState is the --dedup-file payload: for each notification
+// channel, the last severity it delivered and the plan reset period it was
+// delivered in. Channels are tracked separately so a channel that failed is
+// retried on the next run even when another one delivered.
+type usageAlertDedupState struct {
+	Channels map[string]usageAlertDedupEntry `json:"channels"`
+}
+
+type usageAlertDedupEntry struct {
+	Severity   usageAlertSeverity `json:"severity"`
+	Period     string             `json:"period"`
+	NotifiedAt string             `json:"notified_at"`
+}
+
+// usageAlertDedupPeriod identifies the plan reset period of a result. A new
+// reset date starts a new period and clears earlier suppression; without a
+// reset date there is no period to deduplicate within.
+func usageAlertDedupPeriod(result *CIUsageAlertResult) string {
+	if period := strings.TrimSpace(result.Plan.ResetDate); period != "" {
+		return period
+	}
+	return strings.TrimSpace(result.Plan.ResetDateTime)
+}
+
+// readUsageAlertDedupState returns nil when the file does not exist yet.
+func readUsageAlertDedupState(path string) (*usageAlertDedupState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("--dedup-file: failed to read %q: %w", path, err)
+	}
+	var state usageAlertDedupState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("--dedup-file: invalid state in %q: %w", path, err)
+	}
+	return &state, nil
+}
+
+func writeUsageAlertDedupState(path string, state *usageAlertDedupState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("--dedup-file: failed to encode state: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("--dedup-file: failed to create %q: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("--dedup-file: failed to write %q: %w", path, err)
+	}
+	return nil
+}
+
+// usageAlertDedupSuppressed returns the channels that already delivered a
+// notification for result in the same reset period at the same or a higher
+// severity.
+func usageAlertDedupSuppressed(state *usageAlertDedupState, result *CIUsageAlertResult) map[string]bool {
+	suppressed := map[string]bool{}
+	period := usageAlertDedupPeriod(result)
+	if state == nil || period == "" {
+		return suppressed
+	}
+	for channel, entry := range state.Channels {
+		if entry.Period == period && usageAlertSeverityRank(result.Severity) <= usageAlertSeverityRank(entry.Severity) {
+			suppressed[channel] = true
+		}
+	}
+	return suppressed
+}
+
+// recordUsageAlertDeliveries advances the state of every channel that
+// accepted the notification and reports whether anything changed. Channels
+// that failed or were not triggered keep their previous entry.
+func recordUsageAlertDeliveries(state *usageAlertDedupState, result *CIUsageAlertResult) (*usageAlertDedupState, bool) {
+	period := usageAlertDedupPeriod(result)
+	if period == "" {
+		return state, false
+	}
+	next := &usageAlertDedupState{Channels: map[string]usageAlertDedupEntry{}}
+	if state != nil {
+		for channel, entry := range state.Channels {
+			next.Channels[channel] = entry
+		}
+	}
+	changed := false
+	for _, notification := range result.Notifications {
+		if !notification.Delivered {
+			continue
+		}
+		next.Channels[notification.Channel] = usageAlertDedupEntry{
+			Severity:   result.Severity,
+			Period:     period,
+			NotifiedAt: webNowFn().UTC().Format(time.RFC3339),
+		}
+		changed = true
+	}
+	return next, changed
+}
@@ -0,0 +1,237 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestUsageAlertDedupSuppressed(t *testing.T) {
+	result := func(severity usageAlertSeverity, reset string) *CIUsageAlertResult {
+		return &CIUsageAlertResult{Severity: severity, Plan: CIUsageAlertPlan{ResetDate: reset}}
+	}
+	state := &usageAlertDedupState{Channels: map[string]usageAlertDedupEntry{
+		"slack": {Severity: usageAlertSeverityWarning, Period: "2026-03-01"},
+	}}
+
+	tests := []struct {
+		name   string
+		state  *usageAlertDedupState
+		result *CIUsageAlertResult
+		want   bool
+	}{
+		{name: "no state", state: nil, result: result(usageAlertSeverityWarning, "2026-03-01"), want: false},
+		{name: "same severity and period", state: state, result: result(usageAlertSeverityWarning, "2026-03-01"), want: true},
+		{name: "lower severity", state: state, result: result(usageAlertSeverityOK, "2026-03-01"), want: true},
+		{name: "escalation", state: state, result: result(usageAlertSeverityCritical, "2026-03-01"), want: false},
+		{name: "new reset period", state: state, result: result(usageAlertSeverityWarning, "2026-04-01"), want: false},
+		{name: "no reset date", state: &usageAlertDedupState{Channels: map[string]usageAlertDedupEntry{
+			"slack": {Severity: usageAlertSeverityWarning},
+		}}, result: result(usageAlertSeverityWarning, ""), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suppressed := usageAlertDedupSuppressed(tt.state, tt.result)
+			if suppressed["slack"] != tt.want {
+				t.Fatalf("slack suppressed = %v, want %v", suppressed["slack"], tt.want)
+			}
+			if suppressed["webhook"] {
+				t.Fatal("expected a channel without state never to be suppressed")
+			}
+		})
+	}
+}
+
+func TestRecordUsageAlertDeliveriesKeepsFailedChannels(t *testing.T) {
+	previous := &usageAlertDedupState{Channels: map[string]usageAlertDedupEntry{
+		"webhook": {Severity: usageAlertSeverityOK, Period: "2026-03-01"},
+	}}
+	result := &CIUsageAlertResult{
+		Severity: usageAlertSeverityWarning,
+		Plan:     CIUsageAlertPlan{ResetDate: "2026-03-01"},
+		Notifications: []CIUsageAlertNotification{
+			{Channel: "slack", Triggered: true, Delivered: true},
+			{Channel: "webhook", Triggered: true, StatusCode: http.StatusServiceUnavailable},
+		},
+	}
+
+	state, changed := recordUsageAlertDeliveries(previous, result)
+	if !changed {
+		t.Fatal("expected the slack delivery to change the state")
+	}
+	if state.Channels["slack"].Severity != usageAlertSeverityWarning {
+		t.Fatalf("expected slack to advance to warning, got %+v", state.Channels["slack"])
+	}
+	if state.Channels["webhook"].Severity != usageAlertSeverityOK {
+		t.Fatalf("expected the failed webhook to keep its entry, got %+v", state.Channels["webhook"])
+	}
+
+	result.Plan.ResetDate = ""
+	if _, changed := recordUsageAlertDeliveries(previous, result); changed {
+		t.Fatal("expected no state without a reset date")
+	}
+}
+
+func TestReadUsageAlertDedupState(t *testing.T) {
+	dir := t.TempDir()
+	state, err := readUsageAlertDedupState(filepath.Join(dir, "missing.json"))
+	if err != nil || state != nil {
+		t.Fatalf("expected nil state for missing file, got %+v, %v", state, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o600); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	if _, err := readUsageAlertDedupState(invalid); err == nil || !strings.Contains(err.Error(), "--dedup-file: invalid state") {
+		t.Fatalf("expected invalid state error, got %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertDedupFileSuppressesRepeats(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origSendSlack := sendUsageAlertSlackFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		sendUsageAlertSlackFn = origSendSlack
+		webNowFn = origWebNow
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+
+	slackCalls := 0
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+		slackCalls++
+		return http.StatusOK, nil
+	}
+
+	dedupPath := filepath.Join(t.TempDir(), "state", "usage-alert.json")
+	run := func(used int, resetDate string) CIUsageAlertResult {
+		t.Helper()
+		resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+			Plan: webcore.CIUsagePlan{Used: used, Available: 1000 - used, Total: 1000, ResetDate: resetDate},
+		}, nil)
+		cmd := webXcodeCloudUsageAlertCommand()
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--trend-months", "0",
+			"--fail-on", "none",
+			"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+			"--dedup-file", dedupPath,
+			"--output", "json",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		var result CIUsageAlertResult
+		if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &result); err != nil {
+			t.Fatalf("expected valid json output, got error %v", err)
+		}
+		return result
+	}
+
+	run(850, "2026-03-01")
+	if slackCalls != 1 {
+		t.Fatalf("expected first warning to notify, got %d calls", slackCalls)
+	}
+
+	repeat := run(860, "2026-03-01")
+	if slackCalls != 1 {
+		t.Fatalf("expected repeated warning to be suppressed, got %d calls", slackCalls)
+	}
+	if len(repeat.Notifications) != 1 || !repeat.Notifications[0].Suppressed || repeat.Notifications[0].Triggered {
+		t.Fatalf("expected suppressed notification, got %+v", repeat.Notifications)
+	}
+
+	run(990, "2026-03-01")
+	if slackCalls != 2 {
+		t.Fatalf("expected escalation to critical to notify, got %d calls", slackCalls)
+	}
+
+	run(850, "2026-04-01")
+	if slackCalls != 3 {
+		t.Fatalf("expected new reset period to notify, got %d calls", slackCalls)
+	}
+
+	state, err := readUsageAlertDedupState(dedupPath)
+	if err != nil {
+		t.Fatalf("read state error: %v", err)
+	}
+	if state == nil || state.Channels["slack"].Severity != usageAlertSeverityWarning || state.Channels["slack"].Period != "2026-04-01" {
+		t.Fatalf("unexpected dedup state: %+v", state)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertDedupFileRetriesFailedChannel(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origSendSlack := sendUsageAlertSlackFn
+	origSendWebhook := sendUsageAlertWebhookFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		sendUsageAlertSlackFn = origSendSlack
+		sendUsageAlertWebhookFn = origSendWebhook
+		webNowFn = origWebNow
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 850, Available: 150, Total: 1000, ResetDate: "2026-03-01"},
+	}, nil)
+
+	slackCalls, webhookCalls := 0, 0
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+		slackCalls++
+		return http.StatusOK, nil
+	}
+	webhookStatus := http.StatusServiceUnavailable
+	sendUsageAlertWebhookFn = func(ctx context.Context, webhookURL string, headers http.Header, result *CIUsageAlertResult) (int, error) {
+		webhookCalls++
+		if webhookStatus != http.StatusOK {
+			return webhookStatus, errors.New("webhook unavailable")
+		}
+		return webhookStatus, nil
+	}
+
+	dedupPath := filepath.Join(t.TempDir(), "usage-alert.json")
+	run := func() {
+		t.Helper()
+		cmd := webXcodeCloudUsageAlertCommand()
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--trend-months", "0",
+			"--fail-on", "none",
+			"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+			"--webhook", "https://example.com/hook",
+			"--dedup-file", dedupPath,
+			"--output", "json",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, _ = captureOutput(t, func() {
+			_ = cmd.Exec(context.Background(), nil)
+		})
+	}
+
+	run()
+	webhookStatus = http.StatusOK
+	run()
+	if slackCalls != 1 || webhookCalls != 2 {
+		t.Fatalf("expected slack once and the failed webhook retried, got slack=%d webhook=%d", slackCalls, webhookCalls)
+	}
+	run()
+	if slackCalls != 1 || webhookCalls != 2 {
+		t.Fatalf("expected both channels suppressed after delivering, got slack=%d webhook=%d", slackCalls, webhookCalls)
+	}
+}
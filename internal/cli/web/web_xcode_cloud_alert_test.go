@@ -7,10 +7,13 @@ import (
 	"flag"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
@@ -107,6 +110,70 @@ func TestWebXcodeCloudUsageAlertRejectsInvalidWebhookHeader(t *testing.T) {
 	}
 }
 
+func TestResolveUsageAlertSlackWebhookPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "slack-webhook")
+	if err := os.WriteFile(filePath, []byte("  https://hooks.slack.com/services/file\n\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	t.Setenv(usageAlertSlackWebhookEnv, "https://hooks.slack.com/services/env")
+
+	got, err := resolveUsageAlertSlackWebhook("https://hooks.slack.com/services/flag", filePath)
+	if err != nil || got != "https://hooks.slack.com/services/flag" {
+		t.Fatalf("expected flag value to win, got %q (err=%v)", got, err)
+	}
+	got, err = resolveUsageAlertSlackWebhook("", filePath)
+	if err != nil || got != "https://hooks.slack.com/services/file" {
+		t.Fatalf("expected trimmed file value, got %q (err=%v)", got, err)
+	}
+	got, err = resolveUsageAlertSlackWebhook("", "")
+	if err != nil || got != "https://hooks.slack.com/services/env" {
+		t.Fatalf("expected env value, got %q (err=%v)", got, err)
+	}
+}
+
+func TestResolveUsageAlertWebhookSourceFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	emptyPath := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyPath, []byte(" \n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if _, err := resolveUsageAlertWebhookSource("", emptyPath, "--webhook-file", ""); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Fatalf("expected empty file error, got %v", err)
+	}
+	missingPath := filepath.Join(dir, "missing")
+	if _, err := resolveUsageAlertWebhookSource("", missingPath, "--webhook-file", ""); err == nil || !strings.Contains(err.Error(), "--webhook-file: failed to read") {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertRejectsInvalidWebhookFileURL(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "webhook")
+	if err := os.WriteFile(filePath, []byte("ftp://example.com/alert\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--webhook-file", filePath,
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if !errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "--webhook must use http or https scheme") {
+		t.Fatalf("expected webhook scheme error, got %q", stderr)
+	}
+}
+
 func TestWebXcodeCloudUsageAlertReturnsThresholdErrorWithJSONOutput(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	origWebNow := webNowFn
@@ -156,11 +223,158 @@ func TestWebXcodeCloudUsageAlertReturnsThresholdErrorWithJSONOutput(t *testing.T
 	if result.Severity != usageAlertSeverityWarning {
 		t.Fatalf("expected warning severity, got %q", result.Severity)
 	}
+	if result.SeverityLevel != 2 {
+		t.Fatalf("expected severity_level 2, got %d", result.SeverityLevel)
+	}
+	if !strings.Contains(stdout, `"severity_level":2`) {
+		t.Fatalf("expected severity_level in json output, got %q", stdout)
+	}
 	if result.Plan.UsedPercent != 92 {
 		t.Fatalf("expected used percent 92, got %d", result.Plan.UsedPercent)
 	}
 }
 
+func TestWebXcodeCloudUsageAlertPercentOnlyPreservesExitSemantics(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+	})
+
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+	summary := &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Name: "Starter", Used: 920, Available: 80, Total: 1000},
+	}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--fail-on", "warning",
+		"--output", "table",
+		"--percent-only",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "threshold breach") {
+		t.Fatalf("expected threshold breach error, got %v", runErr)
+	}
+	if stdout != "92\n" {
+		t.Fatalf("expected only the percent on stdout, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertPercentOnlyWithoutPlanTotal(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+	})
+
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+	summary := &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Name: "Starter", Used: 300},
+	}
+	months := &webcore.CIUsageMonths{
+		Info: webcore.CIUsageInfo{
+			Current:  webcore.CIUsageInfoCurrent{Used: 300},
+			Previous: webcore.CIUsageInfoCurrent{Used: 100},
+		},
+	}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, months)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--growth-critical", "150",
+		"--percent-only",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "threshold breach") {
+		t.Fatalf("expected the growth breach to keep its exit code, got %v", runErr)
+	}
+	if stdout != "n/a\n" {
+		t.Fatalf("expected n/a on stdout, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertLogfmtPreservesExitSemantics(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+	})
+
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+	summary := &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Name: "Starter", Used: 960, Available: 40, Total: 1000},
+	}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--output", "table",
+		"--log-format", "logfmt",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "threshold breach") {
+		t.Fatalf("expected threshold breach error, got %v", runErr)
+	}
+	if stdout != "severity=critical used=960 total=1000 percent=96 team=TEAM-123\n" {
+		t.Fatalf("unexpected logfmt output: %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertLogfmtRejectsQuiet(t *testing.T) {
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--quiet", "--log-format", "logfmt"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--log-format cannot be used with --quiet or --percent-only") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestPrintUsagePercentOnlyPrintsNAForUnavailableTotal(t *testing.T) {
+	stdout, _ := captureOutput(t, func() {
+		if err := printUsagePercentOnly(10, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "n/a\n" {
+		t.Fatalf("expected n/a, got %q", stdout)
+	}
+}
+
 func TestWebXcodeCloudUsageAlertUsesExactThresholdRatios(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	origWebNow := webNowFn
@@ -236,7 +450,7 @@ func TestWebXcodeCloudUsageAlertSendsSlackOnCritical(t *testing.T) {
 	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
 
 	slackCalls := 0
-	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult) (int, error) {
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
 		slackCalls++
 		if webhookURL != "https://hooks.slack.com/services/T/B/KEY" {
 			t.Fatalf("unexpected slack webhook url %q", webhookURL)
@@ -282,6 +496,87 @@ func TestWebXcodeCloudUsageAlertSendsSlackOnCritical(t *testing.T) {
 	}
 }
 
+func TestWebXcodeCloudUsageAlertSummarizesPartialNotificationFailure(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origSendSlack := sendUsageAlertSlackFn
+	origSendWebhook := sendUsageAlertWebhookFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		sendUsageAlertSlackFn = origSendSlack
+		sendUsageAlertWebhookFn = origSendWebhook
+		webNowFn = origWebNow
+	})
+
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 28, 10, 0, 0, 0, time.UTC) }
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+		return http.StatusOK, nil
+	}
+	sendUsageAlertWebhookFn = func(ctx context.Context, webhookURL string, headers http.Header, result *CIUsageAlertResult) (int, error) {
+		return http.StatusServiceUnavailable, errors.New("notification endpoint returned status 503 (busy)")
+	}
+
+	tests := []struct {
+		name     string
+		used     int
+		wantCode int
+	}{
+		{name: "no breach", used: 850, wantCode: shared.ExitNotificationFailed},
+		{name: "breach", used: 980, wantCode: shared.ExitBreachNotificationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: tt.used, Total: 1000}}
+			resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
+
+			cmd := webXcodeCloudUsageAlertCommand()
+			if err := cmd.FlagSet.Parse([]string{
+				"--apple-id", "user@example.com",
+				"--trend-months", "0",
+				"--notify-on", "warning",
+				"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+				"--webhook", "https://example.com/hook",
+				"--output", "json",
+			}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			var runErr error
+			_, stderr := captureOutput(t, func() {
+				runErr = cmd.Exec(context.Background(), nil)
+			})
+			coder, ok := errors.AsType[shared.ExitCoder](runErr)
+			if !ok || coder.ExitCode() != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %v", tt.wantCode, runErr)
+			}
+			if !strings.Contains(stderr, "notifications: slack delivered, webhook failed (503)") {
+				t.Fatalf("expected notification summary on stderr, got %q", stderr)
+			}
+		})
+	}
+}
+
+func TestFormatUsageAlertNotificationSummary(t *testing.T) {
+	got := formatUsageAlertNotificationSummary([]CIUsageAlertNotification{
+		{Channel: "slack", Triggered: true, Delivered: true},
+		{Channel: "webhook", Triggered: true, Error: "dial tcp: refused"},
+		{Channel: "webhook", Triggered: true, Delivered: true},
+	})
+	if want := "notifications: slack delivered, webhook failed"; got != want {
+		t.Fatalf("formatUsageAlertNotificationSummary() = %q, want %q", got, want)
+	}
+	got = formatUsageAlertNotificationSummary([]CIUsageAlertNotification{
+		{Channel: "slack", Triggered: false},
+		{Channel: "webhook", Suppressed: true},
+	})
+	if want := "notifications: slack skipped, webhook suppressed"; got != want {
+		t.Fatalf("formatUsageAlertNotificationSummary() = %q, want %q", got, want)
+	}
+	if got := formatUsageAlertNotificationSummary(nil); got != "notifications: none sent" {
+		t.Fatalf("formatUsageAlertNotificationSummary(nil) = %q", got)
+	}
+}
+
 func TestWebXcodeCloudUsageAlertDoesNotNotifyBelowLevel(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	origSendSlack := sendUsageAlertSlackFn
@@ -305,7 +600,7 @@ func TestWebXcodeCloudUsageAlertDoesNotNotifyBelowLevel(t *testing.T) {
 	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
 
 	slackCalls := 0
-	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult) (int, error) {
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
 		slackCalls++
 		return http.StatusOK, nil
 	}
@@ -404,6 +699,39 @@ func TestWebXcodeCloudUsageAlertLoadsMonthlyTrend(t *testing.T) {
 	}
 }
 
+func TestUsageAlertRenderersHandleUnavailablePlanTotal(t *testing.T) {
+	result := &CIUsageAlertResult{
+		Severity: usageAlertSeverityUnknown,
+		Plan:     CIUsageAlertPlan{Name: "Plan", Used: 120},
+		Trend: &CIUsageAlertTrend{
+			RequestedMonths: 1,
+			Available:       true,
+			Months:          []CIUsageAlertMonth{{Year: 2026, Month: 2, Minutes: 120, Builds: 4}},
+		},
+	}
+
+	overview := buildCIUsageAlertOverviewRows(result, false)
+	values := map[string]string{}
+	for _, row := range overview {
+		values[row[0]] = row[1]
+	}
+	if values["Usage"] != "[................] n/a" {
+		t.Fatalf("expected n/a usage bar, got %q", values["Usage"])
+	}
+	if values["Used %"] != "n/a" {
+		t.Fatalf("expected n/a used percent, got %q", values["Used %"])
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := renderCIUsageAlertTable(result, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "/0m") {
+		t.Fatalf("expected no /0m suffix in alert table, got %q", stdout)
+	}
+}
+
 func TestUsageAlertMonthWindowAnchorsToMonthBoundaries(t *testing.T) {
 	startMonth, startYear, endMonth, endYear := usageAlertMonthWindow(
 		time.Date(2026, time.March, 31, 20, 15, 0, 0, time.UTC),
@@ -548,3 +876,521 @@ func usageAlertJSONResponse(t *testing.T, status int, payload any) *http.Respons
 		Body:       io.NopCloser(strings.NewReader(string(body))),
 	}
 }
+
+func TestSendUsageAlertToSlackPayloadFormats(t *testing.T) {
+	origHTTPClient := usageAlertHTTPClientFn
+	t.Cleanup(func() { usageAlertHTTPClientFn = origHTTPClient })
+
+	var captured map[string]any
+	usageAlertHTTPClientFn = func() *http.Client {
+		return &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				captured = nil
+				if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("ok")),
+					Request:    req,
+				}, nil
+			}),
+		}
+	}
+
+	result := &CIUsageAlertResult{
+		TeamID:   "team-uuid",
+		Severity: usageAlertSeverityWarning,
+		Message:  "Usage at 85%",
+		Plan: CIUsageAlertPlan{
+			Used:          850,
+			Total:         1000,
+			UsedPercent:   85,
+			ResetDateTime: "2026-03-01T00:00:00Z",
+			ManageURL:     "https://developer.apple.com/xcode-cloud/",
+		},
+		Thresholds: CIUsageAlertThresholds{WarnAt: 80, CriticalAt: 95},
+	}
+
+	t.Run("attachments by default", func(t *testing.T) {
+		if _, err := sendUsageAlertToSlack(context.Background(), "https://hooks.slack.com/services/T/B/KEY", result, false); err != nil {
+			t.Fatalf("send error: %v", err)
+		}
+		text, _ := captured["text"].(string)
+		if !strings.Contains(text, "Xcode Cloud usage alert: warning") {
+			t.Fatalf("expected plaintext fallback, got %q", text)
+		}
+		attachments, ok := captured["attachments"].([]any)
+		if !ok || len(attachments) != 1 {
+			t.Fatalf("expected one attachment, got %#v", captured["attachments"])
+		}
+		attachment := attachments[0].(map[string]any)
+		if attachment["color"] != "warning" {
+			t.Fatalf("expected warning color, got %v", attachment["color"])
+		}
+		raw, _ := json.Marshal(attachment)
+		for _, token := range []string{`"type":"header"`, `*Used*\n850m`, `*Total*\n1000m`, `*Percent*\n85%`, `*Reset*\n2026-03-01T00:00:00Z`, `Manage plan`} {
+			if !strings.Contains(string(raw), token) {
+				t.Fatalf("expected attachment to include %q, got %s", token, raw)
+			}
+		}
+	})
+
+	t.Run("plain text with --slack-plain", func(t *testing.T) {
+		if _, err := sendUsageAlertToSlack(context.Background(), "https://hooks.slack.com/services/T/B/KEY", result, true); err != nil {
+			t.Fatalf("send error: %v", err)
+		}
+		if _, ok := captured["attachments"]; ok {
+			t.Fatalf("expected no attachments in plain payload, got %#v", captured)
+		}
+		if text, _ := captured["text"].(string); !strings.Contains(text, "used=850/1000m") {
+			t.Fatalf("expected plain text payload, got %q", text)
+		}
+	})
+}
+
+func TestParseUsageAlertAnnotations(t *testing.T) {
+	got, err := parseUsageAlertAnnotations([]string{"build=1234", " run_url = https://ci.example.com/runs/42?a=b ", "build=1235", ""})
+	if err != nil {
+		t.Fatalf("parseUsageAlertAnnotations() error: %v", err)
+	}
+	if len(got) != 2 || got["build"] != "1235" || got["run_url"] != "https://ci.example.com/runs/42?a=b" {
+		t.Fatalf("unexpected annotations %v", got)
+	}
+	if got, err := parseUsageAlertAnnotations(nil); err != nil || got != nil {
+		t.Fatalf("expected nil annotations, got %v, %v", got, err)
+	}
+	for _, value := range []string{"build", "=1234"} {
+		if _, err := parseUsageAlertAnnotations([]string{value}); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageAlertAnnotationsInResultAndNotifications(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origHTTPClient := usageAlertHTTPClientFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		usageAlertHTTPClientFn = origHTTPClient
+	})
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 960, Total: 1000},
+	}, nil)
+	bodies := map[string]string{}
+	usageAlertHTTPClientFn = func() *http.Client {
+		return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies[req.URL.Host] = string(body)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}, nil
+		})}
+	}
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--fail-on", "none",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+		"--webhook", "https://example.com/hook",
+		"--annotation", "pipeline=nightly",
+		"--annotation", "build=1234",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	var result CIUsageAlertResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\n%s", err, stdout)
+	}
+	if result.Annotations["build"] != "1234" || result.Annotations["pipeline"] != "nightly" {
+		t.Fatalf("expected annotations in result, got %v", result.Annotations)
+	}
+
+	var webhookPayload struct {
+		Annotations map[string]string  `json:"annotations"`
+		Result      CIUsageAlertResult `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(bodies["example.com"]), &webhookPayload); err != nil {
+		t.Fatalf("failed to parse webhook payload: %v", err)
+	}
+	if webhookPayload.Annotations["build"] != "1234" || webhookPayload.Result.Annotations["pipeline"] != "nightly" {
+		t.Fatalf("expected annotations in webhook payload, got %s", bodies["example.com"])
+	}
+
+	slackBody := bodies["hooks.slack.com"]
+	if !strings.Contains(slackBody, "critical=95%, build=1234, pipeline=nightly)") {
+		t.Fatalf("expected annotations in slack text, got %s", slackBody)
+	}
+	if !strings.Contains(slackBody, `*Annotations*\nbuild=1234\npipeline=nightly`) {
+		t.Fatalf("expected annotations slack field, got %s", slackBody)
+	}
+}
+
+func TestUsageAlertSlackColor(t *testing.T) {
+	tests := map[usageAlertSeverity]string{
+		usageAlertSeverityOK:       "good",
+		usageAlertSeverityWarning:  "warning",
+		usageAlertSeverityCritical: "danger",
+		usageAlertSeverityUnknown:  "#808080",
+	}
+	for severity, want := range tests {
+		if got := usageAlertSlackColor(severity); got != want {
+			t.Fatalf("usageAlertSlackColor(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageAlertStrictFailsWhenTrendUnavailable(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	summary := &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Name: "Starter", Used: 100, Available: 900, Total: 1000},
+	}
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if strings.Contains(req.URL.Path, "/usage/summary") {
+						return usageAlertJSONResponse(t, http.StatusOK, summary), nil
+					}
+					return usageAlertJSONResponse(t, http.StatusInternalServerError, map[string]any{"error": "unavailable"}), nil
+				}),
+			},
+		}, "", nil
+	}
+
+	for _, strict := range []bool{false, true} {
+		cmd := webXcodeCloudUsageAlertCommand()
+		args := []string{
+			"--apple-id", "user@example.com",
+			"--fail-on", "none",
+			"--trend-months", "2",
+			"--output", "json",
+		}
+		if strict {
+			args = append(args, "--strict")
+		}
+		if err := cmd.FlagSet.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+
+		var runErr error
+		stdout, _ := captureOutput(t, func() {
+			runErr = cmd.Exec(context.Background(), nil)
+		})
+		if !strict {
+			if runErr != nil {
+				t.Fatalf("expected lenient mode to degrade, got %v", runErr)
+			}
+			var result CIUsageAlertResult
+			if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &result); err != nil {
+				t.Fatalf("expected valid json output, got error %v", err)
+			}
+			if result.Trend == nil || result.Trend.Available {
+				t.Fatalf("expected unavailable trend, got %+v", result.Trend)
+			}
+			continue
+		}
+		if runErr == nil || !strings.Contains(runErr.Error(), "monthly trend unavailable (--strict)") {
+			t.Fatalf("expected --strict trend error, got %v", runErr)
+		}
+	}
+}
+
+func TestUsageAlertSeverityLevels(t *testing.T) {
+	levels := map[usageAlertSeverity]int{
+		usageAlertSeverityUnknown:  0,
+		usageAlertSeverityOK:       1,
+		usageAlertSeverityWarning:  2,
+		usageAlertSeverityCritical: 3,
+	}
+	for severity, want := range levels {
+		if got := usageAlertSeverityRank(severity); got != want {
+			t.Fatalf("expected %s to map to level %d, got %d", severity, want, got)
+		}
+	}
+}
+
+func TestBuildUsageAlertGrowth(t *testing.T) {
+	tests := []struct {
+		name         string
+		previous     int
+		current      int
+		wantPercent  *int
+		wantSeverity usageAlertSeverity
+	}{
+		{name: "below thresholds", previous: 400, current: 500, wantPercent: intPtr(25), wantSeverity: usageAlertSeverityOK},
+		{name: "warning", previous: 400, current: 600, wantPercent: intPtr(50), wantSeverity: usageAlertSeverityWarning},
+		{name: "critical", previous: 300, current: 900, wantPercent: intPtr(200), wantSeverity: usageAlertSeverityCritical},
+		{name: "decline", previous: 400, current: 100, wantPercent: intPtr(-75), wantSeverity: usageAlertSeverityOK},
+		{name: "no previous usage", previous: 0, current: 100, wantSeverity: usageAlertSeverityUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth := buildUsageAlertGrowth(tt.previous, tt.current, 50, 100)
+			if growth.Severity != tt.wantSeverity {
+				t.Fatalf("severity = %q, want %q", growth.Severity, tt.wantSeverity)
+			}
+			if tt.wantPercent == nil {
+				if growth.Percent != nil || growth.Available {
+					t.Fatalf("expected unavailable growth, got %+v", growth)
+				}
+				return
+			}
+			if growth.Percent == nil || *growth.Percent != *tt.wantPercent {
+				t.Fatalf("percent = %v, want %d", growth.Percent, *tt.wantPercent)
+			}
+		})
+	}
+}
+
+func intPtr(value int) *int { return &value }
+
+func TestValidateUsageAlertGrowthThresholds(t *testing.T) {
+	tests := []struct {
+		warnAt, criticalAt int
+		wantErr            string
+	}{
+		{warnAt: 0, criticalAt: 0},
+		{warnAt: 50, criticalAt: 0},
+		{warnAt: 0, criticalAt: 150},
+		{warnAt: 50, criticalAt: 150},
+		{warnAt: -1, criticalAt: 0, wantErr: "--growth-warn must be 0 or greater"},
+		{warnAt: 0, criticalAt: -5, wantErr: "--growth-critical must be 0 or greater"},
+		{warnAt: 100, criticalAt: 100, wantErr: "--growth-warn must be less than --growth-critical"},
+	}
+	for _, tt := range tests {
+		err := validateUsageAlertGrowthThresholds(tt.warnAt, tt.criticalAt)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Fatalf("validateUsageAlertGrowthThresholds(%d, %d) unexpected error: %v", tt.warnAt, tt.criticalAt, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Fatalf("validateUsageAlertGrowthThresholds(%d, %d) error = %v, want %q", tt.warnAt, tt.criticalAt, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageAlertGrowthEscalatesSeverity(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+	})
+
+	webNowFn = func() time.Time { return time.Date(2026, time.February, 20, 10, 0, 0, 0, time.UTC) }
+	summary := &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 300, Available: 700, Total: 1000, ResetDate: "2026-03-01"},
+	}
+	months := &webcore.CIUsageMonths{
+		Info: webcore.CIUsageInfo{
+			Current:  webcore.CIUsageInfoCurrent{Used: 300},
+			Previous: webcore.CIUsageInfoCurrent{Used: 100},
+		},
+	}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, months)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--growth-warn", "50",
+		"--growth-critical", "150",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "threshold breach") {
+		t.Fatalf("expected threshold breach error, got %v", runErr)
+	}
+
+	var result CIUsageAlertResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &result); err != nil {
+		t.Fatalf("expected valid json output, got error %v", err)
+	}
+	if result.Severity != usageAlertSeverityCritical {
+		t.Fatalf("expected growth to escalate severity to critical, got %q", result.Severity)
+	}
+	if result.SeverityLevel != 3 {
+		t.Fatalf("expected escalated severity_level 3, got %d", result.SeverityLevel)
+	}
+	if result.Growth == nil || result.Growth.Previous != 100 || result.Growth.Current != 300 ||
+		result.Growth.Percent == nil || *result.Growth.Percent != 200 {
+		t.Fatalf("unexpected growth payload: %+v", result.Growth)
+	}
+	if result.Thresholds.GrowthWarnAt != 50 || result.Thresholds.GrowthCriticalAt != 150 {
+		t.Fatalf("unexpected thresholds: %+v", result.Thresholds)
+	}
+	if !strings.Contains(result.Message, "growth: +200%") {
+		t.Fatalf("expected growth in message, got %q", result.Message)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertOmitsGrowthByDefault(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 300, Total: 1000},
+	}, nil)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--trend-months", "0", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, `"growth"`) {
+		t.Fatalf("expected no growth object without growth thresholds, got %s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertQuietPrintsStatusLineOnly(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origSendSlack := sendUsageAlertSlackFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		sendUsageAlertSlackFn = origSendSlack
+	})
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 960, Available: 40, Total: 1000, ResetDate: "2026-03-01"},
+	}, nil)
+	slackCalls := 0
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+		slackCalls++
+		return http.StatusOK, nil
+	}
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/KEY",
+		"--quiet",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "threshold breach") {
+		t.Fatalf("expected threshold breach error, got %v", runErr)
+	}
+	if _, ok := errors.AsType[shared.ReportedError](runErr); !ok {
+		t.Fatalf("expected breach to be marked as reported, got %T", runErr)
+	}
+	if stdout != "" {
+		t.Fatalf("expected no stdout with --quiet, got %q", stdout)
+	}
+	if strings.TrimSpace(stderr) != "xcode-cloud usage critical 96% (960/1000m)" {
+		t.Fatalf("unexpected quiet status line: %q", stderr)
+	}
+	if slackCalls != 1 {
+		t.Fatalf("expected notifications to still fire, got %d calls", slackCalls)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertQuietRejectsPercentOnly(t *testing.T) {
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{"--quiet", "--percent-only"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--quiet and --percent-only are mutually exclusive") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestFormatUsageAlertQuietLineWithoutPlanTotal(t *testing.T) {
+	got := formatUsageAlertQuietLine(&CIUsageAlertResult{Severity: usageAlertSeverityUnknown})
+	if got != "xcode-cloud usage unknown (plan total unavailable)" {
+		t.Fatalf("unexpected quiet line: %q", got)
+	}
+}
+
+func TestBuildCIUsageAlertTrendRowsShowDelta(t *testing.T) {
+	trend := &CIUsageAlertTrend{Months: []CIUsageAlertMonth{
+		{Year: 2025, Month: 12, Minutes: 300, Builds: 10},
+		{Year: 2026, Month: 1, Minutes: 420, Builds: 12},
+		{Year: 2026, Month: 2, Minutes: 380, Builds: 11},
+		{Year: 2026, Month: 3, Minutes: 380, Builds: 9},
+	}}
+
+	if rows := buildCIUsageAlertTrendRows(trend, 1000, false); len(rows[0]) != len(ciUsageAlertTrendHeaders(false)) || len(rows[0]) != 5 {
+		t.Fatalf("expected default trend rows without delta, got %v", rows[0])
+	}
+	rows := buildCIUsageAlertTrendRows(trend, 1000, true)
+	if len(rows[0]) != len(ciUsageAlertTrendHeaders(true)) {
+		t.Fatalf("row width %d does not match headers %v", len(rows[0]), ciUsageAlertTrendHeaders(true))
+	}
+	var deltas []string
+	for _, row := range rows {
+		deltas = append(deltas, row[3])
+	}
+	if strings.Join(deltas, ",") != "n/a,+120,-40,0" {
+		t.Fatalf("unexpected deltas: %v", deltas)
+	}
+}
+
+func TestWebXcodeCloudUsageAlertPercentPrecisionAffectsDisplayOnly(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 9996, Available: 4, Total: 10000, ResetDate: "2026-03-01"},
+	}, nil)
+
+	cmd := webXcodeCloudUsageAlertCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--trend-months", "0",
+		"--fail-on", "none",
+		"--percent-precision", "1",
+		"--percent-rounding", "floor",
+		"--quiet",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if strings.TrimSpace(stderr) != "xcode-cloud usage critical 99.9% (9996/10000m)" {
+		t.Fatalf("unexpected quiet status line: %q", stderr)
+	}
+	if usageNumberFormat != (usageNumberFormatOptions{}) {
+		t.Fatalf("expected percent format to be restored, got %+v", usageNumberFormat)
+	}
+}
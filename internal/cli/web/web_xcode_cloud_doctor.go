@@ -0,0 +1,223 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const defaultDoctorProbeTimeout = 15 * time.Second
+
+const (
+	doctorCheckPass = "pass"
+	doctorCheckFail = "fail"
+	doctorCheckSkip = "skip"
+)
+
+// CIDoctorResult is the output type for the xcode-cloud doctor command.
+type CIDoctorResult struct {
+	Healthy bool            `json:"healthy"`
+	Checks  []CIDoctorCheck `json:"checks"`
+}
+
+// CIDoctorCheck is one step of the doctor checklist. Hint is set for failed
+// steps and says how to fix them.
+type CIDoctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+func webXcodeCloudDoctorCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud doctor", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	probeTimeout := fs.Duration("probe-timeout", defaultDoctorProbeTimeout, "Timeout for the Xcode Cloud API probe")
+
+	return &ffcli.Command{
+		Name:       "doctor",
+		ShortUsage: "asc web xcode-cloud doctor [flags]",
+		ShortHelp:  "EXPERIMENTAL: Check that the web session can reach Xcode Cloud.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Run a short self-test of the pieces every xcode-cloud command depends on and
+report each step as pass, fail, or skip:
+
+  session    a cached or fresh web session can be resolved
+  team       the session has a team (public provider ID)
+  ci-access  the Xcode Cloud usage summary endpoint answers within --probe-timeout
+
+Every failing step includes a remediation hint, such as logging in again when
+the session expired or asking an Admin for Xcode Cloud access. Steps after a
+failure are skipped. Exits 1 when any step fails.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud doctor
+  asc web xcode-cloud doctor --apple-id "user@example.com" --output table
+  asc web xcode-cloud doctor --probe-timeout 5s`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if *probeTimeout <= 0 {
+				fmt.Fprintln(os.Stderr, "Error: --probe-timeout must be greater than zero")
+				return flag.ErrHelp
+			}
+
+			result := runCIDoctor(ctx, sessionFlags, *probeTimeout)
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIDoctorTable(result) },
+				func() error { return renderCIDoctorMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			if !result.Healthy {
+				failed := 0
+				for _, check := range result.Checks {
+					if check.Status == doctorCheckFail {
+						failed++
+					}
+				}
+				return shared.NewReportedError(fmt.Errorf("xcode-cloud doctor: %d check(s) failed", failed))
+			}
+			return nil
+		},
+	}
+}
+
+// runCIDoctor runs the checklist in order; once a step fails the remaining
+// steps are reported as skipped.
+func runCIDoctor(ctx context.Context, sessionFlags webSessionFlags, probeTimeout time.Duration) *CIDoctorResult {
+	result := &CIDoctorResult{}
+	skipRest := func(names ...string) *CIDoctorResult {
+		for _, name := range names {
+			result.Checks = append(result.Checks, CIDoctorCheck{Name: name, Status: doctorCheckSkip, Detail: "skipped after an earlier failure"})
+		}
+		return result
+	}
+
+	requestCtx, cancel := shared.ContextWithTimeout(ctx)
+	defer cancel()
+
+	session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+	if err == nil && session == nil {
+		err = errors.New("no web session was returned")
+	}
+	if err != nil {
+		result.Checks = append(result.Checks, CIDoctorCheck{
+			Name:   "session",
+			Status: doctorCheckFail,
+			Detail: doctorErrorDetail(err),
+			Hint:   doctorSessionHint(err),
+		})
+		return skipRest("team", "ci-access")
+	}
+	sessionDetail := "web session resolved"
+	if email := strings.TrimSpace(session.UserEmail); email != "" {
+		sessionDetail = "web session resolved for " + email
+	}
+	result.Checks = append(result.Checks, CIDoctorCheck{Name: "session", Status: doctorCheckPass, Detail: sessionDetail})
+
+	teamID := strings.TrimSpace(session.PublicProviderID)
+	if teamID == "" {
+		result.Checks = append(result.Checks, CIDoctorCheck{
+			Name:   "team",
+			Status: doctorCheckFail,
+			Detail: "session has no public provider ID",
+			Hint:   "log in again with 'asc web auth login'; if it persists, ask an Admin to add your Apple Account to an App Store Connect team",
+		})
+		return skipRest("ci-access")
+	}
+	result.Checks = append(result.Checks, CIDoctorCheck{Name: "team", Status: doctorCheckPass, Detail: "team " + teamID})
+
+	probeCtx, probeCancel := context.WithTimeout(requestCtx, probeTimeout)
+	defer probeCancel()
+	summary, err := withWebSpinnerValue("Probing Xcode Cloud", func() (*webcore.CIUsageSummary, error) {
+		return newCIClientFn(session).GetCIUsageSummary(probeCtx, teamID)
+	})
+	if err != nil {
+		result.Checks = append(result.Checks, CIDoctorCheck{
+			Name:   "ci-access",
+			Status: doctorCheckFail,
+			Detail: doctorErrorDetail(err),
+			Hint:   doctorCIAccessHint(err, probeTimeout),
+		})
+		return result
+	}
+	detail := "usage summary reachable"
+	if summary != nil && summary.Plan.Total > 0 {
+		detail = fmt.Sprintf("usage summary reachable (%d/%d minutes used)", summary.Plan.Used, summary.Plan.Total)
+	}
+	result.Checks = append(result.Checks, CIDoctorCheck{Name: "ci-access", Status: doctorCheckPass, Detail: detail})
+	result.Healthy = true
+	return result
+}
+
+func doctorErrorDetail(err error) string {
+	if errors.Is(err, flag.ErrHelp) {
+		return "no cached web session and no Apple ID to log in with"
+	}
+	return err.Error()
+}
+
+func doctorSessionHint(err error) string {
+	if errors.Is(err, flag.ErrHelp) {
+		return "run 'asc web auth login --apple-id EMAIL', or pass --apple-id / set ASC_APPLE_ID"
+	}
+	return "run 'asc web auth login' to refresh the web session"
+}
+
+func doctorCIAccessHint(err error, probeTimeout time.Duration) string {
+	var apiErr *webcore.APIError
+	var nonJSONErr *webcore.NonJSONResponseError
+	switch {
+	case errors.Is(err, webcore.ErrNoCIAccess):
+		return "this team does not appear to have Xcode Cloud enabled or your role lacks access; ask an Admin to enable Xcode Cloud or grant access"
+	case errors.As(err, &apiErr) && (apiErr.Status == 401 || apiErr.Status == 403):
+		return "the web session is unauthorized or expired; run 'asc web auth login'"
+	case errors.As(err, &nonJSONErr):
+		return "received a sign-in page instead of JSON, so the web session likely expired; run 'asc web auth login'"
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Sprintf("no response within %s; check your network or proxy, or raise --probe-timeout", probeTimeout)
+	default:
+		return "check your network connection and ASC_CI_BASE_URL, then retry"
+	}
+}
+
+func ciDoctorHeaders() []string {
+	return []string{"Check", "Status", "Detail", "Hint"}
+}
+
+func buildCIDoctorRows(result *CIDoctorResult) [][]string {
+	rows := make([][]string, 0, len(result.Checks))
+	for _, check := range result.Checks {
+		rows = append(rows, []string{check.Name, check.Status, valueOrNA(check.Detail), valueOrNA(check.Hint)})
+	}
+	return rows
+}
+
+func renderCIDoctorTable(result *CIDoctorResult) error {
+	asc.RenderTable(ciDoctorHeaders(), buildCIDoctorRows(result))
+	return nil
+}
+
+func renderCIDoctorMarkdown(result *CIDoctorResult) error {
+	asc.RenderMarkdown(ciDoctorHeaders(), buildCIDoctorRows(result))
+	return nil
+}
@@ -0,0 +1,160 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubDoctorSession(t *testing.T, providerID string, status int, body string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origLoginSession := loginSessionFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		loginSessionFn = origLoginSession
+	})
+	// A rejected cached session signs in again; keep that from prompting.
+	loginSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, error) {
+		return nil, errors.New("password is required")
+	}
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			UserEmail:        "user@example.com",
+			PublicProviderID: providerID,
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func runDoctorCommand(t *testing.T, args ...string) (*CIDoctorResult, error) {
+	t.Helper()
+	cmd := webXcodeCloudDoctorCommand()
+	if err := cmd.FlagSet.Parse(args); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	var result CIDoctorResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v\noutput: %q", err, stdout)
+	}
+	return &result, runErr
+}
+
+func doctorStatuses(result *CIDoctorResult) string {
+	statuses := make([]string, 0, len(result.Checks))
+	for _, check := range result.Checks {
+		statuses = append(statuses, check.Name+"="+check.Status)
+	}
+	return strings.Join(statuses, ",")
+}
+
+func TestWebXcodeCloudDoctorAllPass(t *testing.T) {
+	stubDoctorSession(t, "team-uuid", http.StatusOK, `{"plan":{"used":120,"available":880,"total":1000}}`)
+
+	result, err := runDoctorCommand(t)
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if !result.Healthy {
+		t.Fatalf("expected healthy result, got %+v", result)
+	}
+	if got := doctorStatuses(result); got != "session=pass,team=pass,ci-access=pass" {
+		t.Fatalf("unexpected checklist %q", got)
+	}
+	if !strings.Contains(result.Checks[2].Detail, "120/1000") {
+		t.Fatalf("expected usage in ci-access detail, got %q", result.Checks[2].Detail)
+	}
+}
+
+func TestWebXcodeCloudDoctorMissingTeamSkipsProbe(t *testing.T) {
+	stubDoctorSession(t, "", http.StatusOK, `{}`)
+
+	result, err := runDoctorCommand(t)
+	if _, ok := errors.AsType[shared.ReportedError](err); !ok {
+		t.Fatalf("expected reported error, got %v", err)
+	}
+	if got := doctorStatuses(result); got != "session=pass,team=fail,ci-access=skip" {
+		t.Fatalf("unexpected checklist %q", got)
+	}
+	if result.Healthy || result.Checks[1].Hint == "" {
+		t.Fatalf("expected failing team step with a hint, got %+v", result.Checks[1])
+	}
+}
+
+func TestWebXcodeCloudDoctorCIAccessHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantHint string
+	}{
+		{name: "no ci access", status: http.StatusForbidden, wantHint: "enable Xcode Cloud"},
+		{name: "expired", status: http.StatusUnauthorized, wantHint: "asc web auth login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubDoctorSession(t, "team-uuid", tt.status, `{}`)
+
+			result, err := runDoctorCommand(t)
+			if err == nil {
+				t.Fatal("expected failure")
+			}
+			if got := doctorStatuses(result); got != "session=pass,team=pass,ci-access=fail" {
+				t.Fatalf("unexpected checklist %q", got)
+			}
+			if !strings.Contains(result.Checks[2].Hint, tt.wantHint) {
+				t.Fatalf("expected hint containing %q, got %q", tt.wantHint, result.Checks[2].Hint)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudDoctorSessionFailure(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
+		return nil, "", errors.New("web auth login failed: bad credentials")
+	}
+
+	result, err := runDoctorCommand(t)
+	if err == nil {
+		t.Fatal("expected failure")
+	}
+	if got := doctorStatuses(result); got != "session=fail,team=skip,ci-access=skip" {
+		t.Fatalf("unexpected checklist %q", got)
+	}
+	if !strings.Contains(result.Checks[0].Hint, "asc web auth login") {
+		t.Fatalf("expected login hint, got %q", result.Checks[0].Hint)
+	}
+}
+
+func TestDoctorCIAccessHintTimeout(t *testing.T) {
+	hint := doctorCIAccessHint(context.DeadlineExceeded, 5*time.Second)
+	if !strings.Contains(hint, "5s") || !strings.Contains(hint, "--probe-timeout") {
+		t.Fatalf("unexpected timeout hint %q", hint)
+	}
+}
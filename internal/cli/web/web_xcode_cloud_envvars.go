@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -28,24 +29,50 @@ func webXcodeCloudEnvVarsCommand() *ffcli.Command {
 Manage environment variables on Xcode Cloud workflows and products
 using Apple's private CI API. Requires a web session.
 
-Use list/set/delete for workflow-scoped variables.
+Use list/get/set/delete/apply for workflow-scoped variables.
 Use "shared" subcommand for product-level shared variables.
+Use list-all to list every workflow's variables and values, grouped by workflow.
+Use audit to report every variable across a product's workflows.
+Use rotate to replace a secret in every workflow (and the shared variable) that defines it.
+Use require as a CI preflight that fails when a workflow is missing required variables.
+Use copy to copy plaintext variables from one workflow to another.
+
+JSON output of list and shared list adds "status": "empty" and a "message" when
+no variables are found, matching the table output's empty-state text.
+list and shared list also accept --output tsv for tab-separated rows that honor --columns.
+
+list-all, audit, rotate, and set --workflow-ids work on several workflows at once;
+the global --concurrency flag (default 4) caps how many requests run in parallel.
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com"
+  asc web xcode-cloud env-vars get --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --apple-id "user@example.com"
   asc web xcode-cloud env-vars set --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --value hello --apple-id "user@example.com"
   asc web xcode-cloud env-vars set --product-id "UUID" --workflow-id "WF-UUID" --name MY_SECRET --value s3cret --secret --apple-id "user@example.com"
   asc web xcode-cloud env-vars delete --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars apply --product-id "UUID" --workflow-id "WF-UUID" --file vars.json --dry-run --apple-id "user@example.com"
+  asc web xcode-cloud env-vars list-all --product-id "UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars audit --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud env-vars rotate --product-id "UUID" --name API_TOKEN --value "new-token" --secret --apple-id "user@example.com"
+  asc web xcode-cloud env-vars require --product-id "UUID" --workflow-id "WF-UUID" --names API_KEY,SIGNING_CERT --require-secret --apple-id "user@example.com"
+  asc web xcode-cloud env-vars copy --product-id "UUID" --from-workflow "WF-A" --to-workflow "WF-B" --all --apple-id "user@example.com"
   asc web xcode-cloud env-vars shared list --product-id "UUID" --apple-id "user@example.com"
   asc web xcode-cloud env-vars shared set --product-id "UUID" --name MY_VAR --value hello --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			webXcodeCloudEnvVarsListCommand(),
+			webXcodeCloudEnvVarsListAllCommand(),
+			webXcodeCloudEnvVarsGetCommand(),
 			webXcodeCloudEnvVarsSetCommand(),
 			webXcodeCloudEnvVarsDeleteCommand(),
+			webXcodeCloudEnvVarsApplyCommand(),
+			webXcodeCloudEnvVarsAuditCommand(),
+			webXcodeCloudEnvVarsRotateCommand(),
+			webXcodeCloudEnvVarsRequireCommand(),
+			webXcodeCloudEnvVarsCopyCommand(),
 			webXcodeCloudEnvVarsSharedCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
@@ -58,6 +85,8 @@ Examples:
 type CIEnvVarsListResult struct {
 	WorkflowID string                          `json:"workflow_id"`
 	Variables  []webcore.CIEnvironmentVariable `json:"variables"`
+	Conflicts  []CIEnvVarConflict              `json:"conflicts,omitempty"`
+	emptyResultNote
 }
 
 // CIEnvVarsSetResult is the output type for the env-vars set command.
@@ -74,15 +103,27 @@ type CIEnvVarsDeleteResult struct {
 	WorkflowID   string `json:"workflow_id"`
 	WorkflowName string `json:"workflow_name"`
 	Name         string `json:"name"`
+	// Missing is set when --ignore-missing skipped an absent variable.
+	Missing bool `json:"missing,omitempty"`
 }
 
 func webXcodeCloudEnvVarsListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud env-vars list", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+	shared.AllowTSVOutput(fs)
 
 	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
 	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	warnDuplicates := fs.Bool("warn-duplicates", false, "Warn about names also defined as shared product variables")
+	conflictWinner := bindConflictWinnerFlag(fs)
+	mask := fs.Bool("mask", false, "Mask plaintext values in table/markdown output")
+	maskJSON := fs.Bool("mask-json", false, "Mask plaintext values in JSON output")
+	redactTeam := bindRedactTeamFlag(fs)
+	namesOnly := bindNamesOnlyFlag(fs)
+	raw := bindRawFlag(fs)
+	columnsFlag := bindColumnsFlag(fs, envVarHeaders())
 
 	return &ffcli.Command{
 		Name:       "list",
@@ -92,12 +133,28 @@ func webXcodeCloudEnvVarsListCommand() *ffcli.Command {
 
 List environment variables for an Xcode Cloud workflow.
 Plaintext variables show their values; secret variables show "(redacted)".
+Use --warn-duplicates to flag names that are also defined as shared product
+variables. The workflow value is reported as the winner; use --conflict-winner
+shared if your builds resolve them the other way. A shared variable not linked
+to the workflow never reaches it, so that entry has no winner.
+Use --mask to show only the first two characters of plaintext values in
+table/markdown output, and --mask-json to do the same for JSON output.
+Use --redact-team to replace the team ID inside plaintext values with a stable hashed pseudonym.
+Use --names-only to print just the variable names, one per line, for shell loops.
+Use --raw to print the unparsed workflow response instead of the extracted variables;
+it cannot be combined with --mask, --mask-json, --redact-team, or --names-only.
+Use --columns to choose and order the table/markdown columns (name, type, value).
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com"
-  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --names-only
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --warn-duplicates
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table --mask
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --output table --columns value,name
+  asc web xcode-cloud env-vars list --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com" --raw`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -111,6 +168,20 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --workflow-id is required")
 				return flag.ErrHelp
 			}
+			if *raw && (*mask || *maskJSON || *redactTeam || *namesOnly) {
+				fmt.Fprintln(os.Stderr, "Error: --raw cannot be combined with --mask, --mask-json, --redact-team, or --names-only")
+				return flag.ErrHelp
+			}
+			columns, err := parseTableColumns(*columnsFlag, envVarHeaders())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			winner, err := normalizeConflictWinner(*conflictWinner)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -125,6 +196,8 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			result := &CIEnvVarsListResult{}
 			err = withWebSpinner("Loading Xcode Cloud workflow environment variables", func() error {
 				workflow, err := client.GetCIWorkflow(requestCtx, teamID, pid, wfID)
@@ -140,18 +213,62 @@ Examples:
 					WorkflowID: wfID,
 					Variables:  vars,
 				}
+				if *warnDuplicates {
+					sharedVars, err := client.ListCIProductEnvVars(requestCtx, teamID, pid)
+					if err != nil {
+						return err
+					}
+					result.Conflicts = findCIEnvVarConflicts(
+						[]ciWorkflowEnvVars{{
+							workflow: webcore.CIWorkflow{
+								ID:      wfID,
+								Content: webcore.CIWorkflowContent{Name: extractWorkflowName(workflow.Content)},
+							},
+							vars: vars,
+						}},
+						sharedVars,
+						winner,
+					)
+				}
 				return nil
 			})
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud env-vars list"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud env-vars list")
 			}
-			return shared.PrintOutputWithRenderers(
-				result,
+			if *namesOnly {
+				if err := printEnvVarNames(envVarNames(result.Variables)); err != nil {
+					return err
+				}
+				printEnvVarConflictWarnings(result.Conflicts)
+				return checkFailIfEmpty(*failIfEmpty, len(result.Variables), "xcode-cloud env-vars list")
+			}
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			result.Variables = redactor.EnvVars(result.Variables)
+			result.emptyResultNote = newEmptyResultNote(len(result.Variables), emptyEnvVarsMessage)
+			jsonResult := result
+			if *maskJSON {
+				masked := *result
+				masked.Variables = maskEnvVarValues(result.Variables)
+				jsonResult = &masked
+			}
+			if err := shared.PrintOutputWithTabular(
+				jsonResult,
 				*output.Output,
 				*output.Pretty,
-				func() error { return renderEnvVarsTable(result) },
-				func() error { return renderEnvVarsMarkdown(result) },
-			)
+				func() error { return renderEnvVarsTable(result, *mask, columns) },
+				func() error { return renderEnvVarsMarkdown(result, *mask, columns) },
+				func() ([]string, [][]string) {
+					return columns.apply(envVarHeaders(), buildEnvVarRows(result.Variables, *mask))
+				},
+			); err != nil {
+				return err
+			}
+			printEnvVarConflictWarnings(result.Conflicts)
+			return checkFailIfEmpty(*failIfEmpty, len(result.Variables), "xcode-cloud env-vars list")
 		},
 	}
 }
@@ -162,14 +279,16 @@ func webXcodeCloudEnvVarsSetCommand() *ffcli.Command {
 	output := shared.BindOutputFlags(fs)
 
 	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
-	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
+	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required unless --workflow-ids is set)")
+	workflowIDs := fs.String("workflow-ids", "", "Comma-separated workflow IDs to set the variable on in one run (instead of --workflow-id)")
+	continueOnError := fs.Bool("continue-on-error", false, "With --workflow-ids, keep updating the remaining workflows after a failure")
 	name := fs.String("name", "", "Environment variable name (required)")
 	value := fs.String("value", "", "Environment variable value (required)")
 	secret := fs.Bool("secret", false, "Encrypt the value as a secret")
 
 	return &ffcli.Command{
 		Name:       "set",
-		ShortUsage: "asc web xcode-cloud env-vars set --product-id ID --workflow-id ID --name NAME --value VALUE [--secret] [flags]",
+		ShortUsage: "asc web xcode-cloud env-vars set --product-id ID (--workflow-id ID | --workflow-ids ID,ID) --name NAME --value VALUE [--secret] [flags]",
 		ShortHelp:  "EXPERIMENTAL: Set a workflow environment variable.",
 		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
 
@@ -177,11 +296,19 @@ Set (create or update) an environment variable on an Xcode Cloud workflow.
 Use --secret to encrypt the value using ECIES (the same scheme as the ASC web UI).
 If a variable with the same name already exists, it will be updated.
 
+Use --workflow-ids to set the same variable on several workflows in one run.
+Each workflow is read, modified, and written back separately, a few at a time.
+JSON output lists each workflow with its action: created, updated, failed, or
+skipped. The first failure stops workflows that have not started yet unless
+--continue-on-error is set; either way the command exits non-zero when any
+workflow failed.
+
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud env-vars set --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --value hello --apple-id "user@example.com"
-  asc web xcode-cloud env-vars set --product-id "UUID" --workflow-id "WF-UUID" --name MY_SECRET --value s3cret --secret --apple-id "user@example.com"`,
+  asc web xcode-cloud env-vars set --product-id "UUID" --workflow-id "WF-UUID" --name MY_SECRET --value s3cret --secret --apple-id "user@example.com"
+  asc web xcode-cloud env-vars set --product-id "UUID" --workflow-ids "WF-1,WF-2,WF-3" --name MY_VAR --value hello --continue-on-error --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -191,10 +318,19 @@ Examples:
 				return flag.ErrHelp
 			}
 			wfID := strings.TrimSpace(*workflowID)
-			if wfID == "" {
+			batchIDs := shared.SplitUniqueCSV(*workflowIDs)
+			if wfID != "" && len(batchIDs) > 0 {
+				fmt.Fprintln(os.Stderr, "Error: --workflow-id and --workflow-ids are mutually exclusive")
+				return flag.ErrHelp
+			}
+			if wfID == "" && len(batchIDs) == 0 {
 				fmt.Fprintln(os.Stderr, "Error: --workflow-id is required")
 				return flag.ErrHelp
 			}
+			if *continueOnError && len(batchIDs) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --continue-on-error requires --workflow-ids")
+				return flag.ErrHelp
+			}
 			varName := strings.TrimSpace(*name)
 			if varName == "" {
 				fmt.Fprintln(os.Stderr, "Error: --name is required")
@@ -219,73 +355,41 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
-			result := &CIEnvVarsSetResult{}
-			err = withWebSpinner("Updating Xcode Cloud workflow environment variables", func() error {
-				workflow, err := client.GetCIWorkflow(requestCtx, teamID, pid, wfID)
-				if err != nil {
-					return err
-				}
-				vars, err := webcore.ExtractEnvVars(workflow.Content)
-				if err != nil {
-					return fmt.Errorf("xcode-cloud env-vars set failed: %w", err)
-				}
+			encrypt := newEnvVarEncrypter(requestCtx, client)
 
-				var envVar webcore.CIEnvironmentVariable
-				envVar.Name = varName
-
-				if *secret {
-					keyResp, err := client.GetCIEncryptionKey(requestCtx)
-					if err != nil {
-						return fmt.Errorf("xcode-cloud env-vars set failed: could not fetch encryption key: %w", err)
-					}
-					ct, err := webcore.ECIESEncrypt(keyResp.Key, varValue)
+			if len(batchIDs) > 0 {
+				result := &CIEnvVarsBatchSetResult{}
+				err = withWebSpinner("Updating Xcode Cloud workflow environment variables", func() error {
+					envValue, err := newEnvVarValue(varValue, *secret, encrypt)
 					if err != nil {
-						return fmt.Errorf("xcode-cloud env-vars set failed: encryption error: %w", err)
+						return err
 					}
-					envVar.Value = webcore.CIEnvironmentVariableValue{Ciphertext: &ct}
-				} else {
-					envVar.Value = webcore.CIEnvironmentVariableValue{Plaintext: &varValue}
-				}
-
-				found := false
-				for i, v := range vars {
-					if strings.EqualFold(v.Name, varName) {
-						envVar.ID = v.ID
-						vars[i] = envVar
-						found = true
-						break
-					}
-				}
-				if !found {
-					envVar.ID = newUUID()
-					vars = append(vars, envVar)
-				}
-
-				newContent, err := webcore.SetEnvVars(workflow.Content, vars)
+					result = setCIWorkflowEnvVarBatch(requestCtx, client, teamID, pid, batchIDs, varName, envValue, *continueOnError, shared.Concurrency())
+					return nil
+				})
 				if err != nil {
-					return fmt.Errorf("xcode-cloud env-vars set failed: %w", err)
+					return withWebAuthHint(err, "xcode-cloud env-vars set")
 				}
-				if err := client.UpdateCIWorkflow(requestCtx, teamID, pid, wfID, newContent); err != nil {
+				if err := shared.PrintOutputWithRenderers(
+					result,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderEnvVarsBatchSetTable(result) },
+					func() error { return renderEnvVarsBatchSetMarkdown(result) },
+				); err != nil {
 					return err
 				}
+				return envVarsBatchSetError(result)
+			}
 
-				varType := "plaintext"
-				if *secret {
-					varType = "secret"
-				}
-				action := "created"
-				if found {
-					action = "updated"
-				}
-				wfName := extractWorkflowName(workflow.Content)
-				result = &CIEnvVarsSetResult{
-					WorkflowID:   wfID,
-					WorkflowName: wfName,
-					Name:         varName,
-					Type:         varType,
-					Action:       action,
+			result := &CIEnvVarsSetResult{}
+			err = withWebSpinner("Updating Xcode Cloud workflow environment variables", func() error {
+				envValue, err := newEnvVarValue(varValue, *secret, encrypt)
+				if err != nil {
+					return err
 				}
-				return nil
+				result, err = setCIWorkflowEnvVar(requestCtx, client, teamID, pid, wfID, varName, envValue)
+				return err
 			})
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud env-vars set")
@@ -309,7 +413,8 @@ func webXcodeCloudEnvVarsDeleteCommand() *ffcli.Command {
 	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
 	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
 	name := fs.String("name", "", "Environment variable name to delete (required)")
-	confirm := fs.Bool("confirm", false, "Confirm deletion (required)")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (required unless stdout is a terminal, which prompts instead)")
+	ignoreMissing := bindIgnoreMissingFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "delete",
@@ -319,10 +424,19 @@ func webXcodeCloudEnvVarsDeleteCommand() *ffcli.Command {
 
 Delete an environment variable from an Xcode Cloud workflow by name.
 
+Without --confirm in an interactive terminal, the variable's name, value type, and
+workflow are shown and deletion proceeds only after answering y. Non-interactive
+runs still require --confirm.
+
+A variable that does not exist fails with the not-found exit code (4). Use
+--ignore-missing to exit 0 instead, for idempotent cleanup; the result is then
+marked "missing".
+
 ` + webWarningText + `
 
 Examples:
-  asc web xcode-cloud env-vars delete --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --confirm --apple-id "user@example.com"`,
+  asc web xcode-cloud env-vars delete --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars delete --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --confirm --ignore-missing --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -341,7 +455,7 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --name is required")
 				return flag.ErrHelp
 			}
-			if !*confirm {
+			if !*confirm && !envVarDeleteCanPromptFn() {
 				fmt.Fprintln(os.Stderr, "Error: --confirm is required")
 				return flag.ErrHelp
 			}
@@ -379,17 +493,40 @@ Examples:
 				return withWebAuthHint(err, "xcode-cloud env-vars delete")
 			}
 
-			found := false
+			var target *webcore.CIEnvironmentVariable
 			filtered := make([]webcore.CIEnvironmentVariable, 0, len(vars))
-			for _, v := range vars {
+			for i, v := range vars {
 				if strings.EqualFold(v.Name, varName) {
-					found = true
+					target = &vars[i]
 					continue
 				}
 				filtered = append(filtered, v)
 			}
-			if !found {
-				return fmt.Errorf("environment variable %q not found in workflow %s", varName, wfID)
+			if target == nil {
+				notFound := newEnvVarNotFoundError("environment variable %q not found in workflow %s", varName, wfID)
+				if !*ignoreMissing {
+					return notFound
+				}
+				warnEnvVarAlreadyAbsent(notFound)
+				result := &CIEnvVarsDeleteResult{
+					WorkflowID:   wfID,
+					WorkflowName: extractWorkflowName(workflow.Content),
+					Name:         varName,
+					Missing:      true,
+				}
+				return shared.PrintOutputWithRenderers(
+					result,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderEnvVarsDeleteTable(result) },
+					func() error { return renderEnvVarsDeleteMarkdown(result) },
+				)
+			}
+			if !*confirm {
+				preview := workflowEnvVarDeletePreview(*target, extractWorkflowName(workflow.Content), wfID)
+				if err := confirmEnvVarDelete("xcode-cloud env-vars delete", preview); err != nil {
+					return err
+				}
 			}
 
 			result := &CIEnvVarsDeleteResult{}
@@ -424,27 +561,21 @@ Examples:
 	}
 }
 
-func renderEnvVarsTable(result *CIEnvVarsListResult) error {
+func renderEnvVarsTable(result *CIEnvVarsListResult, mask bool, columns tableColumns) error {
 	if result == nil || len(result.Variables) == 0 {
-		fmt.Println("No environment variables found.")
+		fmt.Println(emptyEnvVarsMessage)
 		return nil
 	}
-	asc.RenderTable(
-		[]string{"Name", "Type", "Value"},
-		buildEnvVarRows(result.Variables),
-	)
+	asc.RenderTable(columns.apply(envVarHeaders(), buildEnvVarRows(result.Variables, mask)))
 	return nil
 }
 
-func renderEnvVarsMarkdown(result *CIEnvVarsListResult) error {
+func renderEnvVarsMarkdown(result *CIEnvVarsListResult, mask bool, columns tableColumns) error {
 	if result == nil || len(result.Variables) == 0 {
-		fmt.Println("No environment variables found.")
+		fmt.Println(emptyEnvVarsMessage)
 		return nil
 	}
-	asc.RenderMarkdown(
-		[]string{"Name", "Type", "Value"},
-		buildEnvVarRows(result.Variables),
-	)
+	asc.RenderMarkdown(columns.apply(envVarHeaders(), buildEnvVarRows(result.Variables, mask)))
 	return nil
 }
 
@@ -467,7 +598,7 @@ func renderEnvVarsSetMarkdown(result *CIEnvVarsSetResult) error {
 func renderEnvVarsDeleteTable(result *CIEnvVarsDeleteResult) error {
 	asc.RenderTable(
 		[]string{"Action", "Name", "Workflow", "Workflow ID"},
-		[][]string{{"deleted", result.Name, result.WorkflowName, result.WorkflowID}},
+		[][]string{{envVarDeleteAction(result.Missing), result.Name, result.WorkflowName, result.WorkflowID}},
 	)
 	return nil
 }
@@ -475,12 +606,16 @@ func renderEnvVarsDeleteTable(result *CIEnvVarsDeleteResult) error {
 func renderEnvVarsDeleteMarkdown(result *CIEnvVarsDeleteResult) error {
 	asc.RenderMarkdown(
 		[]string{"Action", "Name", "Workflow", "Workflow ID"},
-		[][]string{{"deleted", result.Name, result.WorkflowName, result.WorkflowID}},
+		[][]string{{envVarDeleteAction(result.Missing), result.Name, result.WorkflowName, result.WorkflowID}},
 	)
 	return nil
 }
 
-func buildEnvVarRows(vars []webcore.CIEnvironmentVariable) [][]string {
+func envVarHeaders() []string {
+	return []string{"Name", "Type", "Value"}
+}
+
+func buildEnvVarRows(vars []webcore.CIEnvironmentVariable, mask bool) [][]string {
 	rows := make([][]string, 0, len(vars))
 	for _, v := range vars {
 		varType := "plaintext"
@@ -489,6 +624,9 @@ func buildEnvVarRows(vars []webcore.CIEnvironmentVariable) [][]string {
 		case v.Value.Plaintext != nil:
 			varType = "plaintext"
 			varValue = *v.Value.Plaintext
+			if mask {
+				varValue = maskEnvVarValue(varValue)
+			}
 		case v.Value.Ciphertext != nil || v.Value.RedactedValue != nil:
 			varType = "secret"
 			varValue = "(redacted)"
@@ -498,6 +636,124 @@ func buildEnvVarRows(vars []webcore.CIEnvironmentVariable) [][]string {
 	return rows
 }
 
+// setCIWorkflowEnvVar creates or updates one variable on a workflow with a
+// GET-modify-PUT of the workflow content.
+func setCIWorkflowEnvVar(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID, productID, workflowID, name string,
+	value webcore.CIEnvironmentVariableValue,
+) (*CIEnvVarsSetResult, error) {
+	workflow, err := client.GetCIWorkflow(ctx, teamID, productID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := webcore.ExtractEnvVars(workflow.Content)
+	if err != nil {
+		return nil, fmt.Errorf("xcode-cloud env-vars set failed: %w", err)
+	}
+
+	// Replace matches wholesale so a type change drops the old value field.
+	envVar := webcore.CIEnvironmentVariable{Name: name, Value: value}
+	found := false
+	for i, v := range vars {
+		if strings.EqualFold(v.Name, name) {
+			envVar.ID = v.ID
+			vars[i] = envVar
+			found = true
+			break
+		}
+	}
+	if !found {
+		envVar.ID = newUUID()
+		vars = append(vars, envVar)
+	}
+
+	newContent, err := webcore.SetEnvVars(workflow.Content, vars)
+	if err != nil {
+		return nil, fmt.Errorf("xcode-cloud env-vars set failed: %w", err)
+	}
+	if err := client.UpdateCIWorkflow(ctx, teamID, productID, workflowID, newContent); err != nil {
+		return nil, err
+	}
+
+	action := "created"
+	if found {
+		action = "updated"
+	}
+	return &CIEnvVarsSetResult{
+		WorkflowID:   workflowID,
+		WorkflowName: extractWorkflowName(workflow.Content),
+		Name:         name,
+		Type:         envVarValueType(value),
+		Action:       action,
+	}, nil
+}
+
+// newEnvVarValue builds a value with only the field for the requested type
+// set. Values are never patched onto an existing variable's value, so switching
+// between secret and plaintext cannot leave a stale plaintext, ciphertext, or
+// redacted_value in the request.
+func newEnvVarValue(value string, secret bool, encrypt func(string) (string, error)) (webcore.CIEnvironmentVariableValue, error) {
+	if !secret {
+		return webcore.CIEnvironmentVariableValue{Plaintext: &value}, nil
+	}
+	ct, err := encrypt(value)
+	if err != nil {
+		return webcore.CIEnvironmentVariableValue{}, err
+	}
+	return webcore.CIEnvironmentVariableValue{Ciphertext: &ct}, nil
+}
+
+// newEnvVarEncrypter returns the encrypt function for newEnvVarValue. The
+// team's encryption key is fetched on first use and reused; the function is
+// safe for concurrent use.
+func newEnvVarEncrypter(ctx context.Context, client *webcore.Client) func(string) (string, error) {
+	var (
+		mu  sync.Mutex
+		key string
+	)
+	return func(plaintext string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if key == "" {
+			keyResp, err := client.GetCIEncryptionKey(ctx)
+			if err != nil {
+				return "", fmt.Errorf("could not fetch encryption key: %w", err)
+			}
+			key = keyResp.Key
+		}
+		ct, err := webcore.ECIESEncrypt(key, plaintext)
+		if err != nil {
+			return "", fmt.Errorf("encryption error: %w", err)
+		}
+		return ct, nil
+	}
+}
+
+// maskEnvVarValue keeps the first two characters of a plaintext value and hides the rest.
+func maskEnvVarValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 2 {
+		return "***"
+	}
+	return string(runes[:2]) + "***"
+}
+
+// maskEnvVarValues returns a copy of vars with plaintext values masked.
+// Secret values are already redacted by the API and are left untouched.
+func maskEnvVarValues(vars []webcore.CIEnvironmentVariable) []webcore.CIEnvironmentVariable {
+	masked := make([]webcore.CIEnvironmentVariable, len(vars))
+	for i, v := range vars {
+		masked[i] = v
+		if v.Value.Plaintext != nil {
+			value := maskEnvVarValue(*v.Value.Plaintext)
+			masked[i].Value.Plaintext = &value
+		}
+	}
+	return masked
+}
+
 // extractWorkflowName extracts the "name" field from raw workflow content JSON.
 func extractWorkflowName(content json.RawMessage) string {
 	var m struct {
@@ -0,0 +1,281 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// workflowEnvVarApplyStep pairs a planned action with the manifest entry it
+// came from. Entry is unset for delete steps.
+type workflowEnvVarApplyStep struct {
+	Action CIEnvVarApplyAction
+	Entry  ciEnvVarManifestEntry
+}
+
+func webXcodeCloudEnvVarsApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars apply", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
+	filePath := fs.String("file", "", "Path to a JSON manifest of workflow environment variables (required)")
+	prune := fs.Bool("prune", false, "Delete workflow variables that are not in the manifest")
+	confirm := fs.Bool("confirm", false, "Confirm deletions (required with --prune unless --dry-run)")
+	dryRun := fs.Bool("dry-run", false, "Show the plan without changing anything")
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc web xcode-cloud env-vars apply --product-id ID --workflow-id ID --file FILE [--prune --confirm] [--dry-run] [flags]",
+		ShortHelp:  "EXPERIMENTAL: Apply workflow environment variables from a JSON manifest.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Create or update every workflow environment variable listed in a JSON manifest
+in a single workflow update. The manifest is an array of objects:
+
+  [
+    {"name": "API_URL", "value": "https://example.com"},
+    {"name": "API_TOKEN", "value": "s3cret", "secret": true}
+  ]
+
+Variables are matched by name case-insensitively. Secret values are encrypted
+using ECIES (the same scheme as the ASC web UI). locked and workflow_ids only
+apply to shared variables and are rejected here. Plaintext variables whose value
+already matches are reported as unchanged; secrets are always updated because
+their current values cannot be read back.
+
+Use --prune with --confirm to delete workflow variables missing from the manifest.
+Use --dry-run to print the plan without making changes.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars apply --product-id "UUID" --workflow-id "WF-UUID" --file vars.json --dry-run --apple-id "user@example.com"
+  asc web xcode-cloud env-vars apply --product-id "UUID" --workflow-id "WF-UUID" --file vars.json --apple-id "user@example.com"
+  asc web xcode-cloud env-vars apply --product-id "UUID" --workflow-id "WF-UUID" --file vars.json --prune --confirm --apple-id "user@example.com"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			wfID := strings.TrimSpace(*workflowID)
+			if wfID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --workflow-id is required")
+				return flag.ErrHelp
+			}
+			path := strings.TrimSpace(*filePath)
+			if path == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			if *prune && !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required with --prune")
+				return flag.ErrHelp
+			}
+			entries, err := parseCIEnvVarManifest(path)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+			for i, entry := range entries {
+				if entry.Locked || entry.WorkflowIDs != nil {
+					return shared.UsageError(fmt.Sprintf("env-vars manifest entry %d (%s): locked and workflow_ids apply only to shared variables", i+1, entry.Name))
+				}
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars apply failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var (
+				workflow *webcore.CIWorkflowFull
+				existing []webcore.CIEnvironmentVariable
+				steps    []workflowEnvVarApplyStep
+			)
+			err = withWebSpinner("Planning Xcode Cloud workflow environment variable changes", func() error {
+				var err error
+				workflow, err = client.GetCIWorkflow(requestCtx, teamID, pid, wfID)
+				if err != nil {
+					return err
+				}
+				existing, err = webcore.ExtractEnvVars(workflow.Content)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars apply failed: %w", err)
+				}
+				steps = planWorkflowEnvVarsApply(entries, existing, *prune)
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars apply")
+			}
+
+			if !*dryRun && workflowEnvVarsPlanHasChanges(steps) {
+				err = withWebSpinner("Applying Xcode Cloud workflow environment variables", func() error {
+					vars, err := mergeWorkflowEnvVarsPlan(existing, steps, newEnvVarEncrypter(requestCtx, client))
+					if err != nil {
+						return err
+					}
+					newContent, err := webcore.SetEnvVars(workflow.Content, vars)
+					if err != nil {
+						return fmt.Errorf("xcode-cloud env-vars apply failed: %w", err)
+					}
+					return client.UpdateCIWorkflow(requestCtx, teamID, pid, wfID, newContent)
+				})
+				if err != nil {
+					return withWebAuthHint(err, "xcode-cloud env-vars apply")
+				}
+			}
+
+			actions := make([]CIEnvVarApplyAction, 0, len(steps))
+			for _, step := range steps {
+				actions = append(actions, step.Action)
+			}
+			result := newCIEnvVarsApplyResult(pid, path, *dryRun, actions)
+			result.WorkflowID = wfID
+			result.WorkflowName = extractWorkflowName(workflow.Content)
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsApplyTable(result) },
+				func() error { return renderEnvVarsApplyMarkdown(result) },
+			)
+		},
+	}
+}
+
+// planWorkflowEnvVarsApply diffs the manifest against the workflow's current
+// variables. Steps follow manifest order, then prunes by name.
+func planWorkflowEnvVarsApply(entries []ciEnvVarManifestEntry, existing []webcore.CIEnvironmentVariable, prune bool) []workflowEnvVarApplyStep {
+	byName := make(map[string]webcore.CIEnvironmentVariable, len(existing))
+	for _, v := range existing {
+		byName[strings.ToLower(strings.TrimSpace(v.Name))] = v
+	}
+
+	steps := make([]workflowEnvVarApplyStep, 0, len(entries))
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Name)
+		wanted[key] = true
+		action := CIEnvVarApplyAction{
+			Action: envVarApplyCreate,
+			Name:   entry.Name,
+			Type:   manifestEntryType(entry),
+		}
+		if current, exists := byName[key]; exists {
+			action.Action = envVarApplyUpdate
+			action.ID = current.ID
+			if !entry.Secret && current.Value.Plaintext != nil && *current.Value.Plaintext == entry.Value {
+				action.Action = envVarApplyUnchanged
+			}
+		}
+		steps = append(steps, workflowEnvVarApplyStep{Action: action, Entry: entry})
+	}
+
+	if prune {
+		var deletes []workflowEnvVarApplyStep
+		for _, v := range existing {
+			if wanted[strings.ToLower(strings.TrimSpace(v.Name))] {
+				continue
+			}
+			deletes = append(deletes, workflowEnvVarApplyStep{
+				Action: CIEnvVarApplyAction{
+					Action: envVarApplyDelete,
+					Name:   v.Name,
+					ID:     v.ID,
+					Type:   envVarValueType(v.Value),
+				},
+			})
+		}
+		sort.SliceStable(deletes, func(i, j int) bool {
+			return strings.ToLower(deletes[i].Action.Name) < strings.ToLower(deletes[j].Action.Name)
+		})
+		steps = append(steps, deletes...)
+	}
+	return steps
+}
+
+func workflowEnvVarsPlanHasChanges(steps []workflowEnvVarApplyStep) bool {
+	for _, step := range steps {
+		if step.Action.Action != envVarApplyUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeWorkflowEnvVarsPlan returns the workflow's full variable list after the
+// plan: existing variables keep their position, updates replace values in
+// place, deletes are dropped, and creates are appended in manifest order.
+// New variable IDs are written back into the step actions.
+func mergeWorkflowEnvVarsPlan(
+	existing []webcore.CIEnvironmentVariable,
+	steps []workflowEnvVarApplyStep,
+	encrypt func(string) (string, error),
+) ([]webcore.CIEnvironmentVariable, error) {
+	valueFor := func(entry ciEnvVarManifestEntry) (webcore.CIEnvironmentVariableValue, error) {
+		return newEnvVarValue(entry.Value, entry.Secret, encrypt)
+	}
+
+	stepByName := make(map[string]*workflowEnvVarApplyStep, len(steps))
+	for i := range steps {
+		stepByName[strings.ToLower(strings.TrimSpace(steps[i].Action.Name))] = &steps[i]
+	}
+
+	merged := make([]webcore.CIEnvironmentVariable, 0, len(existing)+len(steps))
+	for _, v := range existing {
+		step, ok := stepByName[strings.ToLower(strings.TrimSpace(v.Name))]
+		if !ok {
+			merged = append(merged, v)
+			continue
+		}
+		switch step.Action.Action {
+		case envVarApplyDelete:
+			continue
+		case envVarApplyUpdate:
+			value, err := valueFor(step.Entry)
+			if err != nil {
+				return nil, err
+			}
+			v.Name = step.Entry.Name
+			v.Value = value
+		}
+		merged = append(merged, v)
+	}
+	for i := range steps {
+		if steps[i].Action.Action != envVarApplyCreate {
+			continue
+		}
+		value, err := valueFor(steps[i].Entry)
+		if err != nil {
+			return nil, err
+		}
+		steps[i].Action.ID = newUUID()
+		merged = append(merged, webcore.CIEnvironmentVariable{
+			ID:    steps[i].Action.ID,
+			Name:  steps[i].Entry.Name,
+			Value: value,
+		})
+	}
+	return merged, nil
+}
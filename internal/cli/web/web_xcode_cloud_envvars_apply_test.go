@@ -0,0 +1,163 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubWorkflowEnvVarsApplySession(t *testing.T, workflowBody string, putBodies *[]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	serverKeyB64 := "0xm9f0gX7lzArxrChNrDVUR3MKxueb1DdheWBeLndCVOqoiEsT2jxqZW6cHsIuDGDykvYWgQ1qaPBSxCNFXEUg=="
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{}`
+					switch {
+					case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/keys/client-encryption"):
+						body = `{"key":"` + serverKeyB64 + `"}`
+					case req.Method == http.MethodGet:
+						body = workflowBody
+					case req.Method == http.MethodPut:
+						data, err := io.ReadAll(req.Body)
+						if err != nil {
+							t.Fatalf("failed to read PUT body: %v", err)
+						}
+						*putBodies = append(*putBodies, string(data))
+					default:
+						t.Fatalf("unexpected method: %s", req.Method)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func TestEnvVarsApply_SingleMergedUpdate(t *testing.T) {
+	var putBodies []string
+	stubWorkflowEnvVarsApplySession(t, `{"id":"wf-1","content":{"name":"WF","environment_variables":[
+		{"id":"v-keep","name":"KEEP","value":{"plaintext":"same"}},
+		{"id":"v-change","name":"CHANGE","value":{"plaintext":"old"}},
+		{"id":"v-stale","name":"STALE","value":{"plaintext":"x"}}
+	]}}`, &putBodies)
+	path := writeEnvVarManifest(t, `[
+		{"name":"KEEP","value":"same"},
+		{"name":"change","value":"new"},
+		{"name":"TOKEN","value":"s3cret","secret":true}
+	]`)
+
+	cmd := webXcodeCloudEnvVarsApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--file", path,
+		"--prune",
+		"--confirm",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var result CIEnvVarsApplyResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v: %q", err, stdout)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Unchanged != 1 || result.Deleted != 1 {
+		t.Fatalf("unexpected counts %+v", result)
+	}
+	if result.WorkflowID != "wf-1" || result.WorkflowName != "WF" {
+		t.Fatalf("unexpected workflow %q/%q", result.WorkflowID, result.WorkflowName)
+	}
+	if len(putBodies) != 1 {
+		t.Fatalf("expected one workflow update, got %d", len(putBodies))
+	}
+	body := putBodies[0]
+	if !strings.Contains(body, `"v-change"`) || !strings.Contains(body, `"plaintext":"new"`) {
+		t.Fatalf("expected CHANGE updated in place, got %s", body)
+	}
+	if strings.Contains(body, "STALE") {
+		t.Fatalf("expected STALE to be pruned, got %s", body)
+	}
+	if !strings.Contains(body, `"TOKEN"`) || !strings.Contains(body, `"ciphertext"`) || strings.Contains(body, "s3cret") {
+		t.Fatalf("expected encrypted TOKEN, got %s", body)
+	}
+}
+
+func TestEnvVarsApply_NoChangesSkipsUpdate(t *testing.T) {
+	var putBodies []string
+	stubWorkflowEnvVarsApplySession(t, `{"id":"wf-1","content":{"name":"WF","environment_variables":[
+		{"id":"v-keep","name":"KEEP","value":{"plaintext":"same"}}
+	]}}`, &putBodies)
+	path := writeEnvVarManifest(t, `[{"name":"KEEP","value":"same"}]`)
+
+	cmd := webXcodeCloudEnvVarsApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--file", path,
+		"--output", "table",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if len(putBodies) != 0 {
+		t.Fatalf("expected no workflow update, got %v", putBodies)
+	}
+	if !strings.Contains(stdout, "Applied to workflow WF (wf-1): 0 created, 0 updated, 1 unchanged, 0 deleted") {
+		t.Fatalf("unexpected summary %q", stdout)
+	}
+	if strings.Contains(stdout, "Locked") {
+		t.Fatalf("expected no Locked column for workflow applies, got %q", stdout)
+	}
+}
+
+func TestEnvVarsApply_RejectsSharedOnlyFields(t *testing.T) {
+	path := writeEnvVarManifest(t, `[{"name":"A","value":"1","workflow_ids":["wf-1"]}]`)
+	cmd := webXcodeCloudEnvVarsApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--file", path,
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "locked and workflow_ids apply only to shared variables") {
+		t.Fatalf("expected shared-only field error, got %q", stderr)
+	}
+}
@@ -0,0 +1,535 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	envVarScopeWorkflow = "workflow"
+	envVarScopeShared   = "shared"
+)
+
+const (
+	envVarsAuditGroupByName     = "name"
+	envVarsAuditGroupByWorkflow = "workflow"
+	envVarsAuditGroupByScope    = "scope"
+)
+
+// envVarsAuditUnlinkedGroup holds shared variables linked to no workflow when
+// grouping by workflow.
+const envVarsAuditUnlinkedGroup = "(unlinked)"
+
+// CIEnvVarsAuditResult is the output type for the env-vars audit command.
+// Variables is the flat list; Groups reshapes it according to GroupBy.
+type CIEnvVarsAuditResult struct {
+	ProductID string               `json:"product_id"`
+	GroupBy   string               `json:"group_by"`
+	Groups    []CIEnvVarAuditGroup `json:"groups"`
+	Variables []CIEnvVarAuditItem  `json:"variables"`
+	Conflicts []CIEnvVarConflict   `json:"conflicts"`
+}
+
+// CIEnvVarAuditGroup is one group of audit items. Key is the variable name,
+// workflow name, or scope; WorkflowID is set when grouping by workflow.
+type CIEnvVarAuditGroup struct {
+	Key        string              `json:"key"`
+	WorkflowID string              `json:"workflow_id,omitempty"`
+	Variables  []CIEnvVarAuditItem `json:"variables"`
+}
+
+// CIEnvVarAuditItem describes one variable and the workflows that reference it.
+type CIEnvVarAuditItem struct {
+	Name      string                  `json:"name"`
+	Scope     string                  `json:"scope"`
+	Type      string                  `json:"type"`
+	Locked    bool                    `json:"locked"`
+	Workflows []CIEnvVarAuditWorkflow `json:"workflows"`
+}
+
+// CIEnvVarAuditWorkflow identifies a workflow referencing a variable.
+type CIEnvVarAuditWorkflow struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CIEnvVarConflict describes a name defined both in a workflow and as a shared variable.
+// Winner is the scope selected with --conflict-winner; it is empty when the
+// shared variable is not linked to the workflow, since only one value reaches it.
+type CIEnvVarConflict struct {
+	Name         string `json:"name"`
+	WorkflowID   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name"`
+	SharedLinked bool   `json:"shared_linked"`
+	Winner       string `json:"winner,omitempty"`
+}
+
+type ciWorkflowEnvVars struct {
+	workflow webcore.CIWorkflow
+	vars     []webcore.CIEnvironmentVariable
+}
+
+func webXcodeCloudEnvVarsAuditCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars audit", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	groupBy := fs.String("group-by", envVarsAuditGroupByWorkflow, "Group the report by: name, workflow, scope")
+	conflictWinner := bindConflictWinnerFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "audit",
+		ShortUsage: "asc web xcode-cloud env-vars audit --product-id ID [flags]",
+		ShortHelp:  "EXPERIMENTAL: Audit environment variables across a product.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Report every environment variable defined for an Xcode Cloud product.
+Workflow-scoped variables from all workflows are combined with product-level
+shared variables. Each row lists the variable name, scope (workflow/shared),
+type (plaintext/secret), lock state, and the workflows that reference it.
+Values are never included in the report.
+
+Names defined both in a workflow and as a shared variable are reported
+under "conflicts". The workflow value is reported as the winner; use
+--conflict-winner shared if your builds resolve them the other way. When the
+shared variable is not linked to that workflow, only the workflow value reaches
+it, so the entry has no winner.
+
+Use --group-by to shape the report (JSON "groups" and the table):
+  workflow  every variable each workflow uses, including linked shared ones (default)
+  name      every scope and workflow that defines each variable
+  scope     workflow-scoped variables, then shared variables
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars audit --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud env-vars audit --product-id "UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars audit --product-id "UUID" --group-by name --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			groupKey := strings.ToLower(strings.TrimSpace(*groupBy))
+			switch groupKey {
+			case envVarsAuditGroupByName, envVarsAuditGroupByWorkflow, envVarsAuditGroupByScope:
+			default:
+				fmt.Fprintln(os.Stderr, "Error: --group-by must be one of: name, workflow, scope")
+				return flag.ErrHelp
+			}
+			winner, err := normalizeConflictWinner(*conflictWinner)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars audit failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			result := &CIEnvVarsAuditResult{}
+			err = withWebSpinner("Auditing Xcode Cloud environment variables", func() error {
+				workflows, err := client.ListCIWorkflows(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				primeWorkflowNameCache(teamID, pid, workflows.Items)
+				workflowVars, err := fetchCIWorkflowEnvVars(requestCtx, client, teamID, pid, workflows.Items, shared.Concurrency())
+				if err != nil {
+					return err
+				}
+				sharedVars, err := client.ListCIProductEnvVars(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				variables := buildCIEnvVarsAudit(workflowVars, sharedVars)
+				result = &CIEnvVarsAuditResult{
+					ProductID: pid,
+					GroupBy:   groupKey,
+					Groups:    groupCIEnvVarsAudit(variables, workflows.Items, groupKey),
+					Variables: variables,
+					Conflicts: findCIEnvVarConflicts(workflowVars, sharedVars, winner),
+				}
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars audit")
+			}
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsAuditTable(result) },
+				func() error { return renderEnvVarsAuditMarkdown(result) },
+			)
+		},
+	}
+}
+
+// fetchCIWorkflowEnvVars loads env vars for each workflow using a bounded worker pool.
+// Results preserve the order of the input workflows.
+func fetchCIWorkflowEnvVars(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID, productID string,
+	workflows []webcore.CIWorkflow,
+	workers int,
+) ([]ciWorkflowEnvVars, error) {
+	if len(workflows) == 0 {
+		return []ciWorkflowEnvVars{}, nil
+	}
+
+	results := make([]ciWorkflowEnvVars, len(workflows))
+	err := shared.ForEachBounded(ctx, len(workflows), workers, func(ctx context.Context, idx int) error {
+		workflow := workflows[idx]
+		full, err := client.GetCIWorkflow(ctx, teamID, productID, workflow.ID)
+		if err != nil {
+			return fmt.Errorf("workflow %s: %w", workflow.ID, err)
+		}
+		vars, err := webcore.ExtractEnvVars(full.Content)
+		if err != nil {
+			return fmt.Errorf("workflow %s: %w", workflow.ID, err)
+		}
+		results[idx] = ciWorkflowEnvVars{workflow: workflow, vars: vars}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("context cancelled: %w", err)
+	}
+	return results, nil
+}
+
+// buildCIEnvVarsAudit merges workflow-scoped and shared variables into audit items.
+// Workflow variables with the same name and type are combined into one item;
+// names match case-insensitively, like everywhere else in env-vars.
+func buildCIEnvVarsAudit(
+	workflowVars []ciWorkflowEnvVars,
+	sharedVars []webcore.CIProductEnvironmentVariable,
+) []CIEnvVarAuditItem {
+	items := make([]CIEnvVarAuditItem, 0)
+	indexByKey := map[string]int{}
+	for _, entry := range workflowVars {
+		ref := CIEnvVarAuditWorkflow{ID: entry.workflow.ID, Name: entry.workflow.Content.Name}
+		for _, v := range entry.vars {
+			varType := envVarValueType(v.Value)
+			key := strings.ToLower(strings.TrimSpace(v.Name)) + "\x00" + varType
+			if idx, ok := indexByKey[key]; ok {
+				items[idx].Workflows = append(items[idx].Workflows, ref)
+				continue
+			}
+			indexByKey[key] = len(items)
+			items = append(items, CIEnvVarAuditItem{
+				Name:      v.Name,
+				Scope:     envVarScopeWorkflow,
+				Type:      varType,
+				Workflows: []CIEnvVarAuditWorkflow{ref},
+			})
+		}
+	}
+	for _, v := range sharedVars {
+		workflows := make([]CIEnvVarAuditWorkflow, 0, len(v.RelatedWorkflowSummaries))
+		for _, ws := range v.RelatedWorkflowSummaries {
+			workflows = append(workflows, CIEnvVarAuditWorkflow{ID: ws.ID, Name: ws.Name})
+		}
+		items = append(items, CIEnvVarAuditItem{
+			Name:      v.Name,
+			Scope:     envVarScopeShared,
+			Type:      envVarValueType(v.Value),
+			Locked:    v.IsLocked,
+			Workflows: workflows,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := strings.ToLower(items[i].Name), strings.ToLower(items[j].Name)
+		if a != b {
+			return a < b
+		}
+		return items[i].Scope > items[j].Scope
+	})
+	return items
+}
+
+// groupCIEnvVarsAudit reshapes audit items by groupBy. Workflow grouping lists
+// every workflow, even ones without variables, so dependencies can be read per
+// workflow; shared variables linked to none go in a trailing "(unlinked)" group.
+func groupCIEnvVarsAudit(items []CIEnvVarAuditItem, workflows []webcore.CIWorkflow, groupBy string) []CIEnvVarAuditGroup {
+	groups := make([]CIEnvVarAuditGroup, 0)
+	switch groupBy {
+	case envVarsAuditGroupByName, envVarsAuditGroupByScope:
+		indexByKey := map[string]int{}
+		for _, item := range items {
+			key := item.Name
+			if groupBy == envVarsAuditGroupByScope {
+				key = item.Scope
+			}
+			idx, ok := indexByKey[strings.ToLower(key)]
+			if !ok {
+				idx = len(groups)
+				indexByKey[strings.ToLower(key)] = idx
+				groups = append(groups, CIEnvVarAuditGroup{Key: key, Variables: []CIEnvVarAuditItem{}})
+			}
+			groups[idx].Variables = append(groups[idx].Variables, item)
+		}
+		if groupBy == envVarsAuditGroupByScope {
+			// List the workflow scope before the shared one. This only orders
+			// the groups; it does not imply which value wins a conflict.
+			sort.SliceStable(groups, func(i, j int) bool { return groups[i].Key > groups[j].Key })
+		}
+	case envVarsAuditGroupByWorkflow:
+		indexByID := map[string]int{}
+		for _, workflow := range workflows {
+			indexByID[workflow.ID] = len(groups)
+			groups = append(groups, CIEnvVarAuditGroup{
+				Key:        workflow.Content.Name,
+				WorkflowID: workflow.ID,
+				Variables:  []CIEnvVarAuditItem{},
+			})
+		}
+		unlinked := CIEnvVarAuditGroup{Key: envVarsAuditUnlinkedGroup, Variables: []CIEnvVarAuditItem{}}
+		for _, item := range items {
+			linked := false
+			for _, ref := range item.Workflows {
+				idx, ok := indexByID[ref.ID]
+				if !ok {
+					continue
+				}
+				groups[idx].Variables = append(groups[idx].Variables, item)
+				linked = true
+			}
+			if !linked {
+				unlinked.Variables = append(unlinked.Variables, item)
+			}
+		}
+		sort.SliceStable(groups, func(i, j int) bool {
+			if groups[i].Key != groups[j].Key {
+				return groups[i].Key < groups[j].Key
+			}
+			return groups[i].WorkflowID < groups[j].WorkflowID
+		})
+		if len(unlinked.Variables) > 0 {
+			groups = append(groups, unlinked)
+		}
+	}
+	return groups
+}
+
+// bindConflictWinnerFlag registers --conflict-winner. Which value Xcode Cloud
+// uses for a name defined in both scopes is not documented, so the scope
+// reported as winning is configurable.
+func bindConflictWinnerFlag(fs *flag.FlagSet) *string {
+	return fs.String("conflict-winner", envVarScopeWorkflow, "Scope reported as winning when a name is defined in a workflow and as a shared variable: workflow, shared")
+}
+
+func normalizeConflictWinner(value string) (string, error) {
+	switch winner := strings.ToLower(strings.TrimSpace(value)); winner {
+	case envVarScopeWorkflow, envVarScopeShared:
+		return winner, nil
+	default:
+		return "", fmt.Errorf("--conflict-winner must be one of: workflow, shared")
+	}
+}
+
+// findCIEnvVarConflicts reports workflow variables whose names are also
+// defined as shared variables. winner is the scope whose value applies, and is
+// only recorded when the shared variable is linked to the workflow.
+func findCIEnvVarConflicts(
+	workflowVars []ciWorkflowEnvVars,
+	sharedVars []webcore.CIProductEnvironmentVariable,
+	winner string,
+) []CIEnvVarConflict {
+	conflicts := make([]CIEnvVarConflict, 0)
+	for _, entry := range workflowVars {
+		for _, v := range entry.vars {
+			sharedVar, ok := findSharedEnvVarByName(sharedVars, v.Name)
+			if !ok {
+				continue
+			}
+			linked := false
+			for _, ws := range sharedVar.RelatedWorkflowSummaries {
+				if ws.ID == entry.workflow.ID {
+					linked = true
+					break
+				}
+			}
+			conflict := CIEnvVarConflict{
+				Name:         v.Name,
+				WorkflowID:   entry.workflow.ID,
+				WorkflowName: entry.workflow.Content.Name,
+				SharedLinked: linked,
+			}
+			if linked {
+				conflict.Winner = winner
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+func findSharedEnvVarByName(vars []webcore.CIProductEnvironmentVariable, name string) (webcore.CIProductEnvironmentVariable, bool) {
+	for _, v := range vars {
+		if strings.EqualFold(strings.TrimSpace(v.Name), strings.TrimSpace(name)) {
+			return v, true
+		}
+	}
+	return webcore.CIProductEnvironmentVariable{}, false
+}
+
+func printEnvVarConflictWarnings(conflicts []CIEnvVarConflict) {
+	for _, c := range conflicts {
+		if !c.SharedLinked {
+			fmt.Fprintf(
+				os.Stderr,
+				"Warning: %s is defined in workflow %q (%s) and as a shared variable not linked to it\n",
+				c.Name, c.WorkflowName, c.WorkflowID,
+			)
+			continue
+		}
+		fmt.Fprintf(
+			os.Stderr,
+			"Warning: %s is defined in workflow %q (%s) and as a shared variable; the %s value wins\n",
+			c.Name, c.WorkflowName, c.WorkflowID, c.Winner,
+		)
+	}
+}
+
+func envVarValueType(value webcore.CIEnvironmentVariableValue) string {
+	if value.Plaintext == nil && (value.Ciphertext != nil || value.RedactedValue != nil) {
+		return "secret"
+	}
+	return "plaintext"
+}
+
+func renderEnvVarsAuditTable(result *CIEnvVarsAuditResult) error {
+	if result == nil || len(result.Variables) == 0 {
+		fmt.Println(emptyEnvVarsMessage)
+		return nil
+	}
+	asc.RenderTable(envVarsAuditHeaders(result.GroupBy), buildEnvVarsAuditGroupRows(result))
+	if len(result.Conflicts) > 0 {
+		fmt.Println()
+		fmt.Println("Conflicts:")
+		asc.RenderTable(
+			[]string{"Name", "Workflow", "Workflow ID", "Shared Linked", "Winner"},
+			buildEnvVarConflictRows(result.Conflicts),
+		)
+	}
+	return nil
+}
+
+func renderEnvVarsAuditMarkdown(result *CIEnvVarsAuditResult) error {
+	if result == nil || len(result.Variables) == 0 {
+		fmt.Println(emptyEnvVarsMessage)
+		return nil
+	}
+	asc.RenderMarkdown(envVarsAuditHeaders(result.GroupBy), buildEnvVarsAuditGroupRows(result))
+	if len(result.Conflicts) > 0 {
+		fmt.Println()
+		fmt.Println("**Conflicts**")
+		fmt.Println()
+		asc.RenderMarkdown(
+			[]string{"Name", "Workflow", "Workflow ID", "Shared Linked", "Winner"},
+			buildEnvVarConflictRows(result.Conflicts),
+		)
+	}
+	return nil
+}
+
+func envVarsAuditHeaders(groupBy string) []string {
+	switch groupBy {
+	case envVarsAuditGroupByWorkflow:
+		return []string{"Workflow", "Workflow ID", "Name", "Scope", "Type", "Locked"}
+	case envVarsAuditGroupByScope:
+		return []string{"Scope", "Name", "Type", "Locked", "Workflows"}
+	default:
+		return []string{"Name", "Scope", "Type", "Locked", "Workflows"}
+	}
+}
+
+// buildEnvVarsAuditGroupRows flattens the groups into rows led by the group
+// columns. Results without groups fall back to the flat variable list.
+func buildEnvVarsAuditGroupRows(result *CIEnvVarsAuditResult) [][]string {
+	if len(result.Groups) == 0 {
+		return buildEnvVarsAuditRows(result.Variables)
+	}
+	rows := make([][]string, 0, len(result.Variables))
+	for _, group := range result.Groups {
+		switch result.GroupBy {
+		case envVarsAuditGroupByWorkflow:
+			if len(group.Variables) == 0 {
+				rows = append(rows, []string{group.Key, valueOrNA(group.WorkflowID), "(none)", "", "", ""})
+				continue
+			}
+			for _, row := range buildEnvVarsAuditRows(group.Variables) {
+				rows = append(rows, append([]string{group.Key, valueOrNA(group.WorkflowID)}, row[:4]...))
+			}
+		case envVarsAuditGroupByScope:
+			for _, row := range buildEnvVarsAuditRows(group.Variables) {
+				rows = append(rows, append([]string{row[1], row[0]}, row[2:]...))
+			}
+		default:
+			rows = append(rows, buildEnvVarsAuditRows(group.Variables)...)
+		}
+	}
+	return rows
+}
+
+func buildEnvVarsAuditRows(items []CIEnvVarAuditItem) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		locked := "no"
+		if item.Locked {
+			locked = "yes"
+		}
+		names := make([]string, 0, len(item.Workflows))
+		for _, wf := range item.Workflows {
+			names = append(names, wf.Name)
+		}
+		workflows := strings.Join(names, ", ")
+		if workflows == "" {
+			workflows = "(none)"
+		}
+		rows = append(rows, []string{item.Name, item.Scope, item.Type, locked, workflows})
+	}
+	return rows
+}
+
+func buildEnvVarConflictRows(conflicts []CIEnvVarConflict) [][]string {
+	rows := make([][]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		linked := "no"
+		if c.SharedLinked {
+			linked = "yes"
+		}
+		rows = append(rows, []string{c.Name, c.WorkflowName, c.WorkflowID, linked, valueOrNA(c.Winner)})
+	}
+	return rows
+}
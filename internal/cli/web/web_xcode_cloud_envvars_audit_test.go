@@ -0,0 +1,423 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestBuildCIEnvVarsAuditMergesScopes(t *testing.T) {
+	plain := "value"
+	redacted := "***"
+	workflowVars := []ciWorkflowEnvVars{
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-1", Content: webcore.CIWorkflowContent{Name: "CI"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "API_URL", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+				{Name: "TOKEN", Value: webcore.CIEnvironmentVariableValue{RedactedValue: &redacted}},
+			},
+		},
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-2", Content: webcore.CIWorkflowContent{Name: "Release"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "API_URL", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			},
+		},
+	}
+	sharedVars := []webcore.CIProductEnvironmentVariable{
+		{
+			Name:     "API_URL",
+			Value:    webcore.CIEnvironmentVariableValue{RedactedValue: &redacted},
+			IsLocked: true,
+			RelatedWorkflowSummaries: []webcore.CIRelatedWorkflowSummary{
+				{ID: "wf-2", Name: "Release"},
+			},
+		},
+	}
+
+	items := buildCIEnvVarsAudit(workflowVars, sharedVars)
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d: %+v", len(items), items)
+	}
+
+	if items[0].Name != "API_URL" || items[0].Scope != "workflow" || items[0].Type != "plaintext" {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if len(items[0].Workflows) != 2 || items[0].Workflows[0].ID != "wf-1" || items[0].Workflows[1].ID != "wf-2" {
+		t.Fatalf("expected workflow var to reference wf-1 and wf-2, got %+v", items[0].Workflows)
+	}
+	if items[1].Name != "API_URL" || items[1].Scope != "shared" || items[1].Type != "secret" || !items[1].Locked {
+		t.Fatalf("unexpected shared item: %+v", items[1])
+	}
+	if items[2].Name != "TOKEN" || items[2].Type != "secret" || items[2].Locked {
+		t.Fatalf("unexpected token item: %+v", items[2])
+	}
+}
+
+func TestEnvVarsAudit_MissingProductID(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsAuditCommand()
+	if err := cmd.FlagSet.Parse([]string{}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--product-id is required") {
+		t.Fatalf("expected product-id error in stderr, got %q", stderr)
+	}
+}
+
+func TestEnvVarsAudit_Success(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
+	})
+
+	var workflowFetches atomic.Int32
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					var body string
+					switch {
+					case strings.HasSuffix(req.URL.Path, "/product-environment-variables"):
+						body = `[{"id":"s-1","name":"SHARED_KEY","value":{"redacted_value":"***"},"is_locked":true,"related_workflow_summaries":[{"id":"wf-1","name":"CI"}]}]`
+					case strings.HasSuffix(req.URL.Path, "/workflows-v15"):
+						body = `{"items":[{"id":"wf-1","content":{"name":"CI"}},{"id":"wf-2","content":{"name":"Release"}}]}`
+					case strings.HasSuffix(req.URL.Path, "/workflows-v15/wf-1"):
+						workflowFetches.Add(1)
+						body = `{"id":"wf-1","content":{"name":"CI","environment_variables":[{"id":"v1","name":"MY_VAR","value":{"plaintext":"hello"}}]}}`
+					case strings.HasSuffix(req.URL.Path, "/workflows-v15/wf-2"):
+						workflowFetches.Add(1)
+						body = `{"id":"wf-2","content":{"name":"Release","environment_variables":[{"id":"v2","name":"MY_VAR","value":{"plaintext":"bye"}}]}}`
+					default:
+						return nil, fmt.Errorf("unexpected request path: %s", req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudEnvVarsAuditCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if got := workflowFetches.Load(); got != 2 {
+		t.Fatalf("expected 2 workflow fetches, got %d", got)
+	}
+	if strings.Contains(stdout, "hello") || strings.Contains(stdout, "bye") {
+		t.Fatalf("expected audit output to omit values, got %q", stdout)
+	}
+
+	var result CIEnvVarsAuditResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+	}
+	if result.ProductID != "prod-1" {
+		t.Fatalf("expected product_id prod-1, got %q", result.ProductID)
+	}
+	if len(result.Variables) != 2 {
+		t.Fatalf("expected 2 audit items, got %+v", result.Variables)
+	}
+	if result.Variables[0].Name != "MY_VAR" || len(result.Variables[0].Workflows) != 2 {
+		t.Fatalf("expected MY_VAR to reference both workflows, got %+v", result.Variables[0])
+	}
+	if result.Variables[1].Name != "SHARED_KEY" || result.Variables[1].Scope != "shared" || !result.Variables[1].Locked {
+		t.Fatalf("unexpected shared item: %+v", result.Variables[1])
+	}
+	if result.Conflicts == nil || len(result.Conflicts) != 0 {
+		t.Fatalf("expected empty conflicts array, got %+v", result.Conflicts)
+	}
+	if result.GroupBy != "workflow" || len(result.Groups) != 2 {
+		t.Fatalf("expected default workflow grouping with 2 groups, got %q %+v", result.GroupBy, result.Groups)
+	}
+	if result.Groups[0].Key != "CI" || len(result.Groups[0].Variables) != 2 {
+		t.Fatalf("expected CI to use MY_VAR and SHARED_KEY, got %+v", result.Groups[0])
+	}
+	if result.Groups[1].Key != "Release" || len(result.Groups[1].Variables) != 1 {
+		t.Fatalf("expected Release to use MY_VAR only, got %+v", result.Groups[1])
+	}
+}
+
+func TestEnvVarsAudit_InvalidGroupBy(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsAuditCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--group-by", "team"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--group-by must be one of: name, workflow, scope") {
+		t.Fatalf("expected group-by error in stderr, got %q", stderr)
+	}
+}
+
+func TestGroupCIEnvVarsAudit(t *testing.T) {
+	workflows := []webcore.CIWorkflow{
+		{ID: "wf-2", Content: webcore.CIWorkflowContent{Name: "Release"}},
+		{ID: "wf-1", Content: webcore.CIWorkflowContent{Name: "CI"}},
+		{ID: "wf-3", Content: webcore.CIWorkflowContent{Name: "Nightly"}},
+	}
+	items := []CIEnvVarAuditItem{
+		{Name: "API_URL", Scope: "workflow", Workflows: []CIEnvVarAuditWorkflow{{ID: "wf-1"}, {ID: "wf-2"}}},
+		{Name: "API_URL", Scope: "shared", Workflows: []CIEnvVarAuditWorkflow{{ID: "wf-2"}}},
+		{Name: "ORPHAN", Scope: "shared"},
+	}
+
+	byWorkflow := groupCIEnvVarsAudit(items, workflows, "workflow")
+	gotKeys := make([]string, 0, len(byWorkflow))
+	for _, group := range byWorkflow {
+		gotKeys = append(gotKeys, fmt.Sprintf("%s:%d", group.Key, len(group.Variables)))
+	}
+	if got := strings.Join(gotKeys, ","); got != "CI:1,Nightly:0,Release:2,(unlinked):1" {
+		t.Fatalf("unexpected workflow groups: %s", got)
+	}
+
+	byName := groupCIEnvVarsAudit(items, workflows, "name")
+	if len(byName) != 2 || byName[0].Key != "API_URL" || len(byName[0].Variables) != 2 || byName[1].Key != "ORPHAN" {
+		t.Fatalf("unexpected name groups: %+v", byName)
+	}
+
+	byScope := groupCIEnvVarsAudit(items, workflows, "scope")
+	if len(byScope) != 2 || byScope[0].Key != "workflow" || byScope[1].Key != "shared" || len(byScope[1].Variables) != 2 {
+		t.Fatalf("unexpected scope groups: %+v", byScope)
+	}
+
+	rows := buildEnvVarsAuditGroupRows(&CIEnvVarsAuditResult{GroupBy: "workflow", Groups: byWorkflow, Variables: items})
+	if len(rows) != 5 || rows[1][0] != "Nightly" || rows[1][2] != "(none)" {
+		t.Fatalf("unexpected workflow rows: %+v", rows)
+	}
+}
+
+func TestFindCIEnvVarConflicts(t *testing.T) {
+	plain := "value"
+	workflowVars := []ciWorkflowEnvVars{
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-1", Content: webcore.CIWorkflowContent{Name: "CI"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "API_URL", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+				{Name: "LOCAL_ONLY", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			},
+		},
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-2", Content: webcore.CIWorkflowContent{Name: "Release"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "API_URL", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			},
+		},
+	}
+	sharedVars := []webcore.CIProductEnvironmentVariable{
+		{
+			Name: "API_URL",
+			RelatedWorkflowSummaries: []webcore.CIRelatedWorkflowSummary{
+				{ID: "wf-2", Name: "Release"},
+			},
+		},
+		{Name: "SHARED_ONLY"},
+	}
+
+	conflicts := findCIEnvVarConflicts(workflowVars, sharedVars, envVarScopeWorkflow)
+	if len(conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].WorkflowID != "wf-1" || conflicts[0].SharedLinked || conflicts[0].Winner != "" {
+		t.Fatalf("expected unlinked first conflict without a winner: %+v", conflicts[0])
+	}
+	if conflicts[1].WorkflowID != "wf-2" || !conflicts[1].SharedLinked || conflicts[1].Winner != "workflow" {
+		t.Fatalf("unexpected second conflict: %+v", conflicts[1])
+	}
+	for _, c := range conflicts {
+		if c.Name != "API_URL" {
+			t.Fatalf("unexpected conflict: %+v", c)
+		}
+	}
+}
+
+func TestEnvVarsList_WarnDuplicates(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var sharedRequested atomic.Bool
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"id":"wf-1","content":{"name":"CI","environment_variables":[{"id":"v1","name":"API_URL","value":{"plaintext":"a"}}]}}`
+					if strings.HasSuffix(req.URL.Path, "/product-environment-variables") {
+						sharedRequested.Store(true)
+						body = `[{"id":"s-1","name":"API_URL","value":{"plaintext":"b"},"related_workflow_summaries":[{"id":"wf-1","name":"CI"}]}]`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	t.Run("without flag skips shared lookup", func(t *testing.T) {
+		sharedRequested.Store(false)
+		cmd := webXcodeCloudEnvVarsListCommand()
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--product-id", "prod-1",
+			"--workflow-id", "wf-1",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("exec error: %v", err)
+			}
+		})
+		if sharedRequested.Load() {
+			t.Fatal("expected no shared env var request without --warn-duplicates")
+		}
+		if strings.Contains(stdout, `"conflicts"`) {
+			t.Fatalf("expected no conflicts key, got %q", stdout)
+		}
+	})
+
+	t.Run("with flag reports conflicts", func(t *testing.T) {
+		cmd := webXcodeCloudEnvVarsListCommand()
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--product-id", "prod-1",
+			"--workflow-id", "wf-1",
+			"--warn-duplicates",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("exec error: %v", err)
+			}
+		})
+		var result CIEnvVarsListResult
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Name != "API_URL" || !result.Conflicts[0].SharedLinked {
+			t.Fatalf("unexpected conflicts: %+v", result.Conflicts)
+		}
+		if !strings.Contains(stderr, "Warning: API_URL is defined in workflow") {
+			t.Fatalf("expected conflict warning in stderr, got %q", stderr)
+		}
+	})
+
+	t.Run("conflict winner shared", func(t *testing.T) {
+		cmd := webXcodeCloudEnvVarsListCommand()
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--product-id", "prod-1",
+			"--workflow-id", "wf-1",
+			"--warn-duplicates",
+			"--conflict-winner", "shared",
+		}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("exec error: %v", err)
+			}
+		})
+		var result CIEnvVarsListResult
+		if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0].Winner != "shared" {
+			t.Fatalf("expected shared winner, got %+v", result.Conflicts)
+		}
+		if !strings.Contains(stderr, "the shared value wins") {
+			t.Fatalf("expected shared winner in warning, got %q", stderr)
+		}
+	})
+}
+
+func TestEnvVarsAudit_InvalidConflictWinner(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsAuditCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--conflict-winner", "team"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--conflict-winner must be one of: workflow, shared") {
+		t.Fatalf("expected conflict-winner error in stderr, got %q", stderr)
+	}
+}
+
+func TestEnvVarsAuditMatchesNamesCaseInsensitively(t *testing.T) {
+	plain := "value"
+	workflowVars := []ciWorkflowEnvVars{
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-1", Content: webcore.CIWorkflowContent{Name: "CI"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "api_url", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			},
+		},
+		{
+			workflow: webcore.CIWorkflow{ID: "wf-2", Content: webcore.CIWorkflowContent{Name: "Release"}},
+			vars: []webcore.CIEnvironmentVariable{
+				{Name: "API_URL", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			},
+		},
+	}
+	sharedVars := []webcore.CIProductEnvironmentVariable{{Name: "Api_Url"}}
+
+	if conflicts := findCIEnvVarConflicts(workflowVars, sharedVars, envVarScopeWorkflow); len(conflicts) != 2 {
+		t.Fatalf("expected both workflow variables to conflict with the shared one, got %+v", conflicts)
+	}
+	items := buildCIEnvVarsAudit(workflowVars, nil)
+	if len(items) != 1 || len(items[0].Workflows) != 2 {
+		t.Fatalf("expected one workflow item for both spellings, got %+v", items)
+	}
+}
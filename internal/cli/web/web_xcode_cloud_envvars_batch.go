@@ -0,0 +1,125 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	envVarsBatchActionFailed  = "failed"
+	envVarsBatchActionSkipped = "skipped"
+)
+
+// CIEnvVarsBatchSetResult is the output of env-vars set with --workflow-ids.
+type CIEnvVarsBatchSetResult struct {
+	Name      string                      `json:"name"`
+	Type      string                      `json:"type"`
+	Workflows []CIEnvVarsBatchSetWorkflow `json:"workflows"`
+}
+
+// CIEnvVarsBatchSetWorkflow is the outcome for one workflow: created, updated,
+// failed, or skipped after an earlier failure.
+type CIEnvVarsBatchSetWorkflow struct {
+	WorkflowID   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	Action       string `json:"action"`
+	Error        string `json:"error,omitempty"`
+}
+
+// setCIWorkflowEnvVarBatch sets the variable on each workflow using a bounded
+// worker pool. Results keep the order of workflowIDs. Unless continueOnError
+// is set, the first failure leaves workflows that have not started skipped.
+func setCIWorkflowEnvVarBatch(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID, productID string,
+	workflowIDs []string,
+	name string,
+	value webcore.CIEnvironmentVariableValue,
+	continueOnError bool,
+	workers int,
+) *CIEnvVarsBatchSetResult {
+	result := &CIEnvVarsBatchSetResult{
+		Name:      name,
+		Type:      envVarValueType(value),
+		Workflows: make([]CIEnvVarsBatchSetWorkflow, len(workflowIDs)),
+	}
+	for idx, id := range workflowIDs {
+		result.Workflows[idx] = CIEnvVarsBatchSetWorkflow{WorkflowID: id, Action: envVarsBatchActionSkipped}
+	}
+	// Workflows are handed out in order, so a stop leaves the tail skipped.
+	_ = shared.ForEachBounded(ctx, len(workflowIDs), workers, func(_ context.Context, idx int) error {
+		// In-flight updates finish against the parent context so a
+		// failure elsewhere never interrupts a PUT halfway.
+		set, err := setCIWorkflowEnvVar(ctx, client, teamID, productID, workflowIDs[idx], name, value)
+		if err != nil {
+			result.Workflows[idx].Action = envVarsBatchActionFailed
+			result.Workflows[idx].Error = err.Error()
+			if !continueOnError {
+				return err
+			}
+			return nil
+		}
+		result.Workflows[idx].WorkflowName = set.WorkflowName
+		result.Workflows[idx].Action = set.Action
+		return nil
+	})
+	return result
+}
+
+// envVarsBatchSetError reports failed and skipped workflows, or nil when
+// every workflow was updated.
+func envVarsBatchSetError(result *CIEnvVarsBatchSetResult) error {
+	var errs []error
+	skipped := 0
+	for _, workflow := range result.Workflows {
+		switch workflow.Action {
+		case envVarsBatchActionFailed:
+			errs = append(errs, fmt.Errorf("workflow %s: %s", workflow.WorkflowID, workflow.Error))
+		case envVarsBatchActionSkipped:
+			skipped++
+		}
+	}
+	if len(errs) == 0 && skipped == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("xcode-cloud env-vars set failed for %d of %d workflow(s)", len(errs), len(result.Workflows))
+	if skipped > 0 {
+		message += fmt.Sprintf(", %d skipped", skipped)
+	}
+	return fmt.Errorf("%s: %w", message, errors.Join(errs...))
+}
+
+func renderEnvVarsBatchSetTable(result *CIEnvVarsBatchSetResult) error {
+	asc.RenderTable(envVarsBatchSetHeaders(), buildEnvVarsBatchSetRows(result))
+	return nil
+}
+
+func renderEnvVarsBatchSetMarkdown(result *CIEnvVarsBatchSetResult) error {
+	asc.RenderMarkdown(envVarsBatchSetHeaders(), buildEnvVarsBatchSetRows(result))
+	return nil
+}
+
+func envVarsBatchSetHeaders() []string {
+	return []string{"Action", "Name", "Type", "Workflow", "Workflow ID", "Error"}
+}
+
+func buildEnvVarsBatchSetRows(result *CIEnvVarsBatchSetResult) [][]string {
+	rows := make([][]string, 0, len(result.Workflows))
+	for _, workflow := range result.Workflows {
+		rows = append(rows, []string{
+			workflow.Action,
+			result.Name,
+			result.Type,
+			valueOrNA(workflow.WorkflowName),
+			workflow.WorkflowID,
+			valueOrNA(workflow.Error),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,203 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// stubEnvVarsBatchSession serves workflow GET/PUT requests. Workflows listed in
+// failing answer their GET with a 500; puts records each PUT body by workflow.
+func stubEnvVarsBatchSession(t *testing.T, contents map[string]string, failing map[string]bool) map[string]string {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var mu sync.Mutex
+	puts := map[string]string{}
+	resolveSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					wfID := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+					status, body := http.StatusOK, `{}`
+					switch {
+					case failing[wfID]:
+						status, body = http.StatusInternalServerError, `{"error":"boom"}`
+					case req.Method == http.MethodGet:
+						body = `{"id":"` + wfID + `","content":` + contents[wfID] + `}`
+					case req.Method == http.MethodPut:
+						data, _ := io.ReadAll(req.Body)
+						mu.Lock()
+						puts[wfID] = string(data)
+						mu.Unlock()
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+	return puts
+}
+
+func TestEnvVarsSetWorkflowIDsUpdatesEachWorkflow(t *testing.T) {
+	puts := stubEnvVarsBatchSession(t, map[string]string{
+		"wf-1": `{"name":"Build","environment_variables":[]}`,
+		"wf-2": `{"name":"Release","environment_variables":[{"id":"ev-1","name":"MY_VAR","value":{"plaintext":"old"}}]}`,
+	}, nil)
+
+	cmd := webXcodeCloudEnvVarsSetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-ids", "wf-1,wf-2",
+		"--name", "MY_VAR",
+		"--value", "hello",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	var result CIEnvVarsBatchSetResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected valid JSON output, got %v: %q", err, stdout)
+	}
+	if result.Name != "MY_VAR" || result.Type != "plaintext" || len(result.Workflows) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if got := result.Workflows[0]; got.WorkflowID != "wf-1" || got.WorkflowName != "Build" || got.Action != "created" {
+		t.Fatalf("unexpected first workflow: %+v", got)
+	}
+	if got := result.Workflows[1]; got.WorkflowID != "wf-2" || got.Action != "updated" {
+		t.Fatalf("unexpected second workflow: %+v", got)
+	}
+	if len(puts) != 2 || !strings.Contains(puts["wf-1"], "hello") || !strings.Contains(puts["wf-2"], "ev-1") {
+		t.Fatalf("expected one PUT per workflow, got %v", puts)
+	}
+}
+
+func TestEnvVarsSetWorkflowIDsContinueOnError(t *testing.T) {
+	puts := stubEnvVarsBatchSession(t, map[string]string{
+		"wf-1": `{"name":"Build","environment_variables":[]}`,
+		"wf-3": `{"name":"Nightly","environment_variables":[]}`,
+	}, map[string]bool{"wf-2": true})
+
+	cmd := webXcodeCloudEnvVarsSetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-ids", "wf-1,wf-2,wf-3",
+		"--name", "MY_VAR",
+		"--value", "hello",
+		"--continue-on-error",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "failed for 1 of 3 workflow(s)") {
+		t.Fatalf("expected partial failure error, got %v", runErr)
+	}
+	var result CIEnvVarsBatchSetResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected valid JSON output, got %v: %q", err, stdout)
+	}
+	actions := []string{result.Workflows[0].Action, result.Workflows[1].Action, result.Workflows[2].Action}
+	if strings.Join(actions, ",") != "created,failed,created" {
+		t.Fatalf("unexpected actions %v", actions)
+	}
+	if result.Workflows[1].Error == "" {
+		t.Fatalf("expected error on failed workflow, got %+v", result.Workflows[1])
+	}
+	if len(puts) != 2 {
+		t.Fatalf("expected PUTs for the two healthy workflows, got %v", puts)
+	}
+}
+
+func TestSetCIWorkflowEnvVarBatchStopsOnFirstFailure(t *testing.T) {
+	stubEnvVarsBatchSession(t, map[string]string{
+		"wf-2": `{"name":"Release","environment_variables":[]}`,
+	}, map[string]bool{"wf-1": true})
+	session, _, err := resolveSessionFn(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("session error: %v", err)
+	}
+
+	value := "hello"
+	result := setCIWorkflowEnvVarBatch(
+		context.Background(),
+		newCIClientFn(session),
+		"team-uuid", "prod-1",
+		[]string{"wf-1", "wf-2"},
+		"MY_VAR",
+		webcore.CIEnvironmentVariableValue{Plaintext: &value},
+		false,
+		1,
+	)
+	if result.Workflows[0].Action != envVarsBatchActionFailed || result.Workflows[1].Action != envVarsBatchActionSkipped {
+		t.Fatalf("expected failed then skipped, got %+v", result.Workflows)
+	}
+	err = envVarsBatchSetError(result)
+	if err == nil || !strings.Contains(err.Error(), "failed for 1 of 2 workflow(s), 1 skipped") {
+		t.Fatalf("unexpected batch error: %v", err)
+	}
+}
+
+func TestEnvVarsSetWorkflowIDsFlagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "both workflow flags",
+			args:    []string{"--workflow-id", "wf-1", "--workflow-ids", "wf-2,wf-3"},
+			wantErr: "--workflow-id and --workflow-ids are mutually exclusive",
+		},
+		{
+			name:    "continue-on-error without batch",
+			args:    []string{"--workflow-id", "wf-1", "--continue-on-error"},
+			wantErr: "--continue-on-error requires --workflow-ids",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := webXcodeCloudEnvVarsSetCommand()
+			args := append([]string{"--product-id", "prod-1", "--name", "X", "--value", "Y"}, tt.args...)
+			if err := cmd.FlagSet.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			var runErr error
+			_, stderr := captureOutput(t, func() {
+				runErr = cmd.Exec(context.Background(), nil)
+			})
+			if !errors.Is(runErr, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+			}
+			if !strings.Contains(stderr, tt.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", tt.wantErr, stderr)
+			}
+		})
+	}
+}
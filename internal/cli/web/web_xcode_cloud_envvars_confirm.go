@@ -0,0 +1,122 @@
+package web
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+var (
+	envVarDeleteCanPromptFn = envVarDeleteCanPrompt
+	envVarDeletePromptFn    = promptEnvVarDeleteInteractive
+)
+
+// envVarDeletePreview is what the interactive delete prompt shows before asking
+// for confirmation.
+type envVarDeletePreview struct {
+	Name      string
+	ID        string
+	ValueType string
+	Scope     string
+	Workflows []string
+}
+
+// envVarDeleteCanPrompt reports whether a delete without --confirm may fall
+// back to an interactive prompt. Only a terminal on stdout qualifies, so piped
+// and scripted runs keep requiring --confirm.
+func envVarDeleteCanPrompt() bool {
+	return termIsTerminalFn(int(os.Stdout.Fd()))
+}
+
+func promptEnvVarDeleteInteractive(preview envVarDeletePreview) (bool, error) {
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		defer func() { _ = tty.Close() }()
+		return readEnvVarDeleteConfirmation(tty, tty, preview)
+	}
+	if termIsTerminalFn(int(os.Stdin.Fd())) {
+		return readEnvVarDeleteConfirmation(os.Stdin, os.Stderr, preview)
+	}
+	return false, fmt.Errorf("unable to prompt for confirmation: re-run with --confirm")
+}
+
+// readEnvVarDeleteConfirmation prints the preview and returns true only for an
+// explicit "y" or "yes" answer.
+func readEnvVarDeleteConfirmation(reader io.Reader, writer io.Writer, preview envVarDeletePreview) (bool, error) {
+	if reader == nil || writer == nil {
+		return false, fmt.Errorf("unable to prompt for confirmation: re-run with --confirm")
+	}
+	_, _ = fmt.Fprintf(writer, "About to delete %s environment variable:\n", preview.Scope)
+	_, _ = fmt.Fprintf(writer, "  Name:       %s\n", valueOrNA(preview.Name))
+	if preview.ID != "" {
+		_, _ = fmt.Fprintf(writer, "  ID:         %s\n", preview.ID)
+	}
+	_, _ = fmt.Fprintf(writer, "  Value type: %s\n", valueOrNA(preview.ValueType))
+	workflows := "none"
+	if len(preview.Workflows) > 0 {
+		workflows = strings.Join(preview.Workflows, ", ")
+	}
+	_, _ = fmt.Fprintf(writer, "  Workflows:  %s\n", workflows)
+	if _, err := fmt.Fprint(writer, "Delete this variable? [y/N]: "); err != nil {
+		return false, fmt.Errorf("unable to prompt for confirmation: re-run with --confirm")
+	}
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// confirmEnvVarDelete runs the interactive prompt and turns a declined or
+// empty answer into an error so nothing is deleted.
+func confirmEnvVarDelete(command string, preview envVarDeletePreview) error {
+	confirmed, err := envVarDeletePromptFn(preview)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", command, err)
+	}
+	if !confirmed {
+		return fmt.Errorf("%s cancelled: deletion not confirmed", command)
+	}
+	return nil
+}
+
+func sharedEnvVarDeletePreview(v webcore.CIProductEnvironmentVariable) envVarDeletePreview {
+	workflows := make([]string, 0, len(v.RelatedWorkflowSummaries))
+	for _, wf := range v.RelatedWorkflowSummaries {
+		workflows = append(workflows, formatEnvVarWorkflowLabel(wf.Name, wf.ID))
+	}
+	return envVarDeletePreview{
+		Name:      v.Name,
+		ID:        v.ID,
+		ValueType: envVarValueType(v.Value),
+		Scope:     "shared",
+		Workflows: workflows,
+	}
+}
+
+func workflowEnvVarDeletePreview(v webcore.CIEnvironmentVariable, workflowName, workflowID string) envVarDeletePreview {
+	return envVarDeletePreview{
+		Name:      v.Name,
+		ID:        v.ID,
+		ValueType: envVarValueType(v.Value),
+		Scope:     "workflow",
+		Workflows: []string{formatEnvVarWorkflowLabel(workflowName, workflowID)},
+	}
+}
+
+func formatEnvVarWorkflowLabel(name, id string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", name, id)
+}
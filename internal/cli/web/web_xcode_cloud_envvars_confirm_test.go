@@ -0,0 +1,177 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestReadEnvVarDeleteConfirmation(t *testing.T) {
+	preview := envVarDeletePreview{
+		Name:      "API_KEY",
+		ID:        "var-1",
+		ValueType: "secret",
+		Scope:     "shared",
+		Workflows: []string{"CI (wf-1)", "Release (wf-2)"},
+	}
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "YES\n", want: true},
+		{input: "n\n", want: false},
+		{input: "\n", want: false},
+		{input: "", want: false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := readEnvVarDeleteConfirmation(strings.NewReader(tt.input), &out, preview)
+		if err != nil {
+			t.Fatalf("input %q: unexpected error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Fatalf("input %q: got %v, want %v", tt.input, got, tt.want)
+		}
+		for _, want := range []string{"API_KEY", "var-1", "secret", "CI (wf-1), Release (wf-2)", "[y/N]"} {
+			if !strings.Contains(out.String(), want) {
+				t.Fatalf("expected preview to contain %q, got %q", want, out.String())
+			}
+		}
+	}
+}
+
+func stubEnvVarDeletePrompt(t *testing.T, answer bool) *[]envVarDeletePreview {
+	t.Helper()
+	origCanPrompt := envVarDeleteCanPromptFn
+	origPrompt := envVarDeletePromptFn
+	t.Cleanup(func() {
+		envVarDeleteCanPromptFn = origCanPrompt
+		envVarDeletePromptFn = origPrompt
+	})
+	var previews []envVarDeletePreview
+	envVarDeleteCanPromptFn = func() bool { return true }
+	envVarDeletePromptFn = func(preview envVarDeletePreview) (bool, error) {
+		previews = append(previews, preview)
+		return answer, nil
+	}
+	return &previews
+}
+
+func stubSharedEnvVarDeleteSession(t *testing.T, deleted *[]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{}`
+					switch req.Method {
+					case http.MethodGet:
+						body = `[{"id":"var-1","name":"API_KEY","value":{"redacted_value":"***"},"is_locked":false,"related_workflow_summaries":[{"id":"wf-1","name":"CI"}]}]`
+					case http.MethodDelete:
+						*deleted = append(*deleted, req.URL.Path)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func TestSharedEnvVarsDelete_InteractiveDeclineSkipsDelete(t *testing.T) {
+	previews := stubEnvVarDeletePrompt(t, false)
+	var deleted []string
+	stubSharedEnvVarDeleteSession(t, &deleted)
+
+	cmd := webXcodeCloudEnvVarsSharedDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--name", "api_key",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil || !strings.Contains(err.Error(), "not confirmed") {
+			t.Fatalf("expected not-confirmed error, got %v", err)
+		}
+	})
+	if len(deleted) != 0 {
+		t.Fatalf("expected no DELETE after declining, got %v", deleted)
+	}
+	if len(*previews) != 1 {
+		t.Fatalf("expected one prompt, got %d", len(*previews))
+	}
+	got := (*previews)[0]
+	if got.Name != "API_KEY" || got.ValueType != "secret" || len(got.Workflows) != 1 || got.Workflows[0] != "CI (wf-1)" {
+		t.Fatalf("unexpected preview %+v", got)
+	}
+}
+
+func TestSharedEnvVarsDelete_InteractiveByIDLooksUpPreview(t *testing.T) {
+	previews := stubEnvVarDeletePrompt(t, true)
+	var deleted []string
+	stubSharedEnvVarDeleteSession(t, &deleted)
+
+	cmd := webXcodeCloudEnvVarsSharedDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--id", "var-1",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if len(*previews) != 1 || (*previews)[0].Name != "API_KEY" {
+		t.Fatalf("expected preview for API_KEY, got %+v", *previews)
+	}
+	if len(deleted) != 1 || !strings.Contains(deleted[0], "var-1") {
+		t.Fatalf("expected DELETE for var-1, got %v", deleted)
+	}
+}
+
+func TestEnvVarsDelete_NonInteractiveRequiresConfirm(t *testing.T) {
+	origCanPrompt := envVarDeleteCanPromptFn
+	t.Cleanup(func() { envVarDeleteCanPromptFn = origCanPrompt })
+	envVarDeleteCanPromptFn = func() bool { return false }
+
+	cmd := webXcodeCloudEnvVarsDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--name", "API_KEY",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--confirm is required") {
+		t.Fatalf("expected --confirm error, got %q", stderr)
+	}
+}
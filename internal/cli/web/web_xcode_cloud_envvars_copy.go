@@ -0,0 +1,266 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIEnvVarCopyItem is one variable copied to the destination workflow.
+type CIEnvVarCopyItem struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// CIEnvVarCopySkip is one source variable that was not copied.
+type CIEnvVarCopySkip struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// CIEnvVarsCopyResult is the output type for the env-vars copy command.
+type CIEnvVarsCopyResult struct {
+	ProductID        string             `json:"product_id"`
+	FromWorkflowID   string             `json:"from_workflow_id"`
+	FromWorkflowName string             `json:"from_workflow_name"`
+	ToWorkflowID     string             `json:"to_workflow_id"`
+	ToWorkflowName   string             `json:"to_workflow_name"`
+	Copied           []CIEnvVarCopyItem `json:"copied"`
+	Skipped          []CIEnvVarCopySkip `json:"skipped"`
+}
+
+func webXcodeCloudEnvVarsCopyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars copy", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	fromWorkflow := fs.String("from-workflow", "", "Workflow ID to copy variables from (required)")
+	toWorkflow := fs.String("to-workflow", "", "Workflow ID to copy variables to (required)")
+	name := fs.String("name", "", "Environment variable name to copy (exactly one of --name or --all)")
+	all := fs.Bool("all", false, "Copy every plaintext variable; secrets are skipped (exactly one of --name or --all)")
+
+	return &ffcli.Command{
+		Name:       "copy",
+		ShortUsage: "asc web xcode-cloud env-vars copy --product-id ID --from-workflow ID --to-workflow ID (--name NAME | --all) [flags]",
+		ShortHelp:  "EXPERIMENTAL: Copy plaintext environment variables between workflows.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Copy environment variables from one Xcode Cloud workflow to another in the same
+product, without having to know their values.
+
+Only plaintext values can be copied: secret values cannot be read back from the
+API. With --name, copying a secret fails; with --all, secrets are skipped and
+listed under "skipped". Variables that already exist on the destination are
+updated in place (names match case-insensitively); the destination is written
+once, and only when something changed.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars copy --product-id "UUID" --from-workflow "WF-A" --to-workflow "WF-B" --name API_URL --apple-id "user@example.com"
+  asc web xcode-cloud env-vars copy --product-id "UUID" --from-workflow "WF-A" --to-workflow "WF-B" --all --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			fromID := strings.TrimSpace(*fromWorkflow)
+			toID := strings.TrimSpace(*toWorkflow)
+			if fromID == "" || toID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --from-workflow and --to-workflow are required")
+				return flag.ErrHelp
+			}
+			if strings.EqualFold(fromID, toID) {
+				fmt.Fprintln(os.Stderr, "Error: --from-workflow and --to-workflow must differ")
+				return flag.ErrHelp
+			}
+			varName := strings.TrimSpace(*name)
+			if (varName != "") == *all {
+				fmt.Fprintln(os.Stderr, "Error: exactly one of --name or --all is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars copy failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var result *CIEnvVarsCopyResult
+			err = withWebSpinner("Copying Xcode Cloud workflow environment variables", func() error {
+				source, err := client.GetCIWorkflow(requestCtx, teamID, pid, fromID)
+				if err != nil {
+					return err
+				}
+				sourceVars, err := webcore.ExtractEnvVars(source.Content)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars copy failed: %w", err)
+				}
+				candidates, skipped, err := selectEnvVarsToCopy(sourceVars, varName)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars copy failed: %w", err)
+				}
+
+				destination, err := client.GetCIWorkflow(requestCtx, teamID, pid, toID)
+				if err != nil {
+					return err
+				}
+				destinationVars, err := webcore.ExtractEnvVars(destination.Content)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars copy failed: %w", err)
+				}
+				merged, copied := mergeCopiedEnvVars(destinationVars, candidates)
+				if envVarCopyHasChanges(copied) {
+					newContent, err := webcore.SetEnvVars(destination.Content, merged)
+					if err != nil {
+						return fmt.Errorf("xcode-cloud env-vars copy failed: %w", err)
+					}
+					if err := client.UpdateCIWorkflow(requestCtx, teamID, pid, toID, newContent); err != nil {
+						return err
+					}
+				}
+
+				result = &CIEnvVarsCopyResult{
+					ProductID:        pid,
+					FromWorkflowID:   fromID,
+					FromWorkflowName: extractWorkflowName(source.Content),
+					ToWorkflowID:     toID,
+					ToWorkflowName:   extractWorkflowName(destination.Content),
+					Copied:           copied,
+					Skipped:          skipped,
+				}
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars copy")
+			}
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsCopyTable(result) },
+				func() error { return renderEnvVarsCopyMarkdown(result) },
+			)
+		},
+	}
+}
+
+// selectEnvVarsToCopy picks the source variables to copy. With a name, the
+// variable must exist and be plaintext; without one, every plaintext variable
+// is selected and secrets are reported as skipped.
+func selectEnvVarsToCopy(vars []webcore.CIEnvironmentVariable, name string) ([]webcore.CIEnvironmentVariable, []CIEnvVarCopySkip, error) {
+	skipped := []CIEnvVarCopySkip{}
+	if name != "" {
+		for _, v := range vars {
+			if !strings.EqualFold(strings.TrimSpace(v.Name), name) {
+				continue
+			}
+			if v.Value.Plaintext == nil {
+				return nil, nil, fmt.Errorf("%s is a secret; secret values cannot be read back, so set it on the destination with env-vars set --secret", v.Name)
+			}
+			return []webcore.CIEnvironmentVariable{v}, skipped, nil
+		}
+		return nil, nil, fmt.Errorf("environment variable %q not found on the source workflow", name)
+	}
+
+	selected := make([]webcore.CIEnvironmentVariable, 0, len(vars))
+	for _, v := range vars {
+		if v.Value.Plaintext == nil {
+			skipped = append(skipped, CIEnvVarCopySkip{Name: v.Name, Reason: "secret"})
+			continue
+		}
+		selected = append(selected, v)
+	}
+	return selected, skipped, nil
+}
+
+// mergeCopiedEnvVars applies the copied plaintext values to the destination:
+// existing variables are updated in place and new ones are appended.
+func mergeCopiedEnvVars(destination, copies []webcore.CIEnvironmentVariable) ([]webcore.CIEnvironmentVariable, []CIEnvVarCopyItem) {
+	merged := append([]webcore.CIEnvironmentVariable(nil), destination...)
+	copied := make([]CIEnvVarCopyItem, 0, len(copies))
+	for _, source := range copies {
+		value, _ := newEnvVarValue(*source.Value.Plaintext, false, nil)
+		item := CIEnvVarCopyItem{Name: source.Name, Action: envVarApplyCreate}
+		found := false
+		for i := range merged {
+			if !strings.EqualFold(strings.TrimSpace(merged[i].Name), strings.TrimSpace(source.Name)) {
+				continue
+			}
+			found = true
+			if merged[i].Value.Plaintext != nil && *merged[i].Value.Plaintext == *source.Value.Plaintext {
+				item.Action = envVarApplyUnchanged
+			} else {
+				item.Action = envVarApplyUpdate
+				merged[i].Value = value
+			}
+			break
+		}
+		if !found {
+			merged = append(merged, webcore.CIEnvironmentVariable{ID: newUUID(), Name: source.Name, Value: value})
+		}
+		copied = append(copied, item)
+	}
+	return merged, copied
+}
+
+func envVarCopyHasChanges(copied []CIEnvVarCopyItem) bool {
+	for _, item := range copied {
+		if item.Action != envVarApplyUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnvVarsCopySummary(result *CIEnvVarsCopyResult) string {
+	return fmt.Sprintf(
+		"Copied from %s to %s: %d copied, %d skipped",
+		formatEnvVarWorkflowLabel(result.FromWorkflowName, result.FromWorkflowID),
+		formatEnvVarWorkflowLabel(result.ToWorkflowName, result.ToWorkflowID),
+		len(result.Copied), len(result.Skipped),
+	)
+}
+
+func renderEnvVarsCopyTable(result *CIEnvVarsCopyResult) error {
+	fmt.Println(formatEnvVarsCopySummary(result))
+	fmt.Println()
+	asc.RenderTable([]string{"Name", "Action", "Reason"}, buildEnvVarCopyRows(result))
+	return nil
+}
+
+func renderEnvVarsCopyMarkdown(result *CIEnvVarsCopyResult) error {
+	fmt.Printf("**%s**\n\n", formatEnvVarsCopySummary(result))
+	asc.RenderMarkdown([]string{"Name", "Action", "Reason"}, buildEnvVarCopyRows(result))
+	return nil
+}
+
+func buildEnvVarCopyRows(result *CIEnvVarsCopyResult) [][]string {
+	rows := make([][]string, 0, len(result.Copied)+len(result.Skipped))
+	for _, item := range result.Copied {
+		rows = append(rows, []string{item.Name, item.Action, "n/a"})
+	}
+	for _, skip := range result.Skipped {
+		rows = append(rows, []string{skip.Name, "skipped", skip.Reason})
+	}
+	return rows
+}
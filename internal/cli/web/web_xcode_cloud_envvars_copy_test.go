@@ -0,0 +1,174 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// stubEnvVarCopySession serves wf-a as the source and wf-b as the destination
+// and records PUT bodies.
+func stubEnvVarCopySession(t *testing.T, putBodies *[]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	workflows := map[string]string{
+		"wf-a": `{"id":"wf-a","content":{"name":"Source","environment_variables":[
+			{"id":"a-1","name":"API_URL","value":{"plaintext":"https://api.example.com"}},
+			{"id":"a-2","name":"TOKEN","value":{"redacted_value":""}},
+			{"id":"a-3","name":"REGION","value":{"plaintext":"eu"}},
+			{"id":"a-4","name":"MODE","value":{"plaintext":"release"}}
+		]}}`,
+		"wf-b": `{"id":"wf-b","content":{"name":"Destination","environment_variables":[
+			{"id":"b-1","name":"region","value":{"plaintext":"us"}},
+			{"id":"b-2","name":"MODE","value":{"plaintext":"release"}}
+		]}}`,
+	}
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{}`
+					switch req.Method {
+					case http.MethodGet:
+						body = workflows[req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]]
+					case http.MethodPut:
+						if !strings.HasSuffix(req.URL.Path, "/wf-b") {
+							t.Fatalf("unexpected PUT to %s", req.URL.Path)
+						}
+						data, err := io.ReadAll(req.Body)
+						if err != nil {
+							t.Fatalf("failed to read PUT body: %v", err)
+						}
+						*putBodies = append(*putBodies, string(data))
+					default:
+						t.Fatalf("unexpected method: %s", req.Method)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func runEnvVarsCopyCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := webXcodeCloudEnvVarsCopyCommand()
+	if err := cmd.FlagSet.Parse(append([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--from-workflow", "wf-a",
+		"--to-workflow", "wf-b",
+	}, args...)); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var execErr error
+	stdout, _ := captureOutput(t, func() {
+		execErr = cmd.Exec(context.Background(), nil)
+	})
+	return stdout, execErr
+}
+
+func TestEnvVarsCopy_AllCopiesPlaintextAndSkipsSecrets(t *testing.T) {
+	var putBodies []string
+	stubEnvVarCopySession(t, &putBodies)
+
+	stdout, err := runEnvVarsCopyCommand(t, "--all")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var result CIEnvVarsCopyResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	var actions []string
+	for _, item := range result.Copied {
+		actions = append(actions, item.Name+"="+item.Action)
+	}
+	if strings.Join(actions, ",") != "API_URL=create,REGION=update,MODE=unchanged" {
+		t.Fatalf("unexpected copied actions: %v", actions)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Name != "TOKEN" || result.Skipped[0].Reason != "secret" {
+		t.Fatalf("unexpected skipped: %+v", result.Skipped)
+	}
+	if result.FromWorkflowName != "Source" || result.ToWorkflowName != "Destination" {
+		t.Fatalf("unexpected workflow names: %+v", result)
+	}
+
+	if len(putBodies) != 1 {
+		t.Fatalf("expected one destination update, got %d", len(putBodies))
+	}
+	vars, err := webcore.ExtractEnvVars(json.RawMessage(putBodies[0]))
+	if err != nil {
+		t.Fatalf("failed to decode PUT body: %v", err)
+	}
+	got := map[string]string{}
+	for _, v := range vars {
+		got[v.Name] = *v.Value.Plaintext
+	}
+	if len(vars) != 3 || got["region"] != "eu" || got["MODE"] != "release" || got["API_URL"] != "https://api.example.com" {
+		t.Fatalf("unexpected destination variables: %v", got)
+	}
+	if vars[0].ID != "b-1" {
+		t.Fatalf("expected existing variable to keep its ID, got %+v", vars[0])
+	}
+}
+
+func TestEnvVarsCopy_NameRejectsSecret(t *testing.T) {
+	var putBodies []string
+	stubEnvVarCopySession(t, &putBodies)
+
+	_, err := runEnvVarsCopyCommand(t, "--name", "token")
+	if err == nil || !strings.Contains(err.Error(), "TOKEN is a secret; secret values cannot be read back") {
+		t.Fatalf("expected secret error, got %v", err)
+	}
+	if len(putBodies) != 0 {
+		t.Fatalf("expected no update, got %v", putBodies)
+	}
+}
+
+func TestEnvVarsCopy_UnchangedSkipsUpdate(t *testing.T) {
+	var putBodies []string
+	stubEnvVarCopySession(t, &putBodies)
+
+	if _, err := runEnvVarsCopyCommand(t, "--name", "MODE"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(putBodies) != 0 {
+		t.Fatalf("expected no update when the value already matches, got %v", putBodies)
+	}
+}
+
+func TestEnvVarsCopy_RequiresExactlyOneOfNameOrAll(t *testing.T) {
+	for _, args := range [][]string{{}, {"--name", "A", "--all"}} {
+		cmd := webXcodeCloudEnvVarsCopyCommand()
+		if err := cmd.FlagSet.Parse(append([]string{"--product-id", "p", "--from-workflow", "a", "--to-workflow", "b"}, args...)); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", err)
+			}
+		})
+		if !strings.Contains(stderr, "exactly one of --name or --all is required") {
+			t.Fatalf("unexpected stderr for %v: %q", args, stderr)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIEnvVarsGetResult is the output type for the env-vars get command. Value is
+// only set for plaintext variables.
+type CIEnvVarsGetResult struct {
+	WorkflowID   string  `json:"workflow_id"`
+	WorkflowName string  `json:"workflow_name"`
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	Value        *string `json:"value,omitempty"`
+}
+
+// CISharedEnvVarsGetResult is the output type for the env-vars shared get
+// command. Value is only set for plaintext variables.
+type CISharedEnvVarsGetResult struct {
+	ProductID string                             `json:"product_id"`
+	ID        string                             `json:"id"`
+	Name      string                             `json:"name"`
+	Type      string                             `json:"type"`
+	Value     *string                            `json:"value,omitempty"`
+	IsLocked  bool                               `json:"is_locked"`
+	Workflows []webcore.CIRelatedWorkflowSummary `json:"related_workflow_summaries,omitempty"`
+}
+
+func webXcodeCloudEnvVarsGetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars get", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
+	name := fs.String("name", "", "Environment variable name (required, case-insensitive)")
+
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "asc web xcode-cloud env-vars get --product-id ID --workflow-id ID --name NAME [flags]",
+		ShortHelp:  "EXPERIMENTAL: Show one workflow environment variable.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Show a single workflow environment variable: its type (plaintext or secret) and,
+for plaintext variables, its value. Secret values are never included.
+A variable that does not exist fails with the not-found exit code (4), so the
+command doubles as an existence check in shell scripts.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars get --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --apple-id "user@example.com"
+  asc web xcode-cloud env-vars get --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --apple-id "user@example.com" | jq -r .type
+  asc web xcode-cloud env-vars get --product-id "UUID" --workflow-id "WF-UUID" --name MY_VAR --apple-id "user@example.com" >/dev/null && echo present`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			wfID := strings.TrimSpace(*workflowID)
+			if wfID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --workflow-id is required")
+				return flag.ErrHelp
+			}
+			varName := strings.TrimSpace(*name)
+			if varName == "" {
+				fmt.Fprintln(os.Stderr, "Error: --name is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars get failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var workflow *webcore.CIWorkflowFull
+			var vars []webcore.CIEnvironmentVariable
+			err = withWebSpinner("Loading Xcode Cloud workflow environment variables", func() error {
+				var err error
+				workflow, err = client.GetCIWorkflow(requestCtx, teamID, pid, wfID)
+				if err != nil {
+					return err
+				}
+				vars, err = webcore.ExtractEnvVars(workflow.Content)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars get failed: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars get")
+			}
+
+			index := findEnvVarByName(vars, varName)
+			if index < 0 {
+				return newEnvVarNotFoundError("environment variable %q not found in workflow %s", varName, wfID)
+			}
+			variable := vars[index]
+			result := &CIEnvVarsGetResult{
+				WorkflowID:   wfID,
+				WorkflowName: extractWorkflowName(workflow.Content),
+				Name:         variable.Name,
+				Type:         envVarValueType(variable.Value),
+				Value:        plaintextEnvVarValue(variable.Value),
+			}
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error {
+					asc.RenderTable(envVarHeaders(), buildEnvVarRows([]webcore.CIEnvironmentVariable{variable}, false))
+					return nil
+				},
+				func() error {
+					asc.RenderMarkdown(envVarHeaders(), buildEnvVarRows([]webcore.CIEnvironmentVariable{variable}, false))
+					return nil
+				},
+			)
+		},
+	}
+}
+
+func webXcodeCloudEnvVarsSharedGetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars shared get", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	name := fs.String("name", "", "Environment variable name, case-insensitive (exactly one of --name or --id)")
+	varIDFlag := fs.String("id", "", "Environment variable ID (exactly one of --name or --id)")
+
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "asc web xcode-cloud env-vars shared get --product-id ID (--name NAME | --id ID) [flags]",
+		ShortHelp:  "EXPERIMENTAL: Show one shared environment variable.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Show a single shared (product-level) environment variable: its type (plaintext or
+secret), its value for plaintext variables, whether it is locked, and the workflows
+it is linked to. Secret values are never included.
+A variable that does not exist fails with the not-found exit code (4), so the
+command doubles as an existence check in shell scripts.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars shared get --product-id "UUID" --name MY_VAR --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared get --product-id "UUID" --id "VAR_ID" --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			varName := strings.TrimSpace(*name)
+			varID := strings.TrimSpace(*varIDFlag)
+			if (varName == "") == (varID == "") {
+				fmt.Fprintln(os.Stderr, "Error: exactly one of --name or --id is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars shared get failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			vars, err := withWebSpinnerValue("Loading shared Xcode Cloud environment variables", func() ([]webcore.CIProductEnvironmentVariable, error) {
+				return client.ListCIProductEnvVars(requestCtx, teamID, pid)
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars shared get")
+			}
+
+			var target *webcore.CIProductEnvironmentVariable
+			for i, v := range vars {
+				if (varID != "" && v.ID == varID) || (varID == "" && strings.EqualFold(v.Name, varName)) {
+					target = &vars[i]
+					break
+				}
+			}
+			if target == nil {
+				if varID != "" {
+					return newEnvVarNotFoundError("shared environment variable with ID %q not found in product %s", varID, pid)
+				}
+				return newEnvVarNotFoundError("shared environment variable %q not found in product %s", varName, pid)
+			}
+			result := &CISharedEnvVarsGetResult{
+				ProductID: pid,
+				ID:        target.ID,
+				Name:      target.Name,
+				Type:      envVarValueType(target.Value),
+				Value:     plaintextEnvVarValue(target.Value),
+				IsLocked:  target.IsLocked,
+				Workflows: target.RelatedWorkflowSummaries,
+			}
+			rows := buildSharedEnvVarRows([]webcore.CIProductEnvironmentVariable{*target})
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error {
+					asc.RenderTable(sharedEnvVarHeaders(), rows)
+					return nil
+				},
+				func() error {
+					asc.RenderMarkdown(sharedEnvVarHeaders(), rows)
+					return nil
+				},
+			)
+		},
+	}
+}
+
+// findEnvVarByName returns the index of the variable named name, compared
+// case-insensitively like delete, or -1.
+func findEnvVarByName(vars []webcore.CIEnvironmentVariable, name string) int {
+	for i, v := range vars {
+		if strings.EqualFold(v.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// plaintextEnvVarValue returns a copy of a plaintext value, or nil for
+// secrets so they are never echoed.
+func plaintextEnvVarValue(value webcore.CIEnvironmentVariableValue) *string {
+	if envVarValueType(value) != "plaintext" || value.Plaintext == nil {
+		return nil
+	}
+	plaintext := *value.Plaintext
+	return &plaintext
+}
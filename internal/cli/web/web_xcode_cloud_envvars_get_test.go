@@ -0,0 +1,166 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestEnvVarsGet_PlaintextAndSecret(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"id":"wf-1","content":{"name":"WF","environment_variables":[
+			{"id":"ev-1","name":"API_URL","value":{"plaintext":"https://example.com"}},
+			{"id":"ev-2","name":"API_TOKEN","value":{"redacted_value":"****"}}
+		]}}`
+	})
+
+	tests := []struct {
+		name      string
+		lookup    string
+		wantName  string
+		wantType  string
+		wantValue *string
+	}{
+		{name: "plaintext", lookup: "api_url", wantName: "API_URL", wantType: "plaintext", wantValue: new("https://example.com")},
+		{name: "secret", lookup: "API_TOKEN", wantName: "API_TOKEN", wantType: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := webXcodeCloudEnvVarsGetCommand()
+			if err := cmd.FlagSet.Parse([]string{
+				"--apple-id", "user@example.com",
+				"--product-id", "prod-1",
+				"--workflow-id", "wf-1",
+				"--name", tt.lookup,
+			}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			stdout, _ := captureOutput(t, func() {
+				if err := cmd.Exec(context.Background(), nil); err != nil {
+					t.Fatalf("exec error: %v", err)
+				}
+			})
+			var result CIEnvVarsGetResult
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("expected JSON output, got %v\noutput: %q", err, stdout)
+			}
+			if result.Name != tt.wantName || result.Type != tt.wantType || result.WorkflowName != "WF" {
+				t.Fatalf("unexpected result %+v", result)
+			}
+			switch {
+			case tt.wantValue == nil && result.Value != nil:
+				t.Fatalf("expected no value for a secret, got %q", *result.Value)
+			case tt.wantValue != nil && (result.Value == nil || *result.Value != *tt.wantValue):
+				t.Fatalf("expected value %q, got %+v", *tt.wantValue, result.Value)
+			}
+			if strings.Contains(stdout, "****") {
+				t.Fatalf("expected secret value to be omitted, got %q", stdout)
+			}
+		})
+	}
+}
+
+func TestEnvVarsGet_MissingIsNotFound(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"id":"wf-1","content":{"name":"WF","environment_variables":[]}}`
+	})
+
+	cmd := webXcodeCloudEnvVarsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--name", "GONE",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, ErrEnvVarNotFound) || !errors.Is(err, asc.ErrNotFound) {
+			t.Fatalf("expected a not-found error, got %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected no output for a missing variable, got %q", stdout)
+	}
+}
+
+func TestEnvVarsGet_RequiresName(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--workflow-id", "wf-1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--name is required") {
+		t.Fatalf("expected --name error, got %q", stderr)
+	}
+}
+
+func TestSharedEnvVarsGet(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `[
+			{"id":"var-1","name":"API_URL","value":{"plaintext":"https://example.com"},"is_locked":true,"related_workflow_summaries":[{"id":"wf-1","name":"CI"}]},
+			{"id":"var-2","name":"API_TOKEN","value":{"ciphertext":"abc"},"is_locked":false}
+		]`
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantID   string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "by name", args: []string{"--name", "api_url"}, wantID: "var-1", wantType: "plaintext"},
+		{name: "by id", args: []string{"--id", "var-2"}, wantID: "var-2", wantType: "secret"},
+		{name: "missing", args: []string{"--name", "GONE"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := webXcodeCloudEnvVarsSharedGetCommand()
+			args := append([]string{"--apple-id", "user@example.com", "--product-id", "prod-1"}, tt.args...)
+			if err := cmd.FlagSet.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+
+			var runErr error
+			stdout, _ := captureOutput(t, func() {
+				runErr = cmd.Exec(context.Background(), nil)
+			})
+			if tt.wantErr {
+				if !errors.Is(runErr, ErrEnvVarNotFound) {
+					t.Fatalf("expected ErrEnvVarNotFound, got %v", runErr)
+				}
+				return
+			}
+			if runErr != nil {
+				t.Fatalf("exec error: %v", runErr)
+			}
+			var result CISharedEnvVarsGetResult
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("expected JSON output, got %v\noutput: %q", err, stdout)
+			}
+			if result.ID != tt.wantID || result.Type != tt.wantType || result.ProductID != "prod-1" {
+				t.Fatalf("unexpected result %+v", result)
+			}
+			if tt.wantType == "plaintext" && (result.Value == nil || !result.IsLocked || len(result.Workflows) != 1) {
+				t.Fatalf("expected plaintext value, lock, and linked workflow, got %+v", result)
+			}
+			if tt.wantType == "secret" && result.Value != nil {
+				t.Fatalf("expected no value for a secret, got %q", *result.Value)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIEnvVarsListAllResult is the output type for the env-vars list-all command.
+// Workflows is keyed by workflow ID.
+type CIEnvVarsListAllResult struct {
+	ProductID string                                `json:"product_id"`
+	Workflows map[string]CIEnvVarsWorkflowVariables `json:"workflows"`
+	emptyResultNote
+}
+
+// CIEnvVarsWorkflowVariables holds the variables defined on one workflow.
+type CIEnvVarsWorkflowVariables struct {
+	WorkflowName string                          `json:"workflow_name"`
+	Variables    []webcore.CIEnvironmentVariable `json:"variables"`
+}
+
+func webXcodeCloudEnvVarsListAllCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars list-all", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+	shared.AllowTSVOutput(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	mask := fs.Bool("mask", false, "Mask plaintext values in table/markdown output")
+	maskJSON := fs.Bool("mask-json", false, "Mask plaintext values in JSON output")
+	redactTeam := bindRedactTeamFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "list-all",
+		ShortUsage: "asc web xcode-cloud env-vars list-all --product-id ID [flags]",
+		ShortHelp:  "EXPERIMENTAL: List environment variables for every workflow.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+List environment variables for every workflow of an Xcode Cloud product.
+Workflows are fetched concurrently, capped by the global --concurrency flag.
+JSON output groups variables under "workflows", keyed by workflow ID; table,
+markdown, and tsv output combine them into one list with a Workflow column.
+Plaintext variables show their values; secret variables show "(redacted)".
+Use audit instead for a values-free report that includes shared variables.
+Use --mask to show only the first two characters of plaintext values in
+table/markdown output, and --mask-json to do the same for JSON output.
+Use --redact-team to replace the team ID inside plaintext values with a stable hashed pseudonym.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars list-all --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud env-vars list-all --product-id "UUID" --apple-id "user@example.com" --output table --mask`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars list-all failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var workflowVars []ciWorkflowEnvVars
+			err = withWebSpinner("Loading Xcode Cloud environment variables for all workflows", func() error {
+				workflows, err := client.ListCIWorkflows(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				primeWorkflowNameCache(teamID, pid, workflows.Items)
+				workflowVars, err = fetchCIWorkflowEnvVars(requestCtx, client, teamID, pid, workflows.Items, shared.Concurrency())
+				return err
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars list-all")
+			}
+
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			result := &CIEnvVarsListAllResult{
+				ProductID: pid,
+				Workflows: make(map[string]CIEnvVarsWorkflowVariables, len(workflowVars)),
+			}
+			total := 0
+			for _, entry := range workflowVars {
+				vars := redactor.EnvVars(entry.vars)
+				total += len(vars)
+				result.Workflows[entry.workflow.ID] = CIEnvVarsWorkflowVariables{
+					WorkflowName: entry.workflow.Content.Name,
+					Variables:    vars,
+				}
+			}
+			result.emptyResultNote = newEmptyResultNote(total, emptyEnvVarsMessage)
+
+			jsonResult := result
+			if *maskJSON {
+				masked := *result
+				masked.Workflows = make(map[string]CIEnvVarsWorkflowVariables, len(result.Workflows))
+				for id, workflow := range result.Workflows {
+					workflow.Variables = maskEnvVarValues(workflow.Variables)
+					masked.Workflows[id] = workflow
+				}
+				jsonResult = &masked
+			}
+			if err := shared.PrintOutputWithTabular(
+				jsonResult,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsListAllTable(result, *mask) },
+				func() error { return renderEnvVarsListAllMarkdown(result, *mask) },
+				func() ([]string, [][]string) {
+					return envVarsListAllHeaders(), buildEnvVarsListAllRows(result, *mask)
+				},
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, total, "xcode-cloud env-vars list-all")
+		},
+	}
+}
+
+func renderEnvVarsListAllTable(result *CIEnvVarsListAllResult, mask bool) error {
+	rows := buildEnvVarsListAllRows(result, mask)
+	if len(rows) == 0 {
+		fmt.Println(emptyEnvVarsMessage)
+		return nil
+	}
+	asc.RenderTable(envVarsListAllHeaders(), rows)
+	return nil
+}
+
+func renderEnvVarsListAllMarkdown(result *CIEnvVarsListAllResult, mask bool) error {
+	rows := buildEnvVarsListAllRows(result, mask)
+	if len(rows) == 0 {
+		fmt.Println(emptyEnvVarsMessage)
+		return nil
+	}
+	asc.RenderMarkdown(envVarsListAllHeaders(), rows)
+	return nil
+}
+
+func envVarsListAllHeaders() []string {
+	return append([]string{"Workflow"}, envVarHeaders()...)
+}
+
+// buildEnvVarsListAllRows flattens the per-workflow variables into rows ordered
+// by workflow name, then workflow ID. Unnamed workflows show their ID.
+func buildEnvVarsListAllRows(result *CIEnvVarsListAllResult, mask bool) [][]string {
+	if result == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(result.Workflows))
+	for id := range result.Workflows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		left, right := result.Workflows[ids[i]].WorkflowName, result.Workflows[ids[j]].WorkflowName
+		if left != right {
+			return left < right
+		}
+		return ids[i] < ids[j]
+	})
+
+	var rows [][]string
+	for _, id := range ids {
+		workflow := result.Workflows[id]
+		label := workflow.WorkflowName
+		if label == "" {
+			label = id
+		}
+		for _, row := range buildEnvVarRows(workflow.Variables, mask) {
+			rows = append(rows, append([]string{label}, row...))
+		}
+	}
+	return rows
+}
@@ -0,0 +1,152 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func stubEnvVarsListAllSession(t *testing.T, workflowFetches *atomic.Int32) {
+	t.Helper()
+	t.Cleanup(resetWorkflowNameCache)
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/workflows-v15"):
+			return http.StatusOK, `{"items":[{"id":"wf-2","content":{"name":"Release"}},{"id":"wf-1","content":{"name":"CI"}}]}`
+		case strings.HasSuffix(req.URL.Path, "/workflows-v15/wf-1"):
+			workflowFetches.Add(1)
+			return http.StatusOK, `{"id":"wf-1","content":{"name":"CI","environment_variables":[{"id":"v1","name":"API_URL","value":{"plaintext":"https://example.com"}},{"id":"v2","name":"TOKEN","value":{"redacted_value":"***"}}]}}`
+		case strings.HasSuffix(req.URL.Path, "/workflows-v15/wf-2"):
+			workflowFetches.Add(1)
+			return http.StatusOK, `{"id":"wf-2","content":{"name":"Release","environment_variables":[{"id":"v3","name":"CHANNEL","value":{"plaintext":"stable"}}]}}`
+		default:
+			return http.StatusNotFound, `{}`
+		}
+	})
+}
+
+func TestEnvVarsListAll_MissingProductID(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsListAllCommand()
+	if err := cmd.FlagSet.Parse([]string{}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--product-id is required") {
+		t.Fatalf("expected product-id error in stderr, got %q", stderr)
+	}
+}
+
+func TestEnvVarsListAll_JSONKeyedByWorkflow(t *testing.T) {
+	var workflowFetches atomic.Int32
+	stubEnvVarsListAllSession(t, &workflowFetches)
+
+	cmd := webXcodeCloudEnvVarsListAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--mask-json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if got := workflowFetches.Load(); got != 2 {
+		t.Fatalf("expected 2 workflow fetches, got %d", got)
+	}
+
+	var result CIEnvVarsListAllResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+	}
+	if result.ProductID != "prod-1" || len(result.Workflows) != 2 {
+		t.Fatalf("expected 2 workflows for prod-1, got %+v", result)
+	}
+	ci := result.Workflows["wf-1"]
+	if ci.WorkflowName != "CI" || len(ci.Variables) != 2 {
+		t.Fatalf("unexpected wf-1 entry: %+v", ci)
+	}
+	if ci.Variables[0].Value.Plaintext == nil || *ci.Variables[0].Value.Plaintext == "https://example.com" {
+		t.Fatalf("expected masked plaintext value, got %+v", ci.Variables[0].Value)
+	}
+	if release := result.Workflows["wf-2"]; release.WorkflowName != "Release" || len(release.Variables) != 1 {
+		t.Fatalf("unexpected wf-2 entry: %+v", release)
+	}
+	if result.Status != "" {
+		t.Fatalf("expected no empty status, got %q", result.Status)
+	}
+}
+
+func TestEnvVarsListAll_TableHasWorkflowColumn(t *testing.T) {
+	var workflowFetches atomic.Int32
+	stubEnvVarsListAllSession(t, &workflowFetches)
+
+	cmd := webXcodeCloudEnvVarsListAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--output", "tsv",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	want := strings.Join([]string{
+		"Workflow\tName\tType\tValue",
+		"CI\tAPI_URL\tplaintext\thttps://example.com",
+		"CI\tTOKEN\tsecret\t(redacted)",
+		"Release\tCHANNEL\tplaintext\tstable",
+	}, "\n")
+	if strings.TrimSpace(stdout) != want {
+		t.Fatalf("unexpected tsv output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestEnvVarsListAll_FailIfEmpty(t *testing.T) {
+	t.Cleanup(resetWorkflowNameCache)
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"items":[]}`
+	})
+
+	cmd := webXcodeCloudEnvVarsListAllCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--fail-if-empty",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil {
+		t.Fatal("expected --fail-if-empty to fail")
+	}
+	var result CIEnvVarsListAllResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+	}
+	if result.Status != "empty" || result.Message != emptyEnvVarsMessage {
+		t.Fatalf("expected empty note, got %+v", result)
+	}
+}
@@ -0,0 +1,204 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+// ciEnvVarManifestEntry is one variable in an env-vars apply manifest. A nil
+// WorkflowIDs keeps a shared variable's existing links; an empty list unlinks
+// it from every workflow.
+type ciEnvVarManifestEntry struct {
+	Name        string   `json:"name"`
+	Value       string   `json:"value"`
+	Secret      bool     `json:"secret,omitempty"`
+	Locked      bool     `json:"locked,omitempty"`
+	WorkflowIDs []string `json:"workflow_ids,omitempty"`
+}
+
+// CIEnvVarApplyAction is one planned or applied change from an env-vars apply.
+type CIEnvVarApplyAction struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Locked bool   `json:"locked,omitempty"`
+}
+
+const (
+	envVarApplyCreate    = "create"
+	envVarApplyUpdate    = "update"
+	envVarApplyUnchanged = "unchanged"
+	envVarApplyDelete    = "delete"
+)
+
+// parseCIEnvVarManifest reads a JSON array of manifest entries. Unknown fields,
+// trailing values, empty names or values, and duplicate names (compared
+// case-insensitively, as the API does) are rejected.
+func parseCIEnvVarManifest(path string) ([]ciEnvVarManifestEntry, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var entries []ciEnvVarManifestEntry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid env-vars manifest JSON: %w", err)
+	}
+	var trailing json.RawMessage
+	if err := decoder.Decode(&trailing); err != io.EOF {
+		if err == nil {
+			return nil, fmt.Errorf("invalid env-vars manifest JSON: multiple JSON values found")
+		}
+		return nil, fmt.Errorf("invalid env-vars manifest JSON: %w", err)
+	}
+
+	seen := make(map[string]int, len(entries))
+	for i := range entries {
+		entries[i].Name = strings.TrimSpace(entries[i].Name)
+		if entries[i].Name == "" {
+			return nil, fmt.Errorf("env-vars manifest entry %d: name is required", i+1)
+		}
+		if entries[i].Value == "" {
+			return nil, fmt.Errorf("env-vars manifest entry %d (%s): value is required", i+1, entries[i].Name)
+		}
+		key := strings.ToLower(entries[i].Name)
+		if first, exists := seen[key]; exists {
+			return nil, fmt.Errorf("env-vars manifest entry %d: duplicate name %s (first defined in entry %d)", i+1, entries[i].Name, first)
+		}
+		seen[key] = i + 1
+		if entries[i].WorkflowIDs != nil {
+			entries[i].WorkflowIDs = parseWorkflowIDs(strings.Join(entries[i].WorkflowIDs, ","))
+			if entries[i].WorkflowIDs == nil {
+				entries[i].WorkflowIDs = []string{}
+			}
+		}
+	}
+	return entries, nil
+}
+
+func manifestEntryType(entry ciEnvVarManifestEntry) string {
+	if entry.Secret {
+		return "secret"
+	}
+	return "plaintext"
+}
+
+// countEnvVarApplyActions tallies actions by kind for the apply summary.
+func countEnvVarApplyActions(actions []CIEnvVarApplyAction) (created, updated, unchanged, deleted int) {
+	for _, action := range actions {
+		switch action.Action {
+		case envVarApplyCreate:
+			created++
+		case envVarApplyUpdate:
+			updated++
+		case envVarApplyUnchanged:
+			unchanged++
+		case envVarApplyDelete:
+			deleted++
+		}
+	}
+	return created, updated, unchanged, deleted
+}
+
+// envVarApplyActionRows renders apply actions. Workflow variables cannot be
+// locked, so the Locked column is only shown for shared applies.
+func envVarApplyActionRows(actions []CIEnvVarApplyAction, showLocked bool) [][]string {
+	rows := make([][]string, 0, len(actions))
+	for _, action := range actions {
+		row := []string{action.Action, action.Name, valueOrNA(action.Type)}
+		if showLocked {
+			locked := "no"
+			if action.Locked {
+				locked = "yes"
+			}
+			row = append(row, locked)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// CIEnvVarsApplyResult is the output type for the env-vars apply commands.
+// WorkflowID is set only for workflow-scoped applies.
+type CIEnvVarsApplyResult struct {
+	ProductID    string                `json:"product_id"`
+	WorkflowID   string                `json:"workflow_id,omitempty"`
+	WorkflowName string                `json:"workflow_name,omitempty"`
+	File         string                `json:"file"`
+	DryRun       bool                  `json:"dry_run"`
+	Created      int                   `json:"created"`
+	Updated      int                   `json:"updated"`
+	Unchanged    int                   `json:"unchanged"`
+	Deleted      int                   `json:"deleted"`
+	Actions      []CIEnvVarApplyAction `json:"actions"`
+}
+
+func newCIEnvVarsApplyResult(productID, file string, dryRun bool, actions []CIEnvVarApplyAction) *CIEnvVarsApplyResult {
+	created, updated, unchanged, deleted := countEnvVarApplyActions(actions)
+	return &CIEnvVarsApplyResult{
+		ProductID: productID,
+		File:      file,
+		DryRun:    dryRun,
+		Created:   created,
+		Updated:   updated,
+		Unchanged: unchanged,
+		Deleted:   deleted,
+		Actions:   actions,
+	}
+}
+
+func formatEnvVarsApplySummary(result *CIEnvVarsApplyResult) string {
+	target := "product " + result.ProductID
+	if result.WorkflowID != "" {
+		target = "workflow " + formatEnvVarWorkflowLabel(result.WorkflowName, result.WorkflowID)
+	}
+	prefix := "Applied to " + target
+	if result.DryRun {
+		prefix = "Dry run for " + target + " (no changes applied)"
+	}
+	return fmt.Sprintf(
+		"%s: %d created, %d updated, %d unchanged, %d deleted",
+		prefix, result.Created, result.Updated, result.Unchanged, result.Deleted,
+	)
+}
+
+func renderEnvVarsApplyTable(result *CIEnvVarsApplyResult) error {
+	fmt.Println(formatEnvVarsApplySummary(result))
+	if len(result.Actions) == 0 {
+		return nil
+	}
+	fmt.Println()
+	showLocked := result.WorkflowID == ""
+	asc.RenderTable(envVarApplyActionHeaders(showLocked), envVarApplyActionRows(result.Actions, showLocked))
+	return nil
+}
+
+func renderEnvVarsApplyMarkdown(result *CIEnvVarsApplyResult) error {
+	fmt.Printf("**%s**\n", formatEnvVarsApplySummary(result))
+	if len(result.Actions) == 0 {
+		return nil
+	}
+	fmt.Println()
+	showLocked := result.WorkflowID == ""
+	asc.RenderMarkdown(envVarApplyActionHeaders(showLocked), envVarApplyActionRows(result.Actions, showLocked))
+	return nil
+}
+
+func envVarApplyActionHeaders(showLocked bool) []string {
+	if showLocked {
+		return []string{"Action", "Name", "Type", "Locked"}
+	}
+	return []string{"Action", "Name", "Type"}
+}
@@ -0,0 +1,57 @@
+package web
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// ErrEnvVarNotFound reports that the environment variable to delete does not
+// exist. Errors matching it also match asc.ErrNotFound, so the command exits
+// with the not-found code.
+var ErrEnvVarNotFound = errors.New("environment variable not found")
+
+type envVarNotFoundError struct {
+	message string
+}
+
+func (e envVarNotFoundError) Error() string {
+	return e.message
+}
+
+func (e envVarNotFoundError) Is(target error) bool {
+	return target == ErrEnvVarNotFound || target == asc.ErrNotFound
+}
+
+func newEnvVarNotFoundError(format string, args ...any) error {
+	return envVarNotFoundError{message: fmt.Sprintf(format, args...)}
+}
+
+func bindIgnoreMissingFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("ignore-missing", false, "Exit 0 when the variable does not exist (idempotent delete)")
+}
+
+// isCIResourceGone reports a 404 from a CI endpoint, such as deleting a
+// shared variable by an ID that no longer exists.
+func isCIResourceGone(err error) bool {
+	var apiErr *webcore.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// warnEnvVarAlreadyAbsent notes on stderr that --ignore-missing turned a
+// missing variable into a successful no-op.
+func warnEnvVarAlreadyAbsent(err error) {
+	fmt.Fprintf(os.Stderr, "Warning: %v; nothing to delete\n", err)
+}
+
+func envVarDeleteAction(missing bool) string {
+	if missing {
+		return "missing"
+	}
+	return "deleted"
+}
@@ -0,0 +1,186 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubEnvVarsMissingSession(t *testing.T, handler func(req *http.Request) (int, string)) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					status, body := handler(req)
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func TestEnvVarNotFoundErrorMatchesSentinels(t *testing.T) {
+	err := newEnvVarNotFoundError("environment variable %q not found in workflow %s", "X", "wf-1")
+	if !errors.Is(err, ErrEnvVarNotFound) {
+		t.Fatal("expected errors.Is(err, ErrEnvVarNotFound)")
+	}
+	if !errors.Is(err, asc.ErrNotFound) {
+		t.Fatal("expected errors.Is(err, asc.ErrNotFound) so the not-found exit code applies")
+	}
+	if err.Error() != `environment variable "X" not found in workflow wf-1` {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestEnvVarsDelete_NotFoundIsTyped(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusOK, `{"id":"wf-1","content":{"name":"WF","environment_variables":[]}}`
+	})
+
+	cmd := webXcodeCloudEnvVarsDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--name", "GONE",
+		"--confirm",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, ErrEnvVarNotFound) {
+			t.Fatalf("expected ErrEnvVarNotFound, got %v", err)
+		}
+	})
+}
+
+func TestEnvVarsDelete_IgnoreMissing(t *testing.T) {
+	var methods []string
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		methods = append(methods, req.Method)
+		return http.StatusOK, `{"id":"wf-1","content":{"name":"WF","environment_variables":[]}}`
+	})
+
+	cmd := webXcodeCloudEnvVarsDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--name", "GONE",
+		"--confirm",
+		"--ignore-missing",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("expected success with --ignore-missing, got %v", err)
+		}
+	})
+	for _, method := range methods {
+		if method != http.MethodGet {
+			t.Fatalf("expected no workflow update for a missing variable, saw %s", method)
+		}
+	}
+	var result CIEnvVarsDeleteResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v\noutput: %q", err, stdout)
+	}
+	if !result.Missing || result.Name != "GONE" || result.WorkflowName != "WF" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if !strings.Contains(stderr, "nothing to delete") {
+		t.Fatalf("expected stderr note, got %q", stderr)
+	}
+}
+
+func TestSharedEnvVarsDelete_IgnoreMissingByName(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		if req.Method != http.MethodGet {
+			t.Errorf("unexpected %s request", req.Method)
+		}
+		return http.StatusOK, `[]`
+	})
+
+	cmd := webXcodeCloudEnvVarsSharedDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--name", "GONE",
+		"--confirm",
+		"--ignore-missing",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("expected success with --ignore-missing, got %v", err)
+		}
+	})
+	var result CISharedEnvVarsDeleteResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v\noutput: %q", err, stdout)
+	}
+	if !result.Missing || result.Name != "GONE" || result.ProductID != "prod-1" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestSharedEnvVarsDelete_ByIDTreats404AsMissing(t *testing.T) {
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		return http.StatusNotFound, `{}`
+	})
+
+	for _, ignore := range []bool{false, true} {
+		cmd := webXcodeCloudEnvVarsSharedDeleteCommand()
+		args := []string{
+			"--apple-id", "user@example.com",
+			"--product-id", "prod-1",
+			"--id", "var-9",
+			"--confirm",
+		}
+		if ignore {
+			args = append(args, "--ignore-missing")
+		}
+		if err := cmd.FlagSet.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+
+		stdout, _ := captureOutput(t, func() {
+			err := cmd.Exec(context.Background(), nil)
+			if ignore && err != nil {
+				t.Fatalf("expected success with --ignore-missing, got %v", err)
+			}
+			if !ignore && !errors.Is(err, ErrEnvVarNotFound) {
+				t.Fatalf("expected ErrEnvVarNotFound, got %v", err)
+			}
+		})
+		if ignore && !strings.Contains(stdout, `"missing":true`) {
+			t.Fatalf("expected missing result, got %q", stdout)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func bindNamesOnlyFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("names-only", false, "Print only variable names, one per line (ignores --output)")
+}
+
+// printEnvVarNames writes one name per line, to stdout or --out-file, for shell
+// loops such as `while read name`. Nothing is printed when there are no
+// variables.
+func printEnvVarNames(names []string) error {
+	return shared.WithOutputDestination(func() error {
+		for _, name := range names {
+			if _, err := fmt.Println(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func envVarNames(vars []webcore.CIEnvironmentVariable) []string {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func sharedEnvVarNames(vars []webcore.CIProductEnvironmentVariable) []string {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+	return names
+}
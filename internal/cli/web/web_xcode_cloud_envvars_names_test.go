@@ -0,0 +1,68 @@
+package web
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestPrintEnvVarNames(t *testing.T) {
+	stdout, _ := captureOutput(t, func() {
+		if err := printEnvVarNames(envVarNames([]webcore.CIEnvironmentVariable{{Name: "API_URL"}, {Name: "TOKEN"}})); err != nil {
+			t.Fatalf("printEnvVarNames() error: %v", err)
+		}
+	})
+	if stdout != "API_URL\nTOKEN\n" {
+		t.Fatalf("unexpected names output %q", stdout)
+	}
+
+	stdout, _ = captureOutput(t, func() {
+		if err := printEnvVarNames(sharedEnvVarNames(nil)); err != nil {
+			t.Fatalf("printEnvVarNames() error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected no output for no variables, got %q", stdout)
+	}
+}
+
+func TestPrintEnvVarNamesHonorsOutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "names.txt")
+	bindOutFileForTest(t, path)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := printEnvVarNames([]string{"API_URL"}); err != nil {
+			t.Fatalf("printEnvVarNames() error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected nothing on stdout, got %q", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read out file: %v", err)
+	}
+	if string(data) != "API_URL\n" {
+		t.Fatalf("unexpected file contents %q", data)
+	}
+}
+
+// bindOutFileForTest sets --out-file to path and clears it when the test ends.
+func bindOutFileForTest(t *testing.T, path string) {
+	t.Helper()
+	bind := func(args ...string) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		shared.BindOutFileFlag(fs)
+		if err := fs.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+	}
+	bind("--out-file", path)
+	t.Cleanup(func() { bind() })
+}
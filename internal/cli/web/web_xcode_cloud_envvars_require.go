@@ -0,0 +1,202 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	envVarRequireStatusOK        = "ok"
+	envVarRequireStatusMissing   = "missing"
+	envVarRequireStatusNotSecret = "not_secret"
+)
+
+// CIEnvVarRequireCheck is the outcome for one required variable name.
+type CIEnvVarRequireCheck struct {
+	Name   string `json:"name"`
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status"`
+}
+
+// CIEnvVarsRequireResult is the output type for the env-vars require command.
+type CIEnvVarsRequireResult struct {
+	ProductID     string                 `json:"product_id"`
+	WorkflowID    string                 `json:"workflow_id"`
+	WorkflowName  string                 `json:"workflow_name"`
+	RequireSecret bool                   `json:"require_secret"`
+	Passed        bool                   `json:"passed"`
+	Missing       []string               `json:"missing"`
+	NotSecret     []string               `json:"not_secret,omitempty"`
+	Checks        []CIEnvVarRequireCheck `json:"checks"`
+}
+
+func webXcodeCloudEnvVarsRequireCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars require", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	workflowID := fs.String("workflow-id", "", "Xcode Cloud workflow ID (required)")
+	names := fs.String("names", "", "Comma-separated environment variable names that must exist (required)")
+	requireSecret := fs.Bool("require-secret", false, "Also require every listed variable to be a secret")
+
+	return &ffcli.Command{
+		Name:       "require",
+		ShortUsage: "asc web xcode-cloud env-vars require --product-id ID --workflow-id ID --names NAMES [--require-secret] [flags]",
+		ShortHelp:  "EXPERIMENTAL: Fail when required workflow environment variables are missing.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Check that every name in --names is defined as an environment variable on an
+Xcode Cloud workflow, for CI preflight before triggering a build. Names match
+case-insensitively. Only workflow-scoped variables are checked; shared
+variables linked to the workflow do not count.
+
+Use --require-secret to also fail when a listed variable is plaintext.
+
+The command prints a check per name and exits non-zero listing any missing
+(or non-secret) variables.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars require --product-id "UUID" --workflow-id "WF-UUID" --names API_KEY,SIGNING_CERT --apple-id "user@example.com"
+  asc web xcode-cloud env-vars require --product-id "UUID" --workflow-id "WF-UUID" --names API_KEY,SIGNING_CERT --require-secret --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			wfID := strings.TrimSpace(*workflowID)
+			if wfID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --workflow-id is required")
+				return flag.ErrHelp
+			}
+			required := shared.SplitUniqueCSV(*names)
+			if len(required) == 0 {
+				fmt.Fprintln(os.Stderr, "Error: --names is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars require failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var result *CIEnvVarsRequireResult
+			err = withWebSpinner("Checking Xcode Cloud workflow environment variables", func() error {
+				workflow, err := client.GetCIWorkflow(requestCtx, teamID, pid, wfID)
+				if err != nil {
+					return err
+				}
+				vars, err := webcore.ExtractEnvVars(workflow.Content)
+				if err != nil {
+					return fmt.Errorf("xcode-cloud env-vars require failed: %w", err)
+				}
+				result = checkRequiredEnvVars(vars, required, *requireSecret)
+				result.ProductID = pid
+				result.WorkflowID = wfID
+				result.WorkflowName = extractWorkflowName(workflow.Content)
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars require")
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsRequireTable(result) },
+				func() error { return renderEnvVarsRequireMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			if !result.Passed {
+				return fmt.Errorf("xcode-cloud env-vars require failed: %s", formatEnvVarsRequireFailures(result))
+			}
+			return nil
+		},
+	}
+}
+
+// checkRequiredEnvVars checks each required name against the workflow's
+// variables, in the order the names were given.
+func checkRequiredEnvVars(vars []webcore.CIEnvironmentVariable, required []string, requireSecret bool) *CIEnvVarsRequireResult {
+	byName := make(map[string]webcore.CIEnvironmentVariable, len(vars))
+	for _, v := range vars {
+		byName[strings.ToLower(strings.TrimSpace(v.Name))] = v
+	}
+
+	result := &CIEnvVarsRequireResult{
+		RequireSecret: requireSecret,
+		Missing:       []string{},
+		Checks:        make([]CIEnvVarRequireCheck, 0, len(required)),
+	}
+	for _, name := range required {
+		check := CIEnvVarRequireCheck{Name: name, Status: envVarRequireStatusOK}
+		v, ok := byName[strings.ToLower(name)]
+		switch {
+		case !ok:
+			check.Status = envVarRequireStatusMissing
+			result.Missing = append(result.Missing, name)
+		default:
+			check.Type = envVarValueType(v.Value)
+			if requireSecret && check.Type != "secret" {
+				check.Status = envVarRequireStatusNotSecret
+				result.NotSecret = append(result.NotSecret, name)
+			}
+		}
+		result.Checks = append(result.Checks, check)
+	}
+	result.Passed = len(result.Missing) == 0 && len(result.NotSecret) == 0
+	return result
+}
+
+func formatEnvVarsRequireFailures(result *CIEnvVarsRequireResult) string {
+	parts := make([]string, 0, 2)
+	if len(result.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(result.Missing, ", "))
+	}
+	if len(result.NotSecret) > 0 {
+		parts = append(parts, "not secret "+strings.Join(result.NotSecret, ", "))
+	}
+	return fmt.Sprintf("workflow %s: %s", formatEnvVarWorkflowLabel(result.WorkflowName, result.WorkflowID), strings.Join(parts, "; "))
+}
+
+func renderEnvVarsRequireTable(result *CIEnvVarsRequireResult) error {
+	asc.RenderTable([]string{"Name", "Type", "Status"}, buildEnvVarRequireRows(result.Checks))
+	return nil
+}
+
+func renderEnvVarsRequireMarkdown(result *CIEnvVarsRequireResult) error {
+	asc.RenderMarkdown([]string{"Name", "Type", "Status"}, buildEnvVarRequireRows(result.Checks))
+	return nil
+}
+
+func buildEnvVarRequireRows(checks []CIEnvVarRequireCheck) [][]string {
+	rows := make([][]string, 0, len(checks))
+	for _, check := range checks {
+		rows = append(rows, []string{check.Name, valueOrNA(check.Type), check.Status})
+	}
+	return rows
+}
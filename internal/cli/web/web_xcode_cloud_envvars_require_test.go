@@ -0,0 +1,109 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const requireEnvVarsWorkflowBody = `{"id":"wf-1","content":{"name":"Release","environment_variables":[
+	{"id":"v-1","name":"API_KEY","value":{"redacted_value":""}},
+	{"id":"v-2","name":"SIGNING_CERT","value":{"plaintext":"cert"}}
+]}}`
+
+func runEnvVarsRequireCommand(t *testing.T, args ...string) (CIEnvVarsRequireResult, error) {
+	t.Helper()
+	var putBodies []string
+	stubWorkflowEnvVarsApplySession(t, requireEnvVarsWorkflowBody, &putBodies)
+
+	cmd := webXcodeCloudEnvVarsRequireCommand()
+	if err := cmd.FlagSet.Parse(append([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+	}, args...)); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var execErr error
+	stdout, _ := captureOutput(t, func() {
+		execErr = cmd.Exec(context.Background(), nil)
+	})
+	if len(putBodies) != 0 {
+		t.Fatalf("expected require to be read-only, got PUT %v", putBodies)
+	}
+	var result CIEnvVarsRequireResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	return result, execErr
+}
+
+func TestEnvVarsRequire_Passes(t *testing.T) {
+	result, err := runEnvVarsRequireCommand(t, "--names", "api_key, SIGNING_CERT")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Passed || len(result.Checks) != 2 || result.WorkflowName != "Release" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Checks[0].Type != "secret" || result.Checks[1].Type != "plaintext" {
+		t.Fatalf("unexpected check types: %+v", result.Checks)
+	}
+}
+
+func TestEnvVarsRequire_FailsListingMissing(t *testing.T) {
+	result, err := runEnvVarsRequireCommand(t, "--names", "API_KEY,MATCH_PASSWORD,SENTRY_DSN")
+	if err == nil {
+		t.Fatal("expected missing variables to fail")
+	}
+	if !strings.Contains(err.Error(), "missing MATCH_PASSWORD, SENTRY_DSN") || !strings.Contains(err.Error(), "Release (wf-1)") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Passed || strings.Join(result.Missing, ",") != "MATCH_PASSWORD,SENTRY_DSN" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestEnvVarsRequire_RequireSecret(t *testing.T) {
+	result, err := runEnvVarsRequireCommand(t, "--names", "API_KEY,SIGNING_CERT", "--require-secret")
+	if err == nil || !strings.Contains(err.Error(), "not secret SIGNING_CERT") {
+		t.Fatalf("expected not-secret failure, got %v", err)
+	}
+	if result.Checks[0].Status != "ok" || result.Checks[1].Status != "not_secret" {
+		t.Fatalf("unexpected checks: %+v", result.Checks)
+	}
+}
+
+func TestEnvVarsRequire_RequiresNames(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsRequireCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--workflow-id", "wf-1", "--names", " , "}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--names is required") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestCheckRequiredEnvVarsKeepsGivenOrder(t *testing.T) {
+	plain := "v"
+	result := checkRequiredEnvVars([]webcore.CIEnvironmentVariable{
+		{Name: "B", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+	}, []string{"C", "B", "A"}, false)
+	var names []string
+	for _, check := range result.Checks {
+		names = append(names, check.Name+"="+check.Status)
+	}
+	if strings.Join(names, ",") != "C=missing,B=ok,A=missing" {
+		t.Fatalf("unexpected checks: %v", names)
+	}
+}
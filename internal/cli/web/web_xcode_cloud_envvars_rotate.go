@@ -0,0 +1,329 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	envVarRotateScopeWorkflow = "workflow"
+	envVarRotateScopeShared   = "shared"
+
+	envVarRotateStatusRotated     = "rotated"
+	envVarRotateStatusWouldRotate = "would_rotate"
+	envVarRotateStatusFailed      = "failed"
+)
+
+// CIEnvVarRotateTarget is one secret updated (or planned) by env-vars rotate.
+// WorkflowID is empty for the shared variable.
+type CIEnvVarRotateTarget struct {
+	Scope        string `json:"scope"`
+	WorkflowID   string `json:"workflow_id,omitempty"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	VariableID   string `json:"variable_id,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// CIEnvVarsRotateResult is the output type for the env-vars rotate command.
+type CIEnvVarsRotateResult struct {
+	ProductID string                 `json:"product_id"`
+	Name      string                 `json:"name"`
+	DryRun    bool                   `json:"dry_run"`
+	Rotated   int                    `json:"rotated"`
+	Failed    int                    `json:"failed"`
+	Targets   []CIEnvVarRotateTarget `json:"targets"`
+}
+
+func webXcodeCloudEnvVarsRotateCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars rotate", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	name := fs.String("name", "", "Secret environment variable name (required)")
+	value := fs.String("value", "", "New secret value (required)")
+	secret := fs.Bool("secret", false, "Encrypt the new value as a secret (required)")
+	dryRun := fs.Bool("dry-run", false, "List the secrets that would be rotated without changing anything")
+
+	return &ffcli.Command{
+		Name:       "rotate",
+		ShortUsage: "asc web xcode-cloud env-vars rotate --product-id ID --name NAME --value VALUE --secret [--dry-run] [flags]",
+		ShortHelp:  "EXPERIMENTAL: Rotate a secret across every workflow in a product.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Replace the value of a secret environment variable everywhere it is defined in
+an Xcode Cloud product: every workflow with a secret variable named NAME, plus
+the shared (product-level) variable of that name when it is a secret.
+
+Each workflow is read, updated, and written back on its own, so one failure
+does not stop the rest. The result lists every target with its status, and the
+command exits non-zero when any target failed. Plaintext variables with the
+same name are left untouched. Names match case-insensitively.
+
+--secret is required to make the intent explicit; values are encrypted using
+ECIES (the same scheme as the ASC web UI). Use --dry-run to list the targets
+without changing anything.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars rotate --product-id "UUID" --name API_TOKEN --value "new-token" --secret --dry-run --apple-id "user@example.com"
+  asc web xcode-cloud env-vars rotate --product-id "UUID" --name API_TOKEN --value "new-token" --secret --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			varName := strings.TrimSpace(*name)
+			if varName == "" {
+				fmt.Fprintln(os.Stderr, "Error: --name is required")
+				return flag.ErrHelp
+			}
+			varValue := *value
+			if varValue == "" {
+				fmt.Fprintln(os.Stderr, "Error: --value is required")
+				return flag.ErrHelp
+			}
+			if !*secret {
+				fmt.Fprintln(os.Stderr, "Error: --secret is required; rotate only updates secret variables")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars rotate failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			encrypt := newEnvVarEncrypter(requestCtx, client)
+
+			result := &CIEnvVarsRotateResult{ProductID: pid, Name: varName, DryRun: *dryRun, Targets: []CIEnvVarRotateTarget{}}
+			err = withWebSpinner("Rotating Xcode Cloud secret environment variables", func() error {
+				workflows, err := client.ListCIWorkflows(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				primeWorkflowNameCache(teamID, pid, workflows.Items)
+				targets := make([]CIEnvVarRotateTarget, len(workflows.Items))
+				found := make([]bool, len(workflows.Items))
+				_ = shared.ForEachBounded(requestCtx, len(workflows.Items), shared.Concurrency(), func(ctx context.Context, idx int) error {
+					targets[idx], found[idx] = rotateWorkflowSecret(ctx, client, teamID, pid, workflows.Items[idx], varName, varValue, *dryRun, encrypt)
+					return nil
+				})
+				if err := requestCtx.Err(); err != nil {
+					return err
+				}
+				for idx, target := range targets {
+					if found[idx] {
+						result.Targets = append(result.Targets, target)
+					}
+				}
+
+				sharedVars, err := client.ListCIProductEnvVars(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				if target, ok := rotateSharedSecret(requestCtx, client, teamID, pid, sharedVars, varName, varValue, *dryRun, encrypt); ok {
+					result.Targets = append(result.Targets, target)
+				}
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars rotate")
+			}
+			if len(result.Targets) == 0 {
+				return fmt.Errorf("xcode-cloud env-vars rotate failed: no secret environment variable named %q in product %q", varName, pid)
+			}
+			for _, target := range result.Targets {
+				switch target.Status {
+				case envVarRotateStatusRotated:
+					result.Rotated++
+				case envVarRotateStatusFailed:
+					result.Failed++
+				}
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsRotateTable(result) },
+				func() error { return renderEnvVarsRotateMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			if result.Failed > 0 {
+				return fmt.Errorf("xcode-cloud env-vars rotate failed: %d of %d targets failed", result.Failed, len(result.Targets))
+			}
+			return nil
+		},
+	}
+}
+
+// rotateWorkflowSecret updates NAME in one workflow with a GET-modify-PUT.
+// ok is false when the workflow has no secret of that name; a failure to load
+// the workflow is reported as a failed target rather than skipped.
+func rotateWorkflowSecret(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID, productID string,
+	wf webcore.CIWorkflow,
+	name, value string,
+	dryRun bool,
+	encrypt func(string) (string, error),
+) (CIEnvVarRotateTarget, bool) {
+	target := CIEnvVarRotateTarget{
+		Scope:        envVarRotateScopeWorkflow,
+		WorkflowID:   wf.ID,
+		WorkflowName: strings.TrimSpace(wf.Content.Name),
+		Status:       envVarRotateStatusFailed,
+	}
+	workflow, err := client.GetCIWorkflow(ctx, teamID, productID, wf.ID)
+	if err != nil {
+		target.Error = err.Error()
+		return target, true
+	}
+	vars, err := webcore.ExtractEnvVars(workflow.Content)
+	if err != nil {
+		target.Error = err.Error()
+		return target, true
+	}
+	index := -1
+	for i, v := range vars {
+		if strings.EqualFold(strings.TrimSpace(v.Name), name) && envVarValueType(v.Value) == "secret" {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return CIEnvVarRotateTarget{}, false
+	}
+	target.VariableID = vars[index].ID
+	if dryRun {
+		target.Status = envVarRotateStatusWouldRotate
+		return target, true
+	}
+
+	envValue, err := newEnvVarValue(value, true, encrypt)
+	if err != nil {
+		target.Error = err.Error()
+		return target, true
+	}
+	vars[index].Value = envValue
+	newContent, err := webcore.SetEnvVars(workflow.Content, vars)
+	if err != nil {
+		target.Error = err.Error()
+		return target, true
+	}
+	if err := client.UpdateCIWorkflow(ctx, teamID, productID, wf.ID, newContent); err != nil {
+		target.Error = err.Error()
+		return target, true
+	}
+	target.Status = envVarRotateStatusRotated
+	return target, true
+}
+
+// rotateSharedSecret updates the shared variable NAME when it is a secret,
+// keeping its lock state and workflow links.
+func rotateSharedSecret(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID, productID string,
+	sharedVars []webcore.CIProductEnvironmentVariable,
+	name, value string,
+	dryRun bool,
+	encrypt func(string) (string, error),
+) (CIEnvVarRotateTarget, bool) {
+	for _, v := range sharedVars {
+		if !strings.EqualFold(strings.TrimSpace(v.Name), name) || envVarValueType(v.Value) != "secret" {
+			continue
+		}
+		target := CIEnvVarRotateTarget{
+			Scope:      envVarRotateScopeShared,
+			VariableID: v.ID,
+			Status:     envVarRotateStatusWouldRotate,
+		}
+		if dryRun {
+			return target, true
+		}
+		target.Status = envVarRotateStatusFailed
+		envValue, err := newEnvVarValue(value, true, encrypt)
+		if err != nil {
+			target.Error = err.Error()
+			return target, true
+		}
+		workflowIDs := make([]string, 0, len(v.RelatedWorkflowSummaries))
+		for _, ws := range v.RelatedWorkflowSummaries {
+			workflowIDs = append(workflowIDs, ws.ID)
+		}
+		req := webcore.CIProductEnvVarRequest{
+			Name:        v.Name,
+			Value:       envValue,
+			IsLocked:    v.IsLocked,
+			WorkflowIDs: workflowIDs,
+		}
+		if _, err := client.SetCIProductEnvVar(ctx, teamID, productID, v.ID, req); err != nil {
+			target.Error = err.Error()
+			return target, true
+		}
+		target.Status = envVarRotateStatusRotated
+		return target, true
+	}
+	return CIEnvVarRotateTarget{}, false
+}
+
+func formatEnvVarsRotateSummary(result *CIEnvVarsRotateResult) string {
+	if result.DryRun {
+		return fmt.Sprintf("Dry run for %s in product %s (no changes applied): %d targets", result.Name, result.ProductID, len(result.Targets))
+	}
+	return fmt.Sprintf("Rotated %s in product %s: %d rotated, %d failed", result.Name, result.ProductID, result.Rotated, result.Failed)
+}
+
+func renderEnvVarsRotateTable(result *CIEnvVarsRotateResult) error {
+	fmt.Println(formatEnvVarsRotateSummary(result))
+	fmt.Println()
+	asc.RenderTable(envVarRotateHeaders(), buildEnvVarRotateRows(result.Targets))
+	return nil
+}
+
+func renderEnvVarsRotateMarkdown(result *CIEnvVarsRotateResult) error {
+	fmt.Printf("**%s**\n\n", formatEnvVarsRotateSummary(result))
+	asc.RenderMarkdown(envVarRotateHeaders(), buildEnvVarRotateRows(result.Targets))
+	return nil
+}
+
+func envVarRotateHeaders() []string {
+	return []string{"Scope", "Workflow", "Status", "Error"}
+}
+
+func buildEnvVarRotateRows(targets []CIEnvVarRotateTarget) [][]string {
+	rows := make([][]string, 0, len(targets))
+	for _, target := range targets {
+		workflow := "n/a"
+		if target.Scope == envVarRotateScopeWorkflow {
+			workflow = formatEnvVarWorkflowLabel(target.WorkflowName, target.WorkflowID)
+		}
+		rows = append(rows, []string{target.Scope, workflow, target.Status, valueOrNA(target.Error)})
+	}
+	return rows
+}
@@ -0,0 +1,201 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// stubEnvVarRotateSession serves a product with four workflows: wf-1 holds the
+// secret TOKEN, wf-2 a plaintext TOKEN, wf-3 no TOKEN, and wf-4 a secret TOKEN
+// whose update is rejected. The shared TOKEN is a locked secret. PUT bodies are
+// recorded by path.
+func stubEnvVarRotateSession(t *testing.T, puts map[string]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
+	})
+	serverKeyB64 := "0xm9f0gX7lzArxrChNrDVUR3MKxueb1DdheWBeLndCVOqoiEsT2jxqZW6cHsIuDGDykvYWgQ1qaPBSxCNFXEUg=="
+	workflows := map[string]string{
+		"wf-1": `{"id":"wf-1","content":{"name":"Release","environment_variables":[{"id":"v-1","name":"TOKEN","value":{"redacted_value":""}},{"id":"v-keep","name":"KEEP","value":{"plaintext":"x"}}]}}`,
+		"wf-2": `{"id":"wf-2","content":{"name":"Plain","environment_variables":[{"id":"v-2","name":"token","value":{"plaintext":"visible"}}]}}`,
+		"wf-3": `{"id":"wf-3","content":{"name":"Other","environment_variables":[]}}`,
+		"wf-4": `{"id":"wf-4","content":{"name":"Locked Down","environment_variables":[{"id":"v-4","name":"TOKEN","value":{"redacted_value":""}}]}}`,
+	}
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					status := http.StatusOK
+					body := `{}`
+					path := req.URL.Path
+					switch {
+					case req.Method == http.MethodGet && strings.Contains(path, "/keys/client-encryption"):
+						body = `{"key":"` + serverKeyB64 + `"}`
+					case req.Method == http.MethodGet && strings.HasSuffix(path, "/workflows-v15"):
+						body = `{"items":[
+							{"id":"wf-1","content":{"name":"Release"}},
+							{"id":"wf-2","content":{"name":"Plain"}},
+							{"id":"wf-3","content":{"name":"Other"}},
+							{"id":"wf-4","content":{"name":"Locked Down"}}
+						]}`
+					case req.Method == http.MethodGet && strings.HasSuffix(path, "/product-environment-variables"):
+						body = `[{"id":"s-1","name":"TOKEN","value":{"redacted_value":""},"is_locked":true,
+							"related_workflow_summaries":[{"id":"wf-1","name":"Release"}]}]`
+					case req.Method == http.MethodGet:
+						body = workflows[path[strings.LastIndex(path, "/")+1:]]
+					case req.Method == http.MethodPut:
+						data, err := io.ReadAll(req.Body)
+						if err != nil {
+							t.Fatalf("failed to read PUT body: %v", err)
+						}
+						puts[path] = string(data)
+						if strings.HasSuffix(path, "/wf-4") {
+							status = http.StatusConflict
+							body = `{"errors":[{"status":"409","detail":"workflow is locked"}]}`
+						}
+					default:
+						t.Fatalf("unexpected request: %s %s", req.Method, path)
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func runEnvVarRotateCommand(t *testing.T, args ...string) (CIEnvVarsRotateResult, error) {
+	t.Helper()
+	cmd := webXcodeCloudEnvVarsRotateCommand()
+	if err := cmd.FlagSet.Parse(append([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--name", "TOKEN",
+		"--value", "rotated-value",
+		"--secret",
+	}, args...)); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var execErr error
+	stdout, _ := captureOutput(t, func() {
+		execErr = cmd.Exec(context.Background(), nil)
+	})
+	var result CIEnvVarsRotateResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	return result, execErr
+}
+
+func TestEnvVarsRotate_UpdatesEverySecret(t *testing.T) {
+	puts := map[string]string{}
+	stubEnvVarRotateSession(t, puts)
+
+	result, err := runEnvVarRotateCommand(t)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 targets failed") {
+		t.Fatalf("expected partial failure error, got %v", err)
+	}
+	if result.Rotated != 2 || result.Failed != 1 || len(result.Targets) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	statuses := map[string]string{}
+	for _, target := range result.Targets {
+		statuses[target.Scope+":"+target.WorkflowID] = target.Status
+	}
+	want := map[string]string{"workflow:wf-1": "rotated", "workflow:wf-4": "failed", "shared:": "rotated"}
+	for key, status := range want {
+		if statuses[key] != status {
+			t.Fatalf("status for %s = %q, want %q (all: %v)", key, statuses[key], status, statuses)
+		}
+	}
+	if result.Targets[1].Error == "" {
+		t.Fatalf("expected failed target to carry an error: %+v", result.Targets[1])
+	}
+
+	wf1 := puts["/ci/api/teams/team-uuid/products/prod-1/workflows-v15/wf-1"]
+	vars, err := webcore.ExtractEnvVars(json.RawMessage(wf1))
+	if err != nil {
+		t.Fatalf("failed to decode wf-1 PUT body: %v\n%s", err, wf1)
+	}
+	if len(vars) != 2 || vars[0].ID != "v-1" || vars[0].Value.Ciphertext == nil || vars[0].Value.RedactedValue != nil {
+		t.Fatalf("expected TOKEN to carry only a new ciphertext, got %+v", vars)
+	}
+	if vars[1].Value.Plaintext == nil || *vars[1].Value.Plaintext != "x" {
+		t.Fatalf("expected other variables to be untouched, got %+v", vars[1])
+	}
+	for path := range puts {
+		if strings.HasSuffix(path, "/wf-2") || strings.HasSuffix(path, "/wf-3") {
+			t.Fatalf("expected workflows without a TOKEN secret to be skipped, got PUT %s", path)
+		}
+	}
+
+	var sharedReq struct {
+		IsLocked    bool     `json:"is_locked"`
+		WorkflowIDs []string `json:"workflow_ids"`
+		Value       struct {
+			Ciphertext *string `json:"ciphertext"`
+		} `json:"value"`
+	}
+	sharedBody := puts["/ci/api/teams/team-uuid/products/prod-1/product-environment-variables/s-1"]
+	if err := json.Unmarshal([]byte(sharedBody), &sharedReq); err != nil {
+		t.Fatalf("failed to decode shared PUT body: %v\n%s", err, sharedBody)
+	}
+	if !sharedReq.IsLocked || sharedReq.Value.Ciphertext == nil || strings.Join(sharedReq.WorkflowIDs, ",") != "wf-1" {
+		t.Fatalf("expected shared secret to keep its lock and links with a new ciphertext, got %s", sharedBody)
+	}
+}
+
+func TestEnvVarsRotate_DryRunMakesNoChanges(t *testing.T) {
+	puts := map[string]string{}
+	stubEnvVarRotateSession(t, puts)
+
+	result, err := runEnvVarRotateCommand(t, "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(puts) != 0 {
+		t.Fatalf("expected no PUT requests in dry run, got %v", puts)
+	}
+	if !result.DryRun || len(result.Targets) != 3 || result.Rotated != 0 {
+		t.Fatalf("unexpected dry-run result: %+v", result)
+	}
+	for _, target := range result.Targets {
+		if target.Status != "would_rotate" {
+			t.Fatalf("expected would_rotate, got %+v", target)
+		}
+	}
+}
+
+func TestEnvVarsRotate_RequiresSecret(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsRotateCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--name", "TOKEN", "--value", "v"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--secret is required") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"flag"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/peterbourgon/ff/v3/ffcli"
+
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
@@ -19,14 +22,14 @@ func TestEnvVarsCommandHierarchy(t *testing.T) {
 	if envVarsCmd == nil {
 		t.Fatal("expected 'env-vars' subcommand")
 	}
-	if len(envVarsCmd.Subcommands) != 4 {
-		t.Fatalf("expected 4 subcommands (list, set, delete, shared), got %d", len(envVarsCmd.Subcommands))
+	if len(envVarsCmd.Subcommands) != 11 {
+		t.Fatalf("expected 11 subcommands (list, list-all, get, set, delete, apply, audit, rotate, require, copy, shared), got %d", len(envVarsCmd.Subcommands))
 	}
 	names := map[string]bool{}
 	for _, sub := range envVarsCmd.Subcommands {
 		names[sub.Name] = true
 	}
-	for _, name := range []string{"list", "set", "delete", "shared"} {
+	for _, name := range []string{"list", "list-all", "get", "set", "delete", "apply", "audit", "rotate", "require", "copy", "shared"} {
 		if !names[name] {
 			t.Fatalf("expected %q subcommand", name)
 		}
@@ -141,6 +144,51 @@ func TestEnvVarsList_EmptyList(t *testing.T) {
 	}
 }
 
+func TestEnvVarsList_FailIfEmpty(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"id":"wf-1","content":{"name":"Test WF","environment_variables":[]}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudEnvVarsListCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--workflow-id", "wf-1",
+		"--fail-if-empty",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, _ = captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if err == nil {
+			t.Fatal("expected error for empty result")
+		}
+		if !strings.Contains(err.Error(), "xcode-cloud env-vars list returned no results") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
 func TestEnvVarsList_MissingProductID(t *testing.T) {
 	cmd := webXcodeCloudEnvVarsListCommand()
 	if err := cmd.FlagSet.Parse([]string{
@@ -234,6 +282,82 @@ func TestEnvVarsList_TableOutput(t *testing.T) {
 	}
 }
 
+func TestMaskEnvVarValue(t *testing.T) {
+	tests := map[string]string{
+		"":           "***",
+		"ab":         "***",
+		"hello":      "he***",
+		"éclair-key": "éc***",
+	}
+	for input, want := range tests {
+		if got := maskEnvVarValue(input); got != want {
+			t.Fatalf("maskEnvVarValue(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEnvVarsList_Mask(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"id":"wf-1","content":{"name":"Test WF","environment_variables":[` +
+						`{"id":"ev-1","name":"MY_VAR","value":{"plaintext":"hello"}},` +
+						`{"id":"ev-2","name":"MY_SECRET","value":{"redacted_value":"***"}}]}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantValue string
+		hidden    bool
+	}{
+		{name: "table masked", args: []string{"--output", "table", "--mask"}, wantValue: "he***", hidden: true},
+		{name: "json unmasked with --mask", args: []string{"--mask"}, wantValue: `"plaintext":"hello"`},
+		{name: "json masked with --mask-json", args: []string{"--mask-json"}, wantValue: `"plaintext":"he***"`, hidden: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := webXcodeCloudEnvVarsListCommand()
+			args := append([]string{
+				"--apple-id", "user@example.com",
+				"--product-id", "prod-1",
+				"--workflow-id", "wf-1",
+			}, tt.args...)
+			if err := cmd.FlagSet.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			stdout, _ := captureOutput(t, func() {
+				if err := cmd.Exec(context.Background(), nil); err != nil {
+					t.Fatalf("exec error: %v", err)
+				}
+			})
+			if !strings.Contains(stdout, tt.wantValue) {
+				t.Fatalf("expected output to include %q, got %q", tt.wantValue, stdout)
+			}
+			if tt.hidden && strings.Contains(stdout, "hello") {
+				t.Fatalf("expected plaintext value to be masked, got %q", stdout)
+			}
+		})
+	}
+}
+
 func TestEnvVarsList_JSONOutput(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() { resolveSessionFn = origResolveSession })
@@ -842,3 +966,168 @@ func TestEnvVarsAllCommandsHaveUsageFunc(t *testing.T) {
 		}
 	}
 }
+
+// stubEnvVarToggleSession serves existing variables from getBody for every GET
+// other than the encryption key and captures the PUT body.
+func stubEnvVarToggleSession(t *testing.T, getBody string, putBody *[]byte) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	serverKeyB64 := "0xm9f0gX7lzArxrChNrDVUR3MKxueb1DdheWBeLndCVOqoiEsT2jxqZW6cHsIuDGDykvYWgQ1qaPBSxCNFXEUg=="
+	respond := func(req *http.Request, body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}
+	}
+	resolveSessionFn = func(ctx context.Context, appleID, password, twoFactorCode string) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					switch {
+					case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/keys/client-encryption"):
+						return respond(req, `{"key":"`+serverKeyB64+`"}`), nil
+					case req.Method == http.MethodGet:
+						return respond(req, getBody), nil
+					case req.Method == http.MethodPut:
+						var err error
+						*putBody, err = io.ReadAll(req.Body)
+						if err != nil {
+							t.Fatalf("failed to read PUT body: %v", err)
+						}
+						return respond(req, `{}`), nil
+					}
+					t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+					return nil, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func envVarValueFields(t *testing.T, value json.RawMessage) []string {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(value, &fields); err != nil {
+		t.Fatalf("invalid value JSON %s: %v", value, err)
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func runEnvVarToggleCommand(t *testing.T, cmd *ffcli.Command, args []string) {
+	t.Helper()
+	if err := cmd.FlagSet.Parse(args); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+}
+
+func TestEnvVarsSet_TogglesValueType(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		args       []string
+		wantFields []string
+	}{
+		{
+			name:       "secret to plaintext",
+			existing:   `{"ciphertext":"old-ct","redacted_value":"***"}`,
+			args:       []string{"--value", "plain"},
+			wantFields: []string{"plaintext"},
+		},
+		{
+			name:       "plaintext to secret",
+			existing:   `{"plaintext":"old"}`,
+			args:       []string{"--value", "s3cret", "--secret"},
+			wantFields: []string{"ciphertext"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var putBody []byte
+			stubEnvVarToggleSession(t, `{"id":"wf-1","content":{"name":"WF","environment_variables":[{"id":"existing-id","name":"MY_VAR","value":`+tt.existing+`}]}}`, &putBody)
+
+			runEnvVarToggleCommand(t, webXcodeCloudEnvVarsSetCommand(), append([]string{
+				"--apple-id", "user@example.com",
+				"--product-id", "prod-1",
+				"--workflow-id", "wf-1",
+				"--name", "MY_VAR",
+			}, tt.args...))
+
+			var content struct {
+				EnvironmentVariables []struct {
+					ID    string          `json:"id"`
+					Value json.RawMessage `json:"value"`
+				} `json:"environment_variables"`
+			}
+			if err := json.Unmarshal(putBody, &content); err != nil {
+				t.Fatalf("invalid PUT body %s: %v", putBody, err)
+			}
+			if len(content.EnvironmentVariables) != 1 || content.EnvironmentVariables[0].ID != "existing-id" {
+				t.Fatalf("expected existing variable to be replaced in place, got %s", putBody)
+			}
+			got := envVarValueFields(t, content.EnvironmentVariables[0].Value)
+			if strings.Join(got, ",") != strings.Join(tt.wantFields, ",") {
+				t.Fatalf("value fields = %v, want %v (body %s)", got, tt.wantFields, putBody)
+			}
+		})
+	}
+}
+
+func TestSharedEnvVarsSet_TogglesValueType(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		args       []string
+		wantFields []string
+	}{
+		{
+			name:       "secret to plaintext",
+			existing:   `{"ciphertext":"old-ct","redacted_value":"***"}`,
+			args:       []string{"--value", "plain"},
+			wantFields: []string{"plaintext"},
+		},
+		{
+			name:       "plaintext to secret",
+			existing:   `{"plaintext":"old"}`,
+			args:       []string{"--value", "s3cret", "--secret"},
+			wantFields: []string{"ciphertext"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var putBody []byte
+			stubEnvVarToggleSession(t, `[{"id":"existing-id","name":"MY_VAR","value":`+tt.existing+`,"is_locked":false}]`, &putBody)
+
+			runEnvVarToggleCommand(t, webXcodeCloudEnvVarsSharedSetCommand(), append([]string{
+				"--apple-id", "user@example.com",
+				"--product-id", "prod-1",
+				"--name", "MY_VAR",
+			}, tt.args...))
+
+			var body struct {
+				Value json.RawMessage `json:"value"`
+			}
+			if err := json.Unmarshal(putBody, &body); err != nil {
+				t.Fatalf("invalid PUT body %s: %v", putBody, err)
+			}
+			got := envVarValueFields(t, body.Value)
+			if strings.Join(got, ",") != strings.Join(tt.wantFields, ",") {
+				t.Fatalf("value fields = %v, want %v (body %s)", got, tt.wantFields, putBody)
+			}
+		})
+	}
+}
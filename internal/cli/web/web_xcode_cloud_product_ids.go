@@ -0,0 +1,130 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// defaultUsageDaysMaxProducts caps --product-ids all, which fetches scope
+// rows for every product in the team.
+const defaultUsageDaysMaxProducts = 20
+
+// productIDPattern matches the UUID form Xcode Cloud uses for product IDs.
+var productIDPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+func bindResolveBundleFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("resolve-bundle", false, "Treat --product-ids entries that are not UUIDs as bundle IDs and look up their product IDs")
+}
+
+func looksLikeProductID(value string) bool {
+	return productIDPattern.MatchString(strings.TrimSpace(value))
+}
+
+// validateProductIDFormats flags product IDs that do not look like UUIDs, such
+// as pasted URLs or bundle IDs, which otherwise surface as a confusing 404. It
+// warns on stderr, or returns an error under --strict.
+func validateProductIDFormats(ids []string, strict bool) error {
+	for _, id := range ids {
+		if looksLikeProductID(id) {
+			continue
+		}
+		message := fmt.Sprintf("product ID %q does not look like a UUID; pass a bundle ID with --resolve-bundle", id)
+		if strict {
+			return fmt.Errorf("%s", message)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
+	}
+	return nil
+}
+
+// resolveBundleProductIDs replaces every ID that is not a UUID with the ID of
+// the product whose bundle ID matches it (case-insensitively). IDs already in
+// UUID form are kept as-is, and the product list is only fetched when needed.
+func resolveBundleProductIDs(ctx context.Context, client *webcore.Client, teamID string, ids []string) ([]string, error) {
+	needsLookup := false
+	for _, id := range ids {
+		if !looksLikeProductID(id) {
+			needsLookup = true
+			break
+		}
+	}
+	if !needsLookup {
+		return ids, nil
+	}
+
+	products, err := client.ListCIProducts(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return substituteBundleProductIDs(products, ids)
+}
+
+func substituteBundleProductIDs(products *webcore.CIProductListResponse, ids []string) ([]string, error) {
+	resolved := make([]string, 0, len(ids))
+	seen := map[string]struct{}{}
+	for _, id := range ids {
+		productID := id
+		if !looksLikeProductID(id) {
+			matches := []string{}
+			if products != nil {
+				for _, product := range products.Items {
+					if strings.EqualFold(strings.TrimSpace(product.BundleID), id) {
+						matches = append(matches, product.ID)
+					}
+				}
+			}
+			switch len(matches) {
+			case 0:
+				return nil, fmt.Errorf("no Xcode Cloud product found for bundle ID %q", id)
+			case 1:
+				productID = matches[0]
+			default:
+				return nil, fmt.Errorf("bundle ID %q matches %d Xcode Cloud products (%s); pass a product ID instead", id, len(matches), strings.Join(matches, ", "))
+			}
+		}
+		canonical := strings.ToLower(productID)
+		if _, ok := seen[canonical]; ok {
+			continue
+		}
+		seen[canonical] = struct{}{}
+		resolved = append(resolved, productID)
+	}
+	return resolved, nil
+}
+
+// isAllProductIDs reports whether --product-ids asks for every product.
+func isAllProductIDs(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), "all")
+}
+
+// allProductIDs returns the IDs of the listed products in list order, capped
+// at limit, along with the number of products available before the cap.
+func allProductIDs(products *webcore.CIProductListResponse, limit int) ([]string, int) {
+	if products == nil {
+		return nil, 0
+	}
+	ids := make([]string, 0, len(products.Items))
+	seen := map[string]struct{}{}
+	for _, product := range products.Items {
+		id := strings.TrimSpace(product.ID)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[strings.ToLower(id)]; ok {
+			continue
+		}
+		seen[strings.ToLower(id)] = struct{}{}
+		ids = append(ids, id)
+	}
+	total := len(ids)
+	if limit > 0 && total > limit {
+		ids = ids[:limit]
+	}
+	return ids, total
+}
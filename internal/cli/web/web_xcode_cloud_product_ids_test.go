@@ -0,0 +1,204 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const testProductUUID = "0f6a3c9e-1b2d-4e5f-8a7b-9c0d1e2f3a4b"
+
+func TestValidateProductIDFormatsWarnsOrFails(t *testing.T) {
+	_, stderr := captureOutput(t, func() {
+		if err := validateProductIDFormats([]string{testProductUUID, "com.example.app"}, false); err != nil {
+			t.Fatalf("expected a warning only, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, `Warning: product ID "com.example.app" does not look like a UUID`) {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+	if strings.Contains(stderr, testProductUUID) {
+		t.Fatalf("expected no warning for a UUID product ID, got %q", stderr)
+	}
+
+	err := validateProductIDFormats([]string{"https://appstoreconnect.apple.com/teams/x"}, true)
+	if err == nil || !strings.Contains(err.Error(), "--resolve-bundle") {
+		t.Fatalf("expected strict validation error, got %v", err)
+	}
+}
+
+func TestSubstituteBundleProductIDs(t *testing.T) {
+	products := &webcore.CIProductListResponse{Items: []webcore.CIProduct{
+		{ID: testProductUUID, BundleID: "com.example.app"},
+		{ID: "prod-a", BundleID: "com.example.dup"},
+		{ID: "prod-b", BundleID: "com.example.dup"},
+	}}
+
+	ids, err := substituteBundleProductIDs(products, []string{"COM.example.app", testProductUUID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ids, ",") != testProductUUID {
+		t.Fatalf("expected the bundle ID to resolve and collapse with its UUID, got %v", ids)
+	}
+
+	if _, err := substituteBundleProductIDs(products, []string{"com.example.missing"}); err == nil || !strings.Contains(err.Error(), "no Xcode Cloud product found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := substituteBundleProductIDs(products, []string{"com.example.dup"}); err == nil || !strings.Contains(err.Error(), "matches 2 Xcode Cloud products") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsResolveBundle(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	productLookups := 0
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{
+						"usage":[{"month":1,"year":2026,"duration":100,"number_of_builds":5}],
+						"product_usage":[
+							{"product_id":"` + testProductUUID + `","product_name":"App One","usage_in_minutes":80,"number_of_builds":4},
+							{"product_id":"other","product_name":"App Two","usage_in_minutes":20,"number_of_builds":1}
+						]
+					}`
+					if strings.HasSuffix(req.URL.Path, "/products-v4") {
+						productLookups++
+						body = `{"items":[{"id":"` + testProductUUID + `","name":"App One","bundle_id":"com.example.app"}]}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "com.example.app",
+		"--resolve-bundle",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if strings.Contains(stderr, "Warning:") {
+		t.Fatalf("expected no format warning with --resolve-bundle, got %q", stderr)
+	}
+	if productLookups != 1 {
+		t.Fatalf("expected one product lookup, got %d", productLookups)
+	}
+	var result webcore.CIUsageMonths
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	if len(result.ProductUsage) != 1 || result.ProductUsage[0].ProductID != testProductUUID {
+		t.Fatalf("expected usage filtered to the resolved product, got %+v", result.ProductUsage)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysStrictRejectsMalformedProductID(t *testing.T) {
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-ids", "com.example.app", "--strict"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, `Error: product ID "com.example.app" does not look like a UUID`) {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestAllProductIDsCapsAndDedupes(t *testing.T) {
+	products := &webcore.CIProductListResponse{Items: []webcore.CIProduct{
+		{ID: "prod-1"}, {ID: "PROD-1"}, {ID: ""}, {ID: "prod-2"}, {ID: "prod-3"},
+	}}
+	ids, total := allProductIDs(products, 2)
+	if total != 3 || strings.Join(ids, ",") != "prod-1,prod-2" {
+		t.Fatalf("allProductIDs() = %v, %d; want [prod-1 prod-2], 3", ids, total)
+	}
+	if ids, total := allProductIDs(nil, 2); len(ids) != 0 || total != 0 {
+		t.Fatalf("expected no IDs for a nil product list, got %v, %d", ids, total)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysAllProductsTruncates(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var paths []string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					body := `{"usage":[{"date":"2026-01-15","duration":5,"number_of_builds":1}],"info":{}}`
+					if strings.HasSuffix(req.URL.Path, "/products-v4") {
+						body = `{"items":[{"id":"prod-1","name":"One"},{"id":"prod-2","name":"Two"},{"id":"prod-3","name":"Three"}]}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "all",
+		"--max-products", "2",
+		"--no-overall",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	if !strings.Contains(stderr, "--product-ids all matched 3 products; showing the first 2") {
+		t.Fatalf("expected truncation warning, got %q", stderr)
+	}
+	if strings.Contains(stderr, "does not look like a UUID") {
+		t.Fatalf("expected no format warning for --product-ids all, got %q", stderr)
+	}
+	if len(paths) != 2 || !strings.HasSuffix(paths[0], "/products-v4") || !strings.Contains(paths[1], "/products/prod-1/usage/days") {
+		t.Fatalf("expected a product list lookup then the primary product's days, got %v", paths)
+	}
+}
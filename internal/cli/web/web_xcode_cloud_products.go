@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func webXcodeCloudProductsGetCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud products get", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+
+	return &ffcli.Command{
+		Name:       "get",
+		ShortUsage: "asc web xcode-cloud products get --product-id ID [flags]",
+		ShortHelp:  "EXPERIMENTAL: Show one Xcode Cloud product.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Show a single Xcode Cloud product by ID, including its type and icon URL.
+With table or markdown output, an unknown ID lists the available product IDs.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud products get --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud products get --product-id "UUID" --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud products get failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			products, err := withWebSpinnerValue("Loading Xcode Cloud products", func() (*webcore.CIProductListResponse, error) {
+				return client.ListCIProducts(requestCtx, teamID)
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud products get")
+			}
+
+			product := findCIProductByID(products.Items, pid)
+			if product == nil {
+				format := shared.NormalizeOutputFormat(*output.Output)
+				if format == "table" || format == "markdown" {
+					return fmt.Errorf("product not found: %q (available: %s)", pid, formatCIProductIDs(products.Items))
+				}
+				return fmt.Errorf("product not found: %q", pid)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				product,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIProductDetailTable(product) },
+				func() error { return renderCIProductDetailMarkdown(product) },
+			)
+		},
+	}
+}
+
+func findCIProductByID(products []webcore.CIProduct, productID string) *webcore.CIProduct {
+	for i := range products {
+		if products[i].ID == productID {
+			return &products[i]
+		}
+	}
+	return nil
+}
+
+func formatCIProductIDs(products []webcore.CIProduct) string {
+	if len(products) == 0 {
+		return "none"
+	}
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	return strings.Join(ids, ", ")
+}
+
+func renderCIProductDetailTable(product *webcore.CIProduct) error {
+	asc.RenderTable([]string{"Field", "Value"}, buildCIProductDetailRows(product))
+	return nil
+}
+
+func renderCIProductDetailMarkdown(product *webcore.CIProduct) error {
+	asc.RenderMarkdown([]string{"Field", "Value"}, buildCIProductDetailRows(product))
+	return nil
+}
+
+func buildCIProductDetailRows(product *webcore.CIProduct) [][]string {
+	if product == nil {
+		product = &webcore.CIProduct{}
+	}
+	return [][]string{
+		{"Product ID", valueOrNA(product.ID)},
+		{"Name", valueOrNA(product.Name)},
+		{"Bundle ID", valueOrNA(product.BundleID)},
+		{"Type", valueOrNA(product.Type)},
+		{"Icon URL", valueOrNA(product.IconURL)},
+	}
+}
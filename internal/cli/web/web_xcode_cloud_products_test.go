@@ -0,0 +1,176 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubProductsSession(t *testing.T, body string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+const testCIProductsBody = `{"items":[` +
+	`{"id":"prod-1","name":"My App","bundle_id":"com.example.app","type":"APP","icon_url":"https://example.com/icon.png"},` +
+	`{"id":"prod-2","name":"Other","bundle_id":"com.example.other","type":"FRAMEWORK"}]}`
+
+func TestWebXcodeCloudProductsGetCommandRegistered(t *testing.T) {
+	cmd := findSub(WebXcodeCloudCommand(), "products")
+	if cmd == nil {
+		t.Fatal("expected 'products' subcommand")
+	}
+	if findSub(cmd, "get") == nil {
+		t.Fatal("expected 'products get' subcommand")
+	}
+	if cmd.Exec == nil {
+		t.Fatal("expected 'products' to keep listing products")
+	}
+}
+
+func TestWebXcodeCloudProductsGetRequiresProductID(t *testing.T) {
+	cmd := webXcodeCloudProductsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--product-id is required") {
+		t.Fatalf("expected product-id error, got %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudProductsGetJSON(t *testing.T) {
+	stubProductsSession(t, testCIProductsBody)
+
+	cmd := webXcodeCloudProductsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var product webcore.CIProduct
+	if err := json.Unmarshal([]byte(stdout), &product); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nstdout: %q", err, stdout)
+	}
+	if product.ID != "prod-1" || product.IconURL != "https://example.com/icon.png" {
+		t.Fatalf("unexpected product: %+v", product)
+	}
+}
+
+func TestWebXcodeCloudProductsGetTableShowsIconURL(t *testing.T) {
+	stubProductsSession(t, testCIProductsBody)
+
+	cmd := webXcodeCloudProductsGetCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	for _, token := range []string{"Icon URL", "https://example.com/icon.png", "com.example.app"} {
+		if !strings.Contains(stdout, token) {
+			t.Fatalf("expected output to contain %q, got %q", token, stdout)
+		}
+	}
+}
+
+func TestWebXcodeCloudProductsGetNotFound(t *testing.T) {
+	stubProductsSession(t, testCIProductsBody)
+
+	t.Run("table lists available IDs", func(t *testing.T) {
+		cmd := webXcodeCloudProductsGetCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "missing", "--output", "table"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		var runErr error
+		_, _ = captureOutput(t, func() {
+			runErr = cmd.Exec(context.Background(), nil)
+		})
+		if runErr == nil || !strings.Contains(runErr.Error(), `product not found: "missing" (available: prod-1, prod-2)`) {
+			t.Fatalf("unexpected error: %v", runErr)
+		}
+	})
+
+	t.Run("json omits available IDs", func(t *testing.T) {
+		cmd := webXcodeCloudProductsGetCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "missing"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		var runErr error
+		_, _ = captureOutput(t, func() {
+			runErr = cmd.Exec(context.Background(), nil)
+		})
+		if runErr == nil || runErr.Error() != `product not found: "missing"` {
+			t.Fatalf("unexpected error: %v", runErr)
+		}
+	})
+}
+
+func TestWebXcodeCloudProductsIconURLColumnRequiresWide(t *testing.T) {
+	stubProductsSession(t, testCIProductsBody)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantIcon bool
+	}{
+		{name: "default compact", args: []string{"--output", "table"}, wantIcon: false},
+		{name: "wide table", args: []string{"--output", "table", "--wide"}, wantIcon: true},
+		{name: "wide markdown", args: []string{"--output", "markdown", "--wide"}, wantIcon: true},
+		{name: "json always includes icon", args: []string{}, wantIcon: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := webXcodeCloudProductsCommand()
+			args := append([]string{"--apple-id", "user@example.com"}, tt.args...)
+			if err := cmd.FlagSet.Parse(args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			stdout, _ := captureOutput(t, func() {
+				if err := cmd.Exec(context.Background(), nil); err != nil {
+					t.Fatalf("exec error: %v", err)
+				}
+			})
+			if got := strings.Contains(stdout, "https://example.com/icon.png"); got != tt.wantIcon {
+				t.Fatalf("icon URL present = %t, want %t; output %q", got, tt.wantIcon, stdout)
+			}
+		})
+	}
+}
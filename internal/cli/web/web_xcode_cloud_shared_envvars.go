@@ -2,9 +2,11 @@ package web
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -23,7 +25,7 @@ func webXcodeCloudEnvVarsSharedCommand() *ffcli.Command {
 		ShortHelp:  "EXPERIMENTAL: Manage shared (product-level) environment variables.",
 		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
 
-List, set, and delete shared (product-level) environment variables for
+List, get, set, delete, and apply shared (product-level) environment variables for
 Xcode Cloud products using Apple's private CI API. Requires a web session.
 
 Shared env vars are scoped to a product and can be linked to specific workflows.
@@ -32,15 +34,20 @@ Shared env vars are scoped to a product and can be linked to specific workflows.
 
 Examples:
   asc web xcode-cloud env-vars shared list --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared get --product-id "UUID" --name MY_VAR --apple-id "user@example.com"
   asc web xcode-cloud env-vars shared set --product-id "UUID" --name MY_VAR --value hello --apple-id "user@example.com"
   asc web xcode-cloud env-vars shared set --product-id "UUID" --name MY_SECRET --value s3cret --secret --locked --apple-id "user@example.com"
-  asc web xcode-cloud env-vars shared delete --product-id "UUID" --name MY_VAR --confirm --apple-id "user@example.com"`,
+  asc web xcode-cloud env-vars shared delete --product-id "UUID" --name MY_VAR --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared delete --product-id "UUID" --id "VAR_ID" --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared apply --product-id "UUID" --file vars.json --dry-run --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			webXcodeCloudEnvVarsSharedListCommand(),
+			webXcodeCloudEnvVarsSharedGetCommand(),
 			webXcodeCloudEnvVarsSharedSetCommand(),
 			webXcodeCloudEnvVarsSharedDeleteCommand(),
+			webXcodeCloudEnvVarsSharedApplyCommand(),
 		},
 		Exec: func(ctx context.Context, args []string) error {
 			return flag.ErrHelp
@@ -52,6 +59,16 @@ Examples:
 type CISharedEnvVarsListResult struct {
 	ProductID string                                 `json:"product_id"`
 	Variables []webcore.CIProductEnvironmentVariable `json:"variables"`
+	Summary   CISharedEnvVarsSummary                 `json:"summary"`
+	emptyResultNote
+}
+
+// CISharedEnvVarsSummary counts shared variables by posture.
+type CISharedEnvVarsSummary struct {
+	Total             int `json:"total"`
+	Secrets           int `json:"secrets"`
+	Locked            int `json:"locked"`
+	WithWorkflowLinks int `json:"with_workflow_links"`
 }
 
 // CISharedEnvVarsSetResult is the output type for the env-vars shared set command.
@@ -66,15 +83,25 @@ type CISharedEnvVarsSetResult struct {
 // CISharedEnvVarsDeleteResult is the output type for the env-vars shared delete command.
 type CISharedEnvVarsDeleteResult struct {
 	ProductID string `json:"product_id"`
-	Name      string `json:"name"`
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	// Missing is set when --ignore-missing skipped an absent variable.
+	Missing bool `json:"missing,omitempty"`
 }
 
 func webXcodeCloudEnvVarsSharedListCommand() *ffcli.Command {
 	fs := flag.NewFlagSet("web xcode-cloud env-vars shared list", flag.ExitOnError)
 	sessionFlags := bindWebSessionFlags(fs)
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+	shared.AllowTSVOutput(fs)
 
 	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	sortBy := fs.String("sort", "", "Sort variables by: name, type, locked (default: API order)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	redactTeam := bindRedactTeamFlag(fs)
+	namesOnly := bindNamesOnlyFlag(fs)
+	raw := bindRawFlag(fs)
+	columnsFlag := bindColumnsFlag(fs, sharedEnvVarHeaders())
 
 	return &ffcli.Command{
 		Name:       "list",
@@ -84,12 +111,26 @@ func webXcodeCloudEnvVarsSharedListCommand() *ffcli.Command {
 
 List shared environment variables for an Xcode Cloud product.
 Plaintext variables show their values; secret variables show "(redacted)".
+Use --redact-team to replace the team ID inside plaintext values with a stable hashed pseudonym.
+Use --sort to order variables by name, type (plaintext before secret), or locked (locked first);
+ties are broken by name. JSON, table, and markdown output share the same order.
+Table and markdown output end with a footer counting secrets, locked variables, and
+variables linked to workflows; JSON output includes the same counts as "summary".
+Use --names-only to print just the variable names, one per line, in --sort order.
+Use --raw to print the unparsed API response instead of the decoded variables;
+it cannot be combined with --sort, --redact-team, or --names-only.
+Use --columns to choose and order the table/markdown columns, e.g. --columns workflows,locked,name
+(available: name, type, value, locked, workflows).
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud env-vars shared list --product-id "UUID" --apple-id "user@example.com"
-  asc web xcode-cloud env-vars shared list --product-id "UUID" --apple-id "user@example.com" --output table`,
+  asc web xcode-cloud env-vars shared list --product-id "UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars shared list --product-id "UUID" --sort name --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars shared list --product-id "UUID" --columns workflows,locked,name --apple-id "user@example.com" --output table
+  asc web xcode-cloud env-vars shared list --product-id "UUID" --names-only --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared list --product-id "UUID" --raw --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -98,6 +139,22 @@ Examples:
 				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
 				return flag.ErrHelp
 			}
+			sortKey := strings.ToLower(strings.TrimSpace(*sortBy))
+			switch sortKey {
+			case "", "name", "type", "locked":
+			default:
+				fmt.Fprintln(os.Stderr, "Error: --sort must be one of: name, type, locked")
+				return flag.ErrHelp
+			}
+			if *raw && (sortKey != "" || *redactTeam || *namesOnly) {
+				fmt.Fprintln(os.Stderr, "Error: --raw cannot be combined with --sort, --redact-team, or --names-only")
+				return flag.ErrHelp
+			}
+			columns, err := parseTableColumns(*columnsFlag, sharedEnvVarHeaders())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
 
 			requestCtx, cancel := shared.ContextWithTimeout(ctx)
 			defer cancel()
@@ -112,6 +169,8 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
 			result := &CISharedEnvVarsListResult{}
 			err = withWebSpinner("Loading shared Xcode Cloud environment variables", func() error {
 				vars, err := client.ListCIProductEnvVars(requestCtx, teamID, pid)
@@ -125,16 +184,37 @@ Examples:
 				}
 				return nil
 			})
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud env-vars shared list"))
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud env-vars shared list")
 			}
-			return shared.PrintOutputWithRenderers(
+			redactor := newTeamRedactor(*redactTeam, teamID)
+			redactor.redactEnvelopeTeam()
+			result.Variables = redactor.SharedEnvVars(result.Variables)
+			sortSharedEnvVars(result.Variables, sortKey)
+			if *namesOnly {
+				if err := printEnvVarNames(sharedEnvVarNames(result.Variables)); err != nil {
+					return err
+				}
+				return checkFailIfEmpty(*failIfEmpty, len(result.Variables), "xcode-cloud env-vars shared list")
+			}
+			result.Summary = summarizeSharedEnvVars(result.Variables)
+			result.emptyResultNote = newEmptyResultNote(len(result.Variables), emptySharedEnvVarsMessage)
+			if err := shared.PrintOutputWithTabular(
 				result,
 				*output.Output,
 				*output.Pretty,
-				func() error { return renderSharedEnvVarsTable(result) },
-				func() error { return renderSharedEnvVarsMarkdown(result) },
-			)
+				func() error { return renderSharedEnvVarsTable(result, columns) },
+				func() error { return renderSharedEnvVarsMarkdown(result, columns) },
+				func() ([]string, [][]string) {
+					return columns.apply(sharedEnvVarHeaders(), buildSharedEnvVarRows(result.Variables))
+				},
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(result.Variables), "xcode-cloud env-vars shared list")
 		},
 	}
 }
@@ -203,19 +283,9 @@ Examples:
 			client := newCIClientFn(session)
 			result := &CISharedEnvVarsSetResult{}
 			err = withWebSpinner("Updating shared Xcode Cloud environment variable", func() error {
-				var envValue webcore.CIEnvironmentVariableValue
-				if *secret {
-					keyResp, err := client.GetCIEncryptionKey(requestCtx)
-					if err != nil {
-						return fmt.Errorf("xcode-cloud env-vars shared set failed: could not fetch encryption key: %w", err)
-					}
-					ct, err := webcore.ECIESEncrypt(keyResp.Key, varValue)
-					if err != nil {
-						return fmt.Errorf("xcode-cloud env-vars shared set failed: encryption error: %w", err)
-					}
-					envValue = webcore.CIEnvironmentVariableValue{Ciphertext: &ct}
-				} else {
-					envValue = webcore.CIEnvironmentVariableValue{Plaintext: &varValue}
+				envValue, err := newEnvVarValue(varValue, *secret, newEnvVarEncrypter(requestCtx, client))
+				if err != nil {
+					return err
 				}
 
 				wfIDs := parseWorkflowIDs(*workflowIDs)
@@ -289,21 +359,35 @@ func webXcodeCloudEnvVarsSharedDeleteCommand() *ffcli.Command {
 	output := shared.BindOutputFlags(fs)
 
 	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
-	name := fs.String("name", "", "Environment variable name to delete (required)")
-	confirm := fs.Bool("confirm", false, "Confirm deletion (required)")
+	name := fs.String("name", "", "Environment variable name to delete (exactly one of --name or --id)")
+	varIDFlag := fs.String("id", "", "Environment variable ID to delete, skipping the name lookup (exactly one of --name or --id)")
+	confirm := fs.Bool("confirm", false, "Confirm deletion (required unless stdout is a terminal, which prompts instead)")
+	ignoreMissing := bindIgnoreMissingFlag(fs)
 
 	return &ffcli.Command{
 		Name:       "delete",
-		ShortUsage: "asc web xcode-cloud env-vars shared delete --product-id ID --name NAME --confirm [flags]",
+		ShortUsage: "asc web xcode-cloud env-vars shared delete --product-id ID (--name NAME | --id VAR_ID) --confirm [flags]",
 		ShortHelp:  "EXPERIMENTAL: Delete a shared (product-level) environment variable.",
 		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
 
-Delete a shared environment variable from an Xcode Cloud product by name.
+Delete a shared environment variable from an Xcode Cloud product by name or ID.
+--name lists the product's shared variables and matches case-insensitively; --id deletes
+the variable directly without the lookup.
+
+Without --confirm in an interactive terminal, the variable's name, value type, and
+linked workflows are shown and deletion proceeds only after answering y. Non-interactive
+runs still require --confirm.
+
+A variable that does not exist fails with the not-found exit code (4). Use
+--ignore-missing to exit 0 instead, for idempotent cleanup; the result is then
+marked "missing".
 
 ` + webWarningText + `
 
 Examples:
-  asc web xcode-cloud env-vars shared delete --product-id "UUID" --name MY_VAR --confirm --apple-id "user@example.com"`,
+  asc web xcode-cloud env-vars shared delete --product-id "UUID" --name MY_VAR --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared delete --product-id "UUID" --id "VAR_ID" --confirm --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared delete --product-id "UUID" --name MY_VAR --confirm --ignore-missing --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -313,11 +397,12 @@ Examples:
 				return flag.ErrHelp
 			}
 			varName := strings.TrimSpace(*name)
-			if varName == "" {
-				fmt.Fprintln(os.Stderr, "Error: --name is required")
+			varID := strings.TrimSpace(*varIDFlag)
+			if (varName == "") == (varID == "") {
+				fmt.Fprintln(os.Stderr, "Error: exactly one of --name or --id is required")
 				return flag.ErrHelp
 			}
-			if !*confirm {
+			if !*confirm && !envVarDeleteCanPromptFn() {
 				fmt.Fprintln(os.Stderr, "Error: --confirm is required")
 				return flag.ErrHelp
 			}
@@ -335,42 +420,83 @@ Examples:
 			}
 
 			client := newCIClientFn(session)
-			var existing []webcore.CIProductEnvironmentVariable
-			err = withWebSpinner("Loading shared Xcode Cloud environment variables", func() error {
-				var err error
-				existing, err = client.ListCIProductEnvVars(requestCtx, teamID, pid)
+			printMissing := func(notFound error) error {
+				warnEnvVarAlreadyAbsent(notFound)
+				result := &CISharedEnvVarsDeleteResult{ProductID: pid, ID: varID, Name: varName, Missing: true}
+				return shared.PrintOutputWithRenderers(
+					result,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderSharedEnvVarsDeleteTable(result) },
+					func() error { return renderSharedEnvVarsDeleteMarkdown(result) },
+				)
+			}
+			// The interactive preview needs the variable's details, so --id only
+			// skips the lookup when --confirm is set.
+			if varID == "" || !*confirm {
+				var existing []webcore.CIProductEnvironmentVariable
+				err = withWebSpinner("Loading shared Xcode Cloud environment variables", func() error {
+					var err error
+					existing, err = client.ListCIProductEnvVars(requestCtx, teamID, pid)
+					if err != nil {
+						return err
+					}
+					return nil
+				})
 				if err != nil {
-					return err
+					return withWebAuthHint(err, "xcode-cloud env-vars shared delete")
 				}
-				return nil
-			})
-			if err != nil {
-				return withWebAuthHint(err, "xcode-cloud env-vars shared delete")
-			}
 
-			varID := ""
-			for _, v := range existing {
-				if strings.EqualFold(v.Name, varName) {
-					varID = v.ID
-					break
+				var target *webcore.CIProductEnvironmentVariable
+				for i, v := range existing {
+					if (varID != "" && v.ID == varID) || (varID == "" && strings.EqualFold(v.Name, varName)) {
+						target = &existing[i]
+						break
+					}
+				}
+				if target == nil {
+					notFound := newEnvVarNotFoundError("shared environment variable %q not found in product %s", varName, pid)
+					if varID != "" {
+						notFound = newEnvVarNotFoundError("shared environment variable with ID %q not found in product %s", varID, pid)
+					}
+					if *ignoreMissing {
+						return printMissing(notFound)
+					}
+					return notFound
+				}
+				varID = target.ID
+				if varName == "" {
+					varName = target.Name
+				}
+				if !*confirm {
+					if err := confirmEnvVarDelete("xcode-cloud env-vars shared delete", sharedEnvVarDeletePreview(*target)); err != nil {
+						return err
+					}
 				}
-			}
-			if varID == "" {
-				return fmt.Errorf("shared environment variable %q not found in product %s", varName, pid)
 			}
 
 			result := &CISharedEnvVarsDeleteResult{}
 			err = withWebSpinner("Deleting shared Xcode Cloud environment variable", func() error {
 				if err := client.DeleteCIProductEnvVar(requestCtx, teamID, pid, varID); err != nil {
+					if isCIResourceGone(err) {
+						return newEnvVarNotFoundError("shared environment variable with ID %q not found in product %s", varID, pid)
+					}
 					return err
 				}
 
 				result = &CISharedEnvVarsDeleteResult{
 					ProductID: pid,
+					ID:        varID,
 					Name:      varName,
 				}
 				return nil
 			})
+			if errors.Is(err, ErrEnvVarNotFound) {
+				if *ignoreMissing {
+					return printMissing(err)
+				}
+				return err
+			}
 			if err != nil {
 				return withWebAuthHint(err, "xcode-cloud env-vars shared delete")
 			}
@@ -385,6 +511,34 @@ Examples:
 	}
 }
 
+// sortSharedEnvVars orders vars in place by sortKey. Every key falls back to a
+// case-insensitive name order so listings diff cleanly; an empty key keeps API
+// order.
+func sortSharedEnvVars(vars []webcore.CIProductEnvironmentVariable, sortKey string) {
+	if sortKey == "" {
+		return
+	}
+	sort.SliceStable(vars, func(i, j int) bool {
+		a, b := vars[i], vars[j]
+		switch sortKey {
+		case "type":
+			typeA, typeB := envVarValueType(a.Value), envVarValueType(b.Value)
+			if typeA != typeB {
+				return typeA == "plaintext"
+			}
+		case "locked":
+			if a.IsLocked != b.IsLocked {
+				return a.IsLocked
+			}
+		}
+		nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name)
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return a.ID < b.ID
+	})
+}
+
 func parseWorkflowIDs(value string) []string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -400,30 +554,53 @@ func parseWorkflowIDs(value string) []string {
 	return ids
 }
 
-func renderSharedEnvVarsTable(result *CISharedEnvVarsListResult) error {
+func renderSharedEnvVarsTable(result *CISharedEnvVarsListResult, columns tableColumns) error {
 	if result == nil || len(result.Variables) == 0 {
-		fmt.Println("No shared environment variables found.")
+		fmt.Println(emptySharedEnvVarsMessage)
 		return nil
 	}
-	asc.RenderTable(
-		[]string{"Name", "Type", "Value", "Locked", "Workflows"},
-		buildSharedEnvVarRows(result.Variables),
-	)
+	asc.RenderTable(columns.apply(sharedEnvVarHeaders(), buildSharedEnvVarRows(result.Variables)))
+	fmt.Printf("\n%s\n", formatSharedEnvVarsSummary(result.Summary))
 	return nil
 }
 
-func renderSharedEnvVarsMarkdown(result *CISharedEnvVarsListResult) error {
+func renderSharedEnvVarsMarkdown(result *CISharedEnvVarsListResult, columns tableColumns) error {
 	if result == nil || len(result.Variables) == 0 {
-		fmt.Println("No shared environment variables found.")
+		fmt.Println(emptySharedEnvVarsMessage)
 		return nil
 	}
-	asc.RenderMarkdown(
-		[]string{"Name", "Type", "Value", "Locked", "Workflows"},
-		buildSharedEnvVarRows(result.Variables),
-	)
+	asc.RenderMarkdown(columns.apply(sharedEnvVarHeaders(), buildSharedEnvVarRows(result.Variables)))
+	fmt.Printf("\n**%s**\n", formatSharedEnvVarsSummary(result.Summary))
 	return nil
 }
 
+func summarizeSharedEnvVars(vars []webcore.CIProductEnvironmentVariable) CISharedEnvVarsSummary {
+	summary := CISharedEnvVarsSummary{Total: len(vars)}
+	for _, v := range vars {
+		if envVarValueType(v.Value) == "secret" {
+			summary.Secrets++
+		}
+		if v.IsLocked {
+			summary.Locked++
+		}
+		if len(v.RelatedWorkflowSummaries) > 0 {
+			summary.WithWorkflowLinks++
+		}
+	}
+	return summary
+}
+
+func formatSharedEnvVarsSummary(summary CISharedEnvVarsSummary) string {
+	noun := "variables"
+	if summary.Total == 1 {
+		noun = "variable"
+	}
+	return fmt.Sprintf(
+		"Total: %d %s (%d secret, %d locked, %d with workflow links)",
+		summary.Total, noun, summary.Secrets, summary.Locked, summary.WithWorkflowLinks,
+	)
+}
+
 func renderSharedEnvVarsSetTable(result *CISharedEnvVarsSetResult) error {
 	asc.RenderTable(
 		[]string{"Action", "Name", "Type", "Locked", "Product ID"},
@@ -442,20 +619,24 @@ func renderSharedEnvVarsSetMarkdown(result *CISharedEnvVarsSetResult) error {
 
 func renderSharedEnvVarsDeleteTable(result *CISharedEnvVarsDeleteResult) error {
 	asc.RenderTable(
-		[]string{"Action", "Name", "Product ID"},
-		[][]string{{"deleted", result.Name, result.ProductID}},
+		[]string{"Action", "Name", "ID", "Product ID"},
+		[][]string{{envVarDeleteAction(result.Missing), valueOrNA(result.Name), result.ID, result.ProductID}},
 	)
 	return nil
 }
 
 func renderSharedEnvVarsDeleteMarkdown(result *CISharedEnvVarsDeleteResult) error {
 	asc.RenderMarkdown(
-		[]string{"Action", "Name", "Product ID"},
-		[][]string{{"deleted", result.Name, result.ProductID}},
+		[]string{"Action", "Name", "ID", "Product ID"},
+		[][]string{{envVarDeleteAction(result.Missing), valueOrNA(result.Name), result.ID, result.ProductID}},
 	)
 	return nil
 }
 
+func sharedEnvVarHeaders() []string {
+	return []string{"Name", "Type", "Value", "Locked", "Workflows"}
+}
+
 func buildSharedEnvVarRows(vars []webcore.CIProductEnvironmentVariable) [][]string {
 	rows := make([][]string, 0, len(vars))
 	for _, v := range vars {
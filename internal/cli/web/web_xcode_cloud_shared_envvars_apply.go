@@ -0,0 +1,300 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// sharedEnvVarApplyStep pairs a planned action with the request that carries
+// it out. Request is unset for unchanged and delete steps.
+type sharedEnvVarApplyStep struct {
+	Action  CIEnvVarApplyAction
+	Entry   ciEnvVarManifestEntry
+	Request webcore.CIProductEnvVarRequest
+}
+
+func webXcodeCloudEnvVarsSharedApplyCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud env-vars shared apply", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	filePath := fs.String("file", "", "Path to a JSON manifest of shared environment variables (required)")
+	prune := fs.Bool("prune", false, "Delete shared variables that are not in the manifest")
+	confirm := fs.Bool("confirm", false, "Confirm deletions (required with --prune unless --dry-run)")
+	dryRun := fs.Bool("dry-run", false, "Show the plan without changing anything")
+
+	return &ffcli.Command{
+		Name:       "apply",
+		ShortUsage: "asc web xcode-cloud env-vars shared apply --product-id ID --file FILE [--prune --confirm] [--dry-run] [flags]",
+		ShortHelp:  "EXPERIMENTAL: Apply shared environment variables from a JSON manifest.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Create or update every shared environment variable listed in a JSON manifest.
+The manifest is an array of objects:
+
+  [
+    {"name": "API_URL", "value": "https://example.com"},
+    {"name": "API_TOKEN", "value": "s3cret", "secret": true, "locked": true, "workflow_ids": ["wf-1"]}
+  ]
+
+Variables are matched by name case-insensitively. Secret values are encrypted
+with the same scheme as the ASC web UI. Omitting workflow_ids keeps an existing
+variable's workflow links; an empty list unlinks it. Plaintext variables whose
+value, lock, and links already match are reported as unchanged; secrets are
+always updated because their current values cannot be read back.
+
+Use --prune with --confirm to delete shared variables missing from the manifest.
+Use --dry-run to print the plan without making changes.
+
+Changes are written one variable at a time. If a step fails, the changes that
+were already applied are still printed before the command exits with the error.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud env-vars shared apply --product-id "UUID" --file vars.json --dry-run --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared apply --product-id "UUID" --file vars.json --apple-id "user@example.com"
+  asc web xcode-cloud env-vars shared apply --product-id "UUID" --file vars.json --prune --confirm --apple-id "user@example.com"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			path := strings.TrimSpace(*filePath)
+			if path == "" {
+				fmt.Fprintln(os.Stderr, "Error: --file is required")
+				return flag.ErrHelp
+			}
+			if *prune && !*dryRun && !*confirm {
+				fmt.Fprintln(os.Stderr, "Error: --confirm is required with --prune")
+				return flag.ErrHelp
+			}
+			entries, err := parseCIEnvVarManifest(path)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud env-vars shared apply failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var steps []sharedEnvVarApplyStep
+			err = withWebSpinner("Planning shared Xcode Cloud environment variable changes", func() error {
+				existing, err := client.ListCIProductEnvVars(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				steps = planSharedEnvVarsApply(entries, existing, *prune)
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud env-vars shared apply")
+			}
+
+			var applyErr error
+			if !*dryRun {
+				done := 0
+				err = withWebSpinner("Applying shared Xcode Cloud environment variables", func() error {
+					var err error
+					done, err = applySharedEnvVarsPlan(requestCtx, client, teamID, pid, steps)
+					return err
+				})
+				if err != nil {
+					applyErr = withWebAuthHint(err, "xcode-cloud env-vars shared apply")
+					// Shared variables are written one request at a time, so
+					// report what already changed before surfacing the error.
+					if !sharedEnvVarsPlanHasChanges(steps[:done]) {
+						return applyErr
+					}
+					fmt.Fprintf(os.Stderr, "Warning: stopped after %d of %d steps; only the changes listed were applied\n", done, len(steps))
+					steps = steps[:done]
+				}
+			}
+
+			actions := make([]CIEnvVarApplyAction, 0, len(steps))
+			for _, step := range steps {
+				actions = append(actions, step.Action)
+			}
+			result := newCIEnvVarsApplyResult(pid, path, *dryRun, actions)
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderEnvVarsApplyTable(result) },
+				func() error { return renderEnvVarsApplyMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			return applyErr
+		},
+	}
+}
+
+// planSharedEnvVarsApply diffs the manifest against the product's current
+// shared variables. Steps follow manifest order, then prunes by name.
+func planSharedEnvVarsApply(
+	entries []ciEnvVarManifestEntry,
+	existing []webcore.CIProductEnvironmentVariable,
+	prune bool,
+) []sharedEnvVarApplyStep {
+	byName := make(map[string]webcore.CIProductEnvironmentVariable, len(existing))
+	for _, v := range existing {
+		byName[strings.ToLower(strings.TrimSpace(v.Name))] = v
+	}
+
+	steps := make([]sharedEnvVarApplyStep, 0, len(entries))
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Name)
+		wanted[key] = true
+		action := CIEnvVarApplyAction{
+			Action: envVarApplyCreate,
+			Name:   entry.Name,
+			Type:   manifestEntryType(entry),
+			Locked: entry.Locked,
+		}
+		workflowIDs := entry.WorkflowIDs
+		current, exists := byName[key]
+		if exists {
+			action.Action = envVarApplyUpdate
+			action.ID = current.ID
+			if workflowIDs == nil {
+				workflowIDs = relatedWorkflowIDs(current)
+			}
+			if sharedEnvVarMatchesEntry(current, entry, workflowIDs) {
+				action.Action = envVarApplyUnchanged
+			}
+		}
+		if workflowIDs == nil {
+			workflowIDs = []string{}
+		}
+		steps = append(steps, sharedEnvVarApplyStep{
+			Action: action,
+			Entry:  entry,
+			Request: webcore.CIProductEnvVarRequest{
+				Name:        entry.Name,
+				IsLocked:    entry.Locked,
+				WorkflowIDs: workflowIDs,
+			},
+		})
+	}
+
+	if prune {
+		var deletes []sharedEnvVarApplyStep
+		for _, v := range existing {
+			if wanted[strings.ToLower(strings.TrimSpace(v.Name))] {
+				continue
+			}
+			deletes = append(deletes, sharedEnvVarApplyStep{
+				Action: CIEnvVarApplyAction{
+					Action: envVarApplyDelete,
+					Name:   v.Name,
+					ID:     v.ID,
+					Type:   envVarValueType(v.Value),
+					Locked: v.IsLocked,
+				},
+			})
+		}
+		sort.SliceStable(deletes, func(i, j int) bool {
+			return strings.ToLower(deletes[i].Action.Name) < strings.ToLower(deletes[j].Action.Name)
+		})
+		steps = append(steps, deletes...)
+	}
+	return steps
+}
+
+// sharedEnvVarMatchesEntry reports whether applying entry would be a no-op.
+// Secrets never match because the API only returns redacted values.
+func sharedEnvVarMatchesEntry(current webcore.CIProductEnvironmentVariable, entry ciEnvVarManifestEntry, workflowIDs []string) bool {
+	if entry.Secret || current.Value.Plaintext == nil || *current.Value.Plaintext != entry.Value {
+		return false
+	}
+	if current.IsLocked != entry.Locked {
+		return false
+	}
+	currentIDs := relatedWorkflowIDs(current)
+	if len(currentIDs) != len(workflowIDs) {
+		return false
+	}
+	linked := make(map[string]bool, len(currentIDs))
+	for _, id := range currentIDs {
+		linked[id] = true
+	}
+	for _, id := range workflowIDs {
+		if !linked[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func relatedWorkflowIDs(v webcore.CIProductEnvironmentVariable) []string {
+	ids := make([]string, 0, len(v.RelatedWorkflowSummaries))
+	for _, ws := range v.RelatedWorkflowSummaries {
+		ids = append(ids, ws.ID)
+	}
+	return ids
+}
+
+// applySharedEnvVarsPlan executes the plan in order and returns how many
+// steps completed, so a failure part way through can still be reported. The
+// encryption key is only fetched when a secret is written.
+func applySharedEnvVarsPlan(ctx context.Context, client *webcore.Client, teamID, productID string, steps []sharedEnvVarApplyStep) (int, error) {
+	encrypt := newEnvVarEncrypter(ctx, client)
+	for i := range steps {
+		step := &steps[i]
+		switch step.Action.Action {
+		case envVarApplyCreate, envVarApplyUpdate:
+			req := step.Request
+			value, err := newEnvVarValue(step.Entry.Value, step.Entry.Secret, encrypt)
+			if err != nil {
+				return i, fmt.Errorf("%s %s: %w", step.Action.Action, step.Entry.Name, err)
+			}
+			req.Value = value
+			varID := step.Action.ID
+			if varID == "" {
+				varID = newUUID()
+			}
+			if _, err := client.SetCIProductEnvVar(ctx, teamID, productID, varID, req); err != nil {
+				return i, fmt.Errorf("%s %s: %w", step.Action.Action, step.Entry.Name, err)
+			}
+			step.Action.ID = varID
+		case envVarApplyDelete:
+			if err := client.DeleteCIProductEnvVar(ctx, teamID, productID, step.Action.ID); err != nil {
+				return i, fmt.Errorf("delete %s: %w", step.Action.Name, err)
+			}
+		}
+	}
+	return len(steps), nil
+}
+
+func sharedEnvVarsPlanHasChanges(steps []sharedEnvVarApplyStep) bool {
+	for _, step := range steps {
+		if step.Action.Action != envVarApplyUnchanged {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func writeEnvVarManifest(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vars.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	return path
+}
+
+func TestParseCIEnvVarManifest_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "not an array", body: `{"name":"A"}`, wantErr: "invalid env-vars manifest JSON"},
+		{name: "unknown field", body: `[{"name":"A","value":"1","scope":"x"}]`, wantErr: "unknown field"},
+		{name: "missing name", body: `[{"value":"1"}]`, wantErr: "entry 1: name is required"},
+		{name: "missing value", body: `[{"name":"A"}]`, wantErr: "entry 1 (A): value is required"},
+		{name: "duplicate", body: `[{"name":"A","value":"1"},{"name":"a","value":"2"}]`, wantErr: "duplicate name a"},
+		{name: "trailing", body: `[] []`, wantErr: "multiple JSON values"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCIEnvVarManifest(writeEnvVarManifest(t, tt.body))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPlanSharedEnvVarsApply(t *testing.T) {
+	same := "same"
+	old := "old"
+	redacted := "***"
+	existing := []webcore.CIProductEnvironmentVariable{
+		{ID: "id-same", Name: "SAME", Value: webcore.CIEnvironmentVariableValue{Plaintext: &same}, RelatedWorkflowSummaries: []webcore.CIRelatedWorkflowSummary{{ID: "wf-1"}}},
+		{ID: "id-changed", Name: "CHANGED", Value: webcore.CIEnvironmentVariableValue{Plaintext: &old}},
+		{ID: "id-secret", Name: "TOKEN", Value: webcore.CIEnvironmentVariableValue{RedactedValue: &redacted}},
+		{ID: "id-stale", Name: "STALE", Value: webcore.CIEnvironmentVariableValue{Plaintext: &old}},
+	}
+	entries := []ciEnvVarManifestEntry{
+		{Name: "same", Value: "same"},
+		{Name: "CHANGED", Value: "new"},
+		{Name: "TOKEN", Value: "s3cret", Secret: true},
+		{Name: "NEW", Value: "fresh", WorkflowIDs: []string{"wf-2"}},
+	}
+
+	steps := planSharedEnvVarsApply(entries, existing, true)
+	got := make([]string, 0, len(steps))
+	for _, step := range steps {
+		got = append(got, step.Action.Action+":"+step.Action.Name)
+	}
+	want := "unchanged:same,update:CHANGED,update:TOKEN,create:NEW,delete:STALE"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("plan = %s, want %s", strings.Join(got, ","), want)
+	}
+	if ids := steps[0].Request.WorkflowIDs; len(ids) != 1 || ids[0] != "wf-1" {
+		t.Fatalf("expected omitted workflow_ids to keep existing links, got %v", ids)
+	}
+	if ids := steps[3].Request.WorkflowIDs; len(ids) != 1 || ids[0] != "wf-2" {
+		t.Fatalf("expected manifest workflow_ids for new var, got %v", ids)
+	}
+
+	withoutPrune := planSharedEnvVarsApply(entries, existing, false)
+	if len(withoutPrune) != len(entries) {
+		t.Fatalf("expected no delete steps without --prune, got %d steps", len(withoutPrune))
+	}
+}
+
+func TestSharedEnvVarsApply_PruneRequiresConfirm(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsSharedApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--file", "vars.json", "--prune"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--confirm is required with --prune") {
+		t.Fatalf("expected confirm error, got %q", stderr)
+	}
+}
+
+func stubSharedEnvVarsApplySession(t *testing.T, existing string, requests *[]string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	serverKeyB64 := "0xm9f0gX7lzArxrChNrDVUR3MKxueb1DdheWBeLndCVOqoiEsT2jxqZW6cHsIuDGDykvYWgQ1qaPBSxCNFXEUg=="
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{}`
+					switch {
+					case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/keys/client-encryption"):
+						body = `{"key":"` + serverKeyB64 + `"}`
+					case req.Method == http.MethodGet:
+						body = existing
+					default:
+						entry := req.Method + " " + req.URL.Path
+						if req.Body != nil {
+							data, _ := io.ReadAll(req.Body)
+							entry += " " + string(data)
+						}
+						*requests = append(*requests, entry)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+}
+
+func TestSharedEnvVarsApply_AppliesPlan(t *testing.T) {
+	var requests []string
+	stubSharedEnvVarsApplySession(t, `[
+		{"id":"var-1","name":"API_URL","value":{"plaintext":"old"},"is_locked":false,"related_workflow_summaries":[]},
+		{"id":"var-2","name":"STALE","value":{"plaintext":"x"},"is_locked":false,"related_workflow_summaries":[]}
+	]`, &requests)
+	path := writeEnvVarManifest(t, `[
+		{"name":"API_URL","value":"https://example.com"},
+		{"name":"API_TOKEN","value":"s3cret","secret":true,"locked":true}
+	]`)
+
+	cmd := webXcodeCloudEnvVarsSharedApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--file", path,
+		"--prune",
+		"--confirm",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var result CIEnvVarsApplyResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v: %q", err, stdout)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Deleted != 1 || result.DryRun {
+		t.Fatalf("unexpected counts %+v", result)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 mutating requests, got %v", requests)
+	}
+	if !strings.HasPrefix(requests[0], http.MethodPut) || !strings.Contains(requests[0], "var-1") || !strings.Contains(requests[0], `"plaintext":"https://example.com"`) {
+		t.Fatalf("unexpected update request %q", requests[0])
+	}
+	if !strings.Contains(requests[1], `"ciphertext"`) || strings.Contains(requests[1], "s3cret") {
+		t.Fatalf("expected encrypted secret, got %q", requests[1])
+	}
+	if !strings.HasPrefix(requests[2], http.MethodDelete) || !strings.Contains(requests[2], "var-2") {
+		t.Fatalf("unexpected delete request %q", requests[2])
+	}
+}
+
+func TestSharedEnvVarsApply_DryRunMakesNoChanges(t *testing.T) {
+	var requests []string
+	stubSharedEnvVarsApplySession(t, `[{"id":"var-2","name":"STALE","value":{"plaintext":"x"},"is_locked":false,"related_workflow_summaries":[]}]`, &requests)
+	path := writeEnvVarManifest(t, `[{"name":"API_URL","value":"https://example.com"}]`)
+
+	cmd := webXcodeCloudEnvVarsSharedApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--file", path,
+		"--prune",
+		"--dry-run",
+		"--output", "table",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if len(requests) != 0 {
+		t.Fatalf("expected no mutating requests on dry run, got %v", requests)
+	}
+	if !strings.Contains(stdout, "Dry run for product prod-1 (no changes applied): 1 created, 0 updated, 0 unchanged, 1 deleted") {
+		t.Fatalf("expected dry-run summary, got %q", stdout)
+	}
+}
+
+func TestSharedEnvVarsApply_ReportsChangesAppliedBeforeFailure(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					status, body := http.StatusOK, `{}`
+					switch req.Method {
+					case http.MethodGet:
+						body = `[{"id":"var-2","name":"STALE","value":{"plaintext":"x"},"is_locked":false,"related_workflow_summaries":[]}]`
+					case http.MethodDelete:
+						status, body = http.StatusInternalServerError, `{"error":"boom"}`
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "", nil
+	}
+	path := writeEnvVarManifest(t, `[{"name":"API_URL","value":"https://example.com"}]`)
+
+	cmd := webXcodeCloudEnvVarsSharedApplyCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--file", path,
+		"--prune",
+		"--confirm",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	stdout, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "delete STALE") {
+		t.Fatalf("expected the delete failure, got %v", runErr)
+	}
+
+	var result CIEnvVarsApplyResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON output, got %v: %q", err, stdout)
+	}
+	if result.Created != 1 || result.Deleted != 0 || len(result.Actions) != 1 {
+		t.Fatalf("expected only the applied create to be reported, got %+v", result)
+	}
+	if !strings.Contains(stderr, "stopped after 1 of 2 steps") {
+		t.Fatalf("expected partial-apply warning, got %q", stderr)
+	}
+}
@@ -23,14 +23,14 @@ func TestSharedEnvVarsCommandHierarchy(t *testing.T) {
 	if sharedCmd == nil {
 		t.Fatal("expected 'shared' subcommand under env-vars")
 	}
-	if len(sharedCmd.Subcommands) != 3 {
-		t.Fatalf("expected 3 subcommands (list, set, delete), got %d", len(sharedCmd.Subcommands))
+	if len(sharedCmd.Subcommands) != 5 {
+		t.Fatalf("expected 5 subcommands (list, get, set, delete, apply), got %d", len(sharedCmd.Subcommands))
 	}
 	names := map[string]bool{}
 	for _, sub := range sharedCmd.Subcommands {
 		names[sub.Name] = true
 	}
-	for _, name := range []string{"list", "set", "delete"} {
+	for _, name := range []string{"list", "get", "set", "delete", "apply"} {
 		if !names[name] {
 			t.Fatalf("expected %q subcommand", name)
 		}
@@ -161,6 +161,60 @@ func TestSharedEnvVarsList_MissingProductID(t *testing.T) {
 	}
 }
 
+func TestSharedEnvVarsList_InvalidSort(t *testing.T) {
+	cmd := webXcodeCloudEnvVarsSharedListCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--sort", "value"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--sort must be one of: name, type, locked") {
+		t.Fatalf("expected sort error in stderr, got %q", stderr)
+	}
+}
+
+func TestSortSharedEnvVars(t *testing.T) {
+	plain := "v"
+	redacted := "***"
+	vars := func() []webcore.CIProductEnvironmentVariable {
+		return []webcore.CIProductEnvironmentVariable{
+			{ID: "1", Name: "zeta", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}},
+			{ID: "2", Name: "Beta", Value: webcore.CIEnvironmentVariableValue{RedactedValue: &redacted}, IsLocked: true},
+			{ID: "3", Name: "alpha", Value: webcore.CIEnvironmentVariableValue{RedactedValue: &redacted}},
+			{ID: "4", Name: "gamma", Value: webcore.CIEnvironmentVariableValue{Plaintext: &plain}, IsLocked: true},
+		}
+	}
+	names := func(items []webcore.CIProductEnvironmentVariable) string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		sortKey string
+		want    string
+	}{
+		{sortKey: "", want: "zeta,Beta,alpha,gamma"},
+		{sortKey: "name", want: "alpha,Beta,gamma,zeta"},
+		{sortKey: "type", want: "gamma,zeta,alpha,Beta"},
+		{sortKey: "locked", want: "Beta,gamma,alpha,zeta"},
+	}
+	for _, tt := range tests {
+		items := vars()
+		sortSharedEnvVars(items, tt.sortKey)
+		if got := names(items); got != tt.want {
+			t.Fatalf("sort %q: got %s, want %s", tt.sortKey, got, tt.want)
+		}
+	}
+}
+
 func TestSharedEnvVarsList_TableOutput(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() { resolveSessionFn = origResolveSession })
@@ -217,6 +271,9 @@ func TestSharedEnvVarsList_TableOutput(t *testing.T) {
 			t.Fatalf("expected table output to include %q, got %q", token, stdout)
 		}
 	}
+	if !strings.Contains(stdout, "Total: 2 variables (1 secret, 1 locked, 1 with workflow links)") {
+		t.Fatalf("expected summary footer, got %q", stdout)
+	}
 }
 
 func TestSharedEnvVarsList_JSONOutput(t *testing.T) {
@@ -272,6 +329,9 @@ func TestSharedEnvVarsList_JSONOutput(t *testing.T) {
 	if len(result.Variables) != 1 || result.Variables[0].Name != "FOO" {
 		t.Fatalf("unexpected variables: %+v", result.Variables)
 	}
+	if result.Summary != (CISharedEnvVarsSummary{Total: 1}) {
+		t.Fatalf("unexpected summary: %+v", result.Summary)
+	}
 }
 
 func TestSharedEnvVarsSetPlaintext_Success(t *testing.T) {
@@ -704,6 +764,68 @@ func TestSharedEnvVarsDelete_Success(t *testing.T) {
 	}
 }
 
+func TestSharedEnvVarsDelete_ByIDSkipsLookup(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var deletePath string
+	listed := false
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodGet {
+						listed = true
+					}
+					if req.Method == http.MethodDelete {
+						deletePath = req.URL.Path
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudEnvVarsSharedDeleteCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--id", "var-9",
+		"--confirm",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if listed {
+		t.Fatal("expected --id to skip the variable list lookup")
+	}
+	if !strings.Contains(deletePath, "var-9") {
+		t.Fatalf("expected DELETE path to contain var-9, got %q", deletePath)
+	}
+	var delResult CISharedEnvVarsDeleteResult
+	if err := json.Unmarshal([]byte(stdout), &delResult); err != nil {
+		t.Fatalf("expected valid JSON output, got parse error: %v\noutput: %q", err, stdout)
+	}
+	if delResult.ID != "var-9" || delResult.Name != "" {
+		t.Fatalf("expected id var-9 and no name, got %+v", delResult)
+	}
+}
+
 func TestSharedEnvVarsDelete_NotFound(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() { resolveSessionFn = origResolveSession })
@@ -767,9 +889,14 @@ func TestSharedEnvVarsDelete_MissingFlags(t *testing.T) {
 			wantErr: "--product-id is required",
 		},
 		{
-			name:    "missing name",
+			name:    "missing name and id",
 			args:    []string{"--product-id", "prod-1", "--confirm"},
-			wantErr: "--name is required",
+			wantErr: "exactly one of --name or --id is required",
+		},
+		{
+			name:    "both name and id",
+			args:    []string{"--product-id", "prod-1", "--name", "X", "--id", "var-1", "--confirm"},
+			wantErr: "exactly one of --name or --id is required",
 		},
 		{
 			name:    "missing confirm",
@@ -3,6 +3,7 @@ package web
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"io"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/peterbourgon/ff/v3/ffcli"
 
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
@@ -65,8 +67,8 @@ func TestWebXcodeCloudCommandHierarchy(t *testing.T) {
 	if cmd.Name != "xcode-cloud" {
 		t.Fatalf("expected command name %q, got %q", "xcode-cloud", cmd.Name)
 	}
-	if len(cmd.Subcommands) != 4 {
-		t.Fatalf("expected 4 subcommands (usage, products, workflows, env-vars), got %d", len(cmd.Subcommands))
+	if len(cmd.Subcommands) != 5 {
+		t.Fatalf("expected 5 subcommands (usage, products, workflows, env-vars, doctor), got %d", len(cmd.Subcommands))
 	}
 
 	names := map[string]bool{}
@@ -85,6 +87,9 @@ func TestWebXcodeCloudCommandHierarchy(t *testing.T) {
 	if !names["env-vars"] {
 		t.Fatal("expected 'env-vars' subcommand")
 	}
+	if !names["doctor"] {
+		t.Fatal("expected 'doctor' subcommand")
+	}
 }
 
 func TestWebXcodeCloudUsageSubcommands(t *testing.T) {
@@ -93,14 +98,14 @@ func TestWebXcodeCloudUsageSubcommands(t *testing.T) {
 	if usageCmd == nil {
 		t.Fatal("could not find 'usage' subcommand")
 	}
-	if len(usageCmd.Subcommands) != 5 {
-		t.Fatalf("expected 5 usage subcommands, got %d", len(usageCmd.Subcommands))
+	if len(usageCmd.Subcommands) != 11 {
+		t.Fatalf("expected 11 usage subcommands, got %d", len(usageCmd.Subcommands))
 	}
 	usageNames := map[string]bool{}
 	for _, sub := range usageCmd.Subcommands {
 		usageNames[sub.Name] = true
 	}
-	for _, expected := range []string{"summary", "alert", "months", "days", "workflows"} {
+	for _, expected := range []string{"summary", "alert", "alert-all", "months", "days", "day", "workflows", "products", "top", "metrics", "snapshot"} {
 		if !usageNames[expected] {
 			t.Fatalf("expected %q usage subcommand", expected)
 		}
@@ -247,6 +252,279 @@ func TestWebXcodeCloudUsageSummaryOutputTableUsesHumanRenderer(t *testing.T) {
 	}
 }
 
+func TestWebXcodeCloudUsageSummaryWatchEmitsJSONPerTick(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origUnit := usageWatchIntervalUnit
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		usageWatchIntervalUnit = origUnit
+	})
+	usageWatchIntervalUnit = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requests++
+					if requests == 3 {
+						cancel()
+					}
+					body := `{"plan":{"name":"Plan","available":1500,"used":` + strconv.Itoa(requests) + `,"total":1500}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--watch", "--interval", "1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(ctx, nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if requests != 3 {
+		t.Fatalf("expected 3 requests before cancellation, got %d", requests)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one JSON object per completed tick, got %q", stdout)
+	}
+	if strings.Contains(stdout, clearScreenSequence) {
+		t.Fatalf("expected json output not to clear the screen, got %q", stdout)
+	}
+	if got := strings.Count(stderr, "Refreshed at "); got != 2 {
+		t.Fatalf("expected 2 refresh timestamps in stderr, got %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryRejectsNonPositiveInterval(t *testing.T) {
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--watch", "--interval", "0"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--interval must be greater than 0") {
+		t.Fatalf("expected interval error, got %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryPercentOnly(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"plan":{"name":"Plan","available":500,"used":1000,"total":1500}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--percent-only"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if stdout != "67\n" {
+		t.Fatalf("expected only the percent on stdout, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryLogfmt(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"plan":{"name":"Plan","available":500,"used":1000,"total":1500,"reset_date":"2026-03-01"}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--log-format", "logfmt", "--redact-team"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	want := "used=1000 available=500 total=1500 percent=67 reset_date=2026-03-01 team=" + teamPseudonym("team-uuid") + "\n"
+	if stdout != want {
+		t.Fatalf("logfmt output = %q, want %q", stdout, want)
+	}
+}
+
+func TestFormatUsageBarWithValuesNonPositiveTotal(t *testing.T) {
+	for _, total := range []int{0, -5} {
+		got := formatUsageBarWithValues(42, total)
+		if got != "[................] n/a" {
+			t.Fatalf("formatUsageBarWithValues(42, %d) = %q, want n/a bar without suffix", total, got)
+		}
+	}
+	if got := formatUsageBarWithValues(50, 100); !strings.HasSuffix(got, "(50/100m)") {
+		t.Fatalf("expected value suffix for positive total, got %q", got)
+	}
+}
+
+func TestCIUsageRenderersHandleUnavailablePlanTotal(t *testing.T) {
+	t.Run("summary", func(t *testing.T) {
+		stdout, _ := captureOutput(t, func() {
+			summary := &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Name: "Plan", Used: 30}}
+			if err := renderCIUsageSummaryTable(summary); err != nil {
+				t.Fatalf("render error: %v", err)
+			}
+		})
+		if !strings.Contains(stdout, "n/a") || strings.Contains(stdout, "/0m") {
+			t.Fatalf("expected n/a bar without /0m suffix, got %q", stdout)
+		}
+	})
+
+	t.Run("months", func(t *testing.T) {
+		months := &webcore.CIUsageMonths{
+			Usage: []webcore.CIMonthUsage{{Year: 2026, Month: 1, Duration: 30, NumberOfBuilds: 2}},
+			ProductUsage: []webcore.CIProductUsage{
+				{ProductID: "prod-1", ProductName: "App", UsageInMinutes: 30, NumberOfBuilds: 2},
+			},
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := renderCIUsageMonthsTable(&CIUsageMonthsResult{CIUsageMonths: months}, 0, false, false); err != nil {
+				t.Fatalf("render error: %v", err)
+			}
+		})
+		if !strings.Contains(stdout, "n/a") || strings.Contains(stdout, "/0m") {
+			t.Fatalf("expected n/a bar without /0m suffix, got %q", stdout)
+		}
+	})
+}
+
+func TestFormatUsageChangePercent(t *testing.T) {
+	tests := []struct {
+		current  int
+		previous int
+		want     string
+	}{
+		{current: 150, previous: 100, want: "+50.0%"},
+		{current: 50, previous: 100, want: "-50.0%"},
+		{current: 100, previous: 100, want: "+0.0%"},
+		{current: 40, previous: 0, want: "new"},
+		{current: 0, previous: 0, want: "n/a"},
+	}
+	for _, test := range tests {
+		if got := formatUsageChangePercent(test.current, test.previous); got != test.want {
+			t.Fatalf("formatUsageChangePercent(%d, %d) = %q, want %q", test.current, test.previous, got, test.want)
+		}
+	}
+}
+
+func TestCIUsageMonthsTableDetailedColumns(t *testing.T) {
+	months := &webcore.CIUsageMonths{
+		ProductUsage: []webcore.CIProductUsage{
+			{ProductID: "prod-1", ProductName: "App", UsageInMinutes: 30, UsageInSeconds: 1800, PreviousUsageInMinutes: 20},
+			{ProductID: "prod-2", ProductName: "Fresh", UsageInMinutes: 10, UsageInSeconds: 600},
+		},
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := renderCIUsageMonthsTable(&CIUsageMonthsResult{CIUsageMonths: months}, 100, false, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	if strings.Contains(stdout, "Seconds") || strings.Contains(stdout, "% Change vs Prev") {
+		t.Fatalf("expected detailed columns to be hidden by default, got:\n%s", stdout)
+	}
+
+	stdout, _ = captureOutput(t, func() {
+		if err := renderCIUsageMonthsTable(&CIUsageMonthsResult{CIUsageMonths: months}, 100, true, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	for _, want := range []string{"Seconds", "% Change vs Prev", "1800", "+50.0%", "new"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in detailed output, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestBuildCIMonthUsageRowsShowDelta(t *testing.T) {
+	usage := []webcore.CIMonthUsage{
+		{Year: 2026, Month: 1, Duration: 100, NumberOfBuilds: 5},
+		{Year: 2026, Month: 2, Duration: 220, NumberOfBuilds: 9},
+		{Year: 2026, Month: 3, Duration: 180, NumberOfBuilds: 7},
+		{Year: 2026, Month: 4, Duration: 180, NumberOfBuilds: 7},
+	}
+
+	if headers := ciMonthUsageHeaders(false); strings.Join(headers, ",") != "Year,Month,Minutes,Builds,Usage Bar" {
+		t.Fatalf("unexpected default headers: %v", headers)
+	}
+	if rows := buildCIMonthUsageRows(usage, 220, false); len(rows[0]) != 5 {
+		t.Fatalf("expected no delta column by default, got %v", rows[0])
+	}
+
+	if headers := ciMonthUsageHeaders(true); strings.Join(headers, ",") != "Year,Month,Minutes,Minutes Change,Builds,Usage Bar" {
+		t.Fatalf("unexpected delta headers: %v", headers)
+	}
+	rows := buildCIMonthUsageRows(usage, 220, true)
+	var deltas []string
+	for _, row := range rows {
+		deltas = append(deltas, row[3])
+	}
+	if strings.Join(deltas, ",") != "n/a,+120,-40,0" {
+		t.Fatalf("unexpected deltas: %v", deltas)
+	}
+}
+
 func TestFormatUsageBar(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -631,6 +909,56 @@ func TestWebXcodeCloudUsageMonthsProductIDsValidation(t *testing.T) {
 	})
 }
 
+func TestValidateMonthRange(t *testing.T) {
+	tests := []struct {
+		name                                     string
+		startMonth, startYear, endMonth, endYear int
+		wantErr                                  string
+	}{
+		{name: "same month", startMonth: 3, startYear: 2026, endMonth: 3, endYear: 2026},
+		{name: "across year boundary", startMonth: 11, startYear: 2025, endMonth: 2, endYear: 2026},
+		{name: "exactly 24 months", startMonth: 1, startYear: 2024, endMonth: 12, endYear: 2025},
+		{name: "reversed within year", startMonth: 5, startYear: 2026, endMonth: 4, endYear: 2026, wantErr: "must not be after"},
+		{name: "reversed across years", startMonth: 1, startYear: 2026, endMonth: 12, endYear: 2025, wantErr: "must not be after"},
+		{name: "25 months", startMonth: 1, startYear: 2024, endMonth: 1, endYear: 2026, wantErr: "spans 25 months"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMonthRange(tt.startMonth, tt.startYear, tt.endMonth, tt.endYear)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsRejectsReversedRange(t *testing.T) {
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--start-month", "6", "--start-year", "2026",
+		"--end-month", "1", "--end-year", "2026",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	_, stderr := captureOutput(t, func() {
+		err := cmd.Exec(context.Background(), nil)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "must not be after") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
 func TestWebXcodeCloudUsageMonthsOutputTableWithProductFilter(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() {
@@ -745,7 +1073,10 @@ func TestWebXcodeCloudUsageMonthsOutputTableWithProductFilter(t *testing.T) {
 	})
 }
 
-func TestWebXcodeCloudUsageMonthsTableDoesNotFailWhenSummaryUnavailable(t *testing.T) {
+// stubUsageMonthsSummaryForbidden serves monthly usage but rejects the plan
+// summary lookup with a 403.
+func stubUsageMonthsSummaryForbidden(t *testing.T) {
+	t.Helper()
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() {
 		resolveSessionFn = origResolveSession
@@ -782,6 +1113,10 @@ func TestWebXcodeCloudUsageMonthsTableDoesNotFailWhenSummaryUnavailable(t *testi
 			},
 		}, "cache", nil
 	}
+}
+
+func TestWebXcodeCloudUsageMonthsTableDoesNotFailWhenSummaryUnavailable(t *testing.T) {
+	stubUsageMonthsSummaryForbidden(t)
 
 	cmd := webXcodeCloudUsageMonthsCommand()
 	if err := cmd.FlagSet.Parse([]string{
@@ -801,6 +1136,94 @@ func TestWebXcodeCloudUsageMonthsTableDoesNotFailWhenSummaryUnavailable(t *testi
 	}
 }
 
+func TestWebXcodeCloudUsageMonthsStrictFailsWhenSummaryUnavailable(t *testing.T) {
+	stubUsageMonthsSummaryForbidden(t)
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--output", "table",
+		"--strict",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil {
+		t.Fatalf("expected --strict to fail when the plan summary is unavailable, got output %q", stdout)
+	}
+	if !strings.Contains(runErr.Error(), "plan summary unavailable (--strict)") {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	if strings.Contains(stdout, "App One") {
+		t.Fatalf("expected no table output under --strict, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsRestrictedProductVisibility(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	canViewAll := false
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, nil, &webcore.CIUsageMonths{
+		Usage:        []webcore.CIMonthUsage{{Month: 1, Year: 2026, Duration: 100, NumberOfBuilds: 4}},
+		ProductUsage: []webcore.CIProductUsage{{ProductID: "prod-1", ProductName: "App One", UsageInMinutes: 100}},
+		Info:         webcore.CIUsageInfo{CanViewAllProducts: &canViewAll},
+	})
+
+	for _, format := range []string{"json", "table"} {
+		cmd := webXcodeCloudUsageMonthsCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--output", format}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(stderr, "Warning: this account cannot view all Xcode Cloud products") {
+			t.Fatalf("expected visibility caveat on stderr for %s, got %q", format, stderr)
+		}
+		want := `"can_view_all_products":false`
+		if format == "table" {
+			want = "Product visibility: restricted (product-level numbers may be partial)"
+		}
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in %s output, got %q", want, format, stdout)
+		}
+	}
+}
+
+func TestFormatProductVisibility(t *testing.T) {
+	yes, no := true, false
+	if got := formatProductVisibility(webcore.CIUsageInfo{}); got != "" {
+		t.Fatalf("expected no note when unreported, got %q", got)
+	}
+	if got := formatProductVisibility(webcore.CIUsageInfo{CanViewAllProducts: &yes}); got != "all products" {
+		t.Fatalf("unexpected note for full visibility: %q", got)
+	}
+	if got := formatProductVisibility(webcore.CIUsageInfo{CanViewAllProducts: &no}); !strings.HasPrefix(got, "restricted") {
+		t.Fatalf("unexpected note for restricted visibility: %q", got)
+	}
+}
+
+func TestStrictSupplementaryError(t *testing.T) {
+	lookupErr := errors.New("boom")
+	if err := strictSupplementaryError(false, "plan summary", lookupErr); err != nil {
+		t.Fatalf("expected nil without --strict, got %v", err)
+	}
+	if err := strictSupplementaryError(true, "plan summary", nil); err != nil {
+		t.Fatalf("expected nil for successful lookup, got %v", err)
+	}
+	err := strictSupplementaryError(true, "plan summary", lookupErr)
+	if err == nil || !errors.Is(err, lookupErr) {
+		t.Fatalf("expected wrapped lookup error, got %v", err)
+	}
+}
+
 func TestWebXcodeCloudUsageDaysOutputBehavior(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() {
@@ -1106,7 +1529,9 @@ func TestWebXcodeCloudUsageWorkflowsListOutput(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() {
 		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
 	})
+	resetWorkflowNameCache()
 
 	resolveSessionFn = func(
 		ctx context.Context,
@@ -1224,7 +1649,9 @@ func TestWebXcodeCloudUsageWorkflowsJSONSkipsSummaryFetch(t *testing.T) {
 	origResolveSession := resolveSessionFn
 	t.Cleanup(func() {
 		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
 	})
+	resetWorkflowNameCache()
 
 	summaryCalls := 0
 	resolveSessionFn = func(
@@ -1291,11 +1718,164 @@ func TestWebXcodeCloudUsageWorkflowsJSONSkipsSummaryFetch(t *testing.T) {
 	}
 }
 
-func TestPopulateWorkflowNames(t *testing.T) {
-	workflows := []webcore.CIWorkflowUsage{
-		{WorkflowID: "wf-1", WorkflowName: ""},
-		{WorkflowID: "wf-2", WorkflowName: "Already Named"},
-		{WorkflowID: "wf-3", WorkflowName: ""},
+func stubWorkflowUsageCSVSession(t *testing.T) {
+	t.Helper()
+	t.Cleanup(resetWorkflowNameCache)
+	resetWorkflowNameCache()
+	stubEnvVarsMissingSession(t, func(req *http.Request) (int, string) {
+		switch {
+		case strings.Contains(req.URL.Path, "/usage/summary"):
+			t.Fatalf("unexpected plan summary request in csv mode")
+			return http.StatusInternalServerError, ""
+		case strings.Contains(req.URL.Path, "/workflows-v15"):
+			return http.StatusOK, `{"items":[{"id":"wf-1","content":{"name":"Build, \"Nightly\""}}]}`
+		default:
+			return http.StatusOK, `{
+				"usage":[{"date":"2026-01-15","duration":30,"number_of_builds":3}],
+				"workflow_usage":[{
+					"workflow_id":"wf-1",
+					"usage_in_minutes":20,
+					"number_of_builds":2,
+					"previous_usage_in_minutes":5,
+					"previous_number_of_builds":1,
+					"usage":[
+						{"date":"2026-01-14","duration":8,"number_of_builds":1},
+						{"date":"2026-01-15","duration":12,"number_of_builds":1}
+					]
+				}],
+				"info":{}
+			}`
+		}
+	})
+}
+
+func TestWebXcodeCloudUsageWorkflowsListCSVOutput(t *testing.T) {
+	stubWorkflowUsageCSVSession(t)
+
+	cmd := webXcodeCloudUsageWorkflowsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1", "--output", "csv"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	want := "Workflow ID,Workflow Name,Minutes,Builds,Prev Minutes,Prev Builds\n" +
+		"wf-1,\"Build, \"\"Nightly\"\"\",20,2,5,1\n"
+	if stdout != want {
+		t.Fatalf("unexpected csv output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestWebXcodeCloudUsageWorkflowsDrillInCSVOutput(t *testing.T) {
+	stubWorkflowUsageCSVSession(t)
+
+	cmd := webXcodeCloudUsageWorkflowsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--product-id", "prod-1", "--workflow-id", "wf-1", "--output", "csv"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	want := "Date,Minutes,Builds\n2026-01-14,8,1\n2026-01-15,12,1\n"
+	if stdout != want {
+		t.Fatalf("unexpected csv output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestWebXcodeCloudUsageWorkflowsNoNamesSkipsWorkflowLookup(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
+	})
+	resetWorkflowNameCache()
+
+	workflowCalls := 0
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if strings.Contains(req.URL.Path, "/workflows-v15") {
+						workflowCalls++
+					}
+					body := `{
+						"usage":[{"date":"2026-01-15","duration":30,"number_of_builds":3}],
+						"workflow_usage":[{"workflow_id":"wf-1","usage_in_minutes":20,"number_of_builds":2}],
+						"info":{}
+					}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageWorkflowsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--no-names",
+		"--output", "json",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if workflowCalls != 0 {
+		t.Fatalf("expected no workflow listing request with --no-names, got %d", workflowCalls)
+	}
+	var out CIWorkflowsResult
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("failed to parse JSON output: %v", err)
+	}
+	if len(out.Workflows) != 1 || out.Workflows[0].WorkflowID != "wf-1" || out.Workflows[0].WorkflowName != "" {
+		t.Fatalf("expected bare workflow ID, got %+v", out.Workflows)
+	}
+}
+
+func TestWebXcodeCloudUsageWorkflowsNoNamesRejectsIncludeDeleted(t *testing.T) {
+	cmd := webXcodeCloudUsageWorkflowsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--no-names",
+		"--include-deleted",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	_, stderr := captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if !errors.Is(runErr, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+	}
+	if !strings.Contains(stderr, "--no-names cannot be combined with --include-deleted") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestPopulateWorkflowNames(t *testing.T) {
+	workflows := []webcore.CIWorkflowUsage{
+		{WorkflowID: "wf-1", WorkflowName: ""},
+		{WorkflowID: "wf-2", WorkflowName: "Already Named"},
+		{WorkflowID: "wf-3", WorkflowName: ""},
 	}
 	names := map[string]string{
 		"wf-1": "TestFlight Deploy",
@@ -1316,6 +1896,77 @@ func TestPopulateWorkflowNames(t *testing.T) {
 	}
 }
 
+func TestWebXcodeCloudProductsFailIfEmpty(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var responseBody string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(responseBody)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	t.Run("empty result returns error naming the command", func(t *testing.T) {
+		responseBody = `{"items":[]}`
+		cmd := webXcodeCloudProductsCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--fail-if-empty"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			err := cmd.Exec(context.Background(), nil)
+			if err == nil {
+				t.Fatal("expected error for empty result")
+			}
+			if !strings.Contains(err.Error(), "xcode-cloud products returned no results") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(stdout, `"items"`) {
+			t.Fatalf("expected output to still be printed, got %q", stdout)
+		}
+	})
+
+	t.Run("non-empty result succeeds", func(t *testing.T) {
+		responseBody = `{"items":[{"id":"prod-1","name":"My App","bundle_id":"com.example.app","type":"APP"}]}`
+		cmd := webXcodeCloudProductsCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--fail-if-empty"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, _ = captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+
+	t.Run("empty result without flag succeeds", func(t *testing.T) {
+		responseBody = `{"items":[]}`
+		cmd := webXcodeCloudProductsCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, _ = captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	})
+}
+
 func TestWebXcodeCloudAllCommandsHaveUsageFunc(t *testing.T) {
 	cmd := WebXcodeCloudCommand()
 	if cmd.UsageFunc == nil {
@@ -1406,3 +2057,415 @@ func captureOutput(t *testing.T, fn func()) (string, string) {
 
 	return stdout, stderr
 }
+
+func TestCIUsageTableDataWritesAsCSV(t *testing.T) {
+	workflows := []webcore.CIWorkflowUsage{{WorkflowID: "wf-1", WorkflowName: "Build, Test", UsageInMinutes: 12, NumberOfBuilds: 3}}
+	products := []webcore.CIProductUsage{{ProductID: "prod-1", ProductName: "App", UsageInMinutes: 30}}
+
+	tables := []struct {
+		name    string
+		headers []string
+		rows    [][]string
+	}{
+		{name: "workflows", headers: ciWorkflowUsageHeaders(), rows: buildCIWorkflowUsageRows(workflows, 12)},
+		{name: "products", headers: ciProductUsageSummaryHeaders(true), rows: buildCIProductUsageSummaryRows(products, 100, true)},
+		{name: "env vars", headers: envVarHeaders(), rows: buildEnvVarRows([]webcore.CIEnvironmentVariable{{Name: "KEY"}}, false)},
+	}
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			for _, row := range table.rows {
+				if len(row) != len(table.headers) {
+					t.Fatalf("row has %d columns, headers have %d", len(row), len(table.headers))
+				}
+			}
+			var buf bytes.Buffer
+			if err := shared.WriteCSV(&buf, table.headers, table.rows); err != nil {
+				t.Fatalf("WriteCSV() error: %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), strings.Join(table.headers, ",")+"\n") {
+				t.Fatalf("expected CSV header line, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryTemplateOutput(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 30, Total: 1500},
+	}, nil)
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--output", "template",
+		"--template", "{{.Plan.Used}}/{{.Plan.Total}}",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	if stdout != "30/1500\n" {
+		t.Fatalf("expected rendered template, got %q", stdout)
+	}
+}
+
+func TestReconcileCIUsage(t *testing.T) {
+	overall := &webcore.CIUsageDays{
+		ProductUsage: []webcore.CIProductUsage{
+			{ProductID: "prod-1", UsageInMinutes: 60},
+			{ProductID: "prod-2", UsageInMinutes: 30},
+		},
+		Info: webcore.CIUsageInfo{Current: webcore.CIUsageInfoCurrent{Used: 100}},
+	}
+
+	got := reconcileCIUsage(overall, 5)
+	if got.TeamMinutes != 100 || got.ProductMinutes != 90 || got.DifferenceMinutes != 10 {
+		t.Fatalf("unexpected reconciliation totals: %+v", got)
+	}
+	if got.DifferencePercent != 10 || got.WithinTolerance {
+		t.Fatalf("expected 10%% difference outside 5%% tolerance, got %+v", got)
+	}
+	if !reconcileCIUsage(overall, 10).WithinTolerance {
+		t.Fatal("expected 10% difference to be within a 10% tolerance")
+	}
+	if empty := reconcileCIUsage(nil, 1); !empty.WithinTolerance || empty.DifferencePercent != 0 {
+		t.Fatalf("expected empty usage to reconcile, got %+v", empty)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysReconcile(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{"usage":[{"date":"2026-01-15","duration":5,"number_of_builds":1}],"workflow_usage":[],"info":{}}`
+					if !strings.Contains(req.URL.Path, "/products/") {
+						body = `{
+							"usage":[],
+							"product_usage":[{"product_id":"prod-1","usage_in_minutes":60},{"product_id":"prod-2","usage_in_minutes":20}],
+							"info":{"current":{"used":100}}
+						}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "prod-1",
+		"--output", "json",
+		"--reconcile",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	var payload struct {
+		Usage          []webcore.CIDayUsage   `json:"usage"`
+		Reconciliation *CIUsageReconciliation `json:"reconciliation"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if len(payload.Usage) != 1 {
+		t.Fatalf("expected product usage to keep its JSON shape, got %s", stdout)
+	}
+	if payload.Reconciliation == nil || payload.Reconciliation.DifferenceMinutes != 20 || payload.Reconciliation.WithinTolerance {
+		t.Fatalf("expected mismatched reconciliation, got %+v", payload.Reconciliation)
+	}
+	if !strings.Contains(stderr, "differs from the sum of product usage") {
+		t.Fatalf("expected reconciliation warning, got %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysNoOverallSkipsTeamLookups(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var paths []string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					body := `{
+						"usage":[{"date":"2026-01-15","duration":5,"number_of_builds":1}],
+						"workflow_usage":[{"workflow_id":"wf-1","workflow_name":"CI","usage_in_minutes":5,"number_of_builds":1}],
+						"info":{}
+					}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "prod-1,prod-2",
+		"--output", "table",
+		"--no-overall",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	if len(paths) != 1 || !strings.Contains(paths[0], "/products/prod-1/usage/days") {
+		t.Fatalf("expected only the primary product request, got %v", paths)
+	}
+	if strings.Contains(stdout, "Scope") || strings.Contains(stdout, "Overall usage unavailable") {
+		t.Fatalf("expected no scope comparison output, got %q", stdout)
+	}
+	if !strings.Contains(stdout, "2026-01-15") || !strings.Contains(stdout, "wf-1") {
+		t.Fatalf("expected daily and workflow tables, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysStopsWhenCancelledMidCommand(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var paths []string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if err := req.Context().Err(); err != nil {
+						return nil, err
+					}
+					paths = append(paths, req.URL.Path)
+					// Simulate Ctrl-C arriving after the primary product's days load.
+					cancel()
+					body := `{"usage":[{"date":"2026-01-15","duration":5,"number_of_builds":1}],"info":{}}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "prod-1",
+		"--output", "table",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	started := time.Now()
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		runErr = cmd.Exec(ctx, nil)
+	})
+	if !errors.Is(runErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", runErr)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("expected a prompt return after cancellation, took %s", elapsed)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("expected the overall, summary, and product lookups to be skipped, got %v", paths)
+	}
+	if strings.Contains(stdout, "2026-01-15") {
+		t.Fatalf("expected no output after cancellation, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageDaysNoOverallRejectsReconcile(t *testing.T) {
+	cmd := webXcodeCloudUsageDaysCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-ids", "prod-1", "--no-overall", "--reconcile"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--no-overall cannot be combined with --reconcile") {
+		t.Fatalf("expected conflict error, got %q", stderr)
+	}
+}
+
+func TestBuildWorkflowNameByIDMemoizesPerTeamAndProduct(t *testing.T) {
+	resetWorkflowNameCache()
+	t.Cleanup(resetWorkflowNameCache)
+
+	calls := map[string]int{}
+	failProducts := map[string]bool{"prod-fail": true}
+	client := newCIClientFn(&webcore.AuthSession{
+		Client: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				calls[req.URL.Path]++
+				status := http.StatusOK
+				body := `{"items":[{"id":"WF-1","content":{"name":"Release"}}]}`
+				for product := range failProducts {
+					if strings.Contains(req.URL.Path, product) {
+						status = http.StatusInternalServerError
+						body = `{"errors":[{"status":"500"}]}`
+					}
+				}
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Request:    req,
+				}, nil
+			}),
+		},
+	})
+
+	ctx := context.Background()
+	buildWorkflowNameByID(ctx, client, "team-1", "prod-2", false)
+	for range 3 {
+		names := buildWorkflowNameByID(ctx, client, "team-1", "prod-1", false)
+		if names["wf-1"] != "Release" {
+			t.Fatalf("expected cached workflow name, got %v", names)
+		}
+		names["wf-1"] = "mutated"
+	}
+	buildWorkflowNameByID(ctx, client, "TEAM-1", "PROD-2", false)
+
+	total := 0
+	for _, n := range calls {
+		total += n
+	}
+	if total != 2 {
+		t.Fatalf("expected one request per product, got %v", calls)
+	}
+
+	buildWorkflowNameByID(ctx, client, "team-1", "prod-fail", false)
+	buildWorkflowNameByID(ctx, client, "team-1", "prod-fail", false)
+	failures := 0
+	for path, n := range calls {
+		if strings.Contains(path, "prod-fail") {
+			failures += n
+		}
+	}
+	if failures < 2 {
+		t.Fatalf("expected failed lookups not to be cached, got %v", calls)
+	}
+}
+
+func TestWebXcodeCloudUsageWorkflowsIncludeDeletedNamesRemovedWorkflows(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		resetWorkflowNameCache()
+	})
+	resetWorkflowNameCache()
+
+	var includeDeletedQueries []string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					body := `{
+						"usage":[{"date":"2026-01-15","duration":30,"number_of_builds":3}],
+						"workflow_usage":[
+							{"workflow_id":"wf-1","usage_in_minutes":20,"number_of_builds":2},
+							{"workflow_id":"wf-old","usage_in_minutes":10,"number_of_builds":1}
+						],
+						"info":{}
+					}`
+					if strings.Contains(req.URL.Path, "/workflows-v15") {
+						includeDeleted := req.URL.Query().Get("include_deleted")
+						includeDeletedQueries = append(includeDeletedQueries, includeDeleted)
+						body = `{"items":[{"id":"wf-1","content":{"name":"Build"}}]}`
+						if includeDeleted == "true" {
+							body = `{"items":[{"id":"wf-1","content":{"name":"Build"}},{"id":"wf-old","content":{"name":"Legacy"}}]}`
+						}
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageWorkflowsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-id", "prod-1",
+		"--output", "json",
+		"--include-deleted",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+	var result CIWorkflowsResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v\n%s", err, stdout)
+	}
+	names := map[string]string{}
+	for _, wf := range result.Workflows {
+		names[wf.WorkflowID] = wf.WorkflowName
+	}
+	if names["wf-1"] != "Build" || names["wf-old"] != "Legacy (deleted)" {
+		t.Fatalf("unexpected workflow names: %v", names)
+	}
+	if strings.Join(includeDeletedQueries, ",") != "false,true" {
+		t.Fatalf("expected active then include-deleted listing, got %v", includeDeletedQueries)
+	}
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const defaultClockSkewThreshold = 5 * time.Minute
+
+// assertClockHelpText is the --assert-clock paragraph of the usage commands
+// that default to a date range computed from the local clock.
+const assertClockHelpText = `Use --assert-clock to check the local clock that drives the default date range against the App Store Connect
+server time; a skew beyond --clock-skew-threshold prints a warning, or fails under --strict.`
+
+type clockCheckFlags struct {
+	assert    *bool
+	threshold *time.Duration
+}
+
+func bindClockCheckFlags(fs *flag.FlagSet) clockCheckFlags {
+	return clockCheckFlags{
+		assert:    fs.Bool("assert-clock", false, "Compare the local clock with the App Store Connect server Date header and warn (or fail under --strict) on skew"),
+		threshold: fs.Duration("clock-skew-threshold", defaultClockSkewThreshold, "Allowed --assert-clock difference between local and server time"),
+	}
+}
+
+func validateClockCheckFlags(flags clockCheckFlags) error {
+	if *flags.threshold <= 0 {
+		return fmt.Errorf("--clock-skew-threshold must be positive")
+	}
+	return nil
+}
+
+// assertClock checks the local clock against the server under --assert-clock.
+// Default date ranges are computed from the local clock, so a runner with a
+// wrong clock silently queries the wrong window. The Date header of the
+// command's own usage response is the reference, so call it once that request
+// has succeeded. A skew beyond the threshold is a warning, or an error under
+// --strict.
+func assertClock(client *webcore.Client, flags clockCheckFlags, strict bool) error {
+	if !*flags.assert {
+		return nil
+	}
+	if err := checkClockSkew(webNowFn(), client.ServerDate(), *flags.threshold); err != nil {
+		if strict {
+			return fmt.Errorf("%w (--strict)", err)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+func checkClockSkew(local, server time.Time, threshold time.Duration) error {
+	if server.IsZero() {
+		return fmt.Errorf("cannot check clock skew: server response had no Date header")
+	}
+	skew := local.Sub(server)
+	if skew.Abs() <= threshold {
+		return nil
+	}
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	return fmt.Errorf(
+		"local clock is %s %s App Store Connect server time (local %s, server %s, threshold %s); default date ranges may be off",
+		skew.Abs().Round(time.Second),
+		direction,
+		local.UTC().Format(time.RFC3339),
+		server.UTC().Format(time.RFC3339),
+		threshold,
+	)
+}
@@ -0,0 +1,131 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	server := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+
+	if err := checkClockSkew(server.Add(4*time.Minute), server, 5*time.Minute); err != nil {
+		t.Fatalf("expected skew within threshold to pass, got %v", err)
+	}
+	err := checkClockSkew(server.Add(-3*24*time.Hour), server, 5*time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "local clock is 72h0m0s behind App Store Connect server time") {
+		t.Fatalf("expected behind skew error, got %v", err)
+	}
+	err = checkClockSkew(server.Add(10*time.Minute), server, 5*time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "10m0s ahead of") {
+		t.Fatalf("expected ahead skew error, got %v", err)
+	}
+	err = checkClockSkew(server, time.Time{}, 5*time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "no Date header") {
+		t.Fatalf("expected missing Date header error, got %v", err)
+	}
+}
+
+func stubClockSkewSession(t *testing.T, serverDate time.Time, localNow time.Time) *[]string {
+	t.Helper()
+	var paths []string
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+	})
+	webNowFn = func() time.Time { return localNow }
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					body := `{"usage":[{"year":2026,"month":3,"duration":30,"number_of_builds":3}],"info":{"start_month":3,"start_year":2026,"end_month":3,"end_year":2026}}`
+					if strings.HasSuffix(req.URL.Path, "/usage/summary") {
+						body = `{"plan":{"name":"Plan","used":30,"available":1470,"total":1500}}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header: http.Header{
+							"Content-Type": []string{"application/json"},
+							"Date":         []string{serverDate.Format(http.TimeFormat)},
+						},
+						Body:    io.NopCloser(strings.NewReader(body)),
+						Request: req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+	return &paths
+}
+
+func TestWebXcodeCloudUsageMonthsAssertClockWarnsOnSkew(t *testing.T) {
+	serverDate := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	paths := stubClockSkewSession(t, serverDate, serverDate.Add(-3*24*time.Hour))
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--assert-clock", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "Warning: local clock is 72h0m0s behind App Store Connect server time") {
+		t.Fatalf("expected clock skew warning, got %q", stderr)
+	}
+	if !strings.Contains(stdout, `"usage"`) {
+		t.Fatalf("expected usage output, got %q", stdout)
+	}
+	if len(*paths) != 1 || !strings.HasSuffix((*paths)[0], "/usage/months") {
+		t.Fatalf("expected the clock check to reuse the usage response, got requests %v", *paths)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsAssertClockStrictFails(t *testing.T) {
+	serverDate := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	stubClockSkewSession(t, serverDate, serverDate.Add(time.Hour))
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--assert-clock", "--strict"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var err error
+	_, _ = captureOutput(t, func() {
+		err = cmd.Exec(context.Background(), nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "1h0m0s ahead of App Store Connect server time") || !strings.Contains(err.Error(), "(--strict)") {
+		t.Fatalf("expected strict clock skew error, got %v", err)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsAssertClockPassesWithinThreshold(t *testing.T) {
+	serverDate := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	stubClockSkewSession(t, serverDate, serverDate.Add(time.Hour))
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--assert-clock", "--clock-skew-threshold", "2h", "--strict", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.Contains(stderr, "local clock") {
+		t.Fatalf("expected no clock warning, got %q", stderr)
+	}
+}
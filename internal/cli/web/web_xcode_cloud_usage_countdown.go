@@ -0,0 +1,53 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// usageResetTime returns when the plan resets: reset_date_time when present,
+// otherwise midnight UTC on reset_date.
+func usageResetTime(plan webcore.CIUsagePlan) (time.Time, bool) {
+	if value := strings.TrimSpace(plan.ResetDateTime); value != "" {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed, true
+		}
+	}
+	if value := strings.TrimSpace(plan.ResetDate); value != "" {
+		if parsed, err := time.Parse(usageCycleDateLayout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatUsageResetCountdown describes the time left until the plan resets:
+// whole days, then whole hours inside the last two days. A reset under an hour
+// away is "imminent"; one already in the past (the data lags) is "overdue".
+func formatUsageResetCountdown(plan webcore.CIUsagePlan, now time.Time) string {
+	resetAt, ok := usageResetTime(plan)
+	if !ok {
+		return "n/a"
+	}
+	remaining := resetAt.Sub(now)
+	switch {
+	case remaining < 0:
+		return "overdue"
+	case remaining < time.Hour:
+		return "imminent"
+	case remaining < 48*time.Hour:
+		return fmt.Sprintf("in %s", pluralizeUsageUnit(int(remaining/time.Hour), "hour"))
+	default:
+		return fmt.Sprintf("in %s", pluralizeUsageUnit(int(remaining/(24*time.Hour)), "day"))
+	}
+}
+
+func pluralizeUsageUnit(count int, unit string) string {
+	if count == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestFormatUsageResetCountdown(t *testing.T) {
+	now := time.Date(2026, time.February, 20, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		plan webcore.CIUsagePlan
+		want string
+	}{
+		{name: "days", plan: webcore.CIUsagePlan{ResetDateTime: "2026-03-01T12:00:00Z"}, want: "in 9 days"},
+		{name: "partial day rounds down", plan: webcore.CIUsagePlan{ResetDateTime: "2026-02-25T11:00:00Z"}, want: "in 4 days"},
+		{name: "hours when close", plan: webcore.CIUsagePlan{ResetDateTime: "2026-02-22T02:00:00Z"}, want: "in 38 hours"},
+		{name: "single hour", plan: webcore.CIUsagePlan{ResetDateTime: "2026-02-20T13:30:00Z"}, want: "in 1 hour"},
+		{name: "imminent", plan: webcore.CIUsagePlan{ResetDateTime: "2026-02-20T12:20:00Z"}, want: "imminent"},
+		{name: "overdue", plan: webcore.CIUsagePlan{ResetDateTime: "2026-02-20T09:00:00Z"}, want: "overdue"},
+		{name: "falls back to reset date", plan: webcore.CIUsagePlan{ResetDate: "2026-02-23"}, want: "in 2 days"},
+		{name: "time wins over date", plan: webcore.CIUsagePlan{ResetDate: "2026-02-23", ResetDateTime: "2026-02-21T12:00:00Z"}, want: "in 24 hours"},
+		{name: "unknown", plan: webcore.CIUsagePlan{ResetDate: "soon"}, want: "n/a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUsageResetCountdown(tt.plan, now); got != tt.want {
+				t.Fatalf("formatUsageResetCountdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
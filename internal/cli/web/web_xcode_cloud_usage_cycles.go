@@ -0,0 +1,264 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const usageCycleDateLayout = "2006-01-02"
+
+// CIUsageCyclesResult is the usage months output with --reset-anchored: daily
+// team usage bucketed into billing cycles that start on the plan reset day.
+type CIUsageCyclesResult struct {
+	ResetDay     int                      `json:"reset_day"`
+	Start        string                   `json:"start"`
+	End          string                   `json:"end"`
+	Cycles       []CIUsageCycle           `json:"cycles"`
+	ProductUsage []webcore.CIProductUsage `json:"-"`
+	// ProductUsageOutput is ProductUsage as printed in JSON output.
+	ProductUsageOutput []CIProductUsageOutput `json:"product_usage,omitempty"`
+	// CanViewAllProducts mirrors info.can_view_all_products from daily usage.
+	CanViewAllProducts *bool `json:"can_view_all_products,omitempty"`
+}
+
+// CIUsageCycle is one billing cycle. End is inclusive; the in-progress cycle is
+// marked Current and only counts usage up to today.
+type CIUsageCycle struct {
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Minutes int    `json:"minutes"`
+	Builds  int    `json:"builds"`
+	Current bool   `json:"current,omitempty"`
+}
+
+type ciUsageCyclesOptions struct {
+	startMonth, startYear int
+	endMonth, endYear     int
+	productIDs            []string
+	detailed              bool
+	failIfEmpty           bool
+	output                string
+	pretty                bool
+	clockCheck            clockCheckFlags
+	strict                bool
+}
+
+// runCIUsageCycles implements usage months --reset-anchored. The plan summary
+// is required here because the reset date defines the cycle boundaries.
+func runCIUsageCycles(ctx context.Context, client *webcore.Client, teamID string, opts ciUsageCyclesOptions) error {
+	var result *CIUsageCyclesResult
+	planTotal := 0
+	err := withWebSpinner("Loading Xcode Cloud billing cycle usage", func() error {
+		summary, err := client.GetCIUsageSummary(ctx, teamID)
+		if err != nil {
+			return fmt.Errorf("--reset-anchored requires the plan reset date: %w", err)
+		}
+		resetDay, err := parseUsageResetDay(summary.Plan)
+		if err != nil {
+			return fmt.Errorf("--reset-anchored requires the plan reset date: %w", err)
+		}
+		planTotal = summary.Plan.Total
+
+		now := webNowFn()
+		result = &CIUsageCyclesResult{
+			ResetDay: resetDay,
+			Cycles:   buildUsageCycles(opts.startMonth, opts.startYear, opts.endMonth, opts.endYear, resetDay, now),
+		}
+		if len(result.Cycles) == 0 {
+			return nil
+		}
+		result.Start = result.Cycles[0].Start
+		result.End = usageCyclesFetchEnd(result.Cycles, now)
+
+		days, err := client.GetCIUsageDaysOverall(ctx, teamID, result.Start, result.End)
+		if err != nil {
+			return err
+		}
+		bucketDailyUsageIntoCycles(result.Cycles, days.Usage)
+		result.ProductUsage = days.ProductUsage
+		result.CanViewAllProducts = days.Info.CanViewAllProducts
+		if len(opts.productIDs) > 0 {
+			result.ProductUsage = filterProductUsageByIDs(result.ProductUsage, opts.productIDs)
+		}
+		result.ProductUsageOutput = productUsageOutput(result.ProductUsage)
+		return nil
+	})
+	if err == nil {
+		err = assertClock(client, opts.clockCheck, opts.strict)
+	}
+	if err != nil {
+		return withWebAuthHint(err, "xcode-cloud usage months")
+	}
+	if err := shared.PrintOutputWithRenderers(
+		result,
+		opts.output,
+		opts.pretty,
+		func() error { return renderCIUsageCyclesTable(result, planTotal, opts.detailed) },
+		func() error { return renderCIUsageCyclesMarkdown(result, planTotal, opts.detailed) },
+	); err != nil {
+		return err
+	}
+	printProductVisibilityWarning(webcore.CIUsageInfo{CanViewAllProducts: result.CanViewAllProducts})
+	recordCount := len(result.Cycles)
+	if len(opts.productIDs) > 0 {
+		recordCount = len(result.ProductUsage)
+	}
+	return checkFailIfEmpty(opts.failIfEmpty, recordCount, "xcode-cloud usage months")
+}
+
+// parseUsageResetDay returns the day of month the plan quota resets on, read
+// from reset_date and falling back to reset_date_time.
+func parseUsageResetDay(plan webcore.CIUsagePlan) (int, error) {
+	if value := strings.TrimSpace(plan.ResetDate); value != "" {
+		if parsed, err := time.Parse(usageCycleDateLayout, value); err == nil {
+			return parsed.Day(), nil
+		}
+	}
+	if value := strings.TrimSpace(plan.ResetDateTime); value != "" {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed.Day(), nil
+		}
+	}
+	if strings.TrimSpace(plan.ResetDate) == "" && strings.TrimSpace(plan.ResetDateTime) == "" {
+		return 0, fmt.Errorf("plan summary has no reset date")
+	}
+	return 0, fmt.Errorf("plan summary has an unrecognized reset date %q", valueOrNA(plan.ResetDate))
+}
+
+// usageCycleStart returns the first day of the cycle that starts in the given
+// month. Reset days past the end of a short month clamp to its last day.
+func usageCycleStart(year int, month time.Month, resetDay int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if resetDay > lastDay {
+		resetDay = lastDay
+	}
+	return time.Date(year, month, resetDay, 0, 0, 0, 0, time.UTC)
+}
+
+// buildUsageCycles returns the cycles that start within the month range, in
+// order. Cycles that have not started by now are omitted.
+func buildUsageCycles(startMonth, startYear, endMonth, endYear, resetDay int, now time.Time) []CIUsageCycle {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	cycles := make([]CIUsageCycle, 0)
+	for index := startYear*12 + startMonth - 1; index <= endYear*12+endMonth-1; index++ {
+		year, month := index/12, time.Month(index%12+1)
+		start := usageCycleStart(year, month, resetDay)
+		if start.After(today) {
+			break
+		}
+		end := usageCycleStart(year, month+1, resetDay).AddDate(0, 0, -1)
+		cycles = append(cycles, CIUsageCycle{
+			Start:   start.Format(usageCycleDateLayout),
+			End:     end.Format(usageCycleDateLayout),
+			Current: !today.After(end),
+		})
+	}
+	return cycles
+}
+
+// usageCyclesFetchEnd is the last day to request daily usage for: the final
+// cycle's end, or today while that cycle is still in progress.
+func usageCyclesFetchEnd(cycles []CIUsageCycle, now time.Time) string {
+	if len(cycles) == 0 {
+		return ""
+	}
+	last := cycles[len(cycles)-1]
+	if last.Current {
+		return now.Format(usageCycleDateLayout)
+	}
+	return last.End
+}
+
+// bucketDailyUsageIntoCycles adds each day's minutes and builds to the cycle
+// containing it. Days outside every cycle or with unparseable dates are skipped.
+func bucketDailyUsageIntoCycles(cycles []CIUsageCycle, usage []webcore.CIDayUsage) {
+	for _, day := range usage {
+		date := strings.TrimSpace(day.Date)
+		if _, err := time.Parse(usageCycleDateLayout, date); err != nil {
+			continue
+		}
+		for i := range cycles {
+			// ISO dates compare correctly as strings.
+			if date >= cycles[i].Start && date <= cycles[i].End {
+				cycles[i].Minutes += day.Duration
+				cycles[i].Builds += day.NumberOfBuilds
+				break
+			}
+		}
+	}
+}
+
+func renderCIUsageCyclesTable(result *CIUsageCyclesResult, planTotal int, detailed bool) error {
+	if result == nil {
+		result = &CIUsageCyclesResult{}
+	}
+	fmt.Printf("Range: %s (cycles reset on day %d)\n", formatCIUsageCyclesRange(result), result.ResetDay)
+	if visibility := formatProductVisibility(webcore.CIUsageInfo{CanViewAllProducts: result.CanViewAllProducts}); visibility != "" {
+		fmt.Printf("Product visibility: %s\n", visibility)
+	}
+	fmt.Println()
+	asc.RenderTable(ciUsageCycleHeaders(), buildCIUsageCycleRows(result.Cycles, planTotal))
+
+	if len(result.ProductUsage) > 0 {
+		fmt.Println()
+		asc.RenderTable(
+			ciProductUsageSummaryHeaders(detailed),
+			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal, detailed),
+		)
+	}
+	return nil
+}
+
+func renderCIUsageCyclesMarkdown(result *CIUsageCyclesResult, planTotal int, detailed bool) error {
+	if result == nil {
+		result = &CIUsageCyclesResult{}
+	}
+	fmt.Printf("**Range:** %s (cycles reset on day %d)\n\n", formatCIUsageCyclesRange(result), result.ResetDay)
+	if visibility := formatProductVisibility(webcore.CIUsageInfo{CanViewAllProducts: result.CanViewAllProducts}); visibility != "" {
+		fmt.Printf("**Product visibility:** %s\n\n", visibility)
+	}
+	asc.RenderMarkdown(ciUsageCycleHeaders(), buildCIUsageCycleRows(result.Cycles, planTotal))
+
+	if len(result.ProductUsage) > 0 {
+		fmt.Println()
+		asc.RenderMarkdown(
+			ciProductUsageSummaryHeaders(detailed),
+			buildCIProductUsageSummaryRows(result.ProductUsage, planTotal, detailed),
+		)
+	}
+	return nil
+}
+
+func ciUsageCycleHeaders() []string {
+	return usageUnitHeaders([]string{"Cycle", "Minutes", "Builds", "Usage Bar (Plan)"})
+}
+
+func buildCIUsageCycleRows(cycles []CIUsageCycle, planTotal int) [][]string {
+	rows := make([][]string, 0, len(cycles))
+	for _, cycle := range cycles {
+		label := fmt.Sprintf("%s to %s", cycle.Start, cycle.End)
+		if cycle.Current {
+			label += " (current)"
+		}
+		rows = append(rows, []string{
+			label,
+			formatUsageMinutes(cycle.Minutes),
+			formatUsageCount(cycle.Builds),
+			formatUsageBarWithValues(cycle.Minutes, planTotal),
+		})
+	}
+	return rows
+}
+
+func formatCIUsageCyclesRange(result *CIUsageCyclesResult) string {
+	if result.Start == "" || result.End == "" {
+		return "n/a"
+	}
+	return fmt.Sprintf("%s to %s", result.Start, result.End)
+}
@@ -0,0 +1,175 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestBuildUsageCyclesAnchorsToResetDay(t *testing.T) {
+	now := time.Date(2026, time.March, 20, 9, 0, 0, 0, time.UTC)
+	cycles := buildUsageCycles(1, 2026, 4, 2026, 31, now)
+
+	want := []CIUsageCycle{
+		{Start: "2026-01-31", End: "2026-02-27"},
+		{Start: "2026-02-28", End: "2026-03-30", Current: true},
+	}
+	if len(cycles) != len(want) {
+		t.Fatalf("expected %d cycles, got %+v", len(want), cycles)
+	}
+	for i := range want {
+		if cycles[i] != want[i] {
+			t.Fatalf("cycle %d = %+v, want %+v", i, cycles[i], want[i])
+		}
+	}
+	if got := usageCyclesFetchEnd(cycles, now); got != "2026-03-20" {
+		t.Fatalf("expected fetch to end today, got %q", got)
+	}
+}
+
+func TestBucketDailyUsageIntoCycles(t *testing.T) {
+	cycles := []CIUsageCycle{
+		{Start: "2026-01-15", End: "2026-02-14"},
+		{Start: "2026-02-15", End: "2026-03-14"},
+	}
+	bucketDailyUsageIntoCycles(cycles, []webcore.CIDayUsage{
+		{Date: "2026-01-14", Duration: 99, NumberOfBuilds: 9},
+		{Date: "2026-01-15", Duration: 10, NumberOfBuilds: 1},
+		{Date: "2026-02-14", Duration: 20, NumberOfBuilds: 2},
+		{Date: "2026-02-15", Duration: 30, NumberOfBuilds: 3},
+		{Date: "not-a-date", Duration: 50, NumberOfBuilds: 5},
+	})
+	if cycles[0].Minutes != 30 || cycles[0].Builds != 3 {
+		t.Fatalf("unexpected first cycle totals: %+v", cycles[0])
+	}
+	if cycles[1].Minutes != 30 || cycles[1].Builds != 3 {
+		t.Fatalf("unexpected second cycle totals: %+v", cycles[1])
+	}
+}
+
+func TestParseUsageResetDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    webcore.CIUsagePlan
+		want    int
+		wantErr string
+	}{
+		{name: "reset date", plan: webcore.CIUsagePlan{ResetDate: "2026-03-15"}, want: 15},
+		{name: "reset date time fallback", plan: webcore.CIUsagePlan{ResetDateTime: "2026-03-09T00:00:00Z"}, want: 9},
+		{name: "missing", plan: webcore.CIUsagePlan{}, wantErr: "no reset date"},
+		{name: "unrecognized", plan: webcore.CIUsagePlan{ResetDate: "soon"}, wantErr: "unrecognized reset date"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseUsageResetDay(test.plan)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Fatalf("parseUsageResetDay() = %d, %v; want %d", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsResetAnchored(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origNow
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC) }
+
+	var daysQuery string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					switch {
+					case strings.Contains(req.URL.Path, "/usage/summary"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageSummary{
+							Plan: webcore.CIUsagePlan{Total: 1000, ResetDate: "2026-04-15"},
+						}), nil
+					case strings.HasSuffix(req.URL.Path, "/usage/days"):
+						daysQuery = req.URL.RawQuery
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageDays{
+							Usage: []webcore.CIDayUsage{
+								{Date: "2026-02-14", Duration: 40, NumberOfBuilds: 4},
+								{Date: "2026-02-15", Duration: 60, NumberOfBuilds: 6},
+								{Date: "2026-03-16", Duration: 25, NumberOfBuilds: 2},
+							},
+							ProductUsage: []webcore.CIProductUsage{
+								{ProductID: "prod-1", ProductName: "App One", UsageInMinutes: 125, NumberOfBuilds: 12},
+							},
+						}), nil
+					default:
+						return usageAlertJSONResponse(t, http.StatusNotFound, map[string]any{"error": "not found"}), nil
+					}
+				}),
+			},
+		}, "", nil
+	}
+
+	args := []string{
+		"--apple-id", "user@example.com",
+		"--start-month", "1", "--start-year", "2026",
+		"--end-month", "3", "--end-year", "2026",
+		"--reset-anchored",
+	}
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse(append(args, "--output", "json")); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"start=2026-01-15", "end=2026-03-20"} {
+		if !strings.Contains(daysQuery, want) {
+			t.Fatalf("expected %q in days query, got %q", want, daysQuery)
+		}
+	}
+	var result CIUsageCyclesResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if result.ResetDay != 15 || len(result.Cycles) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Cycles[0].Minutes != 40 || result.Cycles[1].Minutes != 60 || result.Cycles[2].Minutes != 25 {
+		t.Fatalf("unexpected cycle minutes: %+v", result.Cycles)
+	}
+	if !result.Cycles[2].Current {
+		t.Fatalf("expected last cycle to be current: %+v", result.Cycles[2])
+	}
+
+	cmd = webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse(append(args, "--output", "table")); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ = captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"cycles reset on day 15", "2026-03-15 to 2026-04-14 (current)", "App One", "60/1000"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// webXcodeCloudUsageDayCommand is a thin wrapper around usage days that pins
+// --start and --end to a single --date. It shares the days command's flag
+// values, so every other days flag behaves identically.
+func webXcodeCloudUsageDayCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage day", flag.ExitOnError)
+	date := fs.String("date", webNowFn().Format("2006-01-02"), "Date to show (YYYY-MM-DD, default today)")
+
+	days := webXcodeCloudUsageDaysCommand()
+	days.FlagSet.VisitAll(func(f *flag.Flag) {
+		if f.Name == "start" || f.Name == "end" {
+			return
+		}
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+
+	return &ffcli.Command{
+		Name:       "day",
+		ShortUsage: "asc web xcode-cloud usage day --date YYYY-MM-DD --product-ids IDS [flags]",
+		ShortHelp:  "EXPERIMENTAL: Show Xcode Cloud usage for a single day.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Show Xcode Cloud compute usage for one day with per-product and per-workflow breakdown.
+This is "usage days" with --start and --end both set to --date (default today);
+all other flags and the output are the same.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage day --date 2026-01-15 --product-ids "UUID" --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage day --date 2026-01-15 --product-ids "UUID,OTHER_ID" --apple-id "user@example.com"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			day := strings.TrimSpace(*date)
+			if err := validateDateFlag("--date", day); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if err := days.FlagSet.Set("start", day); err != nil {
+				return err
+			}
+			if err := days.FlagSet.Set("end", day); err != nil {
+				return err
+			}
+			return days.Exec(ctx, args)
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestWebXcodeCloudUsageDayPinsStartAndEnd(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	var queries []string
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					queries = append(queries, req.URL.RawQuery)
+					body := `{
+						"usage":[{"date":"2026-01-15","duration":5,"number_of_builds":1}],
+						"workflow_usage":[{"workflow_id":"wf-1","workflow_name":"CI","usage_in_minutes":5,"number_of_builds":1}],
+						"info":{}
+					}`
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			},
+		}, "cache", nil
+	}
+
+	cmd := webXcodeCloudUsageDayCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--product-ids", "prod-1",
+		"--date", "2026-01-15",
+		"--no-overall",
+		"--output", "table",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+	})
+
+	if len(queries) == 0 {
+		t.Fatal("expected a usage request")
+	}
+	for _, query := range queries {
+		if !strings.Contains(query, "start=2026-01-15") || !strings.Contains(query, "end=2026-01-15") {
+			t.Fatalf("expected start and end pinned to --date, got %q", query)
+		}
+	}
+	if !strings.Contains(stdout, "2026-01-15") || !strings.Contains(stdout, "CI") {
+		t.Fatalf("expected the days tables for the single day, got %q", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageDayRejectsInvalidDate(t *testing.T) {
+	cmd := webXcodeCloudUsageDayCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-ids", "prod-1", "--date", "01/15/2026"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--date must be YYYY-MM-DD") {
+		t.Fatalf("expected date validation error, got %q", stderr)
+	}
+}
+
+func TestWebXcodeCloudUsageDayOmitsRangeFlags(t *testing.T) {
+	cmd := webXcodeCloudUsageDayCommand()
+	for _, name := range []string{"start", "end"} {
+		if cmd.FlagSet.Lookup(name) != nil {
+			t.Fatalf("expected --%s to be hidden behind --date", name)
+		}
+	}
+	for _, name := range []string{"product-ids", "no-overall", "output", "apple-id"} {
+		if cmd.FlagSet.Lookup(name) == nil {
+			t.Fatalf("expected --%s to be forwarded from usage days", name)
+		}
+	}
+}
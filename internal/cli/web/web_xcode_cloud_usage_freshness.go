@@ -0,0 +1,43 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func bindMaxDataAgeFlag(fs *flag.FlagSet) *time.Duration {
+	return fs.Duration("max-data-age", 0, "Fail when the plan reset time is more than this long in the past, a sign of stale usage data (e.g. 6h; 0 to disable)")
+}
+
+func validateMaxDataAge(maxAge time.Duration) error {
+	if maxAge < 0 {
+		return fmt.Errorf("--max-data-age must not be negative")
+	}
+	return nil
+}
+
+// checkUsageDataAge reports stale usage data. The plan summary carries no
+// fetch timestamp, so freshness is judged from the reset time: once it has
+// passed, the API should have rolled over to a new cycle, and the data is
+// treated as being as old as the time since then.
+func checkUsageDataAge(plan webcore.CIUsagePlan, now time.Time, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+	resetAt, ok := usageResetTime(plan)
+	if !ok {
+		return fmt.Errorf("cannot check --max-data-age: plan summary has no reset date")
+	}
+	if age := now.Sub(resetAt); age > maxAge {
+		return fmt.Errorf(
+			"usage data looks stale: plan reset was due at %s, %s ago (--max-data-age %s)",
+			resetAt.UTC().Format(time.RFC3339),
+			age.Round(time.Minute),
+			maxAge,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestCheckUsageDataAge(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		plan    webcore.CIUsagePlan
+		maxAge  time.Duration
+		wantErr string
+	}{
+		{name: "disabled", plan: webcore.CIUsagePlan{}, maxAge: 0},
+		{name: "reset ahead", plan: webcore.CIUsagePlan{ResetDateTime: "2026-03-16T09:00:00Z"}, maxAge: time.Hour},
+		{name: "reset just passed", plan: webcore.CIUsagePlan{ResetDateTime: "2026-03-01T08:30:00Z"}, maxAge: time.Hour},
+		{
+			name:    "reset long past",
+			plan:    webcore.CIUsagePlan{ResetDateTime: "2026-03-01T00:00:00Z"},
+			maxAge:  6 * time.Hour,
+			wantErr: "plan reset was due at 2026-03-01T00:00:00Z, 9h0m0s ago (--max-data-age 6h0m0s)",
+		},
+		{
+			name:    "reset date only",
+			plan:    webcore.CIUsagePlan{ResetDate: "2026-02-27"},
+			maxAge:  24 * time.Hour,
+			wantErr: "usage data looks stale",
+		},
+		{name: "no reset date", plan: webcore.CIUsagePlan{}, maxAge: time.Hour, wantErr: "plan summary has no reset date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUsageDataAge(tt.plan, now, tt.maxAge)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageMaxDataAgeFailsOnStaleSummary(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	origWebNow := webNowFn
+	origSendSlack := sendUsageAlertSlackFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origWebNow
+		sendUsageAlertSlackFn = origSendSlack
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.March, 2, 12, 0, 0, 0, time.UTC) }
+	summary := &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 980, Total: 1000, ResetDateTime: "2026-03-01T00:00:00Z"}}
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, summary, nil)
+	slackCalls := 0
+	sendUsageAlertSlackFn = func(ctx context.Context, webhookURL string, result *CIUsageAlertResult, plain bool) (int, error) {
+		slackCalls++
+		return http.StatusOK, nil
+	}
+
+	tests := []struct {
+		name    string
+		command func() *ffcli.Command
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "summary",
+			command: webXcodeCloudUsageSummaryCommand,
+			args:    []string{"--max-data-age", "6h"},
+			wantErr: "xcode-cloud usage summary failed: usage data looks stale",
+		},
+		{
+			name:    "alert",
+			command: webXcodeCloudUsageAlertCommand,
+			args:    []string{"--max-data-age", "6h", "--trend-months", "0", "--slack-webhook", "https://hooks.slack.com/services/T/B/KEY"},
+			wantErr: "xcode-cloud usage alert failed: usage data looks stale",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.command()
+			if err := cmd.FlagSet.Parse(append([]string{"--apple-id", "user@example.com", "--output", "json"}, tt.args...)); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			var runErr error
+			stdout, _ := captureOutput(t, func() {
+				runErr = cmd.Exec(context.Background(), nil)
+			})
+			if runErr == nil || !strings.Contains(runErr.Error(), tt.wantErr) {
+				t.Fatalf("expected stale data error, got %v", runErr)
+			}
+			if stdout != "" {
+				t.Fatalf("expected no output for stale data, got %q", stdout)
+			}
+		})
+	}
+	if slackCalls != 0 {
+		t.Fatalf("expected no notifications for stale data, got %d", slackCalls)
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryMaxDataAgeValidation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"--max-data-age", "-1h"}, wantErr: "--max-data-age must not be negative"},
+		{args: []string{"--max-data-age", "1h", "--watch"}, wantErr: "--max-data-age cannot be used with --watch"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd := webXcodeCloudUsageSummaryCommand()
+			if err := cmd.FlagSet.Parse(append([]string{"--apple-id", "user@example.com"}, tt.args...)); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			var runErr error
+			_, stderr := captureOutput(t, func() {
+				runErr = cmd.Exec(context.Background(), nil)
+			})
+			if !errors.Is(runErr, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", runErr)
+			}
+			if !strings.Contains(stderr, tt.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", tt.wantErr, stderr)
+			}
+		})
+	}
+}
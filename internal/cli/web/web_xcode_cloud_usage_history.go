@@ -0,0 +1,98 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// sparklineLevels are the block characters used by renderSparkline, lowest first.
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// CIUsageHistory is the recent monthly usage shown by usage summary --history.
+type CIUsageHistory struct {
+	Months    []CIUsageAlertMonth `json:"months"`
+	Sparkline string              `json:"sparkline"`
+}
+
+// loadCIUsageHistory fetches the last months of usage, ending with the
+// current month. Months the API omits are reported as zero.
+func loadCIUsageHistory(ctx context.Context, client *webcore.Client, teamID string, months int) (*CIUsageHistory, error) {
+	startMonth, startYear, endMonth, endYear := usageAlertMonthWindow(webNowFn(), months)
+	response, err := client.GetCIUsageMonths(ctx, teamID, startMonth, startYear, endMonth, endYear)
+	if err != nil {
+		return nil, err
+	}
+	return buildCIUsageHistory(response.Usage, startMonth, startYear, months), nil
+}
+
+func buildCIUsageHistory(usage []webcore.CIMonthUsage, startMonth, startYear, months int) *CIUsageHistory {
+	byMonth := make(map[int]webcore.CIMonthUsage, len(usage))
+	for _, month := range usage {
+		byMonth[month.Year*12+month.Month-1] = month
+	}
+
+	history := &CIUsageHistory{Months: make([]CIUsageAlertMonth, 0, months)}
+	minutes := make([]int, 0, months)
+	first := startYear*12 + startMonth - 1
+	for index := first; index < first+months; index++ {
+		month := byMonth[index]
+		history.Months = append(history.Months, CIUsageAlertMonth{
+			Year:    index / 12,
+			Month:   index%12 + 1,
+			Minutes: month.Duration,
+			Builds:  month.NumberOfBuilds,
+		})
+		minutes = append(minutes, month.Duration)
+	}
+	history.Sparkline = renderSparkline(minutes)
+	return history
+}
+
+// renderSparkline draws one block per value, scaled so the largest value is a
+// full block. Zero and negative values, and all-zero input, use the lowest block.
+func renderSparkline(values []int) string {
+	peak := 0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+	var builder strings.Builder
+	top := len(sparklineLevels) - 1
+	for _, value := range values {
+		level := 0
+		if peak > 0 && value > 0 {
+			level = int(math.Round(float64(value) / float64(peak) * float64(top)))
+		}
+		builder.WriteRune(sparklineLevels[level])
+	}
+	return builder.String()
+}
+
+// formatUsageHistoryLine is the summary line for --history, e.g.
+// "History: ▁▂▄█▅▃ (Sep 2025 to Feb 2026, peak 980m)".
+func formatUsageHistoryLine(history *CIUsageHistory) string {
+	if len(history.Months) == 0 {
+		return "History: n/a"
+	}
+	peak := 0
+	for _, month := range history.Months {
+		peak = max(peak, month.Minutes)
+	}
+	first := history.Months[0]
+	last := history.Months[len(history.Months)-1]
+	return fmt.Sprintf(
+		"History: %s (%s to %s, peak %sm)",
+		history.Sparkline,
+		formatUsageHistoryMonth(first),
+		formatUsageHistoryMonth(last),
+		formatUsageCount(peak),
+	)
+}
+
+func formatUsageHistoryMonth(month CIUsageAlertMonth) string {
+	return time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC).Format("Jan 2006")
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{values: []int{0, 10, 30, 70, 40, 20}, want: "▁▂▄█▅▃"},
+		{values: []int{0, 0, 0}, want: "▁▁▁"},
+		{values: []int{5}, want: "█"},
+		{values: []int{-3, 8}, want: "▁█"},
+		{values: nil, want: ""},
+	}
+	for _, test := range tests {
+		if got := renderSparkline(test.values); got != test.want {
+			t.Errorf("renderSparkline(%v) = %q, want %q", test.values, got, test.want)
+		}
+	}
+}
+
+func TestBuildCIUsageHistoryFillsMissingMonths(t *testing.T) {
+	usage := []webcore.CIMonthUsage{
+		{Year: 2026, Month: 2, Duration: 300, NumberOfBuilds: 9},
+		{Year: 2025, Month: 11, Duration: 100, NumberOfBuilds: 3},
+	}
+	history := buildCIUsageHistory(usage, 11, 2025, 4)
+	if len(history.Months) != 4 {
+		t.Fatalf("expected 4 months, got %+v", history.Months)
+	}
+	if history.Months[1].Year != 2025 || history.Months[1].Month != 12 || history.Months[1].Minutes != 0 {
+		t.Fatalf("expected December 2025 to be filled with zero, got %+v", history.Months[1])
+	}
+	if history.Months[3].Year != 2026 || history.Months[3].Month != 2 || history.Months[3].Builds != 9 {
+		t.Fatalf("expected February 2026 last, got %+v", history.Months[3])
+	}
+	if history.Sparkline != "▃▁▁█" {
+		t.Fatalf("unexpected sparkline %q", history.Sparkline)
+	}
+	if got := formatUsageHistoryLine(history); got != "History: ▃▁▁█ (Nov 2025 to Feb 2026, peak 300m)" {
+		t.Fatalf("unexpected history line %q", got)
+	}
+}
@@ -0,0 +1,184 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	usageMetricsFormatOpenMetrics = "openmetrics"
+	usageMetricsFormatPrometheus  = "prometheus"
+)
+
+// usageMetric is one gauge family in the usage metrics exposition.
+type usageMetric struct {
+	name    string
+	help    string
+	samples []usageMetricSample
+}
+
+type usageMetricSample struct {
+	labels [][2]string
+	value  string
+}
+
+func webXcodeCloudUsageMetricsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage metrics", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+
+	format := fs.String("format", usageMetricsFormatOpenMetrics, "Exposition format: openmetrics or prometheus")
+	perProduct := fs.Bool("per-product", false, "Add per-product gauges for the current billing period")
+	shared.BindOutFileFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "metrics",
+		ShortUsage: "asc web xcode-cloud usage metrics [flags]",
+		ShortHelp:  "EXPERIMENTAL: Print Xcode Cloud usage as OpenMetrics gauges.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Print the Xcode Cloud plan usage as gauges with HELP and TYPE lines, ready to
+push to a Prometheus Pushgateway. Gauges cover used, available, and total
+minutes and the used percentage; every sample carries a team label.
+With --per-product, minutes and builds for each product in the current billing
+period are added with product and product_id labels.
+
+--format openmetrics (default) ends the output with "# EOF"; --format prometheus
+prints the classic text format without it.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage metrics --apple-id "user@example.com"
+  asc web xcode-cloud usage metrics --per-product --apple-id "user@example.com"
+  asc web xcode-cloud usage metrics --format prometheus --apple-id "user@example.com" | curl --data-binary @- "$PUSHGATEWAY/metrics/job/xcode_cloud"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			metricsFormat := strings.ToLower(strings.TrimSpace(*format))
+			if metricsFormat != usageMetricsFormatOpenMetrics && metricsFormat != usageMetricsFormatPrometheus {
+				fmt.Fprintln(os.Stderr, "Error: --format must be openmetrics or prometheus")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud usage metrics failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var summary *webcore.CIUsageSummary
+			var products []CIUsageSummaryProduct
+			err = withWebSpinner("Loading Xcode Cloud usage metrics", func() error {
+				var err error
+				summary, err = client.GetCIUsageSummary(requestCtx, teamID)
+				if err != nil {
+					return err
+				}
+				if !*perProduct {
+					return nil
+				}
+				result, err := loadCIUsageSummaryProducts(requestCtx, client, teamID, summary)
+				if err != nil {
+					return err
+				}
+				products = result.Products
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud usage metrics")
+			}
+
+			metrics := buildCIUsageMetrics(summary, teamID, products, *perProduct)
+			return shared.WithOutputDestination(func() error {
+				_, err := fmt.Print(formatUsageMetrics(metrics, metricsFormat))
+				return err
+			})
+		},
+	}
+}
+
+// buildCIUsageMetrics converts the plan summary and optional per-product usage
+// into gauge families. The percent gauge is left out when the plan total is
+// unknown, matching the "n/a" shown by the table output.
+func buildCIUsageMetrics(summary *webcore.CIUsageSummary, teamID string, products []CIUsageSummaryProduct, perProduct bool) []usageMetric {
+	team := [][2]string{{"team", teamID}}
+	gauge := func(name, help string, value int) usageMetric {
+		return usageMetric{name: name, help: help, samples: []usageMetricSample{{labels: team, value: strconv.Itoa(value)}}}
+	}
+	metrics := []usageMetric{
+		gauge("asc_xcode_cloud_usage_used_minutes", "Xcode Cloud compute minutes used in the current plan period.", summary.Plan.Used),
+		gauge("asc_xcode_cloud_usage_available_minutes", "Xcode Cloud compute minutes still available in the current plan period.", summary.Plan.Available),
+		gauge("asc_xcode_cloud_usage_total_minutes", "Xcode Cloud compute minutes included in the plan.", summary.Plan.Total),
+	}
+	if summary.Plan.Total > 0 {
+		percent := float64(summary.Plan.Used) / float64(summary.Plan.Total) * 100
+		metrics = append(metrics, usageMetric{
+			name:    "asc_xcode_cloud_usage_used_percent",
+			help:    "Percentage of the plan's Xcode Cloud compute minutes used.",
+			samples: []usageMetricSample{{labels: team, value: strconv.FormatFloat(percent, 'f', -1, 64)}},
+		})
+	}
+	if !perProduct {
+		return metrics
+	}
+
+	minutes := usageMetric{name: "asc_xcode_cloud_product_usage_minutes", help: "Xcode Cloud compute minutes used per product in the current billing period."}
+	builds := usageMetric{name: "asc_xcode_cloud_product_builds", help: "Xcode Cloud builds per product in the current billing period."}
+	for _, product := range products {
+		name := strings.TrimSpace(product.ProductName)
+		if name == "" {
+			name = product.ProductID
+		}
+		labels := [][2]string{{"team", teamID}, {"product", name}, {"product_id", product.ProductID}}
+		minutes.samples = append(minutes.samples, usageMetricSample{labels: labels, value: strconv.Itoa(product.Minutes)})
+		builds.samples = append(builds.samples, usageMetricSample{labels: labels, value: strconv.Itoa(product.Builds)})
+	}
+	return append(metrics, minutes, builds)
+}
+
+func formatUsageMetrics(metrics []usageMetric, format string) string {
+	var b strings.Builder
+	for _, metric := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.name, escapeUsageMetricHelp(metric.help))
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", metric.name)
+		for _, sample := range metric.samples {
+			b.WriteString(metric.name)
+			if len(sample.labels) > 0 {
+				pairs := make([]string, 0, len(sample.labels))
+				for _, label := range sample.labels {
+					pairs = append(pairs, label[0]+`="`+escapeUsageMetricLabel(label[1])+`"`)
+				}
+				b.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			b.WriteString(" " + sample.value + "\n")
+		}
+	}
+	if format == usageMetricsFormatOpenMetrics {
+		b.WriteString("# EOF\n")
+	}
+	return b.String()
+}
+
+func escapeUsageMetricHelp(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(value)
+}
+
+func escapeUsageMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
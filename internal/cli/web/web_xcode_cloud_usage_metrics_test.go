@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestWebXcodeCloudUsageMetricsPrintsOpenMetrics(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 250, Available: 750, Total: 1000},
+	}, nil)
+
+	cmd := webXcodeCloudUsageMetricsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{
+		"# HELP asc_xcode_cloud_usage_used_minutes ",
+		"# TYPE asc_xcode_cloud_usage_used_minutes gauge\n",
+		`asc_xcode_cloud_usage_used_minutes{team="TEAM-123"} 250` + "\n",
+		`asc_xcode_cloud_usage_available_minutes{team="TEAM-123"} 750` + "\n",
+		`asc_xcode_cloud_usage_total_minutes{team="TEAM-123"} 1000` + "\n",
+		`asc_xcode_cloud_usage_used_percent{team="TEAM-123"} 25` + "\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if !strings.HasSuffix(stdout, "# EOF\n") {
+		t.Fatalf("expected trailing # EOF, got:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "asc_xcode_cloud_product_") {
+		t.Fatalf("expected no per-product gauges without --per-product, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageMetricsWritesOutFile(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, &webcore.CIUsageSummary{
+		Plan: webcore.CIUsagePlan{Used: 250, Available: 750, Total: 1000},
+	}, nil)
+
+	path := filepath.Join(t.TempDir(), "usage.prom")
+	cmd := webXcodeCloudUsageMetricsCommand()
+	t.Cleanup(func() { bindOutFileForTest(t, "") })
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--out-file", path}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if stdout != "" {
+		t.Fatalf("expected nothing on stdout, got %q", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read out file: %v", err)
+	}
+	if !strings.HasSuffix(string(data), "# EOF\n") {
+		t.Fatalf("expected metrics in out file, got:\n%s", data)
+	}
+}
+
+func TestBuildCIUsageMetricsPerProductLabels(t *testing.T) {
+	summary := &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 10}}
+	products := []CIUsageSummaryProduct{
+		{ProductID: "prod-1", ProductName: `My "App"`, Minutes: 8, Builds: 2},
+		{ProductID: "prod-2", Minutes: 2, Builds: 1},
+	}
+
+	got := formatUsageMetrics(buildCIUsageMetrics(summary, "TEAM", products, true), usageMetricsFormatPrometheus)
+
+	for _, want := range []string{
+		`asc_xcode_cloud_product_usage_minutes{team="TEAM",product="My \"App\"",product_id="prod-1"} 8`,
+		`asc_xcode_cloud_product_usage_minutes{team="TEAM",product="prod-2",product_id="prod-2"} 2`,
+		`asc_xcode_cloud_product_builds{team="TEAM",product="My \"App\"",product_id="prod-1"} 2`,
+		"# TYPE asc_xcode_cloud_product_builds gauge",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "asc_xcode_cloud_usage_used_percent") {
+		t.Fatalf("expected no percent gauge without a plan total, got:\n%s", got)
+	}
+	if strings.Contains(got, "# EOF") {
+		t.Fatalf("expected no # EOF in prometheus format, got:\n%s", got)
+	}
+}
+
+func TestEscapeUsageMetricLabel(t *testing.T) {
+	if got := escapeUsageMetricLabel("a\\b\"c\nd"); got != `a\\b\"c\nd` {
+		t.Fatalf("unexpected escaped label %q", got)
+	}
+}
+
+func TestWebXcodeCloudUsageMetricsRejectsUnknownFormat(t *testing.T) {
+	cmd := webXcodeCloudUsageMetricsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--format", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--format must be openmetrics or prometheus") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIProductMonthsResult is the usage months output with --only-product: one
+// product's month-by-month usage instead of the team aggregate.
+type CIProductMonthsResult struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	BundleID    string `json:"bundle_id,omitempty"`
+	Minutes     int    `json:"minutes"`
+	Builds      int    `json:"builds"`
+	// Seconds is only set with --unit seconds.
+	Seconds            int                    `json:"seconds,omitempty"`
+	SecondsApproximate bool                   `json:"seconds_approximate,omitempty"`
+	Usage              []webcore.CIMonthUsage `json:"usage"`
+	emptyResultNote
+}
+
+// EnvelopeCount reports the number of monthly rows.
+func (r *CIProductMonthsResult) EnvelopeCount() int { return len(r.Usage) }
+
+// buildCIProductMonthsResult extracts productID's monthly series from a team
+// months response. A product with no usage in the range yields an empty
+// result. The API only sometimes nests per-product monthly usage, so a product
+// with usage but no monthly series is reported as an error rather than an
+// empty trend.
+func buildCIProductMonthsResult(months *webcore.CIUsageMonths, productID string) (*CIProductMonthsResult, error) {
+	wanted := strings.ToLower(strings.TrimSpace(productID))
+	if months != nil {
+		for _, product := range months.ProductUsage {
+			if strings.ToLower(strings.TrimSpace(product.ProductID)) != wanted {
+				continue
+			}
+			if len(product.Usage) == 0 {
+				return nil, fmt.Errorf("per-product monthly usage is not available for product %q; use --product-ids for its range total instead", productID)
+			}
+			minutes, builds := normalizeProductUsage(product)
+			result := &CIProductMonthsResult{
+				ProductID:   product.ProductID,
+				ProductName: product.ProductName,
+				BundleID:    product.BundleID,
+				Minutes:     minutes,
+				Builds:      builds,
+				Usage:       product.Usage,
+			}
+			if usageNumberFormat.seconds {
+				result.Seconds, result.SecondsApproximate = productUsageSeconds(product)
+			}
+			return result, nil
+		}
+	}
+	return &CIProductMonthsResult{
+		ProductID:       strings.TrimSpace(productID),
+		Usage:           []webcore.CIMonthUsage{},
+		emptyResultNote: newEmptyResultNote(0, emptyMonthlyUsageMessage),
+	}, nil
+}
+
+func renderCIProductMonthsTable(result *CIProductMonthsResult, showDelta bool) error {
+	fmt.Printf("Product: %s (%s)\n", valueOrNA(result.ProductName), result.ProductID)
+	fmt.Printf("Total: %s (%s builds)\n\n", formatUsageTotal(result.Minutes, result.Seconds), formatUsageCount(result.Builds))
+	if len(result.Usage) == 0 {
+		fmt.Println(emptyMonthlyUsageMessage)
+		return nil
+	}
+	asc.RenderTable(
+		ciMonthUsageHeaders(showDelta),
+		buildCIMonthUsageRows(result.Usage, maxMonthUsageMinutes(result.Usage), showDelta),
+	)
+	return nil
+}
+
+func renderCIProductMonthsMarkdown(result *CIProductMonthsResult, showDelta bool) error {
+	fmt.Printf("**Product:** %s (%s)\n\n", valueOrNA(result.ProductName), result.ProductID)
+	fmt.Printf("**Total:** %s (%s builds)\n\n", formatUsageTotal(result.Minutes, result.Seconds), formatUsageCount(result.Builds))
+	if len(result.Usage) == 0 {
+		fmt.Println(emptyMonthlyUsageMessage)
+		return nil
+	}
+	asc.RenderMarkdown(
+		ciMonthUsageHeaders(showDelta),
+		buildCIMonthUsageRows(result.Usage, maxMonthUsageMinutes(result.Usage), showDelta),
+	)
+	return nil
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func testCIProductMonthlyUsage() *webcore.CIUsageMonths {
+	return &webcore.CIUsageMonths{
+		Usage: []webcore.CIMonthUsage{
+			{Month: 1, Year: 2026, Duration: 300, NumberOfBuilds: 9},
+			{Month: 2, Year: 2026, Duration: 500, NumberOfBuilds: 12},
+		},
+		ProductUsage: []webcore.CIProductUsage{
+			{
+				ProductID:      "prod-a",
+				ProductName:    "Alpha",
+				UsageInMinutes: 120,
+				NumberOfBuilds: 5,
+				Usage: []webcore.CIMonthUsage{
+					{Month: 1, Year: 2026, Duration: 45, NumberOfBuilds: 2},
+					{Month: 2, Year: 2026, Duration: 75, NumberOfBuilds: 3},
+				},
+			},
+			{ProductID: "prod-b", ProductName: "Beta", UsageInMinutes: 680, NumberOfBuilds: 16},
+		},
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsOnlyProduct(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t, nil, testCIProductMonthlyUsage())
+
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--only-product", "PROD-A", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result CIProductMonthsResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if result.ProductID != "prod-a" || result.Minutes != 120 || len(result.Usage) != 2 || result.Usage[1].Duration != 75 {
+		t.Fatalf("unexpected product months result: %+v", result)
+	}
+
+	cmd = webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--only-product", "prod-a", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ = captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"Product: Alpha (prod-a)", "Total: 120 minutes (5 builds)", "75"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "500") {
+		t.Fatalf("expected team aggregate rows to be omitted, got:\n%s", stdout)
+	}
+}
+
+func TestBuildCIProductMonthsResultMissingSeries(t *testing.T) {
+	months := testCIProductMonthlyUsage()
+	if _, err := buildCIProductMonthsResult(months, "prod-b"); err == nil || !strings.Contains(err.Error(), "per-product monthly usage is not available") {
+		t.Fatalf("expected missing series error, got %v", err)
+	}
+}
+
+func TestBuildCIProductMonthsResultWithoutUsageIsEmpty(t *testing.T) {
+	result, err := buildCIProductMonthsResult(testCIProductMonthlyUsage(), "prod-z")
+	if err != nil {
+		t.Fatalf("expected an empty result, got error %v", err)
+	}
+	if result.ProductID != "prod-z" || result.Minutes != 0 || result.Usage == nil || len(result.Usage) != 0 {
+		t.Fatalf("expected zero usage for prod-z, got %+v", result)
+	}
+	if result.Status != emptyResultStatus {
+		t.Fatalf("expected empty status, got %q", result.Status)
+	}
+}
+
+func TestRenderCIProductMonthsTableUsesUnit(t *testing.T) {
+	previous := usageNumberFormat
+	t.Cleanup(func() { usageNumberFormat = previous })
+	usageNumberFormat = usageNumberFormatOptions{seconds: true}
+
+	result := &CIProductMonthsResult{ProductID: "prod-a", Minutes: 2, Seconds: 100, Builds: 1}
+	stdout, _ := captureOutput(t, func() {
+		if err := renderCIProductMonthsTable(result, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "Total: 100 seconds (1 builds)") {
+		t.Fatalf("expected total in seconds, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsOnlyProductConflicts(t *testing.T) {
+	for _, extra := range [][]string{{"--product-ids", "prod-b"}, {"--reset-anchored"}} {
+		cmd := webXcodeCloudUsageMonthsCommand()
+		args := append([]string{"--only-product", "prod-a"}, extra...)
+		if err := cmd.FlagSet.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp for %v, got %v", extra, err)
+			}
+		})
+		if !strings.Contains(stderr, "--only-product cannot be combined") {
+			t.Fatalf("expected conflict error, got %q", stderr)
+		}
+	}
+}
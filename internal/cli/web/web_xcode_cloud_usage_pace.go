@@ -0,0 +1,132 @@
+package web
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	usagePaceAhead  = "ahead"
+	usagePaceBehind = "behind"
+	usagePaceOnPace = "on_pace"
+)
+
+// CIUsageSummaryDetailResult is the usage summary output with --pace or
+// --history: the plan summary plus the requested trend context.
+type CIUsageSummaryDetailResult struct {
+	*webcore.CIUsageSummary
+	Pace    *CIUsagePace    `json:"pace,omitempty"`
+	History *CIUsageHistory `json:"history,omitempty"`
+}
+
+// CIUsagePace compares minutes used so far in the billing cycle with the
+// minutes a linear burn of the plan total would have used by now.
+type CIUsagePace struct {
+	CycleStart      string  `json:"cycle_start"`
+	CycleEnd        string  `json:"cycle_end"`
+	DaysElapsed     float64 `json:"days_elapsed"`
+	DaysInCycle     int     `json:"days_in_cycle"`
+	ExpectedUsed    int     `json:"expected_used"`
+	ExpectedPercent int     `json:"expected_percent"`
+	Used            int     `json:"used"`
+	DifferenceUsed  int     `json:"difference_used"`
+	Status          string  `json:"status"`
+}
+
+// buildCIUsagePace places now within the billing cycle derived from the plan
+// reset date. Expected usage is the plan total scaled by the fraction of the
+// cycle elapsed; usage above it is "ahead" of pace.
+func buildCIUsagePace(plan webcore.CIUsagePlan, now time.Time) (*CIUsagePace, error) {
+	if plan.Total <= 0 {
+		return nil, fmt.Errorf("plan total unavailable")
+	}
+	resetDay, err := parseUsageResetDay(plan)
+	if err != nil {
+		return nil, err
+	}
+	now = now.UTC()
+	start := currentUsageCycleStart(resetDay, now)
+	end := usageCycleStart(start.Year(), start.Month()+1, resetDay)
+	cycleDays := end.Sub(start).Hours() / 24
+	elapsed := min(max(now.Sub(start).Hours()/24, 0), cycleDays)
+
+	expected := int(math.Round(float64(plan.Total) * elapsed / cycleDays))
+	pace := &CIUsagePace{
+		CycleStart:      start.Format(usageCycleDateLayout),
+		CycleEnd:        end.Format(usageCycleDateLayout),
+		DaysElapsed:     math.Round(elapsed*10) / 10,
+		DaysInCycle:     int(math.Round(cycleDays)),
+		ExpectedUsed:    expected,
+		ExpectedPercent: webcore.CIUsagePercent(expected, plan.Total),
+		Used:            plan.Used,
+		DifferenceUsed:  plan.Used - expected,
+		Status:          usagePaceOnPace,
+	}
+	switch {
+	case pace.DifferenceUsed > 0:
+		pace.Status = usagePaceAhead
+	case pace.DifferenceUsed < 0:
+		pace.Status = usagePaceBehind
+	}
+	return pace, nil
+}
+
+func formatUsagePaceStatus(pace *CIUsagePace) string {
+	switch pace.Status {
+	case usagePaceAhead:
+		return fmt.Sprintf("ahead by %s", formatUsageMinutes(pace.DifferenceUsed))
+	case usagePaceBehind:
+		return fmt.Sprintf("behind by %s", formatUsageMinutes(-pace.DifferenceUsed))
+	default:
+		return "on pace"
+	}
+}
+
+func formatUsagePaceHeading(pace *CIUsagePace) string {
+	return fmt.Sprintf("Pace: day %.1f of %d (%s to %s)", pace.DaysElapsed, pace.DaysInCycle, pace.CycleStart, pace.CycleEnd)
+}
+
+func ciUsagePaceHeaders() []string {
+	return []string{"Pace Bar", "Expected", "Used", "Status"}
+}
+
+func buildCIUsagePaceRows(pace *CIUsagePace, total int) [][]string {
+	return [][]string{{
+		formatUsageBarWithValues(pace.ExpectedUsed, total),
+		formatUsageMinutes(pace.ExpectedUsed),
+		formatUsageMinutes(pace.Used),
+		formatUsagePaceStatus(pace),
+	}}
+}
+
+func renderCIUsageSummaryDetailTable(result *CIUsageSummaryDetailResult) error {
+	if err := renderCIUsageSummaryTable(result.CIUsageSummary); err != nil {
+		return err
+	}
+	if result.Pace != nil {
+		fmt.Printf("\n%s\n\n", formatUsagePaceHeading(result.Pace))
+		asc.RenderTable(ciUsagePaceHeaders(), buildCIUsagePaceRows(result.Pace, result.Plan.Total))
+	}
+	if result.History != nil {
+		fmt.Printf("\n%s\n", formatUsageHistoryLine(result.History))
+	}
+	return nil
+}
+
+func renderCIUsageSummaryDetailMarkdown(result *CIUsageSummaryDetailResult) error {
+	if err := renderCIUsageSummaryMarkdown(result.CIUsageSummary); err != nil {
+		return err
+	}
+	if result.Pace != nil {
+		fmt.Printf("\n**%s**\n\n", formatUsagePaceHeading(result.Pace))
+		asc.RenderMarkdown(ciUsagePaceHeaders(), buildCIUsagePaceRows(result.Pace, result.Plan.Total))
+	}
+	if result.History != nil {
+		fmt.Printf("\n**%s**\n", formatUsageHistoryLine(result.History))
+	}
+	return nil
+}
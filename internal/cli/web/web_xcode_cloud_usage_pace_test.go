@@ -0,0 +1,50 @@
+package web
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func TestBuildCIUsagePace(t *testing.T) {
+	now := time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC)
+	plan := webcore.CIUsagePlan{ResetDate: "2026-03-01", Used: 700, Total: 1000}
+
+	pace, err := buildCIUsagePace(plan, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pace.CycleStart != "2026-02-01" || pace.CycleEnd != "2026-03-01" || pace.DaysInCycle != 28 {
+		t.Fatalf("unexpected cycle: %+v", pace)
+	}
+	if pace.DaysElapsed != 14 || pace.ExpectedUsed != 500 || pace.ExpectedPercent != 50 {
+		t.Fatalf("expected half the plan by mid-cycle, got %+v", pace)
+	}
+	if pace.Status != usagePaceAhead || pace.DifferenceUsed != 200 {
+		t.Fatalf("expected ahead by 200, got %+v", pace)
+	}
+	if got := formatUsagePaceStatus(pace); got != "ahead by 200" {
+		t.Fatalf("formatUsagePaceStatus() = %q", got)
+	}
+
+	plan.Used = 100
+	pace, err = buildCIUsagePace(plan, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pace.Status != usagePaceBehind || formatUsagePaceStatus(pace) != "behind by 400" {
+		t.Fatalf("expected behind by 400, got %+v", pace)
+	}
+}
+
+func TestBuildCIUsagePaceRequiresResetDateAndTotal(t *testing.T) {
+	now := time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC)
+	if _, err := buildCIUsagePace(webcore.CIUsagePlan{Used: 10, Total: 100}, now); err == nil || !strings.Contains(err.Error(), "no reset date") {
+		t.Fatalf("expected missing reset date error, got %v", err)
+	}
+	if _, err := buildCIUsagePace(webcore.CIUsagePlan{ResetDate: "2026-03-01"}, now); err == nil || !strings.Contains(err.Error(), "plan total unavailable") {
+		t.Fatalf("expected plan total error, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package web
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const productGroupByBundle = "bundle"
+
+// CIProductUsageGroup aggregates usage for products that share a bundle ID,
+// or a bundle ID prefix with --bundle-prefix-depth.
+type CIProductUsageGroup struct {
+	Rank            int      `json:"rank"`
+	GroupKey        string   `json:"group_key"`
+	ProductIDs      []string `json:"product_ids"`
+	ProductNames    []string `json:"product_names,omitempty"`
+	Minutes         int      `json:"minutes"`
+	Builds          int      `json:"builds"`
+	PreviousMinutes int      `json:"previous_minutes"`
+	PreviousBuilds  int      `json:"previous_builds"`
+	// Seconds is only set with --unit seconds.
+	Seconds            int  `json:"seconds,omitempty"`
+	SecondsApproximate bool `json:"seconds_approximate,omitempty"`
+}
+
+type productGroupingFlags struct {
+	groupBy     *string
+	prefixDepth *int
+}
+
+func bindProductGroupingFlags(fs *flag.FlagSet) productGroupingFlags {
+	return productGroupingFlags{
+		groupBy:     fs.String("group-products-by", "", "Aggregate product usage into one line per group: bundle"),
+		prefixDepth: fs.Int("bundle-prefix-depth", 0, "With --group-products-by bundle, group on the first N dot-separated bundle ID components (0 = full bundle ID)"),
+	}
+}
+
+func validateProductGroupingFlags(flags productGroupingFlags) error {
+	groupBy := strings.ToLower(strings.TrimSpace(*flags.groupBy))
+	if groupBy != "" && groupBy != productGroupByBundle {
+		return fmt.Errorf("--group-products-by must be: bundle")
+	}
+	if *flags.prefixDepth < 0 {
+		return fmt.Errorf("--bundle-prefix-depth must be 0 or greater")
+	}
+	if *flags.prefixDepth > 0 && groupBy == "" {
+		return fmt.Errorf("--bundle-prefix-depth requires --group-products-by bundle")
+	}
+	return nil
+}
+
+func (f productGroupingFlags) enabled() bool {
+	return strings.EqualFold(strings.TrimSpace(*f.groupBy), productGroupByBundle)
+}
+
+// groupKey returns the grouping key for a bundle ID.
+func (f productGroupingFlags) groupKey(bundleID string) string {
+	return bundleGroupKey(bundleID, *f.prefixDepth)
+}
+
+// bundleGroupKey truncates bundleID to its first depth dot-separated
+// components; depth 0 keeps the full bundle ID.
+func bundleGroupKey(bundleID string, depth int) string {
+	bundleID = strings.TrimSpace(bundleID)
+	if depth <= 0 {
+		return bundleID
+	}
+	parts := strings.Split(bundleID, ".")
+	if len(parts) <= depth {
+		return bundleID
+	}
+	return strings.Join(parts[:depth], ".")
+}
+
+func buildProductBundleByID(products *webcore.CIProductListResponse) map[string]string {
+	bundles := map[string]string{}
+	if products == nil {
+		return bundles
+	}
+	for _, product := range products.Items {
+		canonical := strings.ToLower(strings.TrimSpace(product.ID))
+		bundleID := strings.TrimSpace(product.BundleID)
+		if canonical == "" || bundleID == "" {
+			continue
+		}
+		bundles[canonical] = bundleID
+	}
+	return bundles
+}
+
+// buildCIProductUsageGroups rolls product usage up by bundle group and ranks
+// the groups by sortKey (minutes, builds, or name for the group key). Bundle
+// IDs missing from the usage data are looked up in bundles; products without
+// any bundle ID keep their own line, keyed by product ID.
+func buildCIProductUsageGroups(
+	productUsage []webcore.CIProductUsage,
+	names, bundles map[string]string,
+	flags productGroupingFlags,
+	sortKey string,
+) []CIProductUsageGroup {
+	groups := make([]CIProductUsageGroup, 0)
+	indexByKey := map[string]int{}
+	for _, product := range productUsage {
+		canonical := strings.ToLower(strings.TrimSpace(product.ProductID))
+		bundleID := strings.TrimSpace(product.BundleID)
+		if bundleID == "" {
+			bundleID = bundles[canonical]
+		}
+		key := flags.groupKey(bundleID)
+		if key == "" {
+			key = product.ProductID
+		}
+
+		idx, ok := indexByKey[key]
+		if !ok {
+			idx = len(groups)
+			indexByKey[key] = idx
+			groups = append(groups, CIProductUsageGroup{GroupKey: key, ProductIDs: []string{}})
+		}
+		group := &groups[idx]
+		minutes, builds := normalizeProductUsage(product)
+		group.ProductIDs = append(group.ProductIDs, product.ProductID)
+		name := strings.TrimSpace(product.ProductName)
+		if name == "" {
+			name = strings.TrimSpace(names[canonical])
+		}
+		if name != "" {
+			group.ProductNames = append(group.ProductNames, name)
+		}
+		group.Minutes += minutes
+		group.Builds += builds
+		group.PreviousMinutes += product.PreviousUsageInMinutes
+		group.PreviousBuilds += product.PreviousNumberOfBuilds
+		if usageNumberFormat.seconds {
+			seconds, approximate := productUsageSeconds(product)
+			group.Seconds += seconds
+			group.SecondsApproximate = group.SecondsApproximate || approximate
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		switch sortKey {
+		case "builds":
+			if a.Builds != b.Builds {
+				return a.Builds > b.Builds
+			}
+		case "minutes":
+			if a.Minutes != b.Minutes {
+				return a.Minutes > b.Minutes
+			}
+		}
+		return strings.ToLower(a.GroupKey) < strings.ToLower(b.GroupKey)
+	})
+	for i := range groups {
+		groups[i].Rank = i + 1
+	}
+	return groups
+}
+
+func renderCIProductUsageGroupsTable(groups []CIProductUsageGroup, planTotal int) {
+	asc.RenderTable(ciProductUsageGroupHeaders(), buildCIProductUsageGroupRows(groups, planTotal))
+}
+
+func renderCIProductUsageGroupsMarkdown(groups []CIProductUsageGroup, planTotal int) {
+	asc.RenderMarkdown(ciProductUsageGroupHeaders(), buildCIProductUsageGroupRows(groups, planTotal))
+}
+
+func ciProductUsageGroupHeaders() []string {
+	return usageUnitHeaders([]string{"Rank", "Bundle Group", "Products", "Minutes", "Builds", "Prev Minutes", "Prev Builds", "Usage Bar (Plan)"})
+}
+
+func buildCIProductUsageGroupRows(groups []CIProductUsageGroup, planTotal int) [][]string {
+	rows := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		products := group.ProductNames
+		if len(products) < len(group.ProductIDs) {
+			products = group.ProductIDs
+		}
+		bar := formatUsageBarWithValues(group.Minutes, planTotal)
+		if usageNumberFormat.seconds && group.Seconds > 0 {
+			bar = formatUsageBarWithSeconds(group.Seconds, planTotal)
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", group.Rank),
+			valueOrNA(group.GroupKey),
+			valueOrNA(strings.Join(products, ", ")),
+			formatUsageDuration(group.Minutes, group.Seconds),
+			formatUsageCount(group.Builds),
+			formatUsageMinutes(group.PreviousMinutes),
+			formatUsageCount(group.PreviousBuilds),
+			bar,
+		})
+	}
+	return rows
+}
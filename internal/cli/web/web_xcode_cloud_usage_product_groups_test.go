@@ -0,0 +1,221 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func testProductGroupingFlags(groupBy string, depth int) productGroupingFlags {
+	return productGroupingFlags{groupBy: &groupBy, prefixDepth: &depth}
+}
+
+func testCIUsageBundleProducts() []webcore.CIProductUsage {
+	return []webcore.CIProductUsage{
+		{ProductID: "prod-app", ProductName: "App", BundleID: "com.example.app", UsageInMinutes: 100, NumberOfBuilds: 10, PreviousUsageInMinutes: 80},
+		{ProductID: "prod-widget", ProductName: "Widget", BundleID: "com.example.app.widget", UsageInMinutes: 50, NumberOfBuilds: 5, PreviousUsageInMinutes: 20},
+		{ProductID: "prod-tool", UsageInMinutes: 120, NumberOfBuilds: 2},
+		{ProductID: "prod-orphan", UsageInMinutes: 10, NumberOfBuilds: 1},
+	}
+}
+
+func TestBundleGroupKey(t *testing.T) {
+	tests := []struct {
+		bundleID string
+		depth    int
+		want     string
+	}{
+		{bundleID: "com.example.app.widget", depth: 0, want: "com.example.app.widget"},
+		{bundleID: "com.example.app.widget", depth: 3, want: "com.example.app"},
+		{bundleID: "com.example.app", depth: 3, want: "com.example.app"},
+		{bundleID: "com.example", depth: 3, want: "com.example"},
+		{bundleID: " ", depth: 2, want: ""},
+	}
+	for _, test := range tests {
+		if got := bundleGroupKey(test.bundleID, test.depth); got != test.want {
+			t.Fatalf("bundleGroupKey(%q, %d) = %q, want %q", test.bundleID, test.depth, got, test.want)
+		}
+	}
+}
+
+func TestValidateProductGroupingFlags(t *testing.T) {
+	tests := []struct {
+		groupBy string
+		depth   int
+		wantErr string
+	}{
+		{groupBy: "", depth: 0},
+		{groupBy: "Bundle", depth: 3},
+		{groupBy: "team", wantErr: "--group-products-by must be: bundle"},
+		{groupBy: "bundle", depth: -1, wantErr: "--bundle-prefix-depth must be 0 or greater"},
+		{groupBy: "", depth: 2, wantErr: "--bundle-prefix-depth requires --group-products-by bundle"},
+	}
+	for _, test := range tests {
+		err := validateProductGroupingFlags(testProductGroupingFlags(test.groupBy, test.depth))
+		if test.wantErr == "" {
+			if err != nil {
+				t.Fatalf("expected %q/%d to be valid, got %v", test.groupBy, test.depth, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Fatalf("expected %q error, got %v", test.wantErr, err)
+		}
+	}
+}
+
+func TestBuildCIProductUsageGroupsRollsUpByBundlePrefix(t *testing.T) {
+	bundles := map[string]string{"prod-tool": "com.example.tool"}
+	names := map[string]string{"prod-tool": "Tool"}
+	groups := buildCIProductUsageGroups(testCIUsageBundleProducts(), names, bundles, testProductGroupingFlags("bundle", 3), "minutes")
+
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %+v", groups)
+	}
+	app := groups[0]
+	if app.Rank != 1 || app.GroupKey != "com.example.app" || app.Minutes != 150 || app.Builds != 15 || app.PreviousMinutes != 100 {
+		t.Fatalf("unexpected app group: %+v", app)
+	}
+	if strings.Join(app.ProductIDs, ",") != "prod-app,prod-widget" || strings.Join(app.ProductNames, ",") != "App,Widget" {
+		t.Fatalf("expected app and widget in the app group, got %+v", app)
+	}
+	if groups[1].GroupKey != "com.example.tool" || strings.Join(groups[1].ProductNames, ",") != "Tool" {
+		t.Fatalf("expected bundle ID and name from the product list, got %+v", groups[1])
+	}
+	if groups[2].GroupKey != "prod-orphan" || groups[2].Rank != 3 {
+		t.Fatalf("expected product without bundle ID keyed by product ID, got %+v", groups[2])
+	}
+}
+
+func TestBuildCIProductUsageGroupsFullBundleIDKeepsExtensionsApart(t *testing.T) {
+	groups := buildCIProductUsageGroups(testCIUsageBundleProducts()[:2], nil, nil, testProductGroupingFlags("bundle", 0), "name")
+	if len(groups) != 2 || groups[0].GroupKey != "com.example.app" || groups[1].GroupKey != "com.example.app.widget" {
+		t.Fatalf("expected one group per full bundle ID sorted by key, got %+v", groups)
+	}
+}
+
+func TestWebXcodeCloudUsageProductsGroupsByBundle(t *testing.T) {
+	stubUsageProductsSession(t, &webcore.CIUsageMonths{ProductUsage: testCIUsageBundleProducts()[:2]}, nil)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--group-products-by", "bundle",
+		"--bundle-prefix-depth", "3",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result CIProductUsageResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if result.GroupProductsBy != "bundle" || len(result.ProductGroups) != 1 || len(result.Products) != 2 {
+		t.Fatalf("expected one bundle group alongside both products, got %+v", result)
+	}
+	if result.ProductGroups[0].GroupKey != "com.example.app" || result.ProductGroups[0].Minutes != 150 {
+		t.Fatalf("unexpected group: %+v", result.ProductGroups[0])
+	}
+}
+
+func TestWebXcodeCloudUsageGroupingUsesSameJSONKeys(t *testing.T) {
+	stubUsageProductsSession(t, &webcore.CIUsageMonths{ProductUsage: testCIUsageBundleProducts()[:2]}, nil)
+
+	for name, cmd := range map[string]*ffcli.Command{
+		"months":   webXcodeCloudUsageMonthsCommand(),
+		"products": webXcodeCloudUsageProductsCommand(),
+	} {
+		if err := cmd.FlagSet.Parse([]string{
+			"--apple-id", "user@example.com",
+			"--group-products-by", "bundle",
+			"--output", "json",
+		}); err != nil {
+			t.Fatalf("%s: parse error: %v", name, err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+		})
+		var payload map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+			t.Fatalf("%s: failed to parse JSON output: %v\n%s", name, err, stdout)
+		}
+		if string(payload["group_products_by"]) != `"bundle"` || payload["product_groups"] == nil {
+			t.Fatalf("%s: expected group_products_by and product_groups, got %s", name, stdout)
+		}
+		if _, ok := payload["groups"]; ok {
+			t.Fatalf("%s: expected no groups key, got %s", name, stdout)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageProductsGroupedTable(t *testing.T) {
+	stubUsageProductsSession(t, &webcore.CIUsageMonths{ProductUsage: testCIUsageBundleProducts()[:2]}, nil)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{
+		"--apple-id", "user@example.com",
+		"--group-products-by", "bundle",
+		"--bundle-prefix-depth", "3",
+		"--output", "tsv",
+	}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := "Rank\tBundle Group\tProducts\tMinutes\tBuilds\tPrev Minutes\tPrev Builds\n" +
+		"1\tcom.example.app\tApp, Widget\t150\t15\t100\t0"
+	if strings.TrimSpace(stdout) != want {
+		t.Fatalf("unexpected tsv output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestWebXcodeCloudUsageMonthsRejectsGroupingWithOnlyProduct(t *testing.T) {
+	cmd := webXcodeCloudUsageMonthsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--group-products-by", "bundle", "--only-product", "prod-1"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--group-products-by cannot be combined") {
+		t.Fatalf("expected combination error, got %q", stderr)
+	}
+}
+
+func TestCIUsageMonthsTableShowsProductGroups(t *testing.T) {
+	data := &CIUsageMonthsResult{
+		CIUsageMonths:   &webcore.CIUsageMonths{ProductUsage: testCIUsageBundleProducts()[:2]},
+		GroupProductsBy: productGroupByBundle,
+		ProductGroups:   buildCIProductUsageGroups(testCIUsageBundleProducts()[:2], nil, nil, testProductGroupingFlags("bundle", 3), "minutes"),
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := renderCIUsageMonthsTable(data, 1000, false, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "Bundle Group") || !strings.Contains(stdout, "com.example.app") {
+		t.Fatalf("expected grouped product table, got:\n%s", stdout)
+	}
+	if strings.Contains(stdout, "prod-widget") {
+		t.Fatalf("expected per-product rows to be replaced by groups, got:\n%s", stdout)
+	}
+}
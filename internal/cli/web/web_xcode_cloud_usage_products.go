@@ -0,0 +1,316 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIProductUsageResult is the output type for the usage products command.
+type CIProductUsageResult struct {
+	StartMonth int                  `json:"start_month"`
+	StartYear  int                  `json:"start_year"`
+	EndMonth   int                  `json:"end_month"`
+	EndYear    int                  `json:"end_year"`
+	Sort       string               `json:"sort"`
+	Products   []CIProductUsageItem `json:"products"`
+	// GroupProductsBy and ProductGroups are set with --group-products-by.
+	GroupProductsBy string                `json:"group_products_by,omitempty"`
+	ProductGroups   []CIProductUsageGroup `json:"product_groups,omitempty"`
+	emptyResultNote
+}
+
+// CIProductUsageItem is one ranked product in the usage products output.
+type CIProductUsageItem struct {
+	Rank            int    `json:"rank"`
+	ProductID       string `json:"product_id"`
+	ProductName     string `json:"product_name,omitempty"`
+	BundleID        string `json:"bundle_id,omitempty"`
+	Minutes         int    `json:"minutes"`
+	Builds          int    `json:"builds"`
+	PreviousMinutes int    `json:"previous_minutes"`
+	PreviousBuilds  int    `json:"previous_builds"`
+	// Seconds is only set with --unit seconds.
+	Seconds            int  `json:"seconds,omitempty"`
+	SecondsApproximate bool `json:"seconds_approximate,omitempty"`
+}
+
+func webXcodeCloudUsageProductsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage products", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+	shared.AllowTSVOutput(fs)
+
+	months := fs.Int("months", 3, "Number of months to include, ending with the current month (1-24)")
+	sortBy := fs.String("sort", "minutes", "Sort products by: minutes, builds, name")
+	grouping := bindProductGroupingFlags(fs)
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+	clockCheck := bindClockCheckFlags(fs)
+	numberFormat := bindUsageNumberFormatFlags(fs)
+	raw := bindRawFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "products",
+		ShortUsage: "asc web xcode-cloud usage products [flags]",
+		ShortHelp:  "EXPERIMENTAL: Rank Xcode Cloud usage by product.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Show Xcode Cloud compute usage per product over the last N months, ranked by minutes.
+Product names are resolved from the products list when the usage data omits them.
+Table and markdown output include a usage bar relative to the plan quota for the
+whole range, i.e. the monthly plan total times --months.
+Use --group-products-by bundle to roll products that share a bundle ID into one ranked line,
+e.g. an app and its extensions; --bundle-prefix-depth 3 groups com.example.app.widget
+with com.example.app. JSON output adds "product_groups" with each group_key and its product IDs.
+Use --raw to print the unparsed usage and product list responses instead of the ranking.
+` + assertClockHelpText + `
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage products --apple-id "user@example.com"
+  asc web xcode-cloud usage products --months 3 --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage products --months 6 --sort builds --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage products --group-products-by bundle --bundle-prefix-depth 3 --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage products --months 3 --raw --apple-id "user@example.com"`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			restoreNumberFormat, err := numberFormat.apply()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			defer restoreNumberFormat()
+
+			if *months < 1 || *months > 24 {
+				fmt.Fprintln(os.Stderr, "Error: --months must be between 1 and 24")
+				return flag.ErrHelp
+			}
+			sortKey := strings.ToLower(strings.TrimSpace(*sortBy))
+			switch sortKey {
+			case "minutes", "builds", "name":
+			default:
+				fmt.Fprintln(os.Stderr, "Error: --sort must be one of: minutes, builds, name")
+				return flag.ErrHelp
+			}
+			if err := validateProductGroupingFlags(grouping); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			if err := validateClockCheckFlags(clockCheck); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud usage products failed: session has no public provider ID")
+			}
+
+			startMonth, startYear, endMonth, endYear := usageAlertMonthWindow(webNowFn(), *months)
+			client := newCIClientFn(session)
+			rawOut := newRawOutput(*raw)
+			requestCtx = rawOut.context(requestCtx)
+			var usage *webcore.CIUsageMonths
+			productNames := map[string]string{}
+			productBundles := map[string]string{}
+			planTotal := 0
+			err = withWebSpinner("Loading Xcode Cloud product usage", func() error {
+				var err error
+				usage, err = client.GetCIUsageMonths(requestCtx, teamID, startMonth, startYear, endMonth, endYear)
+				if err != nil {
+					return err
+				}
+				products, err := client.ListCIProducts(requestCtx, teamID)
+				if strictErr := strictSupplementaryError(*strict, "product names", err); strictErr != nil {
+					return strictErr
+				}
+				if err == nil {
+					productNames = buildProductNameByID(products)
+					productBundles = buildProductBundleByID(products)
+				}
+				switch shared.NormalizeOutputFormat(*output.Output) {
+				case "table", "markdown":
+					summary, err := client.GetCIUsageSummary(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "plan summary", err); strictErr != nil {
+						return strictErr
+					}
+					if err == nil && summary != nil {
+						// Usage is summed over the range, so scale the
+						// monthly quota to match.
+						planTotal = summary.Plan.Total * *months
+					}
+				}
+				return nil
+			})
+			if err == nil {
+				err = assertClock(client, clockCheck, *strict)
+			}
+			if rawOut.enabled() {
+				return rawOut.print(withWebAuthHint(err, "xcode-cloud usage products"))
+			}
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud usage products")
+			}
+
+			result := &CIProductUsageResult{
+				StartMonth: startMonth,
+				StartYear:  startYear,
+				EndMonth:   endMonth,
+				EndYear:    endYear,
+				Sort:       sortKey,
+				Products:   buildCIProductUsageItems(usage.ProductUsage, productNames, sortKey),
+			}
+			if grouping.enabled() {
+				result.GroupProductsBy = productGroupByBundle
+				result.ProductGroups = buildCIProductUsageGroups(usage.ProductUsage, productNames, productBundles, grouping, sortKey)
+			}
+			result.emptyResultNote = newEmptyResultNote(len(result.Products), emptyProductUsageMessage)
+			if err := shared.PrintOutputWithTabular(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIProductUsageTable(result, planTotal) },
+				func() error { return renderCIProductUsageMarkdown(result, planTotal) },
+				func() ([]string, [][]string) {
+					if result.GroupProductsBy != "" {
+						return tabularColumns(ciProductUsageGroupHeaders(), buildCIProductUsageGroupRows(result.ProductGroups, planTotal))
+					}
+					return tabularColumns(ciProductUsageHeaders(), buildCIProductUsageRows(result.Products, planTotal))
+				},
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(result.Products), "xcode-cloud usage products")
+		},
+	}
+}
+
+// buildCIProductUsageItems normalizes product usage, fills in missing names,
+// and ranks the products by sortKey. Ties fall back to name, then product ID.
+func buildCIProductUsageItems(productUsage []webcore.CIProductUsage, names map[string]string, sortKey string) []CIProductUsageItem {
+	items := make([]CIProductUsageItem, 0, len(productUsage))
+	for _, product := range productUsage {
+		minutes, builds := normalizeProductUsage(product)
+		name := strings.TrimSpace(product.ProductName)
+		if name == "" {
+			name = strings.TrimSpace(names[strings.ToLower(strings.TrimSpace(product.ProductID))])
+		}
+		item := CIProductUsageItem{
+			ProductID:       product.ProductID,
+			ProductName:     name,
+			BundleID:        product.BundleID,
+			Minutes:         minutes,
+			Builds:          builds,
+			PreviousMinutes: product.PreviousUsageInMinutes,
+			PreviousBuilds:  product.PreviousNumberOfBuilds,
+		}
+		if usageNumberFormat.seconds {
+			item.Seconds, item.SecondsApproximate = productUsageSeconds(product)
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch sortKey {
+		case "builds":
+			if a.Builds != b.Builds {
+				return a.Builds > b.Builds
+			}
+		case "minutes":
+			if a.Minutes != b.Minutes {
+				return a.Minutes > b.Minutes
+			}
+		}
+		nameA, nameB := strings.ToLower(a.ProductName), strings.ToLower(b.ProductName)
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return a.ProductID < b.ProductID
+	})
+
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items
+}
+
+func renderCIProductUsageTable(result *CIProductUsageResult, planTotal int) error {
+	if result == nil {
+		result = &CIProductUsageResult{}
+	}
+	fmt.Printf("Range: %s\n\n", formatCIProductUsageRange(result))
+	if result.GroupProductsBy != "" {
+		renderCIProductUsageGroupsTable(result.ProductGroups, planTotal)
+		return nil
+	}
+	asc.RenderTable(ciProductUsageHeaders(), buildCIProductUsageRows(result.Products, planTotal))
+	return nil
+}
+
+func renderCIProductUsageMarkdown(result *CIProductUsageResult, planTotal int) error {
+	if result == nil {
+		result = &CIProductUsageResult{}
+	}
+	fmt.Printf("**Range:** %s\n\n", formatCIProductUsageRange(result))
+	if result.GroupProductsBy != "" {
+		renderCIProductUsageGroupsMarkdown(result.ProductGroups, planTotal)
+		return nil
+	}
+	asc.RenderMarkdown(ciProductUsageHeaders(), buildCIProductUsageRows(result.Products, planTotal))
+	return nil
+}
+
+func ciProductUsageHeaders() []string {
+	return usageUnitHeaders([]string{"Rank", "Product Name", "Product ID", "Bundle ID", "Minutes", "Builds", "Usage Bar (Plan)"})
+}
+
+func buildCIProductUsageRows(items []CIProductUsageItem, planTotal int) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", item.Rank),
+			valueOrNA(item.ProductName),
+			valueOrNA(item.ProductID),
+			valueOrNA(item.BundleID),
+			formatUsageDuration(item.Minutes, item.Seconds),
+			formatUsageCount(item.Builds),
+			formatCIProductUsageItemBar(item, planTotal),
+		})
+	}
+	return rows
+}
+
+func formatCIProductUsageItemBar(item CIProductUsageItem, planTotal int) string {
+	if usageNumberFormat.seconds && item.Seconds > 0 {
+		return formatUsageBarWithSeconds(item.Seconds, planTotal)
+	}
+	return formatUsageBarWithValues(item.Minutes, planTotal)
+}
+
+func formatCIProductUsageRange(result *CIProductUsageResult) string {
+	if result.StartYear == 0 || result.EndYear == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%04d-%02d to %04d-%02d", result.StartYear, result.StartMonth, result.EndYear, result.EndMonth)
+}
@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubUsageProductsSession(t *testing.T, months *webcore.CIUsageMonths, monthsQuery *string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origNow
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC) }
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					switch {
+					case strings.Contains(req.URL.Path, "/usage/months"):
+						if monthsQuery != nil {
+							*monthsQuery = req.URL.RawQuery
+						}
+						return usageAlertJSONResponse(t, http.StatusOK, months), nil
+					case strings.Contains(req.URL.Path, "/usage/summary"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageSummary{
+							Plan: webcore.CIUsagePlan{Total: 1000},
+						}), nil
+					case strings.Contains(req.URL.Path, "/products"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIProductListResponse{
+							Items: []webcore.CIProduct{
+								{ID: "prod-a", Name: "Alpha"},
+								{ID: "prod-b", Name: "Beta"},
+							},
+						}), nil
+					default:
+						return usageAlertJSONResponse(t, http.StatusNotFound, map[string]any{"error": "not found"}), nil
+					}
+				}),
+			},
+		}, "", nil
+	}
+}
+
+func testCIUsageProductsMonths() *webcore.CIUsageMonths {
+	return &webcore.CIUsageMonths{
+		ProductUsage: []webcore.CIProductUsage{
+			{ProductID: "prod-a", UsageInMinutes: 100, NumberOfBuilds: 30},
+			{ProductID: "prod-b", UsageInMinutes: 400, NumberOfBuilds: 10},
+			{ProductID: "prod-c", ProductName: "Gamma", UsageInMinutes: 250, NumberOfBuilds: 20},
+		},
+	}
+}
+
+func TestBuildCIProductUsageItemsSortsAndResolvesNames(t *testing.T) {
+	names := map[string]string{"prod-a": "Alpha", "prod-b": "Beta"}
+	usage := testCIUsageProductsMonths().ProductUsage
+
+	tests := []struct {
+		sortKey string
+		want    []string
+	}{
+		{sortKey: "minutes", want: []string{"Beta", "Gamma", "Alpha"}},
+		{sortKey: "builds", want: []string{"Alpha", "Gamma", "Beta"}},
+		{sortKey: "name", want: []string{"Alpha", "Beta", "Gamma"}},
+	}
+	for _, test := range tests {
+		t.Run(test.sortKey, func(t *testing.T) {
+			items := buildCIProductUsageItems(usage, names, test.sortKey)
+			if len(items) != len(test.want) {
+				t.Fatalf("expected %d items, got %d", len(test.want), len(items))
+			}
+			for i, item := range items {
+				if item.ProductName != test.want[i] {
+					t.Fatalf("item %d name = %q, want %q", i, item.ProductName, test.want[i])
+				}
+				if item.Rank != i+1 {
+					t.Fatalf("item %d rank = %d, want %d", i, item.Rank, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageProductsRejectsInvalidFlags(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"--months", "0"}, wantErr: "--months must be between 1 and 24"},
+		{args: []string{"--months", "25"}, wantErr: "--months must be between 1 and 24"},
+		{args: []string{"--sort", "cost"}, wantErr: "--sort must be one of: minutes, builds, name"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			cmd := webXcodeCloudUsageProductsCommand()
+			if err := cmd.FlagSet.Parse(test.args); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			_, stderr := captureOutput(t, func() {
+				if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected flag.ErrHelp, got %v", err)
+				}
+			})
+			if !strings.Contains(stderr, test.wantErr) {
+				t.Fatalf("expected %q in stderr, got %q", test.wantErr, stderr)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageProductsJSON(t *testing.T) {
+	var monthsQuery string
+	stubUsageProductsSession(t, testCIUsageProductsMonths(), &monthsQuery)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--months", "3"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"start_month=1", "start_year=2026", "end_month=3", "end_year=2026"} {
+		if !strings.Contains(monthsQuery, want) {
+			t.Fatalf("expected %q in months query, got %q", want, monthsQuery)
+		}
+	}
+
+	var result CIProductUsageResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if result.Sort != "minutes" || len(result.Products) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Products[0].ProductID != "prod-b" || result.Products[0].ProductName != "Beta" {
+		t.Fatalf("expected Beta ranked first, got %+v", result.Products[0])
+	}
+}
+
+func TestWebXcodeCloudUsageProductsTableShowsPlanBars(t *testing.T) {
+	stubUsageProductsSession(t, testCIUsageProductsMonths(), nil)
+
+	cmd := webXcodeCloudUsageProductsCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--sort", "name", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for _, want := range []string{"Range: 2026-01 to 2026-03", "Usage Bar (Plan)", "Alpha", "Gamma", "400/3000"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+	if strings.Index(stdout, "Alpha") > strings.Index(stdout, "Beta") {
+		t.Fatalf("expected name sort to list Alpha before Beta, got:\n%s", stdout)
+	}
+}
+
+func TestWebXcodeCloudUsageProductsBarScalesPlanToRange(t *testing.T) {
+	for months, want := range map[string]string{"1": "400/1000", "6": "400/6000"} {
+		stubUsageProductsSession(t, testCIUsageProductsMonths(), nil)
+
+		cmd := webXcodeCloudUsageProductsCommand()
+		if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--months", months, "--output", "table"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		stdout, _ := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q for --months %s, got:\n%s", want, months, stdout)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// usageSnapshotVersion is bumped when the snapshot file layout changes.
+const usageSnapshotVersion = 1
+
+// CIUsageSnapshot is the file written by usage snapshot --save. It keeps the
+// month window so --compare can fetch exactly the same range again.
+type CIUsageSnapshot struct {
+	Version    int                     `json:"version"`
+	CapturedAt string                  `json:"captured_at"`
+	TeamID     string                  `json:"team_id"`
+	StartMonth int                     `json:"start_month"`
+	StartYear  int                     `json:"start_year"`
+	EndMonth   int                     `json:"end_month"`
+	EndYear    int                     `json:"end_year"`
+	Summary    *webcore.CIUsageSummary `json:"summary"`
+	Months     *webcore.CIUsageMonths  `json:"months"`
+}
+
+// CIUsageSnapshotSaveResult is the output of usage snapshot --save.
+type CIUsageSnapshotSaveResult struct {
+	Path       string `json:"path"`
+	CapturedAt string `json:"captured_at"`
+	TeamID     string `json:"team_id"`
+	Months     int    `json:"months"`
+	Products   int    `json:"products"`
+}
+
+// CIUsageSnapshotComparison is the output of usage snapshot --compare.
+// Months and Products list every entry from either side; Changed is true when
+// any delta is non-zero.
+type CIUsageSnapshotComparison struct {
+	Baseline           string                   `json:"baseline"`
+	BaselineCapturedAt string                   `json:"baseline_captured_at"`
+	ComparedAt         string                   `json:"compared_at"`
+	TeamID             string                   `json:"team_id"`
+	StartMonth         int                      `json:"start_month"`
+	StartYear          int                      `json:"start_year"`
+	EndMonth           int                      `json:"end_month"`
+	EndYear            int                      `json:"end_year"`
+	Plan               CIUsageSnapshotDelta     `json:"plan"`
+	Months             []CIUsageSnapshotMonth   `json:"months"`
+	Products           []CIUsageSnapshotProduct `json:"products"`
+	Changed            bool                     `json:"changed"`
+}
+
+// CIUsageSnapshotDelta compares plan minutes used in the baseline and now.
+type CIUsageSnapshotDelta struct {
+	BaselineMinutes int `json:"baseline_minutes"`
+	CurrentMinutes  int `json:"current_minutes"`
+	DeltaMinutes    int `json:"delta_minutes"`
+}
+
+// CIUsageSnapshotMonth is one month's change against the baseline.
+type CIUsageSnapshotMonth struct {
+	Year            int `json:"year"`
+	Month           int `json:"month"`
+	BaselineMinutes int `json:"baseline_minutes"`
+	CurrentMinutes  int `json:"current_minutes"`
+	DeltaMinutes    int `json:"delta_minutes"`
+	BaselineBuilds  int `json:"baseline_builds"`
+	CurrentBuilds   int `json:"current_builds"`
+	DeltaBuilds     int `json:"delta_builds"`
+}
+
+// CIUsageSnapshotProduct is one product's change over the whole window.
+type CIUsageSnapshotProduct struct {
+	ProductID       string `json:"product_id"`
+	ProductName     string `json:"product_name,omitempty"`
+	BaselineMinutes int    `json:"baseline_minutes"`
+	CurrentMinutes  int    `json:"current_minutes"`
+	DeltaMinutes    int    `json:"delta_minutes"`
+	BaselineBuilds  int    `json:"baseline_builds"`
+	CurrentBuilds   int    `json:"current_builds"`
+	DeltaBuilds     int    `json:"delta_builds"`
+}
+
+func webXcodeCloudUsageSnapshotCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage snapshot", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+
+	now := webNowFn()
+	startOfWindow := now.AddDate(0, -11, 0)
+
+	save := fs.String("save", "", "Write the current summary and monthly usage to this snapshot file")
+	compare := fs.String("compare", "", "Compare current usage with this snapshot file")
+	startMonth := fs.Int("start-month", int(startOfWindow.Month()), "Start month (1-12) for --save")
+	startYear := fs.Int("start-year", startOfWindow.Year(), "Start year for --save")
+	endMonth := fs.Int("end-month", int(now.Month()), "End month (1-12) for --save")
+	endYear := fs.Int("end-year", now.Year(), "End year for --save")
+
+	return &ffcli.Command{
+		Name:       "snapshot",
+		ShortUsage: "asc web xcode-cloud usage snapshot (--save FILE | --compare FILE) [flags]",
+		ShortHelp:  "EXPERIMENTAL: Save a usage baseline or compare current usage with one.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Save the plan summary and monthly usage to a JSON baseline file, then compare
+fresh data with it later to see how Xcode Cloud consumption changed.
+
+--save FILE stores the summary and the months from --start-month/--start-year
+to --end-month/--end-year (default: the last 12 months).
+--compare FILE fetches the same month window again and reports minutes and
+builds deltas for the plan, each month, and each product. JSON lists every
+month and product; table and markdown output show only the rows that changed.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage snapshot --save baseline.json --apple-id "user@example.com"
+  asc web xcode-cloud usage snapshot --compare baseline.json --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage snapshot --compare baseline.json --apple-id "user@example.com" --output json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			savePath := strings.TrimSpace(*save)
+			comparePath := strings.TrimSpace(*compare)
+			if (savePath == "") == (comparePath == "") {
+				fmt.Fprintln(os.Stderr, "Error: exactly one of --save or --compare is required")
+				return flag.ErrHelp
+			}
+
+			var baseline *CIUsageSnapshot
+			if comparePath != "" {
+				var err error
+				baseline, err = readUsageSnapshot(comparePath)
+				if err != nil {
+					return err
+				}
+			} else {
+				if *startMonth < 1 || *startMonth > 12 {
+					fmt.Fprintln(os.Stderr, "Error: --start-month must be between 1 and 12")
+					return flag.ErrHelp
+				}
+				if *endMonth < 1 || *endMonth > 12 {
+					fmt.Fprintln(os.Stderr, "Error: --end-month must be between 1 and 12")
+					return flag.ErrHelp
+				}
+				if err := validateMonthRange(*startMonth, *startYear, *endMonth, *endYear); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					return flag.ErrHelp
+				}
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud usage snapshot failed: session has no public provider ID")
+			}
+
+			current := &CIUsageSnapshot{
+				Version:    usageSnapshotVersion,
+				CapturedAt: webNowFn().UTC().Format(time.RFC3339),
+				TeamID:     teamID,
+				StartMonth: *startMonth,
+				StartYear:  *startYear,
+				EndMonth:   *endMonth,
+				EndYear:    *endYear,
+			}
+			if baseline != nil {
+				if baseline.TeamID != "" && baseline.TeamID != teamID {
+					fmt.Fprintf(os.Stderr, "Warning: baseline was captured for team %s, comparing with team %s\n", baseline.TeamID, teamID)
+				}
+				current.StartMonth, current.StartYear = baseline.StartMonth, baseline.StartYear
+				current.EndMonth, current.EndYear = baseline.EndMonth, baseline.EndYear
+			}
+
+			client := newCIClientFn(session)
+			err = withWebSpinner("Loading Xcode Cloud usage snapshot", func() error {
+				var err error
+				current.Summary, err = client.GetCIUsageSummary(requestCtx, teamID)
+				if err != nil {
+					return err
+				}
+				current.Months, err = client.GetCIUsageMonths(requestCtx, teamID, current.StartMonth, current.StartYear, current.EndMonth, current.EndYear)
+				return err
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud usage snapshot")
+			}
+
+			if baseline == nil {
+				if err := writeUsageSnapshot(savePath, current); err != nil {
+					return err
+				}
+				result := &CIUsageSnapshotSaveResult{
+					Path:       savePath,
+					CapturedAt: current.CapturedAt,
+					TeamID:     teamID,
+					Months:     len(current.Months.Usage),
+					Products:   len(current.Months.ProductUsage),
+				}
+				return shared.PrintOutputWithRenderers(
+					result,
+					*output.Output,
+					*output.Pretty,
+					func() error { return renderCIUsageSnapshotSaved(result, false) },
+					func() error { return renderCIUsageSnapshotSaved(result, true) },
+				)
+			}
+
+			result := compareCIUsageSnapshots(baseline, current)
+			result.Baseline = comparePath
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIUsageSnapshotComparison(result, false) },
+				func() error { return renderCIUsageSnapshotComparison(result, true) },
+			)
+		},
+	}
+}
+
+func readUsageSnapshot(path string) (*CIUsageSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("--compare: failed to read %q: %w", path, err)
+	}
+	var snapshot CIUsageSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("--compare: invalid snapshot in %q: %w", path, err)
+	}
+	if snapshot.Version != usageSnapshotVersion {
+		return nil, fmt.Errorf("--compare: unsupported snapshot version %d in %q", snapshot.Version, path)
+	}
+	if snapshot.Summary == nil || snapshot.Months == nil {
+		return nil, fmt.Errorf("--compare: snapshot %q is missing summary or months", path)
+	}
+	if err := validateMonthRange(snapshot.StartMonth, snapshot.StartYear, snapshot.EndMonth, snapshot.EndYear); err != nil {
+		return nil, fmt.Errorf("--compare: invalid month window in %q: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+func writeUsageSnapshot(path string, snapshot *CIUsageSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("--save: failed to encode snapshot: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("--save: failed to create %q: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("--save: failed to write %q: %w", path, err)
+	}
+	return nil
+}
+
+// compareCIUsageSnapshots computes plan, month, and product deltas. Entries
+// present on only one side count as zero on the other.
+func compareCIUsageSnapshots(baseline, current *CIUsageSnapshot) *CIUsageSnapshotComparison {
+	result := &CIUsageSnapshotComparison{
+		BaselineCapturedAt: baseline.CapturedAt,
+		ComparedAt:         current.CapturedAt,
+		TeamID:             current.TeamID,
+		StartMonth:         current.StartMonth,
+		StartYear:          current.StartYear,
+		EndMonth:           current.EndMonth,
+		EndYear:            current.EndYear,
+		Plan: CIUsageSnapshotDelta{
+			BaselineMinutes: baseline.Summary.Plan.Used,
+			CurrentMinutes:  current.Summary.Plan.Used,
+			DeltaMinutes:    current.Summary.Plan.Used - baseline.Summary.Plan.Used,
+		},
+		Months:   compareCIUsageSnapshotMonths(baseline.Months.Usage, current.Months.Usage),
+		Products: compareCIUsageSnapshotProducts(baseline.Months.ProductUsage, current.Months.ProductUsage),
+	}
+
+	result.Changed = result.Plan.DeltaMinutes != 0
+	for _, month := range result.Months {
+		if month.DeltaMinutes != 0 || month.DeltaBuilds != 0 {
+			result.Changed = true
+		}
+	}
+	for _, product := range result.Products {
+		if product.DeltaMinutes != 0 || product.DeltaBuilds != 0 {
+			result.Changed = true
+		}
+	}
+	return result
+}
+
+func compareCIUsageSnapshotMonths(baseline, current []webcore.CIMonthUsage) []CIUsageSnapshotMonth {
+	byKey := map[[2]int]*CIUsageSnapshotMonth{}
+	entry := func(usage webcore.CIMonthUsage) *CIUsageSnapshotMonth {
+		key := [2]int{usage.Year, usage.Month}
+		if byKey[key] == nil {
+			byKey[key] = &CIUsageSnapshotMonth{Year: usage.Year, Month: usage.Month}
+		}
+		return byKey[key]
+	}
+	for _, usage := range baseline {
+		month := entry(usage)
+		month.BaselineMinutes += usage.Duration
+		month.BaselineBuilds += usage.NumberOfBuilds
+	}
+	for _, usage := range current {
+		month := entry(usage)
+		month.CurrentMinutes += usage.Duration
+		month.CurrentBuilds += usage.NumberOfBuilds
+	}
+
+	months := make([]CIUsageSnapshotMonth, 0, len(byKey))
+	for _, month := range byKey {
+		month.DeltaMinutes = month.CurrentMinutes - month.BaselineMinutes
+		month.DeltaBuilds = month.CurrentBuilds - month.BaselineBuilds
+		months = append(months, *month)
+	}
+	sort.Slice(months, func(i, j int) bool {
+		if months[i].Year != months[j].Year {
+			return months[i].Year < months[j].Year
+		}
+		return months[i].Month < months[j].Month
+	})
+	return months
+}
+
+// compareCIUsageSnapshotProducts sorts products by the size of their change,
+// largest first, so regressions lead the table.
+func compareCIUsageSnapshotProducts(baseline, current []webcore.CIProductUsage) []CIUsageSnapshotProduct {
+	byID := map[string]*CIUsageSnapshotProduct{}
+	entry := func(usage webcore.CIProductUsage) *CIUsageSnapshotProduct {
+		id := strings.TrimSpace(usage.ProductID)
+		if byID[id] == nil {
+			byID[id] = &CIUsageSnapshotProduct{ProductID: id}
+		}
+		if name := strings.TrimSpace(usage.ProductName); name != "" {
+			byID[id].ProductName = name
+		}
+		return byID[id]
+	}
+	for _, usage := range baseline {
+		product := entry(usage)
+		minutes, builds := normalizeProductUsage(usage)
+		product.BaselineMinutes += minutes
+		product.BaselineBuilds += builds
+	}
+	for _, usage := range current {
+		product := entry(usage)
+		minutes, builds := normalizeProductUsage(usage)
+		product.CurrentMinutes += minutes
+		product.CurrentBuilds += builds
+	}
+
+	products := make([]CIUsageSnapshotProduct, 0, len(byID))
+	for _, product := range byID {
+		product.DeltaMinutes = product.CurrentMinutes - product.BaselineMinutes
+		product.DeltaBuilds = product.CurrentBuilds - product.BaselineBuilds
+		products = append(products, *product)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		left, right := absInt(products[i].DeltaMinutes), absInt(products[j].DeltaMinutes)
+		if left != right {
+			return left > right
+		}
+		return products[i].ProductID < products[j].ProductID
+	})
+	return products
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func renderCIUsageSnapshotSaved(result *CIUsageSnapshotSaveResult, markdown bool) error {
+	line := fmt.Sprintf("Saved usage snapshot to %s (%d months, %d products, captured %s)", result.Path, result.Months, result.Products, result.CapturedAt)
+	if markdown {
+		line = "**" + line + "**"
+	}
+	fmt.Println(line)
+	return nil
+}
+
+func renderCIUsageSnapshotComparison(result *CIUsageSnapshotComparison, markdown bool) error {
+	render := asc.RenderTable
+	heading := func(text string) { fmt.Printf("\n%s\n", text) }
+	if markdown {
+		render = asc.RenderMarkdown
+		heading = func(text string) { fmt.Printf("\n### %s\n\n", text) }
+	}
+
+	fmt.Printf("Baseline %s (captured %s) vs now (%s)\n", result.Baseline, result.BaselineCapturedAt, result.ComparedAt)
+	fmt.Printf("Plan minutes used: %s -> %s (%s)\n",
+		formatUsageMinutes(result.Plan.BaselineMinutes),
+		formatUsageMinutes(result.Plan.CurrentMinutes),
+		formatUsageMinutesDelta(result.Plan.DeltaMinutes),
+	)
+	if !result.Changed {
+		fmt.Println("No usage changes since the baseline.")
+		return nil
+	}
+
+	monthRows := [][]string{}
+	for _, month := range result.Months {
+		if month.DeltaMinutes == 0 && month.DeltaBuilds == 0 {
+			continue
+		}
+		monthRows = append(monthRows, []string{
+			fmt.Sprintf("%d-%02d", month.Year, month.Month),
+			formatUsageMinutes(month.BaselineMinutes),
+			formatUsageMinutes(month.CurrentMinutes),
+			formatUsageMinutesDelta(month.DeltaMinutes),
+			fmt.Sprintf("%+d", month.DeltaBuilds),
+		})
+	}
+	if len(monthRows) > 0 {
+		heading("Months")
+		render([]string{"Month", "Baseline Minutes", "Current Minutes", "Minutes Change", "Builds Change"}, monthRows)
+	}
+
+	productRows := [][]string{}
+	for _, product := range result.Products {
+		if product.DeltaMinutes == 0 && product.DeltaBuilds == 0 {
+			continue
+		}
+		productRows = append(productRows, []string{
+			valueOrNA(product.ProductName),
+			valueOrNA(product.ProductID),
+			formatUsageMinutes(product.BaselineMinutes),
+			formatUsageMinutes(product.CurrentMinutes),
+			formatUsageMinutesDelta(product.DeltaMinutes),
+			fmt.Sprintf("%+d", product.DeltaBuilds),
+		})
+	}
+	if len(productRows) > 0 {
+		heading("Products")
+		render([]string{"Product Name", "Product ID", "Baseline Minutes", "Current Minutes", "Minutes Change", "Builds Change"}, productRows)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func runUsageSnapshotCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := webXcodeCloudUsageSnapshotCommand()
+	if err := cmd.FlagSet.Parse(append([]string{"--apple-id", "user@example.com"}, args...)); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	return stdout
+}
+
+func TestWebXcodeCloudUsageSnapshotSaveThenCompare(t *testing.T) {
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+	path := filepath.Join(t.TempDir(), "snapshots", "baseline.json")
+
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t,
+		&webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 100, Total: 1000}},
+		&webcore.CIUsageMonths{
+			Usage: []webcore.CIMonthUsage{{Year: 2026, Month: 1, Duration: 60, NumberOfBuilds: 3}, {Year: 2026, Month: 2, Duration: 40, NumberOfBuilds: 2}},
+			ProductUsage: []webcore.CIProductUsage{
+				{ProductID: "prod-a", ProductName: "Alpha", UsageInMinutes: 70, NumberOfBuilds: 4},
+				{ProductID: "prod-b", UsageInMinutes: 30, NumberOfBuilds: 1},
+			},
+		},
+	)
+	runUsageSnapshotCommand(t, "--save", path, "--start-month", "1", "--start-year", "2026", "--end-month", "2", "--end-year", "2026", "--output", "json")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	var saved CIUsageSnapshot
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("decode snapshot: %v", err)
+	}
+	if saved.Version != usageSnapshotVersion || saved.TeamID != "TEAM-123" || saved.StartMonth != 1 || saved.EndMonth != 2 || len(saved.Months.Usage) != 2 {
+		t.Fatalf("unexpected snapshot %+v", saved)
+	}
+
+	resolveSessionFn = stubUsageAlertSessionWithResponses(t,
+		&webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 180, Total: 1000}},
+		&webcore.CIUsageMonths{
+			Usage: []webcore.CIMonthUsage{{Year: 2026, Month: 1, Duration: 60, NumberOfBuilds: 3}, {Year: 2026, Month: 2, Duration: 120, NumberOfBuilds: 6}},
+			ProductUsage: []webcore.CIProductUsage{
+				{ProductID: "prod-a", ProductName: "Alpha", UsageInMinutes: 150, NumberOfBuilds: 8},
+				{ProductID: "prod-b", UsageInMinutes: 30, NumberOfBuilds: 1},
+			},
+		},
+	)
+	stdout := runUsageSnapshotCommand(t, "--compare", path, "--output", "json")
+
+	var result CIUsageSnapshotComparison
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output %q: %v", stdout, err)
+	}
+	if !result.Changed || result.Plan.DeltaMinutes != 80 || result.Baseline != path {
+		t.Fatalf("unexpected comparison %+v", result)
+	}
+	if len(result.Months) != 2 || result.Months[0].DeltaMinutes != 0 || result.Months[1].DeltaMinutes != 80 || result.Months[1].DeltaBuilds != 4 {
+		t.Fatalf("unexpected month deltas %+v", result.Months)
+	}
+	if len(result.Products) != 2 || result.Products[0].ProductID != "prod-a" || result.Products[0].DeltaMinutes != 80 || result.Products[1].DeltaMinutes != 0 {
+		t.Fatalf("unexpected product deltas %+v", result.Products)
+	}
+
+	table := runUsageSnapshotCommand(t, "--compare", path, "--output", "table")
+	for _, want := range []string{"Plan minutes used: 100 -> 180 (+80)", "2026-02", "Alpha", "+80"} {
+		if !strings.Contains(table, want) {
+			t.Fatalf("expected %q in table:\n%s", want, table)
+		}
+	}
+	if strings.Contains(table, "2026-01") || strings.Contains(table, "prod-b") {
+		t.Fatalf("expected unchanged rows to be hidden in the table:\n%s", table)
+	}
+}
+
+func TestCompareCIUsageSnapshotsUnchanged(t *testing.T) {
+	snapshot := &CIUsageSnapshot{
+		Summary: &webcore.CIUsageSummary{Plan: webcore.CIUsagePlan{Used: 10}},
+		Months: &webcore.CIUsageMonths{
+			Usage:        []webcore.CIMonthUsage{{Year: 2026, Month: 3, Duration: 10}},
+			ProductUsage: []webcore.CIProductUsage{{ProductID: "prod-a", UsageInMinutes: 10}},
+		},
+	}
+	result := compareCIUsageSnapshots(snapshot, snapshot)
+	if result.Changed {
+		t.Fatalf("expected no changes, got %+v", result)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := renderCIUsageSnapshotComparison(result, false); err != nil {
+			t.Fatalf("render error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "No usage changes since the baseline.") {
+		t.Fatalf("unexpected output %q", stdout)
+	}
+}
+
+func TestReadUsageSnapshotRejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"invalid JSON":        "{",
+		"unsupported version": `{"version":99}`,
+		"missing months":      `{"version":1,"summary":{},"start_month":1,"start_year":2026,"end_month":2,"end_year":2026}`,
+	}
+	for name, contents := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".json")
+			if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+				t.Fatalf("write file: %v", err)
+			}
+			if _, err := readUsageSnapshot(path); err == nil || !strings.Contains(err.Error(), "--compare") {
+				t.Fatalf("expected --compare error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebXcodeCloudUsageSnapshotRequiresOneMode(t *testing.T) {
+	for _, args := range [][]string{nil, {"--save", "a.json", "--compare", "b.json"}} {
+		cmd := webXcodeCloudUsageSnapshotCommand()
+		if err := cmd.FlagSet.Parse(args); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		_, stderr := captureOutput(t, func() {
+			if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected flag.ErrHelp, got %v", err)
+			}
+		})
+		if !strings.Contains(stderr, "exactly one of --save or --compare is required") {
+			t.Fatalf("unexpected stderr %q", stderr)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIUsageSummaryResult is the usage summary output with --all-products: the
+// plan summary plus each product's share of the current billing period.
+type CIUsageSummaryResult struct {
+	*webcore.CIUsageSummary
+	PeriodStart string                  `json:"period_start"`
+	PeriodEnd   string                  `json:"period_end"`
+	Products    []CIUsageSummaryProduct `json:"products"`
+}
+
+// CIUsageSummaryProduct is one product's usage in the current billing period.
+type CIUsageSummaryProduct struct {
+	ProductID    string  `json:"product_id"`
+	ProductName  string  `json:"product_name,omitempty"`
+	BundleID     string  `json:"bundle_id,omitempty"`
+	Minutes      int     `json:"minutes"`
+	Builds       int     `json:"builds"`
+	SharePercent float64 `json:"share_percent"`
+	// Seconds is only set with --unit seconds.
+	Seconds            int  `json:"seconds,omitempty"`
+	SecondsApproximate bool `json:"seconds_approximate,omitempty"`
+}
+
+// loadCIUsageSummaryProducts fetches per-product usage from the start of the
+// current billing period through today. When the plan reset date is missing
+// the period falls back to the current calendar month.
+func loadCIUsageSummaryProducts(
+	ctx context.Context,
+	client *webcore.Client,
+	teamID string,
+	summary *webcore.CIUsageSummary,
+) (*CIUsageSummaryResult, error) {
+	now := webNowFn()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if resetDay, err := parseUsageResetDay(summary.Plan); err == nil {
+		periodStart = currentUsageCycleStart(resetDay, now)
+	} else {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the current calendar month for --all-products\n", err)
+	}
+
+	result := &CIUsageSummaryResult{
+		CIUsageSummary: summary,
+		PeriodStart:    periodStart.Format(usageCycleDateLayout),
+		PeriodEnd:      now.Format(usageCycleDateLayout),
+	}
+	days, err := client.GetCIUsageDaysOverall(ctx, teamID, result.PeriodStart, result.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for _, product := range days.ProductUsage {
+		if strings.TrimSpace(product.ProductName) == "" {
+			products, err := client.ListCIProducts(ctx, teamID)
+			if isContextDoneError(err) {
+				return nil, err
+			}
+			if err == nil {
+				names = buildProductNameByID(products)
+			}
+			break
+		}
+	}
+	result.Products = buildCIUsageSummaryProducts(days.ProductUsage, names)
+	return result, nil
+}
+
+// currentUsageCycleStart returns the start of the billing cycle containing now.
+func currentUsageCycleStart(resetDay int, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := usageCycleStart(today.Year(), today.Month(), resetDay)
+	if start.After(today) {
+		start = usageCycleStart(today.Year(), today.Month()-1, resetDay)
+	}
+	return start
+}
+
+// buildCIUsageSummaryProducts computes each product's share of the summed
+// product minutes, sorted by minutes descending.
+func buildCIUsageSummaryProducts(productUsage []webcore.CIProductUsage, names map[string]string) []CIUsageSummaryProduct {
+	products := make([]CIUsageSummaryProduct, 0, len(productUsage))
+	totalMinutes := 0
+	for _, usage := range productUsage {
+		minutes, builds := normalizeProductUsage(usage)
+		name := strings.TrimSpace(usage.ProductName)
+		if name == "" {
+			name = names[strings.ToLower(strings.TrimSpace(usage.ProductID))]
+		}
+		product := CIUsageSummaryProduct{
+			ProductID:   usage.ProductID,
+			ProductName: name,
+			BundleID:    usage.BundleID,
+			Minutes:     minutes,
+			Builds:      builds,
+		}
+		if usageNumberFormat.seconds {
+			product.Seconds, product.SecondsApproximate = productUsageSeconds(usage)
+		}
+		products = append(products, product)
+		totalMinutes += minutes
+	}
+	for i := range products {
+		if totalMinutes > 0 {
+			products[i].SharePercent = float64(products[i].Minutes) / float64(totalMinutes) * 100
+		}
+	}
+
+	sort.SliceStable(products, func(i, j int) bool {
+		if products[i].Minutes != products[j].Minutes {
+			return products[i].Minutes > products[j].Minutes
+		}
+		return products[i].ProductID < products[j].ProductID
+	})
+	return products
+}
+
+func renderCIUsageSummaryProductsTable(result *CIUsageSummaryResult) error {
+	if err := renderCIUsageSummaryTable(result.CIUsageSummary); err != nil {
+		return err
+	}
+	fmt.Printf("\nCurrent period: %s to %s\n\n", result.PeriodStart, result.PeriodEnd)
+	asc.RenderTable(ciUsageSummaryProductHeaders(), buildCIUsageSummaryProductRows(result.Products))
+	return nil
+}
+
+func renderCIUsageSummaryProductsMarkdown(result *CIUsageSummaryResult) error {
+	if err := renderCIUsageSummaryMarkdown(result.CIUsageSummary); err != nil {
+		return err
+	}
+	fmt.Printf("\n**Current period:** %s to %s\n\n", result.PeriodStart, result.PeriodEnd)
+	asc.RenderMarkdown(ciUsageSummaryProductHeaders(), buildCIUsageSummaryProductRows(result.Products))
+	return nil
+}
+
+func ciUsageSummaryProductHeaders() []string {
+	return usageUnitHeaders([]string{"Product Name", "Product ID", "Bundle ID", "Minutes", "Builds", "Share"})
+}
+
+func buildCIUsageSummaryProductRows(products []CIUsageSummaryProduct) [][]string {
+	rows := make([][]string, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, []string{
+			valueOrNA(product.ProductName),
+			valueOrNA(product.ProductID),
+			valueOrNA(product.BundleID),
+			formatUsageDuration(product.Minutes, product.Seconds),
+			formatUsageCount(product.Builds),
+			fmt.Sprintf("%.1f%%", product.SharePercent),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,140 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubUsageSummaryProductsSession(t *testing.T, daysQuery *string) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origNow
+	})
+	webNowFn = func() time.Time { return time.Date(2026, time.March, 10, 8, 0, 0, 0, time.UTC) }
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					switch {
+					case strings.Contains(req.URL.Path, "/usage/summary"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageSummary{
+							Plan: webcore.CIUsagePlan{Name: "Starter", Used: 400, Available: 600, Total: 1000, ResetDate: "2026-03-15"},
+						}), nil
+					case strings.HasSuffix(req.URL.Path, "/usage/days"):
+						*daysQuery = req.URL.RawQuery
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageDays{
+							ProductUsage: []webcore.CIProductUsage{
+								{ProductID: "prod-a", UsageInMinutes: 100, NumberOfBuilds: 5},
+								{ProductID: "prod-b", ProductName: "Beta", UsageInMinutes: 300, NumberOfBuilds: 9},
+							},
+						}), nil
+					case strings.Contains(req.URL.Path, "/products"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIProductListResponse{
+							Items: []webcore.CIProduct{{ID: "prod-a", Name: "Alpha"}},
+						}), nil
+					default:
+						return usageAlertJSONResponse(t, http.StatusNotFound, map[string]any{"error": "not found"}), nil
+					}
+				}),
+			},
+		}, "", nil
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryAllProductsJSON(t *testing.T) {
+	var daysQuery string
+	stubUsageSummaryProductsSession(t, &daysQuery)
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--all-products"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"start=2026-02-15", "end=2026-03-10"} {
+		if !strings.Contains(daysQuery, want) {
+			t.Fatalf("expected %q in days query, got %q", want, daysQuery)
+		}
+	}
+
+	var result struct {
+		Plan     webcore.CIUsagePlan     `json:"plan"`
+		Products []CIUsageSummaryProduct `json:"products"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if result.Plan.Total != 1000 || len(result.Products) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Products[0].ProductName != "Beta" || result.Products[0].SharePercent != 75 {
+		t.Fatalf("expected Beta first with 75%% share, got %+v", result.Products[0])
+	}
+	if result.Products[1].ProductName != "Alpha" {
+		t.Fatalf("expected Alpha name from products list, got %+v", result.Products[1])
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryAllProductsTable(t *testing.T) {
+	var daysQuery string
+	stubUsageSummaryProductsSession(t, &daysQuery)
+
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--all-products", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"Starter", "Current period: 2026-02-15 to 2026-03-10", "Share", "75.0%", "25.0%"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageSummaryAllProductsRejectsWatch(t *testing.T) {
+	cmd := webXcodeCloudUsageSummaryCommand()
+	if err := cmd.FlagSet.Parse([]string{"--all-products", "--watch"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--all-products cannot be used with --watch or --percent-only") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
+
+func TestCurrentUsageCycleStart(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	if got := currentUsageCycleStart(15, now); !got.Equal(time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected previous month's reset day, got %s", got)
+	}
+	if got := currentUsageCycleStart(10, now); !got.Equal(time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected today's reset day, got %s", got)
+	}
+}
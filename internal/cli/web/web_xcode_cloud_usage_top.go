@@ -0,0 +1,376 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+const (
+	maxUsageTopLimit = 100
+
+	// defaultUsageTopMaxProducts caps the per-product requests usage top makes
+	// to gather workflow usage.
+	defaultUsageTopMaxProducts = 20
+)
+
+// CIUsageTopResult is the output type for the usage top command.
+type CIUsageTopResult struct {
+	Start        string               `json:"start"`
+	End          string               `json:"end"`
+	Limit        int                  `json:"limit"`
+	TotalMinutes int                  `json:"total_minutes"`
+	Products     []CIProductUsageItem `json:"products"`
+	Workflows    []CIUsageTopWorkflow `json:"workflows"`
+	emptyResultNote
+}
+
+// CIUsageTopWorkflow is one ranked workflow in the usage top output. Workflows
+// are ranked across every product of the team.
+type CIUsageTopWorkflow struct {
+	Rank         int    `json:"rank"`
+	ProductID    string `json:"product_id"`
+	ProductName  string `json:"product_name,omitempty"`
+	WorkflowID   string `json:"workflow_id"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	Minutes      int    `json:"minutes"`
+	Builds       int    `json:"builds"`
+}
+
+func webXcodeCloudUsageTopCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud usage top", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlagsWithTemplate(fs)
+
+	now := webNowFn()
+	defaultEnd := now.Format("2006-01-02")
+	defaultStart := now.AddDate(0, 0, -30).Format("2006-01-02")
+
+	limit := fs.Int("limit", 10, fmt.Sprintf("Number of products and workflows to rank (1-%d)", maxUsageTopLimit))
+	start := fs.String("start", defaultStart, "Start date (YYYY-MM-DD)")
+	end := fs.String("end", defaultEnd, "End date (YYYY-MM-DD)")
+	maxProducts := fs.Int("max-products", defaultUsageTopMaxProducts, "Maximum products, by minutes used, whose workflows are fetched")
+	noNames := fs.Bool("no-names", false, "Skip the product and workflow name lookups and show IDs only")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+	clockCheck := bindClockCheckFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "top",
+		ShortUsage: "asc web xcode-cloud usage top [flags]",
+		ShortHelp:  "EXPERIMENTAL: Rank the top Xcode Cloud consumers by product and workflow.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+Rank the products and workflows that used the most Xcode Cloud compute minutes
+in a date range, in one combined report. Defaults to the last 30 days.
+Products come from the team-wide daily usage; workflows are gathered from the
+products with usage in the range and ranked across the whole team. Workflow
+usage is fetched for at most --max-products products, highest usage first and
+concurrently up to the global --concurrency flag; a warning is printed when
+products are left out.
+Table and markdown output show each entry's share of the team's minutes.
+Use --no-names to skip the product and workflow name lookups.
+` + assertClockHelpText + `
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud usage top --apple-id "user@example.com"
+  asc web xcode-cloud usage top --limit 10 --apple-id "user@example.com" --output table
+  asc web xcode-cloud usage top --start 2026-01-01 --end 2026-01-31 --apple-id "user@example.com" --output json`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if *limit < 1 || *limit > maxUsageTopLimit {
+				fmt.Fprintf(os.Stderr, "Error: --limit must be between 1 and %d\n", maxUsageTopLimit)
+				return flag.ErrHelp
+			}
+			if *maxProducts < 1 {
+				fmt.Fprintln(os.Stderr, "Error: --max-products must be at least 1")
+				return flag.ErrHelp
+			}
+			if err := validateDateFlag("--start", *start); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+			if err := validateDateFlag("--end", *end); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			if err := validateClockCheckFlags(clockCheck); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud usage top failed: session has no public provider ID")
+			}
+
+			client := newCIClientFn(session)
+			var overall *webcore.CIUsageDays
+			productNames := map[string]string{}
+			workflowsByProduct := map[string][]webcore.CIWorkflowUsage{}
+			var productIDs []string
+			productsWithUsage := 0
+			err = withWebSpinner("Loading Xcode Cloud usage by product and workflow", func() error {
+				var err error
+				overall, err = client.GetCIUsageDaysOverall(requestCtx, teamID, *start, *end)
+				if err != nil {
+					return err
+				}
+				if !*noNames {
+					products, err := client.ListCIProducts(requestCtx, teamID)
+					if strictErr := strictSupplementaryError(*strict, "product names", err); strictErr != nil {
+						return strictErr
+					}
+					if err == nil {
+						productNames = buildProductNameByID(products)
+					}
+				}
+				productIDs, productsWithUsage = usageTopProductIDs(overall.ProductUsage, *maxProducts)
+				workflowUsage := make([][]webcore.CIWorkflowUsage, len(productIDs))
+				err = shared.ForEachBounded(requestCtx, len(productIDs), shared.Concurrency(), func(ctx context.Context, index int) error {
+					productID := productIDs[index]
+					days, err := client.GetCIUsageDays(ctx, teamID, productID, *start, *end)
+					if err != nil {
+						return fmt.Errorf("product %s: %w", productID, err)
+					}
+					if !*noNames {
+						names, err := resolveWorkflowNameByID(ctx, client, teamID, productID, false)
+						if strictErr := strictSupplementaryError(*strict, "workflow names", err); strictErr != nil {
+							return strictErr
+						}
+						populateWorkflowNames(days.WorkflowUsage, names)
+					}
+					workflowUsage[index] = days.WorkflowUsage
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+				for index, productID := range productIDs {
+					workflowsByProduct[productID] = workflowUsage[index]
+				}
+				return nil
+			})
+			if err == nil {
+				err = assertClock(client, clockCheck, *strict)
+			}
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud usage top")
+			}
+			if productsWithUsage > len(productIDs) {
+				fmt.Fprintf(os.Stderr, "Warning: %d products had usage; workflows were ranked from the top %d (raise --max-products to include more)\n", productsWithUsage, len(productIDs))
+			}
+
+			products := buildCIProductUsageItems(overall.ProductUsage, productNames, "minutes")
+			productNameByID := map[string]string{}
+			for _, product := range products {
+				productNameByID[product.ProductID] = product.ProductName
+			}
+			result := &CIUsageTopResult{
+				Start:        *start,
+				End:          *end,
+				Limit:        *limit,
+				TotalMinutes: usageTopTotalMinutes(overall, products),
+				Products:     truncateUsageTop(products, *limit),
+				Workflows:    truncateUsageTop(buildCIUsageTopWorkflows(workflowsByProduct, productNameByID), *limit),
+			}
+			result.emptyResultNote = newEmptyResultNote(len(result.Products), emptyProductUsageMessage)
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIUsageTopTable(result) },
+				func() error { return renderCIUsageTopMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(result.Products), "xcode-cloud usage top")
+		},
+	}
+}
+
+// usageTopProductIDs returns the IDs of up to maxProducts products with usage
+// in the range, most minutes first, and how many products had usage. Products
+// without minutes cannot contribute workflows.
+func usageTopProductIDs(productUsage []webcore.CIProductUsage, maxProducts int) ([]string, int) {
+	type productMinutes struct {
+		id      string
+		minutes int
+	}
+	candidates := make([]productMinutes, 0, len(productUsage))
+	seen := map[string]bool{}
+	for _, product := range productUsage {
+		id := strings.TrimSpace(product.ProductID)
+		if id == "" || seen[id] {
+			continue
+		}
+		minutes, _ := normalizeProductUsage(product)
+		if minutes <= 0 {
+			continue
+		}
+		seen[id] = true
+		candidates = append(candidates, productMinutes{id: id, minutes: minutes})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].minutes > candidates[j].minutes })
+
+	total := len(candidates)
+	if maxProducts > 0 && len(candidates) > maxProducts {
+		candidates = candidates[:maxProducts]
+	}
+	ids := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.id
+	}
+	return ids, total
+}
+
+// buildCIUsageTopWorkflows flattens per-product workflow usage and ranks it by
+// minutes. Ties fall back to builds, then workflow name, then workflow ID.
+func buildCIUsageTopWorkflows(workflowsByProduct map[string][]webcore.CIWorkflowUsage, productNames map[string]string) []CIUsageTopWorkflow {
+	items := []CIUsageTopWorkflow{}
+	for productID, workflows := range workflowsByProduct {
+		for _, workflow := range workflows {
+			minutes, builds := normalizeWorkflowUsage(workflow)
+			if minutes <= 0 && builds <= 0 {
+				continue
+			}
+			items = append(items, CIUsageTopWorkflow{
+				ProductID:    productID,
+				ProductName:  productNames[productID],
+				WorkflowID:   workflow.WorkflowID,
+				WorkflowName: strings.TrimSpace(workflow.WorkflowName),
+				Minutes:      minutes,
+				Builds:       builds,
+			})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		if a.Builds != b.Builds {
+			return a.Builds > b.Builds
+		}
+		nameA, nameB := strings.ToLower(a.WorkflowName), strings.ToLower(b.WorkflowName)
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		if a.WorkflowID != b.WorkflowID {
+			return a.WorkflowID < b.WorkflowID
+		}
+		return a.ProductID < b.ProductID
+	})
+
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items
+}
+
+func truncateUsageTop[T any](items []T, limit int) []T {
+	if len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// usageTopTotalMinutes prefers the team's daily totals and falls back to the
+// sum of product minutes when the overview has no daily rows.
+func usageTopTotalMinutes(overall *webcore.CIUsageDays, products []CIProductUsageItem) int {
+	total := 0
+	if overall != nil {
+		for _, day := range overall.Usage {
+			total += day.Duration
+		}
+	}
+	if total > 0 {
+		return total
+	}
+	for _, product := range products {
+		total += product.Minutes
+	}
+	return total
+}
+
+func renderCIUsageTopTable(result *CIUsageTopResult) error {
+	fmt.Printf("Range: %s to %s (team total: %s minutes)\n\n", result.Start, result.End, formatUsageCount(result.TotalMinutes))
+	fmt.Printf("Top %d products\n", result.Limit)
+	asc.RenderTable(ciUsageTopProductHeaders(), buildCIUsageTopProductRows(result))
+	fmt.Printf("\nTop %d workflows\n", result.Limit)
+	asc.RenderTable(ciUsageTopWorkflowHeaders(), buildCIUsageTopWorkflowRows(result))
+	return nil
+}
+
+func renderCIUsageTopMarkdown(result *CIUsageTopResult) error {
+	fmt.Printf("**Range:** %s to %s (team total: %s minutes)\n\n", result.Start, result.End, formatUsageCount(result.TotalMinutes))
+	fmt.Printf("### Top %d products\n\n", result.Limit)
+	asc.RenderMarkdown(ciUsageTopProductHeaders(), buildCIUsageTopProductRows(result))
+	fmt.Printf("\n### Top %d workflows\n\n", result.Limit)
+	asc.RenderMarkdown(ciUsageTopWorkflowHeaders(), buildCIUsageTopWorkflowRows(result))
+	return nil
+}
+
+func ciUsageTopProductHeaders() []string {
+	return []string{"Rank", "Product Name", "Product ID", "Minutes", "Builds", "Share"}
+}
+
+func ciUsageTopWorkflowHeaders() []string {
+	return []string{"Rank", "Workflow Name", "Workflow ID", "Product", "Minutes", "Builds", "Share"}
+}
+
+func buildCIUsageTopProductRows(result *CIUsageTopResult) [][]string {
+	rows := make([][]string, 0, len(result.Products))
+	for _, product := range result.Products {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", product.Rank),
+			valueOrNA(product.ProductName),
+			valueOrNA(product.ProductID),
+			formatUsageCount(product.Minutes),
+			formatUsageCount(product.Builds),
+			formatUsagePercent(product.Minutes, result.TotalMinutes),
+		})
+	}
+	return rows
+}
+
+func buildCIUsageTopWorkflowRows(result *CIUsageTopResult) [][]string {
+	rows := make([][]string, 0, len(result.Workflows))
+	for _, workflow := range result.Workflows {
+		product := workflow.ProductName
+		if product == "" {
+			product = workflow.ProductID
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", workflow.Rank),
+			valueOrNA(workflow.WorkflowName),
+			valueOrNA(workflow.WorkflowID),
+			valueOrNA(product),
+			formatUsageCount(workflow.Minutes),
+			formatUsageCount(workflow.Builds),
+			formatUsagePercent(workflow.Minutes, result.TotalMinutes),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,223 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+func stubUsageTopSession(t *testing.T, paths *[]string) {
+	t.Helper()
+	resetWorkflowNameCache()
+	t.Cleanup(resetWorkflowNameCache)
+	origResolveSession := resolveSessionFn
+	t.Cleanup(func() { resolveSessionFn = origResolveSession })
+
+	productDays := map[string]*webcore.CIUsageDays{
+		"prod-a": {WorkflowUsage: []webcore.CIWorkflowUsage{
+			{WorkflowID: "wf-a1", UsageInMinutes: 50, NumberOfBuilds: 5},
+			{WorkflowID: "wf-a2", UsageInMinutes: 250, NumberOfBuilds: 9},
+		}},
+		"prod-b": {WorkflowUsage: []webcore.CIWorkflowUsage{
+			{WorkflowID: "wf-b1", WorkflowName: "Nightly", Usage: []webcore.CIDayUsage{{Duration: 120, NumberOfBuilds: 2}, {Duration: 60, NumberOfBuilds: 1}}},
+			{WorkflowID: "wf-b2", UsageInMinutes: 0, NumberOfBuilds: 0},
+		}},
+	}
+
+	var pathsMu sync.Mutex
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "TEAM-123",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					if paths != nil {
+						pathsMu.Lock()
+						*paths = append(*paths, req.URL.Path)
+						pathsMu.Unlock()
+					}
+					path := req.URL.Path
+					switch {
+					case strings.HasSuffix(path, "/teams/TEAM-123/usage/days"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageDays{
+							Usage: []webcore.CIDayUsage{{Duration: 300}, {Duration: 300}},
+							ProductUsage: []webcore.CIProductUsage{
+								{ProductID: "prod-a", UsageInMinutes: 300, NumberOfBuilds: 14},
+								{ProductID: "prod-b", UsageInMinutes: 180, NumberOfBuilds: 3},
+								{ProductID: "prod-idle", UsageInMinutes: 0},
+							},
+						}), nil
+					case strings.HasSuffix(path, "/usage/days"):
+						productID := strings.Split(strings.SplitAfter(path, "/products/")[1], "/")[0]
+						days, ok := productDays[productID]
+						if !ok {
+							t.Fatalf("unexpected product fan-out for %q", productID)
+						}
+						return usageAlertJSONResponse(t, http.StatusOK, days), nil
+					case strings.HasSuffix(path, "/products-v4"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIProductListResponse{
+							Items: []webcore.CIProduct{{ID: "prod-a", Name: "Alpha"}, {ID: "prod-b", Name: "Beta"}},
+						}), nil
+					case strings.HasSuffix(path, "/prod-a/workflows-v15"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIWorkflowListResponse{
+							Items: []webcore.CIWorkflow{
+								{ID: "wf-a1", Content: webcore.CIWorkflowContent{Name: "PR Checks"}},
+								{ID: "wf-a2", Content: webcore.CIWorkflowContent{Name: "Release"}},
+							},
+						}), nil
+					case strings.HasSuffix(path, "/workflows-v15"):
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIWorkflowListResponse{}), nil
+					default:
+						return usageAlertJSONResponse(t, http.StatusNotFound, map[string]any{"error": "not found"}), nil
+					}
+				}),
+			},
+		}, "", nil
+	}
+}
+
+func TestWebXcodeCloudUsageTopRanksProductsAndWorkflows(t *testing.T) {
+	var paths []string
+	stubUsageTopSession(t, &paths)
+
+	cmd := webXcodeCloudUsageTopCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--limit", "2", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result CIUsageTopResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output %q: %v", stdout, err)
+	}
+	if result.TotalMinutes != 600 || result.Limit != 2 {
+		t.Fatalf("unexpected totals: %+v", result)
+	}
+	if len(result.Products) != 2 || result.Products[0].ProductName != "Alpha" || result.Products[1].ProductName != "Beta" {
+		t.Fatalf("unexpected products: %+v", result.Products)
+	}
+	if len(result.Workflows) != 2 {
+		t.Fatalf("expected workflows limited to 2, got %+v", result.Workflows)
+	}
+	first, second := result.Workflows[0], result.Workflows[1]
+	if first.Rank != 1 || first.WorkflowName != "Release" || first.ProductName != "Alpha" || first.Minutes != 250 {
+		t.Fatalf("unexpected first workflow: %+v", first)
+	}
+	if second.Rank != 2 || second.WorkflowName != "Nightly" || second.ProductID != "prod-b" || second.Minutes != 180 || second.Builds != 3 {
+		t.Fatalf("unexpected second workflow: %+v", second)
+	}
+	for _, path := range paths {
+		if strings.Contains(path, "prod-idle") {
+			t.Fatalf("expected products without usage to be skipped, requested %q", path)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageTopTableShowsShares(t *testing.T) {
+	stubUsageTopSession(t, nil)
+
+	cmd := webXcodeCloudUsageTopCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"team total: 600 minutes", "Top 10 products", "Top 10 workflows", "Release", "PR Checks", "50%", "42%"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageTopNoNamesSkipsLookups(t *testing.T) {
+	var paths []string
+	stubUsageTopSession(t, &paths)
+
+	cmd := webXcodeCloudUsageTopCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--no-names"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, path := range paths {
+		if strings.HasSuffix(path, "/products-v4") || strings.HasSuffix(path, "/workflows-v15") {
+			t.Fatalf("expected no name lookups with --no-names, requested %q", path)
+		}
+	}
+}
+
+func TestWebXcodeCloudUsageTopMaxProducts(t *testing.T) {
+	var paths []string
+	stubUsageTopSession(t, &paths)
+
+	cmd := webXcodeCloudUsageTopCommand()
+	if err := cmd.FlagSet.Parse([]string{"--apple-id", "user@example.com", "--max-products", "1", "--output", "json"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, path := range paths {
+		if strings.Contains(path, "/products/prod-b/") {
+			t.Fatalf("expected only the top product to be fetched, requested %q", path)
+		}
+	}
+	if !strings.Contains(stderr, "2 products had usage; workflows were ranked from the top 1") {
+		t.Fatalf("expected truncation warning, got %q", stderr)
+	}
+	var result CIUsageTopResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode output %q: %v", stdout, err)
+	}
+	if len(result.Products) != 3 || len(result.Workflows) != 2 || result.Workflows[0].ProductID != "prod-a" || result.Workflows[1].ProductID != "prod-a" {
+		t.Fatalf("expected every product ranked and only prod-a workflows, got %+v", result)
+	}
+}
+
+func TestUsageTopProductIDsOrdersByMinutes(t *testing.T) {
+	ids, total := usageTopProductIDs([]webcore.CIProductUsage{
+		{ProductID: "small", UsageInMinutes: 10},
+		{ProductID: "big", UsageInMinutes: 90},
+		{ProductID: "idle"},
+		{ProductID: "mid", UsageInMinutes: 40},
+	}, 2)
+	if total != 3 || strings.Join(ids, ",") != "big,mid" {
+		t.Fatalf("expected big,mid of 3, got %v of %d", ids, total)
+	}
+}
+
+func TestWebXcodeCloudUsageTopRejectsInvalidLimit(t *testing.T) {
+	cmd := webXcodeCloudUsageTopCommand()
+	if err := cmd.FlagSet.Parse([]string{"--limit", "0"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--limit must be between 1 and 100") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
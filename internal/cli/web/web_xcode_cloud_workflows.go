@@ -30,18 +30,21 @@ Describe and manage workflow state for Xcode Cloud workflows
 using Apple's private CI API. Requires a web session.
 
 Use describe to inspect workflow configuration.
+Use status to list every workflow with its state and last build activity.
 Use enable/disable to toggle workflow state.
 
 ` + webWarningText + `
 
 Examples:
   asc web xcode-cloud workflows describe --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com"
+  asc web xcode-cloud workflows status --product-id "UUID" --apple-id "user@example.com" --output table
   asc web xcode-cloud workflows enable --product-id "UUID" --workflow-id "WF-UUID" --apple-id "user@example.com"
   asc web xcode-cloud workflows disable --product-id "UUID" --workflow-id "WF-UUID" --confirm --apple-id "user@example.com"`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			webXcodeCloudWorkflowDescribeCommand(),
+			webXcodeCloudWorkflowStatusCommand(),
 			webXcodeCloudWorkflowEnableCommand(),
 			webXcodeCloudWorkflowDisableCommand(),
 		},
@@ -0,0 +1,227 @@
+package web
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
+)
+
+// CIWorkflowStatusResult is the output type for workflows status.
+type CIWorkflowStatusResult struct {
+	ProductID string `json:"product_id"`
+	// ActivityStart and ActivityEnd bound the daily usage window used for
+	// last build activity. ActivityAvailable is false when that lookup failed.
+	ActivityStart     string                 `json:"activity_start"`
+	ActivityEnd       string                 `json:"activity_end"`
+	ActivityAvailable bool                   `json:"activity_available"`
+	Workflows         []CIWorkflowStatusItem `json:"workflows"`
+}
+
+// CIWorkflowStatusItem is one workflow in the status board.
+type CIWorkflowStatusItem struct {
+	WorkflowID    string `json:"workflow_id"`
+	Name          string `json:"name"`
+	Enabled       bool   `json:"enabled"`
+	Locked        bool   `json:"locked"`
+	LastBuildDate string `json:"last_build_date,omitempty"`
+	RecentBuilds  int    `json:"recent_builds"`
+}
+
+func webXcodeCloudWorkflowStatusCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("web xcode-cloud workflows status", flag.ExitOnError)
+	sessionFlags := bindWebSessionFlags(fs)
+	output := shared.BindOutputFlags(fs)
+
+	productID := fs.String("product-id", "", "Xcode Cloud product ID (required)")
+	days := fs.Int("days", 30, "Days of build activity to scan for each workflow's last build (1-365)")
+	failIfEmpty := bindFailIfEmptyFlag(fs)
+	strict := bindStrictFlag(fs)
+
+	return &ffcli.Command{
+		Name:       "status",
+		ShortUsage: "asc web xcode-cloud workflows status --product-id ID [flags]",
+		ShortHelp:  "EXPERIMENTAL: List workflows with state and last build activity.",
+		LongHelp: `EXPERIMENTAL / UNOFFICIAL / DISCOURAGED
+
+List every workflow for a product with its enabled/locked state and recent build activity.
+
+The private CI API does not expose build run results, so the last build column is the
+most recent day with builds in the last --days days, taken from daily usage. Workflows
+without builds in that window show n/a. If daily usage is unavailable the board still
+lists workflow state; use --strict to fail instead.
+
+` + webWarningText + `
+
+Examples:
+  asc web xcode-cloud workflows status --product-id "UUID" --apple-id "user@example.com"
+  asc web xcode-cloud workflows status --product-id "UUID" --days 90 --apple-id "user@example.com" --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			pid := strings.TrimSpace(*productID)
+			if pid == "" {
+				fmt.Fprintln(os.Stderr, "Error: --product-id is required")
+				return flag.ErrHelp
+			}
+			if *days < 1 || *days > 365 {
+				fmt.Fprintln(os.Stderr, "Error: --days must be between 1 and 365")
+				return flag.ErrHelp
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			session, err := resolveWebSessionForCommand(requestCtx, sessionFlags)
+			if err != nil {
+				return err
+			}
+			teamID := strings.TrimSpace(session.PublicProviderID)
+			if teamID == "" {
+				return fmt.Errorf("xcode-cloud workflows status failed: session has no public provider ID")
+			}
+
+			now := webNowFn()
+			result := &CIWorkflowStatusResult{
+				ProductID:     pid,
+				ActivityStart: now.AddDate(0, 0, -*days).Format("2006-01-02"),
+				ActivityEnd:   now.Format("2006-01-02"),
+			}
+			client := newCIClientFn(session)
+			err = withWebSpinner("Loading Xcode Cloud workflow status", func() error {
+				workflows, err := client.ListCIWorkflows(requestCtx, teamID, pid)
+				if err != nil {
+					return err
+				}
+				primeWorkflowNameCache(teamID, pid, workflows.Items)
+
+				usage, err := client.GetCIUsageDays(requestCtx, teamID, pid, result.ActivityStart, result.ActivityEnd)
+				if strictErr := strictSupplementaryError(*strict, "build activity", err); strictErr != nil {
+					return strictErr
+				}
+				var workflowUsage []webcore.CIWorkflowUsage
+				if err == nil && usage != nil {
+					result.ActivityAvailable = true
+					workflowUsage = usage.WorkflowUsage
+				}
+				result.Workflows = buildCIWorkflowStatusItems(workflows.Items, workflowUsage)
+				return nil
+			})
+			if err != nil {
+				return withWebAuthHint(err, "xcode-cloud workflows status")
+			}
+
+			if err := shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderCIWorkflowStatusTable(result) },
+				func() error { return renderCIWorkflowStatusMarkdown(result) },
+			); err != nil {
+				return err
+			}
+			return checkFailIfEmpty(*failIfEmpty, len(result.Workflows), "xcode-cloud workflows status")
+		},
+	}
+}
+
+// buildCIWorkflowStatusItems joins workflow state with daily usage, sorted by
+// name. Usage for workflows that no longer exist is ignored.
+func buildCIWorkflowStatusItems(workflows []webcore.CIWorkflow, usage []webcore.CIWorkflowUsage) []CIWorkflowStatusItem {
+	usageByID := make(map[string]webcore.CIWorkflowUsage, len(usage))
+	for _, workflowUsage := range usage {
+		usageByID[strings.ToLower(strings.TrimSpace(workflowUsage.WorkflowID))] = workflowUsage
+	}
+
+	items := make([]CIWorkflowStatusItem, 0, len(workflows))
+	for _, workflow := range workflows {
+		item := CIWorkflowStatusItem{
+			WorkflowID: workflow.ID,
+			Name:       strings.TrimSpace(workflow.Content.Name),
+			Enabled:    !workflow.Content.Disabled,
+			Locked:     workflow.Content.Locked,
+		}
+		if workflowUsage, ok := usageByID[strings.ToLower(strings.TrimSpace(workflow.ID))]; ok {
+			_, item.RecentBuilds = normalizeWorkflowUsage(workflowUsage)
+			item.LastBuildDate = lastWorkflowBuildDate(workflowUsage.Usage)
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		nameA, nameB := strings.ToLower(items[i].Name), strings.ToLower(items[j].Name)
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return items[i].WorkflowID < items[j].WorkflowID
+	})
+	return items
+}
+
+// lastWorkflowBuildDate returns the latest day with at least one build.
+func lastWorkflowBuildDate(usage []webcore.CIDayUsage) string {
+	last := ""
+	for _, day := range usage {
+		date := strings.TrimSpace(day.Date)
+		if day.NumberOfBuilds > 0 && date > last {
+			last = date
+		}
+	}
+	return last
+}
+
+func renderCIWorkflowStatusTable(result *CIWorkflowStatusResult) error {
+	if result == nil {
+		result = &CIWorkflowStatusResult{}
+	}
+	fmt.Printf("Build activity: %s\n\n", formatCIWorkflowStatusWindow(result))
+	asc.RenderTable(ciWorkflowStatusHeaders(), buildCIWorkflowStatusRows(result))
+	return nil
+}
+
+func renderCIWorkflowStatusMarkdown(result *CIWorkflowStatusResult) error {
+	if result == nil {
+		result = &CIWorkflowStatusResult{}
+	}
+	fmt.Printf("**Build activity:** %s\n\n", formatCIWorkflowStatusWindow(result))
+	asc.RenderMarkdown(ciWorkflowStatusHeaders(), buildCIWorkflowStatusRows(result))
+	return nil
+}
+
+func ciWorkflowStatusHeaders() []string {
+	return []string{"Workflow", "Workflow ID", "Enabled", "Locked", "Last Build", "Builds"}
+}
+
+func buildCIWorkflowStatusRows(result *CIWorkflowStatusResult) [][]string {
+	rows := make([][]string, 0, len(result.Workflows))
+	for _, item := range result.Workflows {
+		builds := fmt.Sprintf("%d", item.RecentBuilds)
+		if !result.ActivityAvailable {
+			builds = "n/a"
+		}
+		rows = append(rows, []string{
+			valueOrNA(item.Name),
+			item.WorkflowID,
+			fmt.Sprintf("%t", item.Enabled),
+			fmt.Sprintf("%t", item.Locked),
+			valueOrNA(item.LastBuildDate),
+			builds,
+		})
+	}
+	return rows
+}
+
+func formatCIWorkflowStatusWindow(result *CIWorkflowStatusResult) string {
+	if !result.ActivityAvailable {
+		return "unavailable"
+	}
+	return fmt.Sprintf("%s to %s", result.ActivityStart, result.ActivityEnd)
+}
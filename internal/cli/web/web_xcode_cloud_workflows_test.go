@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
@@ -19,14 +20,14 @@ func TestWorkflowsCommandHierarchy(t *testing.T) {
 	if workflowsCmd == nil {
 		t.Fatal("expected 'workflows' subcommand")
 	}
-	if len(workflowsCmd.Subcommands) != 3 {
-		t.Fatalf("expected 3 subcommands (describe, enable, disable), got %d", len(workflowsCmd.Subcommands))
+	if len(workflowsCmd.Subcommands) != 4 {
+		t.Fatalf("expected 4 subcommands (describe, status, enable, disable), got %d", len(workflowsCmd.Subcommands))
 	}
 	names := map[string]bool{}
 	for _, sub := range workflowsCmd.Subcommands {
 		names[sub.Name] = true
 	}
-	for _, name := range []string{"describe", "enable", "disable"} {
+	for _, name := range []string{"describe", "status", "enable", "disable"} {
 		if !names[name] {
 			t.Fatalf("expected %q subcommand", name)
 		}
@@ -475,3 +476,133 @@ func TestWorkflowSectionSummaries(t *testing.T) {
 		t.Fatalf("unexpected post-action summary: %q", postSummary)
 	}
 }
+
+func stubWorkflowStatusSession(t *testing.T, usageStatus int) {
+	t.Helper()
+	origResolveSession := resolveSessionFn
+	origNow := webNowFn
+	t.Cleanup(func() {
+		resolveSessionFn = origResolveSession
+		webNowFn = origNow
+		resetWorkflowNameCache()
+	})
+	resetWorkflowNameCache()
+	webNowFn = func() time.Time { return time.Date(2026, time.March, 31, 12, 0, 0, 0, time.UTC) }
+
+	resolveSessionFn = func(
+		ctx context.Context,
+		appleID, password, twoFactorCode string,
+	) (*webcore.AuthSession, string, error) {
+		return &webcore.AuthSession{
+			PublicProviderID: "team-uuid",
+			Client: &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					switch {
+					case strings.Contains(req.URL.Path, "/products/prod-1/workflows-v15"):
+						return usageAlertJSONResponse(t, http.StatusOK, map[string]any{
+							"items": []map[string]any{
+								{"id": "wf-2", "content": map[string]any{"name": "Release", "disabled": true, "locked": true}},
+								{"id": "wf-1", "content": map[string]any{"name": "CI"}},
+							},
+						}), nil
+					case strings.Contains(req.URL.Path, "/products/prod-1/usage/days"):
+						if got := req.URL.Query().Get("start"); got != "2026-03-01" {
+							t.Fatalf("expected start=2026-03-01, got %q", got)
+						}
+						if usageStatus != http.StatusOK {
+							return usageAlertJSONResponse(t, usageStatus, map[string]any{"error": "unavailable"}), nil
+						}
+						return usageAlertJSONResponse(t, http.StatusOK, &webcore.CIUsageDays{
+							WorkflowUsage: []webcore.CIWorkflowUsage{{
+								WorkflowID: "WF-1",
+								Usage: []webcore.CIDayUsage{
+									{Date: "2026-03-10", Duration: 12, NumberOfBuilds: 2},
+									{Date: "2026-03-28", Duration: 0, NumberOfBuilds: 0},
+									{Date: "2026-03-20", Duration: 8, NumberOfBuilds: 1},
+								},
+							}},
+						}), nil
+					default:
+						t.Fatalf("unexpected path: %s", req.URL.Path)
+						return nil, nil
+					}
+				}),
+			},
+		}, "", nil
+	}
+}
+
+func TestWorkflowsStatusListsStateAndLastBuild(t *testing.T) {
+	stubWorkflowStatusSession(t, http.StatusOK)
+
+	cmd := webXcodeCloudWorkflowStatusCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--apple-id", "user@example.com"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result CIWorkflowStatusResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, stdout)
+	}
+	if !result.ActivityAvailable || len(result.Workflows) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	ci, release := result.Workflows[0], result.Workflows[1]
+	if ci.Name != "CI" || !ci.Enabled || ci.Locked || ci.LastBuildDate != "2026-03-20" || ci.RecentBuilds != 3 {
+		t.Fatalf("unexpected CI workflow: %+v", ci)
+	}
+	if release.Name != "Release" || release.Enabled || !release.Locked || release.LastBuildDate != "" {
+		t.Fatalf("unexpected Release workflow: %+v", release)
+	}
+}
+
+func TestWorkflowsStatusDegradesWithoutBuildActivity(t *testing.T) {
+	stubWorkflowStatusSession(t, http.StatusInternalServerError)
+
+	cmd := webXcodeCloudWorkflowStatusCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--apple-id", "user@example.com", "--output", "table"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	stdout, _ := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"Build activity: unavailable", "Release", "false"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected %q in table output, got:\n%s", want, stdout)
+		}
+	}
+
+	cmd = webXcodeCloudWorkflowStatusCommand()
+	if err := cmd.FlagSet.Parse([]string{"--product-id", "prod-1", "--apple-id", "user@example.com", "--strict"}); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	var runErr error
+	_, _ = captureOutput(t, func() {
+		runErr = cmd.Exec(context.Background(), nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "build activity unavailable (--strict)") {
+		t.Fatalf("expected --strict build activity error, got %v", runErr)
+	}
+}
+
+func TestWorkflowsStatusRequiresProductID(t *testing.T) {
+	cmd := webXcodeCloudWorkflowStatusCommand()
+	if err := cmd.FlagSet.Parse(nil); err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	_, stderr := captureOutput(t, func() {
+		if err := cmd.Exec(context.Background(), nil); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected flag.ErrHelp, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--product-id is required") {
+		t.Fatalf("unexpected stderr: %q", stderr)
+	}
+}
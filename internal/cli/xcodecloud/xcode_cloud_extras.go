@@ -132,11 +132,13 @@ func XcodeCloudProductsBuildRunsCommand() *ffcli.Command {
 Examples:
   asc xcode-cloud products build-runs --id "PRODUCT_ID"
   asc xcode-cloud products build-runs --id "PRODUCT_ID" --limit 50
-  asc xcode-cloud products build-runs --id "PRODUCT_ID" --paginate`,
+  asc xcode-cloud products build-runs --id "PRODUCT_ID" --paginate
+  asc xcode-cloud products build-runs --id "PRODUCT_ID" --paginate --sort createdDate --reverse`,
 		ParentFlag:  "id",
 		ParentUsage: "Product ID",
 		LimitMax:    200,
 		ErrorPrefix: "xcode-cloud products build-runs",
+		SortFields:  []string{"number", "createdDate", "startedDate", "finishedDate", "completionStatus"},
 		ContextTimeout: func(ctx context.Context) (context.Context, context.CancelFunc) {
 			return contextWithXcodeCloudTimeout(ctx, 0)
 		},
@@ -161,11 +163,13 @@ func XcodeCloudProductsWorkflowsCommand() *ffcli.Command {
 Examples:
   asc xcode-cloud products workflows --id "PRODUCT_ID"
   asc xcode-cloud products workflows --id "PRODUCT_ID" --limit 50
-  asc xcode-cloud products workflows --id "PRODUCT_ID" --paginate`,
+  asc xcode-cloud products workflows --id "PRODUCT_ID" --paginate
+  asc xcode-cloud products workflows --id "PRODUCT_ID" --paginate --sort name`,
 		ParentFlag:  "id",
 		ParentUsage: "Product ID",
 		LimitMax:    200,
 		ErrorPrefix: "xcode-cloud products workflows",
+		SortFields:  []string{"name", "lastModifiedDate", "isEnabled"},
 		ContextTimeout: func(ctx context.Context) (context.Context, context.CancelFunc) {
 			return contextWithXcodeCloudTimeout(ctx, 0)
 		},
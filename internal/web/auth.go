@@ -89,6 +89,15 @@ type AuthSession struct {
 	TeamID           string
 	UserEmail        string
 
+	// UserAgent overrides the User-Agent sent by clients built from this
+	// session. When empty, ASC_USER_AGENT or DefaultUserAgent is used.
+	UserAgent string
+
+	// Reauthenticate, when set, returns a replacement for a session the API
+	// has rejected. Clients built from this session call it after an auth
+	// failure and retry the request once with the replacement.
+	Reauthenticate func(ctx context.Context) (*AuthSession, error)
+
 	// Continuation state needed after a 409 SRP completion response.
 	ServiceKey       string
 	AppleIDSessionID string
@@ -115,12 +124,24 @@ func (e *TwoFactorRequiredError) Error() string {
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	userAgent  string
+	// configErr is returned by every request when the client was built with
+	// an invalid configuration, such as a malformed base URL override.
+	configErr error
 
 	// Requests are intentionally throttled to reduce pressure on fragile, unofficial
 	// web-session endpoints and avoid bursty behavior against user accounts.
 	minRequestInterval time.Duration
 	rateLimitMu        sync.Mutex
 	nextAllowedAt      time.Time
+
+	serverDateMu sync.Mutex
+	serverDate   time.Time
+
+	// httpClientMu guards httpClient, which reauthenticate replaces when the
+	// session is rejected. reauthenticate is AuthSession.Reauthenticate.
+	httpClientMu   sync.Mutex
+	reauthenticate func(ctx context.Context) (*AuthSession, error)
 }
 
 // APIError wraps non-2xx internal web API responses.
@@ -225,6 +246,18 @@ type sessionInfo struct {
 	User struct {
 		EmailAddress string `json:"emailAddress"`
 	} `json:"user"`
+	AvailableProviders []struct {
+		ProviderID       int64  `json:"providerId"`
+		PublicProviderID string `json:"publicProviderId"`
+		Name             string `json:"name"`
+	} `json:"availableProviders"`
+}
+
+// Provider is a team the signed-in account can access.
+type Provider struct {
+	ProviderID       int64  `json:"provider_id"`
+	PublicProviderID string `json:"public_provider_id"`
+	Name             string `json:"name,omitempty"`
 }
 
 type authOptionsResponse struct {
@@ -370,7 +403,9 @@ func parseSigninInitResponse(data []byte) (*signinInitResponse, error) {
 func NewClient(session *AuthSession) *Client {
 	return &Client{
 		httpClient:         session.Client,
+		reauthenticate:     session.Reauthenticate,
 		baseURL:            appStoreBaseURL + "/iris/v1",
+		userAgent:          resolveUserAgent(session),
 		minRequestInterval: resolveWebMinRequestInterval(),
 	}
 }
@@ -926,6 +961,86 @@ func getSessionInfo(ctx context.Context, client *http.Client) (*sessionInfo, err
 	return &result, nil
 }
 
+// ListProviders returns the teams available to the session's account. When
+// the session response lists none, the session's current provider is returned.
+func ListProviders(ctx context.Context, session *AuthSession) ([]Provider, error) {
+	if session == nil || session.Client == nil {
+		return nil, fmt.Errorf("session is required")
+	}
+	info, err := getSessionInfo(ctx, session.Client)
+	if err != nil {
+		return nil, err
+	}
+	providers := make([]Provider, 0, len(info.AvailableProviders))
+	for _, available := range info.AvailableProviders {
+		publicID := strings.TrimSpace(available.PublicProviderID)
+		if publicID == "" {
+			continue
+		}
+		providers = append(providers, Provider{
+			ProviderID:       available.ProviderID,
+			PublicProviderID: publicID,
+			Name:             strings.TrimSpace(available.Name),
+		})
+	}
+	if len(providers) == 0 {
+		if publicID := strings.TrimSpace(info.Provider.PublicProviderID); publicID != "" {
+			providers = append(providers, Provider{
+				ProviderID:       info.Provider.ProviderID,
+				PublicProviderID: publicID,
+				Name:             strings.TrimSpace(info.Provider.Name),
+			})
+		}
+	}
+	return providers, nil
+}
+
+// SwitchProvider makes providerID the session's current team, so later
+// requests that act on the current team, such as Xcode Cloud usage, use it. The
+// session's provider fields are refreshed from the updated session info.
+func SwitchProvider(ctx context.Context, session *AuthSession, providerID int64) error {
+	if session == nil || session.Client == nil {
+		return fmt.Errorf("session is required")
+	}
+	body, err := json.Marshal(map[string]any{
+		"provider": map[string]int64{"providerId": providerID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider switch payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", olympusSessionURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	setModifiedCookieHeader(session.Client, req)
+
+	resp, err := session.Client.Do(req)
+	if err != nil {
+		logWebAuthHTTP("switch_provider", req, nil, nil, err)
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, _ := io.ReadAll(resp.Body)
+	logWebAuthHTTP("switch_provider", req, resp, respBody, nil)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("switch provider failed with status %d", resp.StatusCode)
+	}
+
+	info, err := getSessionInfo(ctx, session.Client)
+	if err != nil {
+		return err
+	}
+	if info.Provider.ProviderID != providerID {
+		return fmt.Errorf("switch provider failed: session is still on provider %d", info.Provider.ProviderID)
+	}
+	session.ProviderID = info.Provider.ProviderID
+	session.PublicProviderID = strings.TrimSpace(info.Provider.PublicProviderID)
+	session.TeamID = fmt.Sprintf("%d", info.Provider.ProviderID)
+	return nil
+}
+
 func isSessionInfoAuthExpired(err error) bool {
 	var statusErr *sessionInfoStatusError
 	if !errors.As(err, &statusErr) {
@@ -1193,10 +1308,96 @@ func (c *Client) waitForRateLimit(ctx context.Context) error {
 	}
 }
 
+// ServerDate returns the Date header of the most recent response, or the zero
+// time when no response carried a parseable Date header.
+func (c *Client) ServerDate() time.Time {
+	c.serverDateMu.Lock()
+	defer c.serverDateMu.Unlock()
+	return c.serverDate
+}
+
+func (c *Client) recordServerDate(value string) {
+	date, err := http.ParseTime(strings.TrimSpace(value))
+	if err != nil {
+		return
+	}
+	c.serverDateMu.Lock()
+	c.serverDate = date
+	c.serverDateMu.Unlock()
+}
+
+// currentHTTPClient returns the HTTP client for the next request. It changes
+// when the session is re-authenticated.
+func (c *Client) currentHTTPClient() *http.Client {
+	c.httpClientMu.Lock()
+	defer c.httpClientMu.Unlock()
+	return c.httpClient
+}
+
+// isSessionRejectedError reports whether err means the web session may no
+// longer be valid: a 401, or an HTML sign-in page in place of JSON. Expired
+// sessions are sometimes answered with 403 as well, so a 403 counts only when
+// forbiddenRejects is set: a 403 is also how the API refuses a write the role
+// may not make, and how the usage summary reports a team without Xcode Cloud.
+func isSessionRejectedError(err error, forbiddenRejects bool) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status == http.StatusUnauthorized ||
+			(forbiddenRejects && apiErr.Status == http.StatusForbidden)
+	}
+	var nonJSONErr *NonJSONResponseError
+	return errors.As(err, &nonJSONErr)
+}
+
+// doRequest performs an API request. When the session was rejected and the
+// client has a reauthenticate hook, the request is retried once with the
+// replacement session; if that fails too, the original error is returned.
+// A 403 is only treated as a rejected session for GET requests.
 func (c *Client) doRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	return c.doRequestWithRetry(ctx, method, path, body, method == http.MethodGet)
+}
+
+// doAccessProbeRequest is doRequest for the usage summary probe, whose 403
+// means the team has no Xcode Cloud access, so it is never retried.
+func (c *Client) doAccessProbeRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	return c.doRequestWithRetry(ctx, method, path, body, false)
+}
+
+func (c *Client) doRequestWithRetry(ctx context.Context, method, path string, body any, retryForbidden bool) ([]byte, error) {
+	failedClient := c.currentHTTPClient()
+	respBody, err := c.doRequestOnce(ctx, method, path, body)
+	if err == nil || c.reauthenticate == nil || !isSessionRejectedError(err, retryForbidden) {
+		return respBody, err
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	session, reauthErr := c.reauthenticate(ctx)
+	if reauthErr != nil {
+		return nil, errors.Join(err, fmt.Errorf("re-authentication failed: %w", reauthErr))
+	}
+	if session == nil || session.Client == nil {
+		return nil, err
+	}
+	c.httpClientMu.Lock()
+	if c.httpClient == failedClient {
+		c.httpClient = session.Client
+	}
+	c.httpClientMu.Unlock()
+	return c.doRequestOnce(ctx, method, path, body)
+}
+
+func (c *Client) doRequestOnce(ctx context.Context, method, path string, body any) ([]byte, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	if err := ctx.Err(); err != nil {
+		// Skip the request entirely once the caller has given up.
+		return nil, err
+	}
 	if err := c.waitForRateLimit(ctx); err != nil {
 		return nil, err
 	}
@@ -1220,14 +1421,21 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
 	req.Header.Set("Origin", appStoreBaseURL)
 	req.Header.Set("Referer", appStoreBaseURL+"/")
-	setModifiedCookieHeader(c.httpClient, req)
+	userAgent := c.userAgent
+	if userAgent == "" {
+		userAgent = resolveUserAgent(nil)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	httpClient := c.currentHTTPClient()
+	setModifiedCookieHeader(httpClient, req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		logWebAuthHTTP("iris_request", req, nil, nil, err)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	c.recordServerDate(resp.Header.Get("Date"))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -1235,6 +1443,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 	logWebAuthHTTP("iris_request", req, resp, respBody, nil)
+	recordRawResponse(ctx, method, path, resp.StatusCode, respBody)
 
 	appleRequestID := extractAppleRequestID(resp.Header)
 	correlationKey := strings.TrimSpace(resp.Header.Get("X-Apple-Jingle-Correlation-Key"))
@@ -1247,5 +1456,8 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 			rawBody:        respBody,
 		}
 	}
+	if err := checkJSONResponse(resp.StatusCode, resp.Header.Get("Content-Type"), respBody); err != nil {
+		return nil, err
+	}
 	return respBody, nil
 }
@@ -11,10 +11,14 @@ import (
 	"crypto/x509/pkix"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"io"
 	"log/slog"
 	"math/big"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -363,3 +367,252 @@ func generateSelfSignedCertPEM(t *testing.T) ([]byte, *x509.Certificate) {
 	}
 	return pem.EncodeToMemory(block), cert
 }
+
+type sessionInfoTransport func(*http.Request) (*http.Response, error)
+
+func (f sessionInfoTransport) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestListProviders(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Provider
+	}{
+		{
+			name: "available providers",
+			body: `{"provider":{"providerId":1,"publicProviderId":"team-a","name":"A"},"availableProviders":[{"providerId":1,"publicProviderId":"team-a","name":"A"},{"providerId":2,"publicProviderId":"team-b","name":" B "},{"providerId":3,"publicProviderId":""}]}`,
+			want: []Provider{{ProviderID: 1, PublicProviderID: "team-a", Name: "A"}, {ProviderID: 2, PublicProviderID: "team-b", Name: "B"}},
+		},
+		{
+			name: "falls back to current provider",
+			body: `{"provider":{"providerId":1,"publicProviderId":"team-a","name":"A"}}`,
+			want: []Provider{{ProviderID: 1, PublicProviderID: "team-a", Name: "A"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &AuthSession{Client: &http.Client{Transport: sessionInfoTransport(func(req *http.Request) (*http.Response, error) {
+				if req.URL.String() != olympusSessionURL {
+					t.Fatalf("unexpected request URL %q", req.URL.String())
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+					Request:    req,
+				}, nil
+			})}}
+			got, err := ListProviders(context.Background(), session)
+			if err != nil {
+				t.Fatalf("ListProviders() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListProviders() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("provider %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSwitchProvider(t *testing.T) {
+	var posted string
+	session := &AuthSession{PublicProviderID: "team-a", ProviderID: 1, Client: &http.Client{Transport: sessionInfoTransport(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != olympusSessionURL {
+			t.Fatalf("unexpected request URL %q", req.URL.String())
+		}
+		body := `{}`
+		if req.Method == http.MethodPost {
+			data, _ := io.ReadAll(req.Body)
+			posted = string(data)
+		} else {
+			body = `{"provider":{"providerId":2,"publicProviderId":"team-b","name":"B"}}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}}
+
+	if err := SwitchProvider(context.Background(), session, 2); err != nil {
+		t.Fatalf("SwitchProvider() error = %v", err)
+	}
+	if posted != `{"provider":{"providerId":2}}` {
+		t.Fatalf("unexpected switch payload %q", posted)
+	}
+	if session.ProviderID != 2 || session.PublicProviderID != "team-b" || session.TeamID != "2" {
+		t.Fatalf("expected session on team-b, got %+v", session)
+	}
+
+	if err := SwitchProvider(context.Background(), session, 3); err == nil || !strings.Contains(err.Error(), "still on provider 2") {
+		t.Fatalf("expected unchanged provider error, got %v", err)
+	}
+}
+
+func sessionTestClient(t *testing.T, serverURL, token string) *http.Client {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookiejar.New() error: %v", err)
+	}
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatalf("url.Parse() error: %v", err)
+	}
+	jar.SetCookies(parsed, []*http.Cookie{{Name: "session", Value: token}})
+	return &http.Client{Jar: jar}
+}
+
+func TestDoRequestReauthenticatesOnceAfterRejectedSession(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, _ := r.Cookie("session")
+		requests = append(requests, cookie.Value)
+		if cookie.Value != "fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	reauthCalls := 0
+	client := &Client{
+		httpClient: sessionTestClient(t, server.URL, "expired"),
+		baseURL:    server.URL,
+		reauthenticate: func(ctx context.Context) (*AuthSession, error) {
+			reauthCalls++
+			return &AuthSession{Client: sessionTestClient(t, server.URL, "fresh")}, nil
+		},
+	}
+
+	body, err := client.doRequest(context.Background(), http.MethodGet, "/probe", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("unexpected body %q", body)
+	}
+	if reauthCalls != 1 || strings.Join(requests, ",") != "expired,fresh" {
+		t.Fatalf("expected one re-authentication and retry, got %d calls and requests %v", reauthCalls, requests)
+	}
+
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/probe", nil); err != nil {
+		t.Fatalf("second doRequest() error: %v", err)
+	}
+	if reauthCalls != 1 {
+		t.Fatalf("expected later requests to reuse the fresh session, got %d re-authentications", reauthCalls)
+	}
+}
+
+func TestDoRequestReturnsOriginalErrorWhenReauthenticationFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: sessionTestClient(t, server.URL, "expired"),
+		baseURL:    server.URL,
+		reauthenticate: func(ctx context.Context) (*AuthSession, error) {
+			return nil, errors.New("password is required")
+		},
+	}
+
+	_, err := client.doRequest(context.Background(), http.MethodGet, "/probe", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+		t.Fatalf("expected the original 401 error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "re-authentication failed: password is required") {
+		t.Fatalf("expected re-authentication failure in error, got %v", err)
+	}
+}
+
+func TestDoRequestReauthenticatesOnForbiddenGet(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, _ := r.Cookie("session")
+		requests = append(requests, cookie.Value)
+		if cookie.Value == "expired" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: sessionTestClient(t, server.URL, "expired"),
+		baseURL:    server.URL,
+		reauthenticate: func(ctx context.Context) (*AuthSession, error) {
+			return &AuthSession{Client: sessionTestClient(t, server.URL, "fresh")}, nil
+		},
+	}
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/probe", nil); err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	if strings.Join(requests, ",") != "expired,fresh" {
+		t.Fatalf("expected the 403 to be retried with the fresh session, got %v", requests)
+	}
+}
+
+func TestDoRequestDoesNotRetryForbiddenWrite(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	reauthCalls := 0
+	client := &Client{
+		httpClient: sessionTestClient(t, server.URL, "expired"),
+		baseURL:    server.URL,
+		reauthenticate: func(ctx context.Context) (*AuthSession, error) {
+			reauthCalls++
+			return &AuthSession{Client: sessionTestClient(t, server.URL, "fresh")}, nil
+		},
+	}
+	_, err := client.doRequest(context.Background(), http.MethodPost, "/items", map[string]string{"name": "API_URL"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("expected the 403 error, got %v", err)
+	}
+	if reauthCalls != 0 || len(requests) != 1 {
+		t.Fatalf("expected a single POST without re-authentication, got %d calls and requests %v", reauthCalls, requests)
+	}
+}
+
+func TestGetCIUsageSummaryForbiddenDoesNotReauthenticate(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	reauthCalls := 0
+	client := &Client{
+		httpClient: sessionTestClient(t, server.URL, "valid"),
+		baseURL:    server.URL,
+		reauthenticate: func(ctx context.Context) (*AuthSession, error) {
+			reauthCalls++
+			return &AuthSession{Client: sessionTestClient(t, server.URL, "fresh")}, nil
+		},
+	}
+	_, err := client.GetCIUsageSummary(context.Background(), "team-uuid")
+	if !errors.Is(err, ErrNoCIAccess) {
+		t.Fatalf("expected ErrNoCIAccess, got %v", err)
+	}
+	if reauthCalls != 0 || requests != 1 {
+		t.Fatalf("expected the probe 403 without re-authentication, got %d calls and %d requests", reauthCalls, requests)
+	}
+}
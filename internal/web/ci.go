@@ -4,20 +4,99 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 )
 
+const (
+	defaultCIBaseURL = appStoreBaseURL + "/ci/api"
+	ciBaseURLEnv     = "ASC_CI_BASE_URL"
+)
+
+// CIClientOption configures a client created by NewCIClient.
+type CIClientOption func(*ciClientOptions)
+
+type ciClientOptions struct {
+	baseURL       string
+	baseURLSource string
+}
+
+// WithCIBaseURL overrides the CI API base URL, for example to point at a proxy
+// or replay server. It takes precedence over ASC_CI_BASE_URL.
+func WithCIBaseURL(baseURL string) CIClientOption {
+	return func(opts *ciClientOptions) {
+		opts.baseURL = strings.TrimSpace(baseURL)
+		opts.baseURLSource = "WithCIBaseURL"
+	}
+}
+
 // NewCIClient creates a CI API client reusing an authenticated web session.
-// The CI API lives at /ci/api and uses the same session cookies as IRIS.
-func NewCIClient(session *AuthSession) *Client {
-	return &Client{
+// The CI API lives at /ci/api and uses the same session cookies as IRIS. The
+// base URL can be overridden with ASC_CI_BASE_URL or WithCIBaseURL; an invalid
+// override is reported by the client's first request.
+func NewCIClient(session *AuthSession, options ...CIClientOption) *Client {
+	opts := ciClientOptions{
+		baseURL:       strings.TrimSpace(os.Getenv(ciBaseURLEnv)),
+		baseURLSource: ciBaseURLEnv,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	client := &Client{
 		httpClient:         session.Client,
-		baseURL:            appStoreBaseURL + "/ci/api",
+		reauthenticate:     session.Reauthenticate,
+		baseURL:            defaultCIBaseURL,
+		userAgent:          resolveUserAgent(session),
 		minRequestInterval: resolveWebMinRequestInterval(),
 	}
+	if opts.baseURL != "" {
+		baseURL, err := normalizeCIBaseURL(opts.baseURL)
+		if err != nil {
+			client.configErr = fmt.Errorf("invalid CI base URL from %s: %w", opts.baseURLSource, err)
+		} else {
+			client.baseURL = baseURL
+		}
+	}
+	return client
+}
+
+// normalizeCIBaseURL checks that raw is an absolute http(s) URL without a
+// query or fragment and trims any trailing slash.
+func normalizeCIBaseURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid URL: %w", raw, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("%q must use http or https", raw)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("%q has no host", raw)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("%q must not include a query or fragment", raw)
+	}
+	return strings.TrimRight(raw, "/"), nil
+}
+
+// ciAccessError marks a 403 from the usage summary as ErrNoCIAccess. The
+// summary is the access probe: every team with Xcode Cloud can read it, so a
+// 403 there means the team has no Xcode Cloud or the account's role cannot
+// see it. Other CI endpoints keep the plain *APIError, because a 403 there can
+// also mean an expired session or a write the role may not make.
+func ciAccessError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusForbidden {
+		return fmt.Errorf("%w: %w", ErrNoCIAccess, err)
+	}
+	return err
 }
 
 // NOTE: The CI API (/ci/api) uses snake_case JSON keys and query parameters,
@@ -70,11 +149,13 @@ type CIProductUsage struct {
 
 // CIUsageInfo holds metadata about the usage response.
 type CIUsageInfo struct {
-	StartMonth         int                `json:"start_month,omitempty"`
-	StartYear          int                `json:"start_year,omitempty"`
-	EndMonth           int                `json:"end_month,omitempty"`
-	EndYear            int                `json:"end_year,omitempty"`
-	CanViewAllProducts bool               `json:"can_view_all_products,omitempty"`
+	StartMonth int `json:"start_month,omitempty"`
+	StartYear  int `json:"start_year,omitempty"`
+	EndMonth   int `json:"end_month,omitempty"`
+	EndYear    int `json:"end_year,omitempty"`
+	// CanViewAllProducts is nil when the API does not report it; false means
+	// per-product usage only covers the products this account can see.
+	CanViewAllProducts *bool              `json:"can_view_all_products,omitempty"`
 	Current            CIUsageInfoCurrent `json:"current,omitempty"`
 	Previous           CIUsageInfoCurrent `json:"previous,omitempty"`
 	Links              map[string]string  `json:"links,omitempty"`
@@ -133,10 +214,12 @@ type CIWorkflow struct {
 	Content CIWorkflowContent `json:"content"`
 }
 
-// CIWorkflowContent holds the workflow's configuration including its name.
+// CIWorkflowContent holds the workflow's configuration including its name and state.
 type CIWorkflowContent struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Locked      bool   `json:"locked,omitempty"`
 }
 
 // CIWorkflowListResponse is the response from the workflows endpoint.
@@ -198,12 +281,12 @@ func (d *CIDayUsage) UnmarshalJSON(data []byte) error {
 func (c *Client) GetCIUsageSummary(ctx context.Context, teamID string) (*CIUsageSummary, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/usage/summary"
-	body, err := c.doRequest(ctx, "GET", path, nil)
+	body, err := c.doAccessProbeRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return nil, err
+		return nil, ciAccessError(err)
 	}
 	var result CIUsageSummary
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -216,7 +299,7 @@ func (c *Client) GetCIUsageSummary(ctx context.Context, teamID string) (*CIUsage
 func (c *Client) GetCIUsageMonths(ctx context.Context, teamID string, startMonth, startYear, endMonth, endYear int) (*CIUsageMonths, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	query := url.Values{}
 	query.Set("start_month", strconv.Itoa(startMonth))
@@ -239,11 +322,11 @@ func (c *Client) GetCIUsageMonths(ctx context.Context, teamID string, startMonth
 func (c *Client) GetCIUsageDays(ctx context.Context, teamID, productID, start, end string) (*CIUsageDays, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return nil, fmt.Errorf("product id is required")
+		return nil, ErrMissingProductID
 	}
 	start = strings.TrimSpace(start)
 	if start == "" {
@@ -272,7 +355,7 @@ func (c *Client) GetCIUsageDays(ctx context.Context, teamID, productID, start, e
 func (c *Client) GetCIUsageDaysOverall(ctx context.Context, teamID, start, end string) (*CIUsageDays, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	start = strings.TrimSpace(start)
 	if start == "" {
@@ -303,7 +386,7 @@ func (c *Client) GetCIUsageDaysOverall(ctx context.Context, teamID, start, end s
 func (c *Client) ListCIProducts(ctx context.Context, teamID string) (*CIProductListResponse, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	query := url.Values{}
 	query.Set("limit", "100")
@@ -365,17 +448,27 @@ type CIEncryptionKeyResponse struct {
 
 // ListCIWorkflows lists Xcode Cloud workflows for a product.
 func (c *Client) ListCIWorkflows(ctx context.Context, teamID, productID string) (*CIWorkflowListResponse, error) {
+	return c.listCIWorkflows(ctx, teamID, productID, false)
+}
+
+// ListCIWorkflowsIncludingDeleted lists Xcode Cloud workflows for a product,
+// including workflows that have since been deleted.
+func (c *Client) ListCIWorkflowsIncludingDeleted(ctx context.Context, teamID, productID string) (*CIWorkflowListResponse, error) {
+	return c.listCIWorkflows(ctx, teamID, productID, true)
+}
+
+func (c *Client) listCIWorkflows(ctx context.Context, teamID, productID string, includeDeleted bool) (*CIWorkflowListResponse, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return nil, fmt.Errorf("product id is required")
+		return nil, ErrMissingProductID
 	}
 	query := url.Values{}
 	query.Set("limit", "100")
-	query.Set("include_deleted", "false")
+	query.Set("include_deleted", strconv.FormatBool(includeDeleted))
 	path := queryPath("/teams/"+url.PathEscape(teamID)+"/products/"+url.PathEscape(productID)+"/workflows-v15", query)
 	body, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -393,15 +486,15 @@ func (c *Client) ListCIWorkflows(ctx context.Context, teamID, productID string)
 func (c *Client) GetCIWorkflow(ctx context.Context, teamID, productID, workflowID string) (*CIWorkflowFull, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return nil, fmt.Errorf("product id is required")
+		return nil, ErrMissingProductID
 	}
 	workflowID = strings.TrimSpace(workflowID)
 	if workflowID == "" {
-		return nil, fmt.Errorf("workflow id is required")
+		return nil, ErrMissingWorkflowID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/products/" + url.PathEscape(productID) + "/workflows-v15/" + url.PathEscape(workflowID)
 	body, err := c.doRequest(ctx, "GET", path, nil)
@@ -420,15 +513,15 @@ func (c *Client) GetCIWorkflow(ctx context.Context, teamID, productID, workflowI
 func (c *Client) UpdateCIWorkflow(ctx context.Context, teamID, productID, workflowID string, content json.RawMessage) error {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return fmt.Errorf("team id is required")
+		return ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return fmt.Errorf("product id is required")
+		return ErrMissingProductID
 	}
 	workflowID = strings.TrimSpace(workflowID)
 	if workflowID == "" {
-		return fmt.Errorf("workflow id is required")
+		return ErrMissingWorkflowID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/products/" + url.PathEscape(productID) + "/workflows-v15/" + url.PathEscape(workflowID)
 	_, err := c.doRequest(ctx, "PUT", path, content)
@@ -446,6 +539,9 @@ func (c *Client) GetCIEncryptionKey(ctx context.Context) (*CIEncryptionKeyRespon
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode ci encryption key: %w", err)
 	}
+	if err := ValidateCIEncryptionKey(result.Key); err != nil {
+		return nil, fmt.Errorf("invalid ci encryption key: %w", err)
+	}
 	return &result, nil
 }
 
@@ -481,11 +577,11 @@ type CIProductEnvVarRequest struct {
 func (c *Client) ListCIProductEnvVars(ctx context.Context, teamID, productID string) ([]CIProductEnvironmentVariable, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return nil, fmt.Errorf("product id is required")
+		return nil, ErrMissingProductID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/products/" + url.PathEscape(productID) + "/product-environment-variables"
 	body, err := c.doRequest(ctx, "GET", path, nil)
@@ -504,15 +600,15 @@ func (c *Client) ListCIProductEnvVars(ctx context.Context, teamID, productID str
 func (c *Client) SetCIProductEnvVar(ctx context.Context, teamID, productID, varID string, req CIProductEnvVarRequest) (*CIProductEnvironmentVariable, error) {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return nil, fmt.Errorf("team id is required")
+		return nil, ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return nil, fmt.Errorf("product id is required")
+		return nil, ErrMissingProductID
 	}
 	varID = strings.TrimSpace(varID)
 	if varID == "" {
-		return nil, fmt.Errorf("variable id is required")
+		return nil, ErrMissingVariableID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/products/" + url.PathEscape(productID) + "/product-environment-variables/" + url.PathEscape(varID)
 	body, err := c.doRequest(ctx, "PUT", path, req)
@@ -531,15 +627,15 @@ func (c *Client) SetCIProductEnvVar(ctx context.Context, teamID, productID, varI
 func (c *Client) DeleteCIProductEnvVar(ctx context.Context, teamID, productID, varID string) error {
 	teamID = strings.TrimSpace(teamID)
 	if teamID == "" {
-		return fmt.Errorf("team id is required")
+		return ErrMissingTeamID
 	}
 	productID = strings.TrimSpace(productID)
 	if productID == "" {
-		return fmt.Errorf("product id is required")
+		return ErrMissingProductID
 	}
 	varID = strings.TrimSpace(varID)
 	if varID == "" {
-		return fmt.Errorf("variable id is required")
+		return ErrMissingVariableID
 	}
 	path := "/teams/" + url.PathEscape(teamID) + "/products/" + url.PathEscape(productID) + "/product-environment-variables/" + url.PathEscape(varID)
 	_, err := c.doRequest(ctx, "DELETE", path, nil)
@@ -565,31 +661,91 @@ func ExtractEnvVars(content json.RawMessage) ([]CIEnvironmentVariable, error) {
 
 // SetEnvVars sets environment_variables in raw workflow content, preserving other fields.
 func SetEnvVars(content json.RawMessage, vars []CIEnvironmentVariable) (json.RawMessage, error) {
-	var m map[string]json.RawMessage
-	if err := json.Unmarshal(content, &m); err != nil {
-		return nil, fmt.Errorf("failed to decode workflow content: %w", err)
-	}
-	if m == nil {
-		return nil, fmt.Errorf("failed to decode workflow content: expected JSON object")
-	}
-	varsJSON, err := json.Marshal(vars)
-	if err != nil {
+	var varsJSON bytes.Buffer
+	encoder := json.NewEncoder(&varsJSON)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(vars); err != nil {
 		return nil, fmt.Errorf("failed to marshal environment_variables: %w", err)
 	}
-	m["environment_variables"] = varsJSON
-	// Use compact JSON to match API expectations.
-	result, err := json.Marshal(m)
+	updated, err := setTopLevelJSONField(content, "environment_variables", bytes.TrimSpace(varsJSON.Bytes()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal workflow content: %w", err)
+		return nil, fmt.Errorf("failed to decode workflow content: %w", err)
 	}
-	// Compact to remove any extra whitespace.
+	// Compact to match API expectations; unlike re-marshaling, this keeps key
+	// order and string escapes in every other field exactly as received.
 	var buf bytes.Buffer
-	if err := json.Compact(&buf, result); err != nil {
+	if err := json.Compact(&buf, updated); err != nil {
 		return nil, fmt.Errorf("failed to compact workflow content: %w", err)
 	}
 	return buf.Bytes(), nil
 }
 
+// setTopLevelJSONField replaces the value of key in a JSON object by splicing
+// bytes, so every other field is preserved verbatim. The key is appended when
+// absent.
+func setTopLevelJSONField(content json.RawMessage, key string, value []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected JSON object")
+	}
+
+	type span struct{ start, end int64 }
+	var spans []span
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+		afterKey := decoder.InputOffset()
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+		if name != key {
+			continue
+		}
+		end := decoder.InputOffset()
+		start := afterKey + int64(bytes.Index(content[afterKey:end], raw))
+		spans = append(spans, span{start: start, end: start + int64(len(raw))})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON object")
+	}
+
+	if len(spans) == 0 {
+		closing := bytes.LastIndexByte(content, '}')
+		var out bytes.Buffer
+		out.Write(content[:closing])
+		if bytes.IndexByte(bytes.TrimSpace(content[:closing]), ':') >= 0 {
+			out.WriteByte(',')
+		}
+		keyJSON, _ := json.Marshal(key)
+		out.Write(keyJSON)
+		out.WriteByte(':')
+		out.Write(value)
+		out.Write(content[closing:])
+		return out.Bytes(), nil
+	}
+
+	var out bytes.Buffer
+	previous := int64(0)
+	for _, field := range spans {
+		out.Write(content[previous:field.start])
+		out.Write(value)
+		previous = field.end
+	}
+	out.Write(content[previous:])
+	return out.Bytes(), nil
+}
+
 // ExtractWorkflowConfig extracts known workflow configuration fields from raw workflow content.
 func ExtractWorkflowConfig(content json.RawMessage) (*CIWorkflowConfig, error) {
 	var m map[string]json.RawMessage
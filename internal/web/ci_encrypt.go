@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 
 	"golang.org/x/crypto/hkdf"
 )
@@ -26,21 +27,9 @@ import (
 //  7. Base64 encode
 func ECIESEncrypt(serverKeyB64 string, plaintext string) (string, error) {
 	// 1. Decode server public key (64 bytes raw) and build uncompressed point
-	serverKeyRaw, err := base64.StdEncoding.DecodeString(serverKeyB64)
+	serverPub, err := parseCIEncryptionKey(serverKeyB64)
 	if err != nil {
-		return "", fmt.Errorf("decode server key: %w", err)
-	}
-	if len(serverKeyRaw) != 64 {
-		return "", fmt.Errorf("expected 64-byte server key, got %d", len(serverKeyRaw))
-	}
-	// Prepend 0x04 for uncompressed EC point format
-	uncompressed := make([]byte, 65)
-	uncompressed[0] = 0x04
-	copy(uncompressed[1:], serverKeyRaw)
-
-	serverPub, err := ecdh.P256().NewPublicKey(uncompressed)
-	if err != nil {
-		return "", fmt.Errorf("import server public key: %w", err)
+		return "", err
 	}
 
 	// 2. Generate ephemeral ECDH P-256 key pair
@@ -94,3 +83,47 @@ func ECIESEncrypt(serverKeyB64 string, plaintext string) (string, error) {
 	// 7. Base64 encode
 	return base64.StdEncoding.EncodeToString(output), nil
 }
+
+// ValidateCIEncryptionKey reports whether key is a server encryption key that
+// ECIESEncrypt can use, so format changes surface before any secret is sent.
+func ValidateCIEncryptionKey(key string) error {
+	_, err := parseCIEncryptionKey(key)
+	return err
+}
+
+// parseCIEncryptionKey decodes the server key as a P-256 point. The expected
+// form is 64 raw x||y bytes in standard base64; an uncompressed 65-byte point
+// and unpadded or URL-safe base64 are accepted too.
+func parseCIEncryptionKey(key string) (*ecdh.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("unexpected encryption key format: key is empty")
+	}
+	var raw []byte
+	var decodeErr error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		raw, decodeErr = encoding.DecodeString(key)
+		if decodeErr == nil {
+			break
+		}
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("unexpected encryption key format: value is not base64: %w", decodeErr)
+	}
+
+	var uncompressed []byte
+	switch {
+	case len(raw) == 64:
+		// Prepend 0x04 for uncompressed EC point format
+		uncompressed = append([]byte{0x04}, raw...)
+	case len(raw) == 65 && raw[0] == 0x04:
+		uncompressed = raw
+	default:
+		return nil, fmt.Errorf("unexpected encryption key format, value %d bytes (expected a 64-byte P-256 public key)", len(raw))
+	}
+	serverPub, err := ecdh.P256().NewPublicKey(uncompressed)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected encryption key format: not a P-256 public key: %w", err)
+	}
+	return serverPub, nil
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/ecdh"
 	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -93,3 +94,47 @@ func TestECIESEncrypt_ProduceCiphertextForLiveTest(t *testing.T) {
 	t.Logf("Secret env var for live test:\n%s", string(jsonBytes))
 	t.Logf("\nCiphertext to use: %s", ct)
 }
+
+func TestValidateCIEncryptionKey(t *testing.T) {
+	serverKeyB64 := "0xm9f0gX7lzArxrChNrDVUR3MKxueb1DdheWBeLndCVOqoiEsT2jxqZW6cHsIuDGDykvYWgQ1qaPBSxCNFXEUg=="
+	raw, err := base64.StdEncoding.DecodeString(serverKeyB64)
+	if err != nil {
+		t.Fatalf("decode test key: %v", err)
+	}
+	offCurve := make([]byte, 64)
+	offCurve[63] = 1
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr string
+	}{
+		{name: "raw x||y", key: serverKeyB64},
+		{name: "uncompressed point", key: base64.StdEncoding.EncodeToString(append([]byte{0x04}, raw...))},
+		{name: "unpadded", key: base64.RawStdEncoding.EncodeToString(raw)},
+		{name: "empty", key: " ", wantErr: "unexpected encryption key format: key is empty"},
+		{name: "not base64", key: "not base64!", wantErr: "unexpected encryption key format: value is not base64"},
+		{name: "wrong length", key: base64.StdEncoding.EncodeToString([]byte("short-key")), wantErr: "unexpected encryption key format, value 9 bytes"},
+		{name: "off curve", key: base64.StdEncoding.EncodeToString(offCurve), wantErr: "unexpected encryption key format: not a P-256 public key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCIEncryptionKey(tt.key)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateCIEncryptionKey() error = %v", err)
+				}
+				if _, err := ECIESEncrypt(tt.key, "value"); err != nil {
+					t.Fatalf("ECIESEncrypt() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateCIEncryptionKey() error = %v, want %q", err, tt.wantErr)
+			}
+			if _, err := ECIESEncrypt(tt.key, "value"); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ECIESEncrypt() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetCIUsageSummaryParsesResponse(t *testing.T) {
@@ -61,14 +63,35 @@ func TestGetCIUsageSummaryParsesResponse(t *testing.T) {
 	}
 }
 
+func TestClientServerDateRecordsResponseDateHeader(t *testing.T) {
+	serverDate := time.Date(2026, time.March, 2, 10, 30, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverDate.Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"plan":{"name":"Plan"}}`))
+	}))
+	defer server.Close()
+
+	client := testWebClient(server)
+	if !client.ServerDate().IsZero() {
+		t.Fatalf("expected zero server date before any request, got %v", client.ServerDate())
+	}
+	if _, err := client.GetCIUsageSummary(context.Background(), "team-uuid"); err != nil {
+		t.Fatalf("GetCIUsageSummary() error = %v", err)
+	}
+	if got := client.ServerDate(); !got.Equal(serverDate) {
+		t.Fatalf("ServerDate() = %v, want %v", got, serverDate)
+	}
+}
+
 func TestGetCIUsageSummaryRejectsEmptyTeamID(t *testing.T) {
 	client := &Client{httpClient: http.DefaultClient, baseURL: "http://localhost"}
 	_, err := client.GetCIUsageSummary(context.Background(), "")
 	if err == nil {
 		t.Fatal("expected error for empty team ID")
 	}
-	if !strings.Contains(err.Error(), "team id is required") {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, ErrMissingTeamID) {
+		t.Fatalf("expected ErrMissingTeamID, got %v", err)
 	}
 }
 
@@ -132,7 +155,7 @@ func TestGetCIUsageMonthsParsesProductUsage(t *testing.T) {
 	if pu.ProductID != "prod-1" || pu.UsageInMinutes != 120 || pu.NumberOfBuilds != 3 || pu.PreviousUsageInMinutes != 80 || pu.PreviousNumberOfBuilds != 2 {
 		t.Fatalf("unexpected product usage: %+v", pu)
 	}
-	if !result.Info.CanViewAllProducts || result.Info.Current.Used != 120 || result.Info.Previous.Used != 80 {
+	if result.Info.CanViewAllProducts == nil || !*result.Info.CanViewAllProducts || result.Info.Current.Used != 120 || result.Info.Previous.Used != 80 {
 		t.Fatalf("unexpected info: %+v", result.Info)
 	}
 }
@@ -143,8 +166,8 @@ func TestGetCIUsageMonthsRejectsEmptyTeamID(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for empty team ID")
 	}
-	if !strings.Contains(err.Error(), "team id is required") {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, ErrMissingTeamID) {
+		t.Fatalf("expected ErrMissingTeamID, got %v", err)
 	}
 }
 
@@ -353,8 +376,8 @@ func TestListCIProductsRejectsEmptyTeamID(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for empty team ID")
 	}
-	if !strings.Contains(err.Error(), "team id is required") {
-		t.Fatalf("unexpected error: %v", err)
+	if !errors.Is(err, ErrMissingTeamID) {
+		t.Fatalf("expected ErrMissingTeamID, got %v", err)
 	}
 }
 
@@ -379,6 +402,54 @@ func TestGetCIUsageSummaryHandles4xxError(t *testing.T) {
 	}
 }
 
+func TestGetCIUsageSummaryRejectsHTMLResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<!DOCTYPE html>\n<html><head><title>Sign In</title></head></html>"))
+	}))
+	defer server.Close()
+
+	client := testWebClient(server)
+	_, err := client.GetCIUsageSummary(context.Background(), "team-uuid")
+	var nonJSON *NonJSONResponseError
+	if !errors.As(err, &nonJSON) {
+		t.Fatalf("expected NonJSONResponseError, got %T: %v", err, err)
+	}
+	if nonJSON.ContentType != "text/html" {
+		t.Fatalf("expected text/html content type, got %q", nonJSON.ContentType)
+	}
+	if nonJSON.FirstLine != "<!DOCTYPE html>" {
+		t.Fatalf("expected first body line in error, got %q", nonJSON.FirstLine)
+	}
+	if !strings.Contains(err.Error(), "<!DOCTYPE html>") {
+		t.Fatalf("expected first line in error message, got %q", err.Error())
+	}
+}
+
+func TestCheckJSONResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     bool
+	}{
+		{name: "json", contentType: "application/json", body: `{"ok":true}`},
+		{name: "empty body", contentType: "text/html", body: "  "},
+		{name: "json without content type", body: `[]`},
+		{name: "html content type", contentType: "text/html", body: "oops", wantErr: true},
+		{name: "markup without content type", body: "<html></html>", wantErr: true},
+		{name: "markup with json content type", contentType: "application/json", body: "<xml/>"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkJSONResponse(http.StatusOK, test.contentType, []byte(test.body))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("checkJSONResponse() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
 func TestCIUsagePlanJSONRoundTrip(t *testing.T) {
 	raw := `{"name":"Plan","reset_date":"2026-03-16","reset_date_time":"2026-03-16T09:43:54Z","available":1467,"used":33,"total":1500}`
 	var plan CIUsagePlan
@@ -402,6 +473,7 @@ func TestCIUsagePlanJSONRoundTrip(t *testing.T) {
 }
 
 func TestNewCIClientSetsBaseURL(t *testing.T) {
+	t.Setenv(ciBaseURLEnv, "")
 	session := &AuthSession{Client: http.DefaultClient}
 	client := NewCIClient(session)
 	if !strings.HasSuffix(client.baseURL, "/ci/api") {
@@ -409,6 +481,58 @@ func TestNewCIClientSetsBaseURL(t *testing.T) {
 	}
 }
 
+func TestNewCIClientBaseURLOverrides(t *testing.T) {
+	session := &AuthSession{Client: http.DefaultClient}
+
+	t.Run("env var", func(t *testing.T) {
+		t.Setenv(ciBaseURLEnv, "http://127.0.0.1:8080/ci/api/")
+		client := NewCIClient(session)
+		if client.configErr != nil || client.baseURL != "http://127.0.0.1:8080/ci/api" {
+			t.Fatalf("unexpected client base URL %q (err %v)", client.baseURL, client.configErr)
+		}
+	})
+
+	t.Run("option wins over env var", func(t *testing.T) {
+		t.Setenv(ciBaseURLEnv, "http://127.0.0.1:8080/ci/api")
+		client := NewCIClient(session, WithCIBaseURL("https://proxy.example.com/ci/v2"))
+		if client.configErr != nil || client.baseURL != "https://proxy.example.com/ci/v2" {
+			t.Fatalf("unexpected client base URL %q (err %v)", client.baseURL, client.configErr)
+		}
+	})
+
+	t.Run("requests go to the override", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/replay/teams/team-uuid/usage/summary" {
+				t.Fatalf("unexpected path: %s", r.URL.Path)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"plan":{"used":1,"total":2}}`))
+		}))
+		defer server.Close()
+
+		t.Setenv(ciBaseURLEnv, "")
+		client := NewCIClient(&AuthSession{Client: server.Client()}, WithCIBaseURL(server.URL+"/replay"))
+		client.minRequestInterval = 0
+		if _, err := client.GetCIUsageSummary(context.Background(), "team-uuid"); err != nil {
+			t.Fatalf("GetCIUsageSummary() error = %v", err)
+		}
+	})
+
+	for _, raw := range []string{"ftp://example.com/ci", "not a url", "https://", "https://example.com/ci?x=1"} {
+		t.Run("invalid "+raw, func(t *testing.T) {
+			t.Setenv(ciBaseURLEnv, raw)
+			client := NewCIClient(session)
+			if client.baseURL != defaultCIBaseURL {
+				t.Fatalf("expected default base URL to be kept, got %q", client.baseURL)
+			}
+			_, err := client.GetCIUsageSummary(context.Background(), "team-uuid")
+			if err == nil || !strings.Contains(err.Error(), "invalid CI base URL from ASC_CI_BASE_URL") {
+				t.Fatalf("expected invalid base URL error, got %v", err)
+			}
+		})
+	}
+}
+
 func TestListCIWorkflowsParsesResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/products/prod-1/workflows-v15") {
@@ -424,7 +548,7 @@ func TestListCIWorkflowsParsesResponse(t *testing.T) {
 		_, _ = w.Write([]byte(`{
 			"items": [
 				{"id":"wf-1","content":{"name":"TestFlight Deploy","description":"Build on main"}},
-				{"id":"wf-2","content":{"name":"PR Check"}}
+				{"id":"wf-2","content":{"name":"PR Check","disabled":true,"locked":true}}
 			]
 		}`))
 	}))
@@ -444,6 +568,29 @@ func TestListCIWorkflowsParsesResponse(t *testing.T) {
 	if result.Items[1].Content.Name != "PR Check" {
 		t.Fatalf("unexpected second workflow name: %q", result.Items[1].Content.Name)
 	}
+	if result.Items[0].Content.Disabled || !result.Items[1].Content.Disabled || !result.Items[1].Content.Locked {
+		t.Fatalf("unexpected workflow state: %+v", result.Items)
+	}
+}
+
+func TestListCIWorkflowsIncludingDeletedSetsQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include_deleted") != "true" {
+			t.Fatalf("expected include_deleted=true, got %q", r.URL.Query().Get("include_deleted"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":"wf-old","content":{"name":"Legacy"}}]}`))
+	}))
+	defer server.Close()
+
+	client := testWebClient(server)
+	result, err := client.ListCIWorkflowsIncludingDeleted(context.Background(), "team-uuid", "prod-1")
+	if err != nil {
+		t.Fatalf("ListCIWorkflowsIncludingDeleted() error = %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Content.Name != "Legacy" {
+		t.Fatalf("unexpected workflows: %+v", result.Items)
+	}
 }
 
 func TestListCIWorkflowsRejectsEmptyInputs(t *testing.T) {
@@ -452,10 +599,10 @@ func TestListCIWorkflowsRejectsEmptyInputs(t *testing.T) {
 		name      string
 		teamID    string
 		productID string
-		wantErr   string
+		wantErr   error
 	}{
-		{"empty team", "", "prod", "team id is required"},
-		{"empty product", "team", "", "product id is required"},
+		{"empty team", "", "prod", ErrMissingTeamID},
+		{"empty product", "team", "", ErrMissingProductID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -463,8 +610,8 @@ func TestListCIWorkflowsRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -526,11 +673,11 @@ func TestGetCIWorkflowRejectsEmptyInputs(t *testing.T) {
 		teamID     string
 		productID  string
 		workflowID string
-		wantErr    string
+		wantErr    error
 	}{
-		{"empty team", "", "prod", "wf", "team id is required"},
-		{"empty product", "team", "", "wf", "product id is required"},
-		{"empty workflow", "team", "prod", "", "workflow id is required"},
+		{"empty team", "", "prod", "wf", ErrMissingTeamID},
+		{"empty product", "team", "", "wf", ErrMissingProductID},
+		{"empty workflow", "team", "prod", "", ErrMissingWorkflowID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -538,8 +685,8 @@ func TestGetCIWorkflowRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -592,11 +739,11 @@ func TestUpdateCIWorkflowRejectsEmptyInputs(t *testing.T) {
 		teamID     string
 		productID  string
 		workflowID string
-		wantErr    string
+		wantErr    error
 	}{
-		{"empty team", "", "prod", "wf", "team id is required"},
-		{"empty product", "team", "", "wf", "product id is required"},
-		{"empty workflow", "team", "prod", "", "workflow id is required"},
+		{"empty team", "", "prod", "wf", ErrMissingTeamID},
+		{"empty product", "team", "", "wf", ErrMissingProductID},
+		{"empty workflow", "team", "prod", "", ErrMissingWorkflowID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -604,8 +751,8 @@ func TestUpdateCIWorkflowRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -631,6 +778,20 @@ func TestGetCIEncryptionKeyParsesResponse(t *testing.T) {
 	}
 }
 
+func TestGetCIEncryptionKeyRejectsMalformedKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"c2hvcnQta2V5"}`))
+	}))
+	defer server.Close()
+
+	client := testWebClient(server)
+	_, err := client.GetCIEncryptionKey(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unexpected encryption key format, value 9 bytes") {
+		t.Fatalf("expected descriptive key format error, got %v", err)
+	}
+}
+
 func TestExtractEnvVars(t *testing.T) {
 	content := json.RawMessage(`{
 		"name":"Test",
@@ -912,10 +1073,10 @@ func TestListCIProductEnvVarsRejectsEmptyInputs(t *testing.T) {
 		name      string
 		teamID    string
 		productID string
-		wantErr   string
+		wantErr   error
 	}{
-		{"empty team", "", "prod", "team id is required"},
-		{"empty product", "team", "", "product id is required"},
+		{"empty team", "", "prod", ErrMissingTeamID},
+		{"empty product", "team", "", ErrMissingProductID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -923,8 +1084,8 @@ func TestListCIProductEnvVarsRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -990,11 +1151,11 @@ func TestSetCIProductEnvVarRejectsEmptyInputs(t *testing.T) {
 		teamID    string
 		productID string
 		varID     string
-		wantErr   string
+		wantErr   error
 	}{
-		{"empty team", "", "prod", "var", "team id is required"},
-		{"empty product", "team", "", "var", "product id is required"},
-		{"empty var", "team", "prod", "", "variable id is required"},
+		{"empty team", "", "prod", "var", ErrMissingTeamID},
+		{"empty product", "team", "", "var", ErrMissingProductID},
+		{"empty var", "team", "prod", "", ErrMissingVariableID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1002,8 +1163,8 @@ func TestSetCIProductEnvVarRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -1041,11 +1202,11 @@ func TestDeleteCIProductEnvVarRejectsEmptyInputs(t *testing.T) {
 		teamID    string
 		productID string
 		varID     string
-		wantErr   string
+		wantErr   error
 	}{
-		{"empty team", "", "prod", "var", "team id is required"},
-		{"empty product", "team", "", "var", "product id is required"},
-		{"empty var", "team", "prod", "", "variable id is required"},
+		{"empty team", "", "prod", "var", ErrMissingTeamID},
+		{"empty product", "team", "", "var", ErrMissingProductID},
+		{"empty var", "team", "prod", "", ErrMissingVariableID},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1053,8 +1214,8 @@ func TestDeleteCIProductEnvVarRejectsEmptyInputs(t *testing.T) {
 			if err == nil {
 				t.Fatal("expected error")
 			}
-			if !strings.Contains(err.Error(), tt.wantErr) {
-				t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
 			}
 		})
 	}
@@ -1067,3 +1228,123 @@ func keysOf(m map[string]json.RawMessage) []string {
 	}
 	return keys
 }
+
+func TestSetEnvVarsPreservesNestedWorkflowConfig(t *testing.T) {
+	content := json.RawMessage(`{"id":"wf-1","name":"Release <main>","description":"Build & ship","disabled":false,` +
+		`"start_conditions":[{"type":"branch","auto_cancel":true,"files_and_folders_rule":{"mode":"START_IF_ANY_FILE_MATCHES","matchers":[{"directory":"Sources","file_extension":"swift"}]},"source":{"is_all_match":false,"patterns":[{"pattern":"release/*","is_prefix":true}]}}],` +
+		`"environment_variables":[{"id":"1","name":"OLD","value":{"plaintext":"old"}}],` +
+		`"actions":[{"name":"Archive - iOS","action_type":"ARCHIVE","platform":"IOS","scheme":"App","is_required_to_pass":true,"build_distribution_audience":"APP_STORE_ELIGIBLE","destination":null,"test_configuration":{"test_plan_name":"","test_destinations":[{"device_type_name":"iPhone 15","runtime_name":"iOS 17.0","kind":"SIMULATOR"}]}}],` +
+		`"post_actions":[{"name":"TestFlight","action_type":"TESTFLIGHT_INTERNAL_TESTING","testflight_groups":["grp-1","grp-2"],"script":"ci_scripts/notify.sh && echo \"done\" > out.txt"}],` +
+		`"xcode_version":{"id":"latest:stable","name":"Latest Release"},"macos_version":{"id":"latest:stable"},"clean":true,"container_file_path":"App.xcodeproj",` +
+		`"repo":{"id":"repo-1","http_url":"https://github.com/example/app.git","owner_name":"example"},"product_environment_variables":["shared-1"],"build_timeout":1.5e3,"emoji":"🚀 é"}`)
+	pt := "a&b<c>"
+	result, err := SetEnvVars(content, []CIEnvironmentVariable{
+		{ID: "2", Name: "NEW", Value: CIEnvironmentVariableValue{Plaintext: &pt}},
+	})
+	if err != nil {
+		t.Fatalf("SetEnvVars() error = %v", err)
+	}
+
+	decodeOrdered := func(data []byte) ([]string, map[string]json.RawMessage) {
+		t.Helper()
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if _, err := decoder.Token(); err != nil {
+			t.Fatalf("token error: %v", err)
+		}
+		var keys []string
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				t.Fatalf("token error: %v", err)
+			}
+			keys = append(keys, token.(string))
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+		}
+		return keys, fields
+	}
+
+	beforeKeys, before := decodeOrdered(content)
+	afterKeys, after := decodeOrdered(result)
+	if strings.Join(beforeKeys, ",") != strings.Join(afterKeys, ",") {
+		t.Fatalf("top-level key order changed:\nbefore %v\nafter  %v", beforeKeys, afterKeys)
+	}
+	for key, value := range before {
+		if key == "environment_variables" {
+			continue
+		}
+		if !bytes.Equal(value, after[key]) {
+			t.Fatalf("field %q changed:\nbefore %s\nafter  %s", key, value, after[key])
+		}
+	}
+
+	vars, err := ExtractEnvVars(result)
+	if err != nil {
+		t.Fatalf("ExtractEnvVars() error = %v", err)
+	}
+	if len(vars) != 1 || vars[0].Name != "NEW" || *vars[0].Value.Plaintext != pt {
+		t.Fatalf("unexpected env vars: %+v", vars)
+	}
+	if !bytes.Contains(result, []byte(`"plaintext":"a&b<c>"`)) {
+		t.Fatalf("expected env var value without HTML escaping, got %s", result)
+	}
+}
+
+func TestSetEnvVarsAddsMissingField(t *testing.T) {
+	pt := "v"
+	vars := []CIEnvironmentVariable{{ID: "1", Name: "X", Value: CIEnvironmentVariableValue{Plaintext: &pt}}}
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{content: `{"name":"WF"}`, want: `{"name":"WF","environment_variables":[{"id":"1","name":"X","value":{"plaintext":"v"}}]}`},
+		{content: ` { } `, want: `{"environment_variables":[{"id":"1","name":"X","value":{"plaintext":"v"}}]}`},
+	}
+	for _, tt := range tests {
+		result, err := SetEnvVars(json.RawMessage(tt.content), vars)
+		if err != nil {
+			t.Fatalf("SetEnvVars(%s) error = %v", tt.content, err)
+		}
+		if string(result) != tt.want {
+			t.Fatalf("SetEnvVars(%s) = %s, want %s", tt.content, result, tt.want)
+		}
+	}
+}
+
+func TestCIRequestForbiddenIsErrNoCIAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"403"}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), baseURL: server.URL}
+	_, err := client.GetCIUsageSummary(context.Background(), "team-uuid")
+	if !errors.Is(err, ErrNoCIAccess) {
+		t.Fatalf("expected ErrNoCIAccess, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("expected the wrapped *APIError with status 403, got %v", err)
+	}
+
+	_, err = client.GetCIEncryptionKey(context.Background())
+	if errors.Is(err, ErrNoCIAccess) {
+		t.Fatalf("expected non-CI endpoints to keep the plain API error, got %v", err)
+	}
+
+	_, err = client.ListCIProducts(context.Background(), "team-uuid")
+	if errors.Is(err, ErrNoCIAccess) {
+		t.Fatalf("expected CI endpoints other than the summary probe to keep the plain API error, got %v", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("expected a plain *APIError with status 403, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package web
+
+// CIUsageSeverity classifies plan usage against warning and critical
+// percentage thresholds.
+type CIUsageSeverity string
+
+const (
+	CIUsageSeverityUnknown  CIUsageSeverity = "unknown"
+	CIUsageSeverityOK       CIUsageSeverity = "ok"
+	CIUsageSeverityWarning  CIUsageSeverity = "warning"
+	CIUsageSeverityCritical CIUsageSeverity = "critical"
+)
+
+// CIUsagePercent returns used as a whole percent of total, rounded half up.
+// used is clamped to [0, total]; a non-positive total yields 0.
+func CIUsagePercent(used, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	if used < 0 {
+		used = 0
+	}
+	if used > total {
+		used = total
+	}
+	return (used*100 + total/2) / total
+}
+
+// ClassifyCIUsageSeverity compares used/total against warnAt and criticalAt
+// percent thresholds. The comparison uses exact integer arithmetic rather than
+// the rounded percent, so 79.6% stays below an 80% threshold. A non-positive
+// total yields CIUsageSeverityUnknown.
+func ClassifyCIUsageSeverity(used, total, warnAt, criticalAt int) CIUsageSeverity {
+	if total <= 0 {
+		return CIUsageSeverityUnknown
+	}
+	if used < 0 {
+		used = 0
+	}
+	usedScaled := int64(used) * 100
+	totalScaled := int64(total)
+	if usedScaled >= int64(criticalAt)*totalScaled {
+		return CIUsageSeverityCritical
+	}
+	if usedScaled >= int64(warnAt)*totalScaled {
+		return CIUsageSeverityWarning
+	}
+	return CIUsageSeverityOK
+}
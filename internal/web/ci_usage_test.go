@@ -0,0 +1,45 @@
+package web
+
+import "testing"
+
+func TestCIUsagePercent(t *testing.T) {
+	tests := []struct {
+		used, total, want int
+	}{
+		{used: 0, total: 100, want: 0},
+		{used: 50, total: 100, want: 50},
+		{used: 1, total: 3, want: 33},
+		{used: 2, total: 3, want: 67},
+		{used: 150, total: 100, want: 100},
+		{used: -5, total: 100, want: 0},
+		{used: 10, total: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := CIUsagePercent(tt.used, tt.total); got != tt.want {
+			t.Errorf("CIUsagePercent(%d, %d) = %d, want %d", tt.used, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyCIUsageSeverity(t *testing.T) {
+	tests := []struct {
+		name                    string
+		used, total, warn, crit int
+		want                    CIUsageSeverity
+	}{
+		{name: "below warning", used: 70, total: 100, warn: 80, crit: 95, want: CIUsageSeverityOK},
+		{name: "at warning", used: 80, total: 100, warn: 80, crit: 95, want: CIUsageSeverityWarning},
+		{name: "at critical", used: 95, total: 100, warn: 80, crit: 95, want: CIUsageSeverityCritical},
+		{name: "rounds up but stays below", used: 796, total: 1000, warn: 80, crit: 95, want: CIUsageSeverityOK},
+		{name: "over total", used: 150, total: 100, warn: 80, crit: 95, want: CIUsageSeverityCritical},
+		{name: "negative used", used: -1, total: 100, warn: 80, crit: 95, want: CIUsageSeverityOK},
+		{name: "no total", used: 10, total: 0, warn: 80, crit: 95, want: CIUsageSeverityUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyCIUsageSeverity(tt.used, tt.total, tt.warn, tt.crit); got != tt.want {
+				t.Fatalf("ClassifyCIUsageSeverity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
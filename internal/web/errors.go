@@ -1,11 +1,30 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
 	"strings"
 )
 
+// Errors returned by the CI client, for use with errors.Is.
+var (
+	// ErrMissingTeamID is returned when a CI call is made without a team ID.
+	ErrMissingTeamID = errors.New("team id is required")
+	// ErrMissingProductID is returned when a CI call needs a product ID and none was given.
+	ErrMissingProductID = errors.New("product id is required")
+	// ErrMissingWorkflowID is returned when a CI call needs a workflow ID and none was given.
+	ErrMissingWorkflowID = errors.New("workflow id is required")
+	// ErrMissingVariableID is returned when a CI call needs an environment variable ID and none was given.
+	ErrMissingVariableID = errors.New("variable id is required")
+	// ErrNoCIAccess wraps a 403 from the CI usage summary, the access probe:
+	// the team does not have Xcode Cloud enabled or the account's role cannot
+	// access it. The underlying *APIError is still available via errors.As.
+	ErrNoCIAccess = errors.New("no xcode cloud access")
+)
+
 // IsDuplicateAppNameError reports whether an internal API error means app name is taken.
 func IsDuplicateAppNameError(err error) bool {
 	var apiErr *APIError
@@ -39,3 +58,57 @@ func IsDuplicateAppNameError(err error) bool {
 	}
 	return false
 }
+
+const nonJSONFirstLineLimit = 120
+
+// NonJSONResponseError reports a successful response whose body is not JSON,
+// typically an HTML login page served after the web session has expired.
+type NonJSONResponseError struct {
+	Status      int
+	ContentType string
+	FirstLine   string
+}
+
+func (e *NonJSONResponseError) Error() string {
+	message := fmt.Sprintf("web api returned a non-JSON response (status %d", e.Status)
+	if e.ContentType != "" {
+		message += ", content-type " + e.ContentType
+	}
+	message += ")"
+	if e.FirstLine != "" {
+		message += fmt.Sprintf(": %q", e.FirstLine)
+	}
+	return message
+}
+
+// checkJSONResponse returns a NonJSONResponseError when a response declares an
+// HTML content type or has a markup body without a JSON content type.
+// Empty bodies are accepted because some endpoints return no content.
+func checkJSONResponse(status int, contentType string, body []byte) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(contentType))
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		mediaType = parsed
+	}
+	isHTML := strings.Contains(mediaType, "html")
+	if !isHTML && (strings.Contains(mediaType, "json") || trimmed[0] != '<') {
+		return nil
+	}
+	return &NonJSONResponseError{
+		Status:      status,
+		ContentType: mediaType,
+		FirstLine:   firstBodyLine(trimmed),
+	}
+}
+
+func firstBodyLine(body []byte) string {
+	line, _, _ := strings.Cut(string(body), "\n")
+	line = strings.TrimSpace(line)
+	if runes := []rune(line); len(runes) > nonJSONFirstLineLimit {
+		line = string(runes[:nonJSONFirstLineLimit]) + "..."
+	}
+	return line
+}
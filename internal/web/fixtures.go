@@ -0,0 +1,272 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// fixtureSessionFile stores the identifiers a replayed session needs so that
+// request paths match the recording.
+const fixtureSessionFile = "session.json"
+
+const redactedFixtureValue = "REDACTED"
+
+// fixtureSecretFields are JSON keys whose string values are replaced before a
+// fixture is written. Ciphertext is included because ECIES output differs on
+// every run and would otherwise make request keys unstable.
+var fixtureSecretFields = map[string]struct{}{
+	"ciphertext":      {},
+	"password":        {},
+	"accountpassword": {},
+	"token":           {},
+	"sessiontoken":    {},
+	"cookie":          {},
+}
+
+// Fixture is one recorded request/response pair. Request headers and cookies
+// are never stored.
+type Fixture struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	RequestBody string `json:"request_body,omitempty"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// FixtureSession holds the non-secret session identifiers saved alongside
+// recorded fixtures.
+type FixtureSession struct {
+	PublicProviderID string `json:"public_provider_id"`
+	ProviderID       int64  `json:"provider_id,omitempty"`
+	TeamID           string `json:"team_id,omitempty"`
+}
+
+// RecordingTransport forwards requests to Base and writes each exchange to Dir
+// as a JSON fixture with secrets redacted.
+type RecordingTransport struct {
+	Dir  string
+	Base http.RoundTripper
+
+	mu sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	redactedRequest := redactFixtureBody(requestBody)
+	fixture := Fixture{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: string(redactedRequest),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        string(redactFixtureBody(body)),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := writeFixtureFile(filepath.Join(t.Dir, FixtureFileName(req.Method, req.URL.Path, req.URL.Query().Encode(), redactedRequest)), fixture); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReplayTransport serves responses from fixtures in Dir and never touches the
+// network. A request without a matching fixture fails.
+type ReplayTransport struct {
+	Dir string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	name := FixtureFileName(req.Method, req.URL.Path, req.URL.Query().Encode(), redactFixtureBody(requestBody))
+	data, err := os.ReadFile(filepath.Join(t.Dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("replay: no fixture for %s %s (expected %s)", req.Method, req.URL.Path, name)
+		}
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("replay: invalid fixture %s: %w", name, err)
+	}
+	header := http.Header{}
+	if fixture.ContentType != "" {
+		header.Set("Content-Type", fixture.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(fixture.Body)),
+		ContentLength: int64(len(fixture.Body)),
+		Request:       req,
+	}, nil
+}
+
+// FixtureFileName derives a stable fixture file name from the parts of a
+// request that identify it: method, path, encoded query, and redacted body.
+func FixtureFileName(method, path, rawQuery string, body []byte) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, strings.ToUpper(method)+" "+path+"?"+rawQuery+"\n")
+	_, _ = hash.Write(body)
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, path), "_")
+	if len(slug) > 80 {
+		slug = slug[len(slug)-80:]
+	}
+	return fmt.Sprintf("%s-%s-%s.json", strings.ToLower(method), slug, hex.EncodeToString(hash.Sum(nil))[:12])
+}
+
+// WriteFixtureSession saves session identifiers for a later replay.
+func WriteFixtureSession(dir string, session *AuthSession) error {
+	if session == nil {
+		return fmt.Errorf("record: session is required")
+	}
+	return writeFixtureFile(filepath.Join(dir, fixtureSessionFile), FixtureSession{
+		PublicProviderID: session.PublicProviderID,
+		ProviderID:       session.ProviderID,
+		TeamID:           session.TeamID,
+	})
+}
+
+// NewReplaySession builds an offline session whose client serves every
+// request from the fixtures in dir.
+func NewReplaySession(dir string) (*AuthSession, error) {
+	data, err := os.ReadFile(filepath.Join(dir, fixtureSessionFile))
+	if err != nil {
+		return nil, fmt.Errorf("replay: failed to read %s: %w", fixtureSessionFile, err)
+	}
+	var saved FixtureSession
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("replay: invalid %s: %w", fixtureSessionFile, err)
+	}
+	return &AuthSession{
+		Client:           &http.Client{Transport: &ReplayTransport{Dir: dir}},
+		ProviderID:       saved.ProviderID,
+		PublicProviderID: saved.PublicProviderID,
+		TeamID:           saved.TeamID,
+	}, nil
+}
+
+// WithRecording returns a copy of session whose client records every exchange
+// to dir. The original session and its client are left untouched.
+func WithRecording(session *AuthSession, dir string) (*AuthSession, error) {
+	if session == nil || session.Client == nil {
+		return nil, fmt.Errorf("record: session has no HTTP client")
+	}
+	if err := WriteFixtureSession(dir, session); err != nil {
+		return nil, err
+	}
+	client := *session.Client
+	client.Transport = &RecordingTransport{Dir: dir, Base: session.Client.Transport}
+	recorded := *session
+	recorded.Client = &client
+	return &recorded, nil
+}
+
+func readAndRestoreRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// redactFixtureBody replaces secret string values in a JSON body. Non-JSON
+// bodies are returned unchanged; JSON bodies are re-encoded, which sorts keys.
+func redactFixtureBody(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(redactFixtureValue(value))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func redactFixtureValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if _, secret := fixtureSecretFields[strings.ToLower(key)]; secret {
+				if _, isString := nested.(string); isString {
+					typed[key] = redactedFixtureValue
+					continue
+				}
+			}
+			typed[key] = redactFixtureValue(nested)
+		}
+		return typed
+	case []any:
+		for i := range typed {
+			typed[i] = redactFixtureValue(typed[i])
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+func writeFixtureFile(path string, value any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("record: failed to create fixture directory: %w", err)
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("record: failed to marshal fixture: %w", err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("record: failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordingAndReplayTransportsRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPut && !strings.Contains(string(body), "live-ciphertext") {
+			t.Fatalf("expected the live request body to reach the server, got %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "myacinfo=secret")
+		_, _ = w.Write([]byte(`{"id":"var-1","value":{"ciphertext":"server-secret"},"name":"TOKEN"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	session, err := WithRecording(&AuthSession{Client: server.Client(), PublicProviderID: "team-uuid"}, dir)
+	if err != nil {
+		t.Fatalf("WithRecording() error: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/ci/api/teams/team-uuid/vars/var-1?limit=5", strings.NewReader(`{"value":{"ciphertext":"live-ciphertext"}}`))
+	if err != nil {
+		t.Fatalf("NewRequest() error: %v", err)
+	}
+	resp, err := session.Client.Do(req)
+	if err != nil {
+		t.Fatalf("recorded request error: %v", err)
+	}
+	liveBody, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if !strings.Contains(string(liveBody), "server-secret") {
+		t.Fatalf("expected the caller to see the unredacted live body, got %s", liveBody)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected session.json and one fixture, got %d entries", len(entries))
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile() error: %v", err)
+		}
+		for _, secret := range []string{"server-secret", "live-ciphertext", "myacinfo"} {
+			if strings.Contains(string(data), secret) {
+				t.Fatalf("expected %q to be redacted from %s, got %s", secret, entry.Name(), data)
+			}
+		}
+	}
+
+	replay, err := NewReplaySession(dir)
+	if err != nil {
+		t.Fatalf("NewReplaySession() error: %v", err)
+	}
+	if replay.PublicProviderID != "team-uuid" {
+		t.Fatalf("expected replayed public provider ID, got %q", replay.PublicProviderID)
+	}
+	// A fresh ciphertext must still match because ciphertext is redacted
+	// before the fixture key is derived.
+	replayReq, _ := http.NewRequest(http.MethodPut, server.URL+"/ci/api/teams/team-uuid/vars/var-1?limit=5", strings.NewReader(`{"value":{"ciphertext":"other-ciphertext"}}`))
+	server.Close()
+	replayResp, err := replay.Client.Do(replayReq)
+	if err != nil {
+		t.Fatalf("replayed request error: %v", err)
+	}
+	replayBody, _ := io.ReadAll(replayResp.Body)
+	_ = replayResp.Body.Close()
+	if replayResp.StatusCode != http.StatusOK || replayResp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected replay status/header %d %q", replayResp.StatusCode, replayResp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(replayBody), `"name":"TOKEN"`) || !strings.Contains(string(replayBody), redactedFixtureValue) {
+		t.Fatalf("unexpected replay body %s", replayBody)
+	}
+}
+
+func TestReplayTransportMissingFixture(t *testing.T) {
+	transport := &ReplayTransport{Dir: t.TempDir()}
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/ci/api/teams/x/products", nil)
+	_, err := transport.RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "replay: no fixture for GET /ci/api/teams/x/products") {
+		t.Fatalf("expected missing fixture error, got %v", err)
+	}
+}
+
+func TestFixtureFileNameIsStableAndDistinct(t *testing.T) {
+	first := FixtureFileName("GET", "/ci/api/teams/t/products", "limit=5", nil)
+	if again := FixtureFileName("get", "/ci/api/teams/t/products", "limit=5", nil); again != first {
+		t.Fatalf("expected stable name, got %q and %q", first, again)
+	}
+	if other := FixtureFileName("GET", "/ci/api/teams/t/products", "limit=6", nil); other == first {
+		t.Fatalf("expected distinct names for distinct queries, got %q", other)
+	}
+	if !strings.HasPrefix(first, "get-ci_api_teams_t_products-") || !strings.HasSuffix(first, ".json") {
+		t.Fatalf("unexpected fixture name %q", first)
+	}
+}
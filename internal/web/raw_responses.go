@@ -0,0 +1,57 @@
+package web
+
+import (
+	"context"
+	"sync"
+)
+
+// RawResponse is one API response body exactly as the server returned it,
+// before any decoding or normalization.
+type RawResponse struct {
+	Method string
+	Path   string
+	Status int
+	Body   []byte
+}
+
+// RawResponseRecorder collects raw response bodies for requests made with a
+// context from WithRawResponseRecorder. It is safe for concurrent use.
+type RawResponseRecorder struct {
+	mu        sync.Mutex
+	responses []RawResponse
+}
+
+type rawResponseRecorderKey struct{}
+
+// WithRawResponseRecorder returns a context that records every response body
+// received by Client requests made with it, including error responses.
+func WithRawResponseRecorder(ctx context.Context, recorder *RawResponseRecorder) context.Context {
+	if recorder == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, rawResponseRecorderKey{}, recorder)
+}
+
+// Responses returns the recorded responses in the order they were received.
+func (r *RawResponseRecorder) Responses() []RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RawResponse(nil), r.responses...)
+}
+
+func (r *RawResponseRecorder) record(method, path string, status int, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, RawResponse{
+		Method: method,
+		Path:   path,
+		Status: status,
+		Body:   append([]byte(nil), body...),
+	})
+}
+
+func recordRawResponse(ctx context.Context, method, path string, status int, body []byte) {
+	if recorder, ok := ctx.Value(rawResponseRecorderKey{}).(*RawResponseRecorder); ok {
+		recorder.record(method, path, status, body)
+	}
+}
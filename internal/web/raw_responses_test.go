@@ -0,0 +1,68 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRawResponseRecorderCapturesBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/teams/team-uuid/usage/summary" {
+			_, _ = w.Write([]byte(`{"plan":{"used":1,"unexpected_field":true}}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer server.Close()
+
+	client := testWebClient(server)
+	recorder := &RawResponseRecorder{}
+	ctx := WithRawResponseRecorder(context.Background(), recorder)
+
+	if _, err := client.GetCIUsageSummary(ctx, "team-uuid"); err != nil {
+		t.Fatalf("GetCIUsageSummary() error = %v", err)
+	}
+	_, err := client.GetCIUsageMonths(ctx, "team-uuid", 1, 2026, 2, 2026)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+
+	responses := recorder.Responses()
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 recorded responses, got %d", len(responses))
+	}
+	if responses[0].Method != http.MethodGet || responses[0].Status != http.StatusOK || responses[0].Path != "/teams/team-uuid/usage/summary" {
+		t.Fatalf("unexpected first response %+v", responses[0])
+	}
+	if string(responses[0].Body) != `{"plan":{"used":1,"unexpected_field":true}}` {
+		t.Fatalf("expected the unparsed body, got %s", responses[0].Body)
+	}
+	if responses[1].Status != http.StatusInternalServerError || string(responses[1].Body) != `{"error":"boom"}` {
+		t.Fatalf("expected the error body to be recorded, got %+v", responses[1])
+	}
+}
+
+func TestRawResponseRecorderIsOptIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	recorder := &RawResponseRecorder{}
+	if ctx := WithRawResponseRecorder(context.Background(), nil); ctx.Value(rawResponseRecorderKey{}) != nil {
+		t.Fatal("expected a nil recorder to leave the context unchanged")
+	}
+	if _, err := testWebClient(server).GetCIUsageSummary(context.Background(), "team-uuid"); err != nil {
+		t.Fatalf("GetCIUsageSummary() error = %v", err)
+	}
+	if len(recorder.Responses()) != 0 {
+		t.Fatal("expected nothing recorded without the context value")
+	}
+}
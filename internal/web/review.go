@@ -842,7 +842,7 @@ func (c *Client) DownloadAttachment(ctx context.Context, signedURL string) ([]by
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if c == nil || c.httpClient == nil {
+	if c == nil || c.currentHTTPClient() == nil {
 		return nil, 0, fmt.Errorf("web client is not initialized")
 	}
 	if err := c.waitForRateLimit(ctx); err != nil {
@@ -868,9 +868,10 @@ func (c *Client) DownloadAttachment(ctx context.Context, signedURL string) ([]by
 		return nil, 0, fmt.Errorf("failed to create download request")
 	}
 	request.Header.Set("Accept", "*/*")
-	setModifiedCookieHeader(c.httpClient, request)
+	httpClient := c.currentHTTPClient()
+	setModifiedCookieHeader(httpClient, request)
 
-	response, err := c.httpClient.Do(request)
+	response, err := httpClient.Do(request)
 	if err != nil {
 		var urlErr *url.Error
 		if errors.As(err, &urlErr) {
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// userAgentEnv overrides the User-Agent sent by web and CI clients.
+const userAgentEnv = "ASC_USER_AGENT"
+
+var (
+	userAgentVersionMu sync.RWMutex
+	userAgentVersion   = "dev"
+)
+
+// SetUserAgentVersion sets the CLI version reported in the default
+// User-Agent. Empty values are ignored.
+func SetUserAgentVersion(version string) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return
+	}
+	userAgentVersionMu.Lock()
+	defer userAgentVersionMu.Unlock()
+	userAgentVersion = version
+}
+
+// DefaultUserAgent returns the User-Agent sent when neither the session nor
+// ASC_USER_AGENT overrides it, e.g. "App-Store-Connect-CLI/1.2.3 (darwin; arm64)".
+func DefaultUserAgent() string {
+	userAgentVersionMu.RLock()
+	version := userAgentVersion
+	userAgentVersionMu.RUnlock()
+	return fmt.Sprintf("App-Store-Connect-CLI/%s (%s; %s)", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// resolveUserAgent picks the session override, then ASC_USER_AGENT, then the
+// default.
+func resolveUserAgent(session *AuthSession) string {
+	if session != nil {
+		if userAgent := strings.TrimSpace(session.UserAgent); userAgent != "" {
+			return userAgent
+		}
+	}
+	if userAgent := strings.TrimSpace(os.Getenv(userAgentEnv)); userAgent != "" {
+		return userAgent
+	}
+	return DefaultUserAgent()
+}
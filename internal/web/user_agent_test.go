@@ -0,0 +1,85 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoRequestSetsUserAgentForEveryMethod(t *testing.T) {
+	t.Setenv(userAgentEnv, "")
+	SetUserAgentVersion("1.2.3")
+	t.Cleanup(func() { SetUserAgentVersion("dev") })
+
+	seen := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen[r.Method] = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewCIClient(&AuthSession{Client: server.Client()}, WithCIBaseURL(server.URL))
+	client.minRequestInterval = 0
+
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	for _, method := range methods {
+		if _, err := client.doRequest(context.Background(), method, "/ping", nil); err != nil {
+			t.Fatalf("%s request error = %v", method, err)
+		}
+	}
+
+	want := DefaultUserAgent()
+	if !strings.HasPrefix(want, "App-Store-Connect-CLI/1.2.3 (") {
+		t.Fatalf("unexpected default user agent %q", want)
+	}
+	for _, method := range methods {
+		if got := seen[method]; got != want {
+			t.Fatalf("%s User-Agent = %q, want %q", method, got, want)
+		}
+	}
+}
+
+func TestUserAgentOverrides(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(userAgentEnv, "from-env/1.0")
+
+	client := NewCIClient(&AuthSession{Client: server.Client()}, WithCIBaseURL(server.URL))
+	client.minRequestInterval = 0
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/ping", nil); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if got != "from-env/1.0" {
+		t.Fatalf("expected ASC_USER_AGENT to be used, got %q", got)
+	}
+
+	client = NewCIClient(&AuthSession{Client: server.Client(), UserAgent: "from-flag/2.0"}, WithCIBaseURL(server.URL))
+	client.minRequestInterval = 0
+	if _, err := client.doRequest(context.Background(), http.MethodGet, "/ping", nil); err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if got != "from-flag/2.0" {
+		t.Fatalf("expected the session override to win over ASC_USER_AGENT, got %q", got)
+	}
+}
+
+func TestNewClientUsesResolvedUserAgent(t *testing.T) {
+	t.Setenv(userAgentEnv, "")
+	client := NewClient(&AuthSession{Client: http.DefaultClient, UserAgent: "custom/1"})
+	if client.userAgent != "custom/1" {
+		t.Fatalf("expected session user agent, got %q", client.userAgent)
+	}
+	client = NewClient(&AuthSession{Client: http.DefaultClient})
+	if client.userAgent != DefaultUserAgent() {
+		t.Fatalf("expected default user agent, got %q", client.userAgent)
+	}
+}
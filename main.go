@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/registry"
+	webcore "github.com/rudrankriyam/App-Store-Connect-CLI/internal/web"
 )
 
 var (
@@ -18,6 +20,8 @@ func versionInfoString() string {
 }
 
 func run(args []string) int {
+	registry.SetBuildInfo(version, commit, date)
+	webcore.SetUserAgentVersion(registry.CurrentBuildInfo().Version)
 	return cmd.Run(args, versionInfoString())
 }
 